@@ -2,14 +2,18 @@ package config
 
 import (
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type DecisionMakerConfig struct {
-	Server  ServerConfig  `mapstructure:"server"`
-	Logging LoggingConfig `mapstructure:"logging"`
-	Token   TokenConfig   `mapstructure:"token"`
+	Server    ServerConfig    `mapstructure:"server"`
+	Logging   LoggingConfig   `mapstructure:"logging"`
+	Token     TokenConfig     `mapstructure:"token"`
+	Discovery DiscoveryConfig `mapstructure:"discovery"`
+	Store     StoreConfig     `mapstructure:"store"`
+	Manager   ManagerConfig   `mapstructure:"manager"`
 }
 
 var (
@@ -51,3 +55,46 @@ type TokenConfig struct {
 	RsaPrivateKeyPem SecretValue `mapstructure:"rsa_private_key_pem"`
 	TokenDurationHr  int         `mapstructure:"token_duration_hr"` // in hours
 }
+
+const (
+	defaultRescanInterval = 10 * time.Second
+	defaultCgroupRoot     = "/sys/fs/cgroup"
+)
+
+type DiscoveryConfig struct {
+	Backend           string `mapstructure:"backend"`             // "proc" (default) or "cgroup"
+	CgroupRoot        string `mapstructure:"cgroup_root"`         // mount point of the cgroup v2 hierarchy
+	RescanIntervalSec int    `mapstructure:"rescan_interval_sec"` // in seconds
+}
+
+// GetCgroupRoot returns the mount point of the cgroup v2 hierarchy, falling back to /sys/fs/cgroup when unset
+func (c DiscoveryConfig) GetCgroupRoot() string {
+	if c.CgroupRoot == "" {
+		return defaultCgroupRoot
+	}
+	return c.CgroupRoot
+}
+
+// RescanInterval returns the interval between two process rescans, falling back to 10 seconds when unset
+func (c DiscoveryConfig) RescanInterval() time.Duration {
+	if c.RescanIntervalSec <= 0 {
+		return defaultRescanInterval
+	}
+	return time.Duration(c.RescanIntervalSec) * time.Second
+}
+
+type StoreConfig struct {
+	StateDir string `mapstructure:"state_dir"` // directory of the persisted intents, persistence is disabled when empty
+}
+
+type ManagerConfig struct {
+	URL             string `mapstructure:"url"`               // base URL of the manager, the sync with the manager is disabled when empty
+	ClientID        string `mapstructure:"client_id"`         // client identifier presented to the manager
+	NodeID          string `mapstructure:"node_id"`           // name of the node the decision maker runs on
+	SyncIntervalSec int    `mapstructure:"sync_interval_sec"` // in seconds, the full state is only synced at startup when 0
+}
+
+// SyncInterval returns the interval between two full state syncs with the manager
+func (c ManagerConfig) SyncInterval() time.Duration {
+	return time.Duration(c.SyncIntervalSec) * time.Second
+}
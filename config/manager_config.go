@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -30,12 +31,14 @@ type LoggingConfig struct {
 }
 
 type ManageConfig struct {
-	Server  ServerConfig  `mapstructure:"server"`
-	Logging LoggingConfig `mapstructure:"logging"`
-	MongoDB MongoDBConfig `mapstructure:"mongodb"`
-	Key     KeyConfig     `mapstructure:"key"`
-	Account AccountConfig `mapstructure:"account"`
-	K8S     K8SConfig     `mapstructure:"k8s"`
+	Server   ServerConfig   `mapstructure:"server"`
+	Logging  LoggingConfig  `mapstructure:"logging"`
+	MongoDB  MongoDBConfig  `mapstructure:"mongodb"`
+	Key      KeyConfig      `mapstructure:"key"`
+	Account  AccountConfig  `mapstructure:"account"`
+	K8S      K8SConfig      `mapstructure:"k8s"`
+	Delivery DeliveryConfig `mapstructure:"delivery"`
+	Schedule ScheduleConfig `mapstructure:"schedule"`
 }
 
 type MongoDBConfig struct {
@@ -57,6 +60,7 @@ type KeyConfig struct {
 	RsaPrivateKeyPem SecretValue `mapstructure:"rsa_private_key_pem"`
 	DMPublicKeyPem   SecretValue `mapstructure:"dm_public_key_pem"`
 	ClientID         string      `mapstructure:"client_id"`
+	DMClientID       string      `mapstructure:"dm_client_id"` // client identifier the decision makers present in their tokens
 }
 
 type AccountConfig struct {
@@ -65,8 +69,95 @@ type AccountConfig struct {
 }
 
 type K8SConfig struct {
-	KubeConfigPath string `mapstructure:"kube_config_path"`
-	IsInCluster    bool   `mapstructure:"in_cluster"`
+	KubeConfigPath            string `mapstructure:"kube_config_path"`
+	IsInCluster               bool   `mapstructure:"in_cluster"`
+	StrategyResources         bool   `mapstructure:"strategy_resources"`           // mirror the SchedulingStrategy custom resources into strategies, requires the CRD
+	StrategyResourceResyncSec int    `mapstructure:"strategy_resource_resync_sec"` // in seconds, interval at which the status of every resource is refreshed
+	// AnnotationNamespaces are the namespaces whose pods may request their scheduling with annotations, none when empty
+	AnnotationNamespaces []string `mapstructure:"annotation_namespaces"`
+}
+
+const defaultStrategyResourceResync = time.Minute
+
+// StrategyResourceResync returns the interval at which the SchedulingStrategy resources are reconciled again, falling back to 1 minute when unset
+func (c K8SConfig) StrategyResourceResync() time.Duration {
+	if c.StrategyResourceResyncSec <= 0 {
+		return defaultStrategyResourceResync
+	}
+	return time.Duration(c.StrategyResourceResyncSec) * time.Second
+}
+
+const (
+	defaultDeliveryWorkers        = 4
+	defaultDeliveryPollInterval   = 5 * time.Second
+	defaultDeliveryInitialBackoff = time.Second
+	defaultDeliveryMaxBackoff     = 5 * time.Minute
+	defaultDeliveryMaxAttempts    = 10
+)
+
+// DeliveryConfig configures the queue delivering the schedule intents to the decision makers
+type DeliveryConfig struct {
+	Workers          int `mapstructure:"workers"`            // number of nodes delivered concurrently
+	PollIntervalSec  int `mapstructure:"poll_interval_sec"`  // in seconds, interval between two scans of the pending intents
+	InitialBackoffMs int `mapstructure:"initial_backoff_ms"` // in milliseconds, delay before the first retry
+	MaxBackoffSec    int `mapstructure:"max_backoff_sec"`    // in seconds, upper bound of the retry delay
+	MaxAttempts      int `mapstructure:"max_attempts"`       // failed attempts before an intent is marked as failed
+}
+
+// GetWorkers returns the size of the delivery worker pool, falling back to 4 when unset
+func (c DeliveryConfig) GetWorkers() int {
+	if c.Workers <= 0 {
+		return defaultDeliveryWorkers
+	}
+	return c.Workers
+}
+
+// PollInterval returns the interval between two scans of the pending intents, falling back to 5 seconds when unset
+func (c DeliveryConfig) PollInterval() time.Duration {
+	if c.PollIntervalSec <= 0 {
+		return defaultDeliveryPollInterval
+	}
+	return time.Duration(c.PollIntervalSec) * time.Second
+}
+
+// Backoff returns the delay before retrying a delivery that failed attempts times,
+// doubling from the initial backoff (1 second when unset) up to the max backoff (5 minutes when unset)
+func (c DeliveryConfig) Backoff(attempts int) time.Duration {
+	backoff := defaultDeliveryInitialBackoff
+	if c.InitialBackoffMs > 0 {
+		backoff = time.Duration(c.InitialBackoffMs) * time.Millisecond
+	}
+	maxBackoff := defaultDeliveryMaxBackoff
+	if c.MaxBackoffSec > 0 {
+		maxBackoff = time.Duration(c.MaxBackoffSec) * time.Second
+	}
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
+
+// GetMaxAttempts returns the failed attempts after which an intent is given up, falling back to 10 when unset
+func (c DeliveryConfig) GetMaxAttempts() int {
+	if c.MaxAttempts <= 0 {
+		return defaultDeliveryMaxAttempts
+	}
+	return c.MaxAttempts
+}
+
+const defaultSchedulePollInterval = 15 * time.Second
+
+// ScheduleConfig configures the scheduler activating and expiring the time-bounded strategies
+type ScheduleConfig struct {
+	PollIntervalSec int `mapstructure:"poll_interval_sec"` // in seconds, interval between two evaluations of the strategy time bounds
+}
+
+// PollInterval returns the interval between two evaluations of the strategy time bounds, falling back to 15 seconds when unset
+func (c ScheduleConfig) PollInterval() time.Duration {
+	if c.PollIntervalSec <= 0 {
+		return defaultSchedulePollInterval
+	}
+	return time.Duration(c.PollIntervalSec) * time.Second
 }
 
 var (
@@ -19,6 +19,15 @@ func ConfigModule(cfg config.DecisionMakerConfig) (fx.Option, error) {
 		fx.Provide(func(dmCfg config.DecisionMakerConfig) config.TokenConfig {
 			return dmCfg.Token
 		}),
+		fx.Provide(func(dmCfg config.DecisionMakerConfig) config.DiscoveryConfig {
+			return dmCfg.Discovery
+		}),
+		fx.Provide(func(dmCfg config.DecisionMakerConfig) config.StoreConfig {
+			return dmCfg.Store
+		}),
+		fx.Provide(func(dmCfg config.DecisionMakerConfig) config.ManagerConfig {
+			return dmCfg.Manager
+		}),
 	), nil
 }
 
@@ -2,9 +2,12 @@ package app
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Gthulhu/api/config"
+	"github.com/Gthulhu/api/decisionmaker/client"
 	"github.com/Gthulhu/api/decisionmaker/rest"
+	"github.com/Gthulhu/api/decisionmaker/service"
 	"github.com/Gthulhu/api/pkg/logger"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/fx"
@@ -27,6 +30,8 @@ func NewRestApp(configName string, configDirPath string) (*fx.App, error) {
 
 	app := fx.New(
 		handlerModule,
+		fx.Invoke(StartProcessDiscovery),
+		fx.Invoke(StartManagerSync),
 		fx.Invoke(StartRestApp),
 	)
 	return app, nil
@@ -60,3 +65,46 @@ func StartRestApp(lc fx.Lifecycle, cfg config.ServerConfig, handler *rest.Handle
 
 	return nil
 }
+
+// StartProcessDiscovery runs the process discovery that keeps the scheduling intents bound to the live PIDs
+func StartProcessDiscovery(lc fx.Lifecycle, svc service.Service) {
+	ctx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(startCtx context.Context) error {
+			go svc.RunProcessDiscovery(ctx)
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+}
+
+// StartManagerSync replaces the intents of the decision maker with the full state held by the manager at startup,
+// and periodically afterwards to recover from missed pushes
+func StartManagerSync(lc fx.Lifecycle, managerCfg config.ManagerConfig, tokenCfg config.TokenConfig, svc service.Service) error {
+	if managerCfg.URL == "" {
+		logger.Logger(context.Background()).Info().Msg("manager url is not configured, skipping intents sync")
+		return nil
+	}
+	if managerCfg.NodeID == "" {
+		return errors.New("manager.node_id is required to sync intents from the manager")
+	}
+	managerClient, err := client.NewManagerClient(managerCfg, tokenCfg)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(startCtx context.Context) error {
+			go svc.RunManagerSync(ctx, managerClient, managerCfg.NodeID, managerCfg.SyncInterval())
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+	return nil
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Gthulhu/api/config"
+	"github.com/Gthulhu/api/decisionmaker/domain"
+	mgrrest "github.com/Gthulhu/api/manager/rest"
+	"github.com/Gthulhu/api/pkg/logger"
+	"github.com/Gthulhu/api/pkg/util"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const tokenTTL = 5 * time.Minute
+
+func NewManagerClient(managerConfig config.ManagerConfig, tokenConfig config.TokenConfig) (domain.ManagerAdapter, error) {
+	privateKey, err := util.InitRSAPrivateKey(string(tokenConfig.RsaPrivateKeyPem))
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize JWT private key: %v", err)
+	}
+	return &ManagerClient{
+		Client:     &http.Client{Timeout: 30 * time.Second},
+		baseURL:    strings.TrimSuffix(managerConfig.URL, "/"),
+		clientID:   managerConfig.ClientID,
+		privateKey: privateKey,
+	}, nil
+}
+
+type ManagerClient struct {
+	*http.Client
+
+	baseURL    string
+	clientID   string
+	privateKey *rsa.PrivateKey
+}
+
+// Claims represents the claims of the token the decision maker signs to authenticate to the manager
+type Claims struct {
+	ClientID string `json:"client_id"`
+	NodeID   string `json:"node_id"`
+	jwt.RegisteredClaims
+}
+
+func (m *ManagerClient) ListNodeIntents(ctx context.Context, nodeID string) ([]*domain.Intent, error) {
+	token, err := m.signToken(nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := m.baseURL + "/api/v1/decisionmaker/intents?nodeID=" + url.QueryEscape(nodeID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("manager %s returned non-OK status: %s", m.baseURL, resp.Status)
+	}
+
+	var intentsResp mgrrest.SuccessResponse[mgrrest.ListNodeScheduleIntentsResponse]
+	err = json.NewDecoder(resp.Body).Decode(&intentsResp)
+	if err != nil {
+		return nil, err
+	}
+	if intentsResp.Data == nil {
+		return []*domain.Intent{}, nil
+	}
+	intents := make([]*domain.Intent, 0, len(intentsResp.Data.Intents))
+	for _, intent := range intentsResp.Data.Intents {
+		selector := make([]domain.LabelSelector, 0, len(intent.Selector))
+		for _, requirement := range intent.Selector {
+			selector = append(selector, domain.LabelSelector{
+				Key:      requirement.Key,
+				Operator: requirement.Operator,
+				Values:   requirement.Values,
+			})
+		}
+		var matchers []domain.ProcessMatcher
+		for _, matcher := range intent.ProcessMatchers {
+			matchers = append(matchers, domain.ProcessMatcher{Field: matcher.Field, Regex: matcher.Regex})
+		}
+		intents = append(intents, &domain.Intent{
+			PodName:             intent.PodName,
+			PodID:               intent.PodID,
+			NodeID:              intent.NodeID,
+			K8sNamespace:        intent.K8sNamespace,
+			CommandRegex:        intent.CommandRegex,
+			Matchers:            matchers,
+			ThreadRegex:         intent.ThreadRegex,
+			IncludeDescendants:  intent.IncludeDescendants,
+			ContainerIDs:        intent.ContainerIDs,
+			Priority:            intent.Priority,
+			ExecutionTime:       intent.ExecutionTime,
+			PodLabels:           intent.PodLabels,
+			Selector:            selector,
+			StrategyID:          intent.StrategyID,
+			Weight:              intent.Weight,
+			Specificity:         intent.Specificity,
+			StrategyCreatedTime: intent.StrategyCreatedTime,
+		})
+	}
+	logger.Logger(ctx).Debug().Msgf("Fetched %d intents of node %s from manager", len(intents), nodeID)
+	return intents, nil
+}
+
+// signToken signs a short-lived token with the decision maker private key, the manager verifies it with the matching public key
+func (m *ManagerClient) signToken(nodeID string) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		ClientID: m.clientID,
+		NodeID:   nodeID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(tokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "decision-maker-service",
+			Subject:   nodeID,
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	tokenStr, err := token.SignedString(m.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT token: %v", err)
+	}
+	return tokenStr, nil
+}
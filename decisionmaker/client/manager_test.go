@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Gthulhu/api/config"
+	mgrrest "github.com/Gthulhu/api/manager/rest"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestListNodeIntents tests that the client authenticates with a token signed by the decision maker key and decodes the intents
+func TestListNodeIntents(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/decisionmaker/intents", r.URL.Path)
+		assert.Equal(t, "node-a", r.URL.Query().Get("nodeID"))
+		claims := &Claims{}
+		_, err := jwt.ParseWithClaims(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), claims, func(token *jwt.Token) (interface{}, error) {
+			return &key.PublicKey, nil
+		})
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "node-a", claims.NodeID)
+		assert.Equal(t, "decisionmaker", claims.ClientID)
+
+		resp := mgrrest.NewSuccessResponse(&mgrrest.ListNodeScheduleIntentsResponse{
+			NodeID: "node-a",
+			Intents: []*mgrrest.NodeScheduleIntent{
+				{ID: "1", PodID: "pod-a", NodeID: "node-a", CommandRegex: "nginx", Priority: 1, ExecutionTime: 1000},
+			},
+		})
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	managerClient, err := NewManagerClient(config.ManagerConfig{URL: server.URL + "/", ClientID: "decisionmaker"}, config.TokenConfig{RsaPrivateKeyPem: config.SecretValue(keyPem)})
+	require.NoError(t, err)
+	intents, err := managerClient.ListNodeIntents(context.Background(), "node-a")
+	require.NoError(t, err)
+	require.Len(t, intents, 1)
+	assert.Equal(t, "pod-a", intents[0].PodID)
+	assert.Equal(t, "nginx", intents[0].CommandRegex)
+	assert.Equal(t, 1, intents[0].Priority)
+	assert.EqualValues(t, 1000, intents[0].ExecutionTime)
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+// ManagerAdapter is the client of the manager used by the decision maker
+type ManagerAdapter interface {
+	// ListNodeIntents returns every intent the decision maker of the node should enforce
+	ListNodeIntents(ctx context.Context, nodeID string) ([]*Intent, error)
+}
@@ -2,10 +2,22 @@ package domain
 
 // PodProcess represents a process information within a pod
 type PodProcess struct {
-	PID         int    `json:"pid"`
-	Command     string `json:"command"`
-	PPID        int    `json:"ppid,omitempty"`
-	ContainerID string `json:"container_id,omitempty"`
+	PID         int      `json:"pid"`
+	Command     string   `json:"command"`
+	PPID        int      `json:"ppid,omitempty"`
+	ContainerID string   `json:"container_id,omitempty"`
+	Cmdline     string   `json:"cmdline,omitempty"` // arguments separated by spaces, empty for a kernel thread
+	Exe         string   `json:"exe,omitempty"`     // path of the executable, empty if it cannot be read
+	UID         int      `json:"uid"`               // effective user ID, -1 if unknown
+	GID         int      `json:"gid"`               // effective group ID, -1 if unknown
+	Cgroup      string   `json:"cgroup,omitempty"`  // cgroup path of the process
+	Threads     []Thread `json:"threads,omitempty"` // threads of the process, ordered by TID
+}
+
+// Thread is a task of a process, as listed in /proc/<pid>/task
+type Thread struct {
+	TID  int    `json:"tid"`
+	Name string `json:"name"`
 }
 
 // PodInfo represents pod information with associated processes
@@ -16,25 +28,76 @@ type PodInfo struct {
 }
 
 type Intent struct {
-	PodName       string            `json:"podName,omitempty"`
-	PodID         string            `json:"podID,omitempty"`
-	NodeID        string            `json:"nodeID,omitempty"`
-	K8sNamespace  string            `json:"k8sNamespace,omitempty"`
-	CommandRegex  string            `json:"commandRegex,omitempty"`
-	Priority      int               `json:"priority,omitempty"`
-	ExecutionTime int64             `json:"executionTime,omitempty"`
-	PodLabels     map[string]string `json:"podLabels,omitempty"`
+	PodName             string            `json:"podName,omitempty"`
+	PodID               string            `json:"podID,omitempty"`
+	NodeID              string            `json:"nodeID,omitempty"`
+	K8sNamespace        string            `json:"k8sNamespace,omitempty"`
+	CommandRegex        string            `json:"commandRegex,omitempty"`
+	Matchers            []ProcessMatcher  `json:"matchers,omitempty"`           // ANDed with the command regex
+	ThreadRegex         string            `json:"threadRegex,omitempty"`        // binds the matching threads of the processes instead of the processes
+	IncludeDescendants  bool              `json:"includeDescendants,omitempty"` // also binds the descendants of the matching processes
+	ContainerIDs        []string          `json:"containerIDs,omitempty"`       // containers of the pod the intent is restricted to, every container if empty
+	Priority            int               `json:"priority,omitempty"`
+	ExecutionTime       int64             `json:"executionTime,omitempty"`
+	PodLabels           map[string]string `json:"podLabels,omitempty"`
+	Selector            []LabelSelector   `json:"selector,omitempty"` // label selector of the strategy that targeted the pod
+	StrategyID          string            `json:"strategyID,omitempty"`
+	Weight              int               `json:"weight,omitempty"`      // precedence of the strategy, see ComparePrecedence
+	Specificity         int               `json:"specificity,omitempty"` // criteria count of the strategy
+	StrategyCreatedTime int64             `json:"strategyCreatedTime,omitempty"`
+}
+
+// IntentResultState is the outcome of binding an intent to the processes of its pod
+type IntentResultState string
+
+const (
+	IntentResultApplied           IntentResultState = "Applied"
+	IntentResultNoMatchingProcess IntentResultState = "NoMatchingProcess"
+	IntentResultFailed            IntentResultState = "Failed"
+)
+
+// IntentResult acknowledges an intent received from the manager, intents are identified by their pod ID, strategy, command regex, matchers,
+// thread regex and containers
+type IntentResult struct {
+	PodID        string            `json:"podID"`
+	StrategyID   string            `json:"strategyID,omitempty"`
+	CommandRegex string            `json:"commandRegex,omitempty"`
+	Matchers     []ProcessMatcher  `json:"matchers,omitempty"`
+	ThreadRegex  string            `json:"threadRegex,omitempty"`
+	ContainerIDs []string          `json:"containerIDs,omitempty"`
+	State        IntentResultState `json:"state"`
+	MatchedPIDs  int               `json:"matchedPIDs"`
+	MatchedTIDs  int               `json:"matchedTIDs,omitempty"` // threads bound by an intent with a thread regex
+	Error        string            `json:"error,omitempty"`
+}
+
+// IntentPreview lists the processes an intent would be bound to, without the intent being retained.
+// With a thread regex, the threads of every process are restricted to the matching ones.
+type IntentPreview struct {
+	PodID        string           `json:"podID"`
+	CommandRegex string           `json:"commandRegex,omitempty"`
+	Matchers     []ProcessMatcher `json:"matchers,omitempty"`
+	ThreadRegex  string           `json:"threadRegex,omitempty"`
+	Processes    []PodProcess     `json:"processes"`
+	Error        string           `json:"error,omitempty"`
 }
 
 type SchedulingIntents struct {
-	Priority      bool            `json:"priority"`                // If true, set vtime to minimum vtime
-	ExecutionTime uint64          `json:"execution_time"`          // Time slice for this process in nanoseconds
-	PID           int             `json:"pid,omitempty"`           // Process ID to apply this strategy to
-	Selectors     []LabelSelector `json:"selectors,omitempty"`     // Label selectors to match pods
-	CommandRegex  string          `json:"command_regex,omitempty"` // Regex to match process command
+	Priority      bool             `json:"priority"`                // If true, set vtime to minimum vtime
+	ExecutionTime uint64           `json:"execution_time"`          // Time slice for this process in nanoseconds
+	PID           int              `json:"pid,omitempty"`           // Process ID to apply this strategy to
+	TID           int              `json:"tid,omitempty"`           // Thread of the process to apply this strategy to, it overrides the entry of the process for this thread
+	Selectors     []LabelSelector  `json:"selectors,omitempty"`     // Label selectors to match pods
+	CommandRegex  string           `json:"command_regex,omitempty"` // Regex to match process command
+	Matchers      []ProcessMatcher `json:"matchers,omitempty"`      // Matchers of the process fields, ANDed with the command regex
+	ThreadRegex   string           `json:"thread_regex,omitempty"`  // Regex of the thread names, set on the entries of threads
+	StrategyID    string           `json:"strategy_id,omitempty"`   // Strategy of the intent that took precedence for this process
 }
 
+// LabelSelector is an equality selector when the operator is empty, otherwise a requirement of a kubernetes label selector
 type LabelSelector struct {
-	Key   string `json:"key"`
-	Value string `json:"value"`
+	Key      string   `json:"key"`
+	Value    string   `json:"value"`
+	Operator string   `json:"operator,omitempty"` // In, NotIn, Exists or DoesNotExist
+	Values   []string `json:"values,omitempty"`
 }
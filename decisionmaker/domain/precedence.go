@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"cmp"
+	"strings"
+)
+
+// ComparePrecedence orders two intents bound to the same process, it returns a positive number when a takes precedence over b.
+// The higher weight wins, then the more specific strategy, then the newest strategy; the strategy ID, the command regex,
+// the matchers and the thread regex break the remaining ties, the manager resolves the intents with the same rules.
+func ComparePrecedence(a, b *Intent) int {
+	if c := cmp.Compare(a.Weight, b.Weight); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Specificity, b.Specificity); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.StrategyCreatedTime, b.StrategyCreatedTime); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.StrategyID, b.StrategyID); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.CommandRegex, b.CommandRegex); c != 0 {
+		return c
+	}
+	if c := strings.Compare(MatchersKey(a.Matchers), MatchersKey(b.Matchers)); c != 0 {
+		return c
+	}
+	return strings.Compare(a.ThreadRegex, b.ThreadRegex)
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "context"
+
+type ProcessEventType int8
+
+const (
+	ProcessEventUnknown ProcessEventType = iota
+	// ProcessAppeared is emitted when a process shows up in a pod
+	ProcessAppeared
+	// ProcessGone is emitted when a process of a pod exits
+	ProcessGone
+	// ProcessSynced is emitted once the watcher has reported every process that existed when it started
+	ProcessSynced
+	// ProcessThreadsChanged is emitted with the current threads of a known process when threads were created or exited
+	ProcessThreadsChanged
+)
+
+// ProcessEvent describes a change of the processes running in a pod
+type ProcessEvent struct {
+	Type    ProcessEventType
+	PodUID  string
+	Process PodProcess
+}
+
+// ProcessEventHandler handles the events emitted by a ProcessWatcher
+type ProcessEventHandler func(ctx context.Context, event *ProcessEvent)
+
+// ProcessWatcher discovers the processes of the pods running on the node
+type ProcessWatcher interface {
+	// Name returns the name of the discovery backend
+	Name() string
+	// Watch emits process events to handler until ctx is cancelled, it returns an error if the backend cannot run on this node
+	Watch(ctx context.Context, handler ProcessEventHandler) error
+}
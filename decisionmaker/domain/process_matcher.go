@@ -0,0 +1,29 @@
+package domain
+
+import "strings"
+
+// fields of a process a ProcessMatcher applies to
+const (
+	ProcessFieldComm    = "comm"    // command name, truncated to 15 characters by the kernel
+	ProcessFieldCmdline = "cmdline" // full command line, the arguments separated by spaces
+	ProcessFieldExe     = "exe"     // path of the executable
+	ProcessFieldUID     = "uid"     // effective user ID
+	ProcessFieldGID     = "gid"     // effective group ID
+	ProcessFieldCgroup  = "cgroup"  // cgroup path
+	ProcessFieldThread  = "thread"  // name of any thread of the process
+)
+
+// ProcessMatcher matches a regex against a field of a process, the matchers of an intent are ANDed with its command regex
+type ProcessMatcher struct {
+	Field string `json:"field"`
+	Regex string `json:"regex"`
+}
+
+// MatchersKey identifies a list of matchers, it is empty when there is none
+func MatchersKey(matchers []ProcessMatcher) string {
+	var key strings.Builder
+	for _, matcher := range matchers {
+		key.WriteString("&" + matcher.Field + "=" + matcher.Regex)
+	}
+	return key.String()
+}
@@ -163,7 +163,9 @@ func (h *Handler) SetupRoutes(engine *echo.Echo) error {
 		// auth routes
 		apiV1.POST("/intents", h.echoHandler(h.HandleIntents), echo.WrapMiddleware(authMiddleware))
 		apiV1.DELETE("/intents", h.echoHandler(h.DeleteIntent), echo.WrapMiddleware(authMiddleware))
+		apiV1.POST("/intents/preview", h.echoHandler(h.PreviewIntents), echo.WrapMiddleware(authMiddleware))
 		apiV1.GET("/scheduling/strategies", h.echoHandler(h.ListIntents), echo.WrapMiddleware(authMiddleware))
+		apiV1.GET("/scheduling/pods", h.echoHandler(h.ListPodIntents), echo.WrapMiddleware(authMiddleware))
 		apiV1.POST("/metrics", h.echoHandler(h.UpdateMetrics), echo.WrapMiddleware(authMiddleware))
 		// token routes
 		apiV1.POST("/auth/token", h.echoHandler(h.GenTokenHandler))
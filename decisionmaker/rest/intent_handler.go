@@ -12,14 +12,48 @@ type HandleIntentsRequest struct {
 }
 
 type Intent struct {
-	PodName       string            `json:"podName,omitempty"`
-	PodID         string            `json:"podID,omitempty"`
-	NodeID        string            `json:"nodeID,omitempty"`
-	K8sNamespace  string            `json:"k8sNamespace,omitempty"`
-	CommandRegex  string            `json:"commandRegex,omitempty"`
-	Priority      int               `json:"priority,omitempty"`
-	ExecutionTime int64             `json:"executionTime,omitempty"`
-	PodLabels     map[string]string `json:"podLabels,omitempty"`
+	PodName             string            `json:"podName,omitempty"`
+	PodID               string            `json:"podID,omitempty"`
+	NodeID              string            `json:"nodeID,omitempty"`
+	K8sNamespace        string            `json:"k8sNamespace,omitempty"`
+	CommandRegex        string            `json:"commandRegex,omitempty"`
+	Matchers            []ProcessMatcher  `json:"matchers,omitempty"`           // ANDed with the command regex
+	ThreadRegex         string            `json:"threadRegex,omitempty"`        // binds the matching threads of the processes instead of the processes
+	IncludeDescendants  bool              `json:"includeDescendants,omitempty"` // also binds the descendants of the matching processes, whatever their command
+	ContainerIDs        []string          `json:"containerIDs,omitempty"`       // containers of the pod the intent is restricted to, every container if empty
+	Priority            int               `json:"priority,omitempty"`
+	ExecutionTime       int64             `json:"executionTime,omitempty"`
+	PodLabels           map[string]string `json:"podLabels,omitempty"`
+	Selector            []LabelSelector   `json:"selector,omitempty"`
+	StrategyID          string            `json:"strategyID,omitempty"`
+	Weight              int               `json:"weight,omitempty"`
+	Specificity         int               `json:"specificity,omitempty"`
+	StrategyCreatedTime int64             `json:"strategyCreatedTime,omitempty"` // unix milli, the newest strategy wins a tie of weight and specificity
+}
+
+// HandleIntentsResponse acknowledges every received intent, in the order of the request
+type HandleIntentsResponse struct {
+	Results []IntentResult `json:"results"`
+}
+
+// ProcessMatcher matches a regex against a field of a process: comm, cmdline, exe, uid, gid, cgroup or thread
+type ProcessMatcher struct {
+	Field string `json:"field"`
+	Regex string `json:"regex"`
+}
+
+// IntentResult is the outcome of binding an intent to the processes of its pod
+type IntentResult struct {
+	PodID        string           `json:"podID"`
+	StrategyID   string           `json:"strategyID,omitempty"`
+	CommandRegex string           `json:"commandRegex,omitempty"`
+	Matchers     []ProcessMatcher `json:"matchers,omitempty"`
+	ThreadRegex  string           `json:"threadRegex,omitempty"`
+	ContainerIDs []string         `json:"containerIDs,omitempty"`
+	State        string           `json:"state"` // Applied, NoMatchingProcess or Failed
+	MatchedPIDs  int              `json:"matchedPIDs"`
+	MatchedTIDs  int              `json:"matchedTIDs,omitempty"`
+	Error        string           `json:"error,omitempty"`
 }
 
 func (h *Handler) HandleIntents(w http.ResponseWriter, r *http.Request) {
@@ -30,40 +64,187 @@ func (h *Handler) HandleIntents(w http.ResponseWriter, r *http.Request) {
 		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Invalid request payload", err)
 		return
 	}
-	intents := make([]*domain.Intent, 0, len(req.Intents))
-	for _, intent := range req.Intents {
+	results, err := h.Service.ProcessIntents(r.Context(), toDomainIntents(req.Intents))
+	if err != nil {
+		h.ErrorResponse(ctx, w, http.StatusInternalServerError, "Failed to process intents", err)
+		return
+	}
+	resp := HandleIntentsResponse{
+		Results: make([]IntentResult, 0, len(results)),
+	}
+	for _, result := range results {
+		resp.Results = append(resp.Results, IntentResult{
+			PodID:        result.PodID,
+			StrategyID:   result.StrategyID,
+			CommandRegex: result.CommandRegex,
+			Matchers:     convertDomainMatchers(result.Matchers),
+			ThreadRegex:  result.ThreadRegex,
+			ContainerIDs: result.ContainerIDs,
+			State:        string(result.State),
+			MatchedPIDs:  result.MatchedPIDs,
+			MatchedTIDs:  result.MatchedTIDs,
+			Error:        result.Error,
+		})
+	}
+	h.JSONResponse(ctx, w, http.StatusOK, NewSuccessResponse[HandleIntentsResponse](&resp))
+}
+
+func toDomainIntents(reqIntents []Intent) []*domain.Intent {
+	intents := make([]*domain.Intent, 0, len(reqIntents))
+	for _, intent := range reqIntents {
 		intents = append(intents, &domain.Intent{
-			PodName:       intent.PodName,
-			PodID:         intent.PodID,
-			NodeID:        intent.NodeID,
-			K8sNamespace:  intent.K8sNamespace,
-			CommandRegex:  intent.CommandRegex,
-			Priority:      intent.Priority,
-			ExecutionTime: intent.ExecutionTime,
-			PodLabels:     intent.PodLabels,
+			PodName:             intent.PodName,
+			PodID:               intent.PodID,
+			NodeID:              intent.NodeID,
+			K8sNamespace:        intent.K8sNamespace,
+			CommandRegex:        intent.CommandRegex,
+			Matchers:            toDomainMatchers(intent.Matchers),
+			ThreadRegex:         intent.ThreadRegex,
+			IncludeDescendants:  intent.IncludeDescendants,
+			ContainerIDs:        intent.ContainerIDs,
+			Priority:            intent.Priority,
+			ExecutionTime:       intent.ExecutionTime,
+			PodLabels:           intent.PodLabels,
+			Selector:            toDomainLabelSelectors(intent.Selector),
+			StrategyID:          intent.StrategyID,
+			Weight:              intent.Weight,
+			Specificity:         intent.Specificity,
+			StrategyCreatedTime: intent.StrategyCreatedTime,
 		})
 	}
-	err = h.Service.ProcessIntents(r.Context(), intents)
+	return intents
+}
+
+func toDomainLabelSelectors(selectors []LabelSelector) []domain.LabelSelector {
+	domainSelectors := make([]domain.LabelSelector, 0, len(selectors))
+	for _, sel := range selectors {
+		domainSelectors = append(domainSelectors, domain.LabelSelector{
+			Key:      sel.Key,
+			Value:    sel.Value,
+			Operator: sel.Operator,
+			Values:   sel.Values,
+		})
+	}
+	return domainSelectors
+}
+
+func toDomainMatchers(matchers []ProcessMatcher) []domain.ProcessMatcher {
+	if len(matchers) == 0 {
+		return nil
+	}
+	domainMatchers := make([]domain.ProcessMatcher, 0, len(matchers))
+	for _, matcher := range matchers {
+		domainMatchers = append(domainMatchers, domain.ProcessMatcher{Field: matcher.Field, Regex: matcher.Regex})
+	}
+	return domainMatchers
+}
+
+func convertDomainMatchers(matchers []domain.ProcessMatcher) []ProcessMatcher {
+	if len(matchers) == 0 {
+		return nil
+	}
+	respMatchers := make([]ProcessMatcher, 0, len(matchers))
+	for _, matcher := range matchers {
+		respMatchers = append(respMatchers, ProcessMatcher{Field: matcher.Field, Regex: matcher.Regex})
+	}
+	return respMatchers
+}
+
+// PreviewIntentsResponse lists the processes every intent of the request would be bound to, in the order of the request
+type PreviewIntentsResponse struct {
+	Previews []IntentPreview `json:"previews"`
+}
+
+type IntentPreview struct {
+	PodID        string           `json:"podID"`
+	CommandRegex string           `json:"commandRegex,omitempty"`
+	Matchers     []ProcessMatcher `json:"matchers,omitempty"`
+	ThreadRegex  string           `json:"threadRegex,omitempty"`
+	Processes    []PreviewProcess `json:"processes"`
+	Error        string           `json:"error,omitempty"`
+}
+
+type PreviewProcess struct {
+	PID         int             `json:"pid"`
+	Command     string          `json:"command"`
+	ContainerID string          `json:"containerID,omitempty"`
+	Cmdline     string          `json:"cmdline,omitempty"`
+	Exe         string          `json:"exe,omitempty"`
+	UID         int             `json:"uid"`               // -1 if unknown
+	GID         int             `json:"gid"`               // -1 if unknown
+	Threads     []PreviewThread `json:"threads,omitempty"` // restricted to the matching threads with a thread regex
+}
+
+type PreviewThread struct {
+	TID  int    `json:"tid"`
+	Name string `json:"name"`
+}
+
+// PreviewIntents matches the intents against the live processes without retaining them
+func (h *Handler) PreviewIntents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req HandleIntentsRequest
+	err := h.JSONBind(r, &req)
+	if err != nil {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+	previews, err := h.Service.PreviewIntents(ctx, toDomainIntents(req.Intents))
 	if err != nil {
-		h.ErrorResponse(ctx, w, http.StatusInternalServerError, "Failed to process intents", err)
+		h.ErrorResponse(ctx, w, http.StatusInternalServerError, "Failed to preview intents", err)
 		return
 	}
-	h.JSONResponse(ctx, w, http.StatusOK, NewSuccessResponse[EmptyResponse](nil))
+	resp := PreviewIntentsResponse{
+		Previews: make([]IntentPreview, 0, len(previews)),
+	}
+	for _, preview := range previews {
+		intentPreview := IntentPreview{
+			PodID:        preview.PodID,
+			CommandRegex: preview.CommandRegex,
+			Matchers:     convertDomainMatchers(preview.Matchers),
+			ThreadRegex:  preview.ThreadRegex,
+			Processes:    make([]PreviewProcess, 0, len(preview.Processes)),
+			Error:        preview.Error,
+		}
+		for _, process := range preview.Processes {
+			previewProcess := PreviewProcess{
+				PID:         process.PID,
+				Command:     process.Command,
+				ContainerID: process.ContainerID,
+				Cmdline:     process.Cmdline,
+				Exe:         process.Exe,
+				UID:         process.UID,
+				GID:         process.GID,
+			}
+			for _, thread := range process.Threads {
+				previewProcess.Threads = append(previewProcess.Threads, PreviewThread{TID: thread.TID, Name: thread.Name})
+			}
+			intentPreview.Processes = append(intentPreview.Processes, previewProcess)
+		}
+		resp.Previews = append(resp.Previews, intentPreview)
+	}
+	h.JSONResponse(ctx, w, http.StatusOK, NewSuccessResponse[PreviewIntentsResponse](&resp))
 }
 
 // SchedulingStrategy represents a strategy for process scheduling
 type SchedulingIntents struct {
-	Priority      bool            `json:"priority"`                // If true, set vtime to minimum vtime
-	ExecutionTime uint64          `json:"execution_time"`          // Time slice for this process in nanoseconds
-	PID           int             `json:"pid,omitempty"`           // Process ID to apply this strategy to
-	Selectors     []LabelSelector `json:"selectors,omitempty"`     // Label selectors to match pods
-	CommandRegex  string          `json:"command_regex,omitempty"` // Regex to match process command
+	Priority      bool             `json:"priority"`                // If true, set vtime to minimum vtime
+	ExecutionTime uint64           `json:"execution_time"`          // Time slice for this process in nanoseconds
+	PID           int              `json:"pid,omitempty"`           // Process ID to apply this strategy to
+	TID           int              `json:"tid,omitempty"`           // Thread of the process to apply this strategy to, it overrides the entry of the process for this thread
+	Selectors     []LabelSelector  `json:"selectors,omitempty"`     // Label selectors to match pods
+	CommandRegex  string           `json:"command_regex,omitempty"` // Regex to match process command
+	Matchers      []ProcessMatcher `json:"matchers,omitempty"`      // Matchers of the process fields, ANDed with the command regex
+	ThreadRegex   string           `json:"thread_regex,omitempty"`  // Regex of the thread names, set on the entries of threads
+	StrategyID    string           `json:"strategy_id,omitempty"`   // Strategy of the intent that took precedence for this process
 }
 
 // LabelSelector represents a key-value pair for pod label selection
 type LabelSelector struct {
-	Key   string `json:"key"`   // Label key
-	Value string `json:"value"` // Label value
+	Key      string   `json:"key"`                // Label key
+	Value    string   `json:"value"`              // Label value
+	Operator string   `json:"operator,omitempty"` // Operator of a label selector requirement, empty for an equality selector
+	Values   []string `json:"values,omitempty"`   // Values of a label selector requirement
 }
 
 type ListIntentsResponse struct {
@@ -87,8 +268,12 @@ func (h *Handler) ListIntents(w http.ResponseWriter, r *http.Request) {
 			Priority:      intent.Priority,
 			ExecutionTime: intent.ExecutionTime,
 			PID:           intent.PID,
+			TID:           intent.TID,
 			Selectors:     convertMapToLabelSelectors(intent.Selectors),
 			CommandRegex:  intent.CommandRegex,
+			Matchers:      convertDomainMatchers(intent.Matchers),
+			ThreadRegex:   intent.ThreadRegex,
+			StrategyID:    intent.StrategyID,
 		})
 	}
 
@@ -101,21 +286,67 @@ func (h *Handler) ListIntents(w http.ResponseWriter, r *http.Request) {
 	h.JSONResponse(ctx, w, http.StatusOK, response)
 }
 
+// PodSchedulingIntentsResponse lists the scheduling intents bound to the processes of a pod
+type PodSchedulingIntentsResponse struct {
+	PodID      string               `json:"podID"`
+	Scheduling []*SchedulingIntents `json:"scheduling"`
+}
+
+// ListPodIntents returns the scheduling intents currently enforced for the processes of the pod given by the podID query parameter
+func (h *Handler) ListPodIntents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	podID := r.URL.Query().Get("podID")
+	if podID == "" {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "podID is required", nil)
+		return
+	}
+	intents, err := h.Service.ListPodSchedulingIntents(ctx, podID)
+	if err != nil {
+		h.ErrorResponse(ctx, w, http.StatusInternalServerError, "Failed to list scheduling intents of pod", err)
+		return
+	}
+	resp := PodSchedulingIntentsResponse{
+		PodID:      podID,
+		Scheduling: make([]*SchedulingIntents, 0, len(intents)),
+	}
+	for _, intent := range intents {
+		resp.Scheduling = append(resp.Scheduling, &SchedulingIntents{
+			Priority:      intent.Priority,
+			ExecutionTime: intent.ExecutionTime,
+			PID:           intent.PID,
+			TID:           intent.TID,
+			Selectors:     convertMapToLabelSelectors(intent.Selectors),
+			CommandRegex:  intent.CommandRegex,
+			Matchers:      convertDomainMatchers(intent.Matchers),
+			ThreadRegex:   intent.ThreadRegex,
+			StrategyID:    intent.StrategyID,
+		})
+	}
+	h.JSONResponse(ctx, w, http.StatusOK, NewSuccessResponse[PodSchedulingIntentsResponse](&resp))
+}
+
 func convertMapToLabelSelectors(selectorMap []domain.LabelSelector) []LabelSelector {
 	labelSelectors := make([]LabelSelector, 0, len(selectorMap))
 	for _, sel := range selectorMap {
 		labelSelectors = append(labelSelectors, LabelSelector{
-			Key:   sel.Key,
-			Value: sel.Value,
+			Key:      sel.Key,
+			Value:    sel.Value,
+			Operator: sel.Operator,
+			Values:   sel.Values,
 		})
 	}
 	return labelSelectors
 }
 
 type DeleteIntentRequest struct {
-	PodID string `json:"podId,omitempty"` // If provided, deletes all intents for this pod
-	PID   *int   `json:"pid,omitempty"`   // If provided with PodID, deletes specific intent
-	All   bool   `json:"all,omitempty"`   // If true, deletes all intents
+	PodID        string           `json:"podId,omitempty"`        // If provided, deletes all intents for this pod
+	PID          *int             `json:"pid,omitempty"`          // If provided with PodID, deletes the scheduling intents of this process and its threads, which are not bound again until the process exits
+	CommandRegex *string          `json:"commandRegex,omitempty"` // If provided with PodID, deletes the intent of the pod with this command regex
+	StrategyID   string           `json:"strategyID,omitempty"`   // Strategy of the intent to delete with CommandRegex
+	Matchers     []ProcessMatcher `json:"matchers,omitempty"`     // Matchers of the intent to delete with CommandRegex
+	ThreadRegex  string           `json:"threadRegex,omitempty"`  // Thread regex of the intent to delete with CommandRegex
+	ContainerIDs []string         `json:"containerIDs,omitempty"` // Containers of the intent to delete with CommandRegex
+	All          bool             `json:"all,omitempty"`          // If true, deletes all intents
 }
 
 func (h *Handler) DeleteIntent(w http.ResponseWriter, r *http.Request) {
@@ -144,6 +375,15 @@ func (h *Handler) DeleteIntent(w http.ResponseWriter, r *http.Request) {
 
 	if req.PID != nil {
 		err = h.Service.DeleteIntentByPID(ctx, req.PodID, *req.PID)
+	} else if req.CommandRegex != nil {
+		err = h.Service.DeleteIntentByCommandRegex(ctx, &domain.Intent{
+			PodID:        req.PodID,
+			StrategyID:   req.StrategyID,
+			CommandRegex: *req.CommandRegex,
+			Matchers:     toDomainMatchers(req.Matchers),
+			ThreadRegex:  req.ThreadRegex,
+			ContainerIDs: req.ContainerIDs,
+		})
 	} else {
 		err = h.Service.DeleteIntentByPodID(ctx, req.PodID)
 	}
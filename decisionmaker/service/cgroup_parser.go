@@ -0,0 +1,98 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Support multiple cgroup formats:
+// - systemd: kubelet-kubepods-pod20da609e_6973_4463_a1f9_2db9bcc5becc.slice (underscores)
+// - cgroupfs: /kubepods/burstable/pod31e4e721-a5a0-421a-ae1d-b7971ae30d6e/ (dashes)
+var podRegex = regexp.MustCompile(`pod([0-9a-fA-F]{8}[-_][0-9a-fA-F]{4}[-_][0-9a-fA-F]{4}[-_][0-9a-fA-F]{4}[-_][0-9a-fA-F]{12})`)
+
+// containerIDRegex matches the 64 hex characters ID of a container, shared by containerd, CRI-O and Docker
+var containerIDRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// containerCgroupParser extracts the ID of a container from the name of its cgroup, the child of the pod cgroup.
+// It reports false when the cgroup is not a container cgroup of its runtime.
+type containerCgroupParser func(name string) (containerID string, ok bool)
+
+// containerCgroupParsers are tried in order on the name of the cgroup of a container, the first match wins
+var containerCgroupParsers = []containerCgroupParser{
+	// containerd with the systemd cgroup driver: cri-containerd-<id>.scope
+	prefixedCgroupParser("cri-containerd-"),
+	// CRI-O with the systemd cgroup driver: crio-<id>.scope, or the cgroupfs driver: crio-<id>;
+	// crio-conmon-<id>.scope runs the monitor of the container, not the container
+	prefixedCgroupParser("crio-"),
+	// Docker with the systemd cgroup driver: docker-<id>.scope
+	prefixedCgroupParser("docker-"),
+	// containerd and Docker with the cgroupfs driver: /kubepods/burstable/pod<uid>/<id>
+	prefixedCgroupParser(""),
+}
+
+// prefixedCgroupParser parses the cgroup names made of the prefix, the container ID and an optional .scope suffix
+func prefixedCgroupParser(prefix string) containerCgroupParser {
+	return func(name string) (string, bool) {
+		containerID, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			return "", false
+		}
+		containerID = strings.TrimSuffix(containerID, ".scope")
+		return containerID, containerIDRegex.MatchString(containerID)
+	}
+}
+
+// parseContainerCgroup returns the ID of the container of a cgroup name, or an empty ID when no runtime recognizes it
+func parseContainerCgroup(name string) string {
+	for _, parser := range containerCgroupParsers {
+		if containerID, ok := parser(name); ok {
+			return containerID
+		}
+	}
+	return ""
+}
+
+// getPodInfoFromCgroup extracts the pod UID and the container ID from a cgroup path, the container ID is the name of the child
+// of the pod cgroup and is empty for the processes of the pod cgroup itself
+func getPodInfoFromCgroup(cgroupPath string) (podUID string, containerID string, err error) {
+	// e.g. /kubelet.slice/kubelet-kubepods.slice/kubelet-kubepods-pod20da609e_6973_4463_a1f9_2db9bcc5becc.slice/cri-containerd-10ec3c89629f71226b227e6510b2d465168b24005bbdcc5d7940517080830635.scope
+	parts := strings.Split(cgroupPath, "/")
+	for i, part := range parts {
+		match := podRegex.FindStringSubmatch(part)
+		if match == nil {
+			continue
+		}
+		podUID = strings.ReplaceAll(match[1], "_", "-")
+		containerID = ""
+		if i+1 < len(parts) {
+			containerID = parseContainerCgroup(parts[i+1])
+		}
+	}
+
+	if podUID == "" {
+		return "", "", fmt.Errorf("pod UID not found in cgroup path")
+	}
+
+	return podUID, containerID, nil
+}
+
+// parseProcCgroup picks the cgroup of a pod among the lines of /proc/<pid>/cgroup: the unified hierarchy on cgroup v2 (0::<path>),
+// or one line per hierarchy on cgroup v1 (<id>:<controllers>:<path>), where the first path of a container wins over the ones of
+// the pod. It reports false when the process does not run in a pod.
+func parseProcCgroup(content string) (cgroupPath string, podUID string, containerID string, ok bool) {
+	for _, line := range strings.Split(content, "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) < 3 || !strings.Contains(parts[2], kubepodsCgroupName) {
+			continue
+		}
+		linePodUID, lineContainerID, err := getPodInfoFromCgroup(parts[2])
+		if err != nil {
+			continue
+		}
+		if !ok || (containerID == "" && lineContainerID != "") {
+			cgroupPath, podUID, containerID, ok = parts[2], linePodUID, lineContainerID, true
+		}
+	}
+	return cgroupPath, podUID, containerID, ok
+}
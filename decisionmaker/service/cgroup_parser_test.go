@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Gthulhu/api/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addFakeCgroupProcess adds a fake process with the given /proc/<pid>/cgroup lines to the fake /proc directory
+func addFakeCgroupProcess(t *testing.T, root string, pid string, comm string, cgroupLines ...string) {
+	pidDir := filepath.Join(root, pid)
+	require.NoError(t, os.Mkdir(pidDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(pidDir, "cgroup"), []byte(strings.Join(cgroupLines, "\n")+"\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(pidDir, "comm"), []byte(comm+"\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(pidDir, "stat"), []byte(pid+" ("+comm+") S 1 2 3 4 5"), 0644))
+}
+
+// TestFindPodInfoFromRuntimes tests that the pod UID and the container ID of a process are found in the cgroup layouts of the
+// container runtimes and cgroup drivers, on cgroup v1 and v2, and that a process is reported once
+func TestFindPodInfoFromRuntimes(t *testing.T) {
+	logger.InitLogger()
+	const (
+		systemdPod  = "kubepods-burstable-pod20da609e_6973_4463_a1f9_2db9bcc5becc.slice"
+		cgroupfsPod = "pod20da609e-6973-4463-a1f9-2db9bcc5becc"
+	)
+	tests := []struct {
+		name        string
+		cgroup      []string
+		podUID      string
+		containerID string
+		cgroupPath  string
+	}{
+		{
+			name:        "containerd systemd v2",
+			cgroup:      []string{"0::/kubepods.slice/kubepods-burstable.slice/" + systemdPod + "/cri-containerd-" + testContainerID + ".scope"},
+			podUID:      testPodUID,
+			containerID: testContainerID,
+			cgroupPath:  "/kubepods.slice/kubepods-burstable.slice/" + systemdPod + "/cri-containerd-" + testContainerID + ".scope",
+		},
+		{
+			name:        "cri-o systemd v2",
+			cgroup:      []string{"0::/kubepods.slice/kubepods-burstable.slice/" + systemdPod + "/crio-" + testContainerID + ".scope"},
+			podUID:      testPodUID,
+			containerID: testContainerID,
+		},
+		{
+			name:   "cri-o conmon",
+			cgroup: []string{"0::/kubepods.slice/kubepods-burstable.slice/" + systemdPod + "/crio-conmon-" + testContainerID + ".scope"},
+			podUID: testPodUID,
+		},
+		{
+			name:        "cri-o cgroupfs v2",
+			cgroup:      []string{"0::/kubepods/burstable/" + cgroupfsPod + "/crio-" + testContainerID},
+			podUID:      testPodUID,
+			containerID: testContainerID,
+		},
+		{
+			name:        "docker systemd v2",
+			cgroup:      []string{"0::/kubepods.slice/kubepods-burstable.slice/" + systemdPod + "/docker-" + testContainerID + ".scope"},
+			podUID:      testPodUID,
+			containerID: testContainerID,
+		},
+		{
+			name:        "cgroupfs guaranteed v2",
+			cgroup:      []string{"0::/kubepods/" + cgroupfsPod + "/" + testContainerID},
+			podUID:      testPodUID,
+			containerID: testContainerID,
+		},
+		{
+			name: "cgroupfs v1",
+			cgroup: []string{
+				"12:pids:/kubepods/burstable/" + cgroupfsPod + "/" + testContainerID,
+				"11:memory:/kubepods/burstable/" + cgroupfsPod + "/" + testContainerID,
+				"4:cpu,cpuacct:/kubepods/burstable/" + cgroupfsPod + "/" + testContainerID,
+				"1:name=systemd:/kubepods/burstable/" + cgroupfsPod + "/" + testContainerID,
+				"0::/",
+			},
+			podUID:      testPodUID,
+			containerID: testContainerID,
+			cgroupPath:  "/kubepods/burstable/" + cgroupfsPod + "/" + testContainerID,
+		},
+		{
+			name: "systemd v1 with a pod level hierarchy",
+			cgroup: []string{
+				"12:pids:/kubepods.slice/kubepods-burstable.slice/" + systemdPod,
+				"11:memory:/kubepods.slice/kubepods-burstable.slice/" + systemdPod + "/docker-" + testContainerID + ".scope",
+			},
+			podUID:      testPodUID,
+			containerID: testContainerID,
+		},
+		{
+			name:   "not a pod",
+			cgroup: []string{"0::/system.slice/containerd.service"},
+		},
+		{
+			name:   "kubepods without pod",
+			cgroup: []string{"0::/kubepods.slice/kubepods-burstable.slice"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeProc := t.TempDir()
+			addFakeCgroupProcess(t, fakeProc, "4321", "app", tt.cgroup...)
+
+			pods, err := findPodInfoFrom(context.Background(), fakeProc)
+			require.NoError(t, err)
+			if tt.podUID == "" {
+				assert.Empty(t, pods)
+				return
+			}
+			require.Len(t, pods, 1)
+			pod := pods[tt.podUID]
+			require.NotNil(t, pod)
+			require.Len(t, pod.Processes, 1, "the process should be reported once")
+			assert.Equal(t, 4321, pod.Processes[0].PID)
+			assert.Equal(t, tt.containerID, pod.Processes[0].ContainerID)
+			if tt.cgroupPath != "" {
+				assert.Equal(t, tt.cgroupPath, pod.Processes[0].Cgroup)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Gthulhu/api/decisionmaker/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	cgroupProcsFile       = "cgroup.procs"
+	cgroupEventsFile      = "cgroup.events"
+	cgroupControllersFile = "cgroup.controllers"
+	kubepodsCgroupName    = "kubepods"
+)
+
+// CgroupWatcher discovers the processes of the pods by watching the kubepods cgroup v2 hierarchy with inotify.
+// New pod and container cgroups are picked up when their directory is created, and the cgroup.procs file of every
+// container cgroup is re-read when it changes. Since the kernel does not notify every fork, the known cgroups are
+// also re-read every resyncInterval, which is still much cheaper than walking /proc.
+type CgroupWatcher struct {
+	cgroupRoot     string
+	procRoot       string
+	resyncInterval time.Duration
+	// known holds the pids reported for every pod cgroup directory along with their threads, it is only accessed from the Watch goroutine
+	known map[string]map[int][]domain.Thread
+}
+
+func NewCgroupWatcher(cgroupRoot string, procRoot string, resyncInterval time.Duration) *CgroupWatcher {
+	return &CgroupWatcher{
+		cgroupRoot:     cgroupRoot,
+		procRoot:       procRoot,
+		resyncInterval: resyncInterval,
+		known:          make(map[string]map[int][]domain.Thread),
+	}
+}
+
+func (w *CgroupWatcher) Name() string {
+	return DiscoveryBackendCgroup
+}
+
+// Watch reports the processes of the existing pod cgroups, then follows the changes of the hierarchy until ctx is cancelled
+func (w *CgroupWatcher) Watch(ctx context.Context, handler domain.ProcessEventHandler) error {
+	if _, err := os.Stat(filepath.Join(w.cgroupRoot, cgroupControllersFile)); err != nil {
+		return fmt.Errorf("%s is not a cgroup v2 hierarchy: %w", w.cgroupRoot, err)
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create inotify watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	err = w.addTree(ctx, watcher, w.cgroupRoot, handler)
+	if err != nil {
+		return err
+	}
+	handler(ctx, &domain.ProcessEvent{Type: domain.ProcessSynced})
+
+	ticker := time.NewTicker(w.resyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return errors.New("inotify watcher closed")
+			}
+			w.handleFsEvent(ctx, watcher, event, handler)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return errors.New("inotify watcher closed")
+			}
+			logger.Logger(ctx).Warn().Err(err).Msg("cgroup watcher error, resyncing known cgroups")
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				w.resync(ctx, watcher, handler)
+			}
+		case <-ticker.C:
+			w.resync(ctx, watcher, handler)
+		}
+	}
+}
+
+// addTree watches dir and the kubepods cgroups below it, and reports the processes they already contain
+func (w *CgroupWatcher) addTree(ctx context.Context, watcher *fsnotify.Watcher, dir string, handler domain.ProcessEventHandler) error {
+	if dir != w.cgroupRoot && !w.isKubepodsPath(dir) {
+		return nil
+	}
+	err := watcher.Add(dir)
+	if err != nil {
+		return fmt.Errorf("watch cgroup %s: %w", dir, err)
+	}
+	w.syncProcs(ctx, dir, handler)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read cgroup %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		// a child cgroup may be removed while walking the hierarchy, it must not abort the walk
+		err = w.addTree(ctx, watcher, filepath.Join(dir, entry.Name()), handler)
+		if err != nil {
+			logger.Logger(ctx).Warn().Err(err).Msgf("failed to watch cgroup %s", entry.Name())
+		}
+	}
+	return nil
+}
+
+func (w *CgroupWatcher) handleFsEvent(ctx context.Context, watcher *fsnotify.Watcher, event fsnotify.Event, handler domain.ProcessEventHandler) {
+	name := filepath.Base(event.Name)
+	switch {
+	case name == cgroupProcsFile || name == cgroupEventsFile:
+		if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) {
+			w.syncProcs(ctx, filepath.Dir(event.Name), handler)
+		}
+	case event.Has(fsnotify.Create):
+		info, err := os.Stat(event.Name)
+		if err != nil || !info.IsDir() {
+			return
+		}
+		err = w.addTree(ctx, watcher, event.Name, handler)
+		if err != nil {
+			logger.Logger(ctx).Warn().Err(err).Msgf("failed to watch new cgroup %s", event.Name)
+		}
+	case event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename):
+		w.removeTree(ctx, event.Name, handler)
+	}
+}
+
+// syncProcs re-reads the cgroup.procs file of a pod cgroup directory and reports the pids that changed since the last read,
+// as well as the known pids whose threads changed
+func (w *CgroupWatcher) syncProcs(ctx context.Context, dir string, handler domain.ProcessEventHandler) {
+	relPath, err := filepath.Rel(w.cgroupRoot, dir)
+	if err != nil {
+		return
+	}
+	podUID, containerID, err := getPodInfoFromCgroup("/" + filepath.ToSlash(relPath))
+	if err != nil {
+		// not a pod cgroup (e.g. the kubepods or QoS slices)
+		return
+	}
+	pids, err := readCgroupProcs(filepath.Join(dir, cgroupProcsFile))
+	if err != nil && !os.IsNotExist(err) {
+		logger.Logger(ctx).Warn().Err(err).Msgf("failed to read %s of cgroup %s", cgroupProcsFile, dir)
+		return
+	}
+
+	known := w.known[dir]
+	current := make(map[int][]domain.Thread, len(pids))
+	for pid := range pids {
+		threads, ok := known[pid]
+		if ok {
+			current[pid] = threads
+			if slices.Equal(threads, getProcessThreads(w.procRoot, pid)) {
+				continue
+			}
+		}
+		process, err := getProcessInfo(w.procRoot, pid)
+		if err != nil {
+			logger.Logger(ctx).Warn().Err(err).Msgf("failed to read process info of pid %d", pid)
+			continue
+		}
+		process.ContainerID = containerID
+		process.Cgroup = "/" + filepath.ToSlash(relPath)
+		current[pid] = process.Threads
+		eventType := domain.ProcessAppeared
+		if ok {
+			eventType = domain.ProcessThreadsChanged
+		}
+		handler(ctx, &domain.ProcessEvent{Type: eventType, PodUID: podUID, Process: process})
+	}
+	for pid := range known {
+		if _, ok := pids[pid]; !ok {
+			handler(ctx, &domain.ProcessEvent{Type: domain.ProcessGone, PodUID: podUID, Process: domain.PodProcess{PID: pid, ContainerID: containerID}})
+		}
+	}
+	if len(current) == 0 {
+		delete(w.known, dir)
+		return
+	}
+	w.known[dir] = current
+}
+
+// removeTree reports the processes of a removed cgroup directory and of its children as gone
+func (w *CgroupWatcher) removeTree(ctx context.Context, dir string, handler domain.ProcessEventHandler) {
+	for knownDir := range w.known {
+		if knownDir == dir || strings.HasPrefix(knownDir, dir+string(filepath.Separator)) {
+			w.syncProcs(ctx, knownDir, handler)
+		}
+	}
+}
+
+// resync re-reads the cgroup.procs file of every known pod cgroup, and picks up the cgroups whose creation was missed
+func (w *CgroupWatcher) resync(ctx context.Context, watcher *fsnotify.Watcher, handler domain.ProcessEventHandler) {
+	for dir := range w.known {
+		w.syncProcs(ctx, dir, handler)
+	}
+	err := w.addTree(ctx, watcher, w.cgroupRoot, handler)
+	if err != nil {
+		logger.Logger(ctx).Warn().Err(err).Msg("failed to resync cgroup hierarchy")
+	}
+}
+
+func (w *CgroupWatcher) isKubepodsPath(dir string) bool {
+	relPath, err := filepath.Rel(w.cgroupRoot, dir)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(relPath, kubepodsCgroupName)
+}
+
+// readCgroupProcs reads the pids listed in a cgroup.procs file
+func readCgroupProcs(path string) (map[int]struct{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pids := make(map[int]struct{})
+	for _, field := range strings.Fields(string(data)) {
+		pid, err := strconv.Atoi(field)
+		if err != nil {
+			continue
+		}
+		pids[pid] = struct{}{}
+	}
+	return pids, nil
+}
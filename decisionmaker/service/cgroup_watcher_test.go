@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Gthulhu/api/decisionmaker/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPodUID         = "20da609e-6973-4463-a1f9-2db9bcc5becc"
+	testPodCgroup      = "kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod20da609e_6973_4463_a1f9_2db9bcc5becc.slice"
+	testContainerID    = "10ec3c89629f71226b227e6510b2d465168b24005bbdcc5d7940517080830635"
+	testNewContainerID = "bc96d8a88e39e8be4ff9fc02f431c7db802002c1456a56166265f19d1a3cbbc3"
+)
+
+// setupFakeCgroupDir creates a temporary cgroup v2 hierarchy with one pod container running pid 1234
+func setupFakeCgroupDir(t *testing.T) string {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "cgroup.controllers"), []byte("cpu memory pids\n"), 0644))
+	containerDir := filepath.Join(root, testPodCgroup, "cri-containerd-"+testContainerID+".scope")
+	require.NoError(t, os.MkdirAll(containerDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(containerDir, "cgroup.procs"), []byte("1234\n"), 0644))
+	// cgroups outside of kubepods are ignored
+	systemDir := filepath.Join(root, "system.slice", "sshd.service")
+	require.NoError(t, os.MkdirAll(systemDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(systemDir, "cgroup.procs"), []byte("1\n"), 0644))
+	return root
+}
+
+// writeCgroupProcs replaces the cgroup.procs file of dir atomically, so that the watcher never reads a truncated file
+func writeCgroupProcs(t *testing.T, dir string, content string) {
+	tmpFile := filepath.Join(dir, ".cgroup.procs.tmp")
+	require.NoError(t, os.WriteFile(tmpFile, []byte(content), 0644))
+	require.NoError(t, os.Rename(tmpFile, filepath.Join(dir, "cgroup.procs")))
+}
+
+func nextProcessEvent(t *testing.T, events <-chan *domain.ProcessEvent) *domain.ProcessEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for process event")
+		return nil
+	}
+}
+
+// TestCgroupWatcher tests that the cgroup watcher reports the processes of new, changed and removed pod cgroups
+func TestCgroupWatcher(t *testing.T) {
+	logger.InitLogger()
+	fakeProc := setupFakeProcDir(t)
+	addFakeProcess(t, fakeProc, "2345", "worker")
+	addFakeProcess(t, fakeProc, "3456", "sidecar")
+	fakeCgroup := setupFakeCgroupDir(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan *domain.ProcessEvent, 16)
+	watcher := NewCgroupWatcher(fakeCgroup, fakeProc, time.Hour)
+	done := make(chan error, 1)
+	go func() {
+		done <- watcher.Watch(ctx, func(ctx context.Context, event *domain.ProcessEvent) {
+			events <- event
+		})
+	}()
+
+	event := nextProcessEvent(t, events)
+	assert.Equal(t, domain.ProcessAppeared, event.Type)
+	assert.Equal(t, testPodUID, event.PodUID)
+	assert.Equal(t, 1234, event.Process.PID)
+	assert.Equal(t, "nginx", event.Process.Command)
+	assert.Equal(t, testContainerID, event.Process.ContainerID)
+	assert.Equal(t, domain.ProcessSynced, nextProcessEvent(t, events).Type)
+
+	// a process forked in the existing container
+	containerDir := filepath.Join(fakeCgroup, testPodCgroup, "cri-containerd-"+testContainerID+".scope")
+	writeCgroupProcs(t, containerDir, "1234\n2345\n")
+	event = nextProcessEvent(t, events)
+	assert.Equal(t, domain.ProcessAppeared, event.Type)
+	assert.Equal(t, 2345, event.Process.PID)
+	assert.Equal(t, "worker", event.Process.Command)
+
+	// the forked process started a thread
+	addFakeThread(t, fakeProc, "2345", 2346, "worker-io")
+	writeCgroupProcs(t, containerDir, "1234\n2345\n")
+	event = nextProcessEvent(t, events)
+	assert.Equal(t, domain.ProcessThreadsChanged, event.Type)
+	assert.Equal(t, 2345, event.Process.PID)
+	assert.Equal(t, []domain.Thread{{TID: 2346, Name: "worker-io"}}, event.Process.Threads)
+	assert.Equal(t, testContainerID, event.Process.ContainerID)
+
+	// the original process exited
+	writeCgroupProcs(t, containerDir, "2345\n")
+	event = nextProcessEvent(t, events)
+	assert.Equal(t, domain.ProcessGone, event.Type)
+	assert.Equal(t, 1234, event.Process.PID)
+
+	// a new container started in the pod
+	newContainerDir := filepath.Join(fakeCgroup, testPodCgroup, "cri-containerd-"+testNewContainerID+".scope")
+	require.NoError(t, os.Mkdir(newContainerDir, 0755))
+	writeCgroupProcs(t, newContainerDir, "3456\n")
+	event = nextProcessEvent(t, events)
+	assert.Equal(t, domain.ProcessAppeared, event.Type)
+	assert.Equal(t, 3456, event.Process.PID)
+	assert.Equal(t, testNewContainerID, event.Process.ContainerID)
+
+	// the new container was removed
+	require.NoError(t, os.RemoveAll(newContainerDir))
+	event = nextProcessEvent(t, events)
+	assert.Equal(t, domain.ProcessGone, event.Type)
+	assert.Equal(t, 3456, event.Process.PID)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+// TestCgroupWatcherRequiresCgroupV2 tests that the cgroup watcher refuses to run on a hierarchy that is not cgroup v2
+func TestCgroupWatcherRequiresCgroupV2(t *testing.T) {
+	watcher := NewCgroupWatcher(t.TempDir(), t.TempDir(), time.Hour)
+	err := watcher.Watch(context.Background(), func(ctx context.Context, event *domain.ProcessEvent) {})
+	require.Error(t, err)
+}
+
+// TestRunProcessDiscoveryFallback tests that the /proc scanner takes over when the cgroup watcher cannot run
+func TestRunProcessDiscoveryFallback(t *testing.T) {
+	logger.InitLogger()
+	fakeProc := setupFakeProcDir(t)
+	svc := newTestService()
+	svc.processWatchers = []domain.ProcessWatcher{
+		NewCgroupWatcher(t.TempDir(), fakeProc, time.Hour),
+		NewProcScanner(fakeProc, time.Hour),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.RunProcessDiscovery(ctx)
+		close(done)
+	}()
+	require.Eventually(t, svc.processTable.isSynced, 5*time.Second, 10*time.Millisecond)
+	podInfos := svc.processTable.snapshot()
+	require.Len(t, podInfos, 2)
+	require.Len(t, podInfos[testPodUID].Processes, 1)
+	cancel()
+	<-done
+}
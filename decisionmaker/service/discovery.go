@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/Gthulhu/api/config"
+	"github.com/Gthulhu/api/decisionmaker/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+)
+
+const (
+	DiscoveryBackendProc   = "proc"
+	DiscoveryBackendCgroup = "cgroup"
+)
+
+// newProcessWatchers returns the discovery backends in order of preference, the /proc scanner is always the last resort
+func newProcessWatchers(cfg config.DiscoveryConfig) []domain.ProcessWatcher {
+	procScanner := NewProcScanner(procDir, cfg.RescanInterval())
+	if cfg.Backend == DiscoveryBackendCgroup {
+		return []domain.ProcessWatcher{
+			NewCgroupWatcher(cfg.GetCgroupRoot(), procDir, cfg.RescanInterval()),
+			procScanner,
+		}
+	}
+	return []domain.ProcessWatcher{procScanner}
+}
+
+// RunProcessDiscovery feeds the process events of the first discovery backend able to run into the intent store,
+// it blocks until ctx is cancelled
+func (svc *Service) RunProcessDiscovery(ctx context.Context) {
+	for _, watcher := range svc.processWatchers {
+		logger.Logger(ctx).Info().Msgf("starting %s process discovery", watcher.Name())
+		err := watcher.Watch(ctx, svc.HandleProcessEvent)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.Logger(ctx).Warn().Err(err).Msgf("%s process discovery stopped, falling back to the next backend", watcher.Name())
+		}
+	}
+	logger.Logger(ctx).Error().Msg("no process discovery backend is running")
+}
+
+// HandleProcessEvent binds the retained intents of the pod to an appeared process and evicts the scheduling intent of a gone process
+func (svc *Service) HandleProcessEvent(ctx context.Context, event *domain.ProcessEvent) {
+	svc.bindMu.Lock()
+	defer svc.bindMu.Unlock()
+	svc.processTable.apply(event)
+
+	switch event.Type {
+	case domain.ProcessAppeared, domain.ProcessThreadsChanged:
+		// the threads bound before are evicted, they may have exited
+		svc.deleteProcessSchedulingIntents(event.PodUID, event.Process.PID)
+		intents := []*domain.Intent{}
+		for _, intent := range svc.retainedIntents() {
+			if intent.PodID == event.PodUID {
+				intents = append(intents, intent)
+			}
+		}
+		if len(intents) == 0 {
+			return
+		}
+		podInfos := map[string]*domain.PodInfo{
+			event.PodUID: {
+				PodUID:    event.PodUID,
+				Processes: []domain.PodProcess{event.Process},
+			},
+		}
+		// the process may descend from a process matched by an intent, or be the parent of processes reported before it,
+		// so the intents including descendants are bound against the whole pod
+		if slices.ContainsFunc(intents, func(intent *domain.Intent) bool { return intent.IncludeDescendants }) {
+			if podInfo := svc.processTable.pod(event.PodUID); podInfo != nil {
+				podInfos[event.PodUID] = podInfo
+			}
+		}
+		bound, _ := svc.bindIntents(ctx, podInfos, intents)
+		for key, schedulingIntents := range bound {
+			svc.schedulingIntentsMap.Store(key, schedulingIntents)
+			logger.Logger(ctx).Info().Msgf("Bound scheduling intent %s to process %s", key, event.Process.Command)
+		}
+	case domain.ProcessGone:
+		if svc.deleteProcessSchedulingIntents(event.PodUID, event.Process.PID) {
+			logger.Logger(ctx).Info().Msgf("Evicted scheduling intents of exited process %s-%d", event.PodUID, event.Process.PID)
+		}
+		// the PID may be reused by another process of the pod
+		if svc.forgetDeletedPID(event.PodUID, event.Process.PID) {
+			if err := svc.persistIntents(); err != nil {
+				logger.Logger(ctx).Warn().Err(err).Msg("failed to persist the deleted processes")
+			}
+		}
+	}
+}
+
+// processTable keeps the processes of every pod on the node as reported by the process watchers
+type processTable struct {
+	mu     sync.RWMutex
+	synced bool
+	pods   map[string]map[int]domain.PodProcess
+}
+
+func newProcessTable() *processTable {
+	return &processTable{
+		pods: make(map[string]map[int]domain.PodProcess),
+	}
+}
+
+func (t *processTable) apply(event *domain.ProcessEvent) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch event.Type {
+	case domain.ProcessAppeared, domain.ProcessThreadsChanged:
+		processes, ok := t.pods[event.PodUID]
+		if !ok {
+			processes = make(map[int]domain.PodProcess)
+			t.pods[event.PodUID] = processes
+		}
+		processes[event.Process.PID] = event.Process
+	case domain.ProcessGone:
+		delete(t.pods[event.PodUID], event.Process.PID)
+		if len(t.pods[event.PodUID]) == 0 {
+			delete(t.pods, event.PodUID)
+		}
+	case domain.ProcessSynced:
+		t.synced = true
+	}
+}
+
+func (t *processTable) isSynced() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.synced
+}
+
+// pod returns the processes of a pod, or nil when the pod is unknown
+func (t *processTable) pod(podUID string) *domain.PodInfo {
+	if t == nil {
+		return nil
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	processes, ok := t.pods[podUID]
+	if !ok {
+		return nil
+	}
+	return newPodInfo(podUID, processes)
+}
+
+func (t *processTable) snapshot() map[string]*domain.PodInfo {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	podInfos := make(map[string]*domain.PodInfo, len(t.pods))
+	for podUID, processes := range t.pods {
+		podInfos[podUID] = newPodInfo(podUID, processes)
+	}
+	return podInfos
+}
+
+func newPodInfo(podUID string, processes map[int]domain.PodProcess) *domain.PodInfo {
+	podInfo := &domain.PodInfo{
+		PodUID:    podUID,
+		Processes: make([]domain.PodProcess, 0, len(processes)),
+	}
+	for _, process := range processes {
+		podInfo.Processes = append(podInfo.Processes, process)
+	}
+	return podInfo
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Gthulhu/api/decisionmaker/domain"
+)
+
+const (
+	intentStoreFileName = "intents.json"
+	intentStoreVersion  = 1
+)
+
+// intentStoreFile is the on-disk format of the intent store
+type intentStoreFile struct {
+	Version     int              `json:"version"`
+	Intents     []*domain.Intent `json:"intents"`
+	DeletedPIDs map[string][]int `json:"deletedPIDs,omitempty"` // processes per pod whose scheduling intents were deleted
+}
+
+// fileIntentStore persists the intents received from the manager to a snapshot file under the state directory,
+// so that they can be bound again to the live PIDs after a restart of the decision maker
+type fileIntentStore struct {
+	path string
+}
+
+func newFileIntentStore(stateDir string) (*fileIntentStore, error) {
+	err := os.MkdirAll(stateDir, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("create state dir %s: %w", stateDir, err)
+	}
+	return &fileIntentStore{
+		path: filepath.Join(stateDir, intentStoreFileName),
+	}, nil
+}
+
+// Load returns the persisted intents and deleted processes, or nothing if nothing was persisted yet
+func (s *fileIntentStore) Load() ([]*domain.Intent, map[string][]int, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("read intent store %s: %w", s.path, err)
+	}
+	var file intentStoreFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode intent store %s: %w", s.path, err)
+	}
+	if file.Version != intentStoreVersion {
+		return nil, nil, fmt.Errorf("unsupported intent store version %d", file.Version)
+	}
+	return file.Intents, file.DeletedPIDs, nil
+}
+
+// Save replaces the persisted intents and deleted processes, the snapshot is written to a temporary file and renamed so that
+// a crash never leaves a partial file
+func (s *fileIntentStore) Save(intents []*domain.Intent, deletedPIDs map[string][]int) error {
+	data, err := json.Marshal(intentStoreFile{
+		Version:     intentStoreVersion,
+		Intents:     intents,
+		DeletedPIDs: deletedPIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("encode intents: %w", err)
+	}
+	tmpFile, err := os.CreateTemp(filepath.Dir(s.path), intentStoreFileName+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temporary intent store file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	_, err = tmpFile.Write(data)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("write temporary intent store file: %w", err)
+	}
+	err = os.Rename(tmpFile.Name(), s.path)
+	if err != nil {
+		return fmt.Errorf("replace intent store %s: %w", s.path, err)
+	}
+	return nil
+}
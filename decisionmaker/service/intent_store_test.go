@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Gthulhu/api/decisionmaker/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFileIntentStore tests the save and load round trip of the intent store
+func TestFileIntentStore(t *testing.T) {
+	store, err := newFileIntentStore(filepath.Join(t.TempDir(), "state"))
+	require.NoError(t, err)
+
+	intents, deletedPIDs, err := store.Load()
+	require.NoError(t, err, "loading a missing store should not return error")
+	assert.Empty(t, intents)
+	assert.Empty(t, deletedPIDs)
+
+	saved := []*domain.Intent{
+		{PodID: testPodUID, PodName: "nginx", CommandRegex: "^nginx", Priority: 1, ExecutionTime: 1000, PodLabels: map[string]string{"app": "web"}},
+	}
+	require.NoError(t, store.Save(saved, map[string][]int{testPodUID: {1234}}))
+	intents, deletedPIDs, err = store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, saved, intents)
+	assert.Equal(t, map[string][]int{testPodUID: {1234}}, deletedPIDs)
+
+	require.NoError(t, os.WriteFile(store.path, []byte("{not json"), 0644))
+	_, _, err = store.Load()
+	require.Error(t, err, "loading a corrupted store should return error")
+}
+
+// TestIntentsSurviveRestart tests that the intents received before a restart are bound again to the live PIDs
+func TestIntentsSurviveRestart(t *testing.T) {
+	logger.InitLogger()
+	ctx := context.Background()
+	stateDir := t.TempDir()
+	fakeProc := setupFakeProcDir(t)
+
+	svc := newTestService()
+	store, err := newFileIntentStore(stateDir)
+	require.NoError(t, err)
+	svc.intentStore = store
+	require.NoError(t, NewProcScanner(fakeProc, time.Hour).Watch(canceledContext(), svc.HandleProcessEvent))
+	_, err = svc.ProcessIntents(ctx, []*domain.Intent{
+		{PodID: testPodUID, CommandRegex: "^nginx", Priority: 1, ExecutionTime: 1000},
+		{PodID: "e52d4a2a-6e5f-44d9-a8b8-37ff3daa7413", CommandRegex: "busybox"},
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{1234, 5678}, listIntentPIDs(t, svc))
+	require.NoError(t, svc.DeleteIntentByPodID(ctx, "e52d4a2a-6e5f-44d9-a8b8-37ff3daa7413"))
+
+	// the decision maker restarts and the process got a new PID meanwhile
+	require.NoError(t, os.RemoveAll(filepath.Join(fakeProc, "1234")))
+	addFakeProcess(t, fakeProc, "2345", "nginx")
+	restarted := newTestService()
+	restarted.intentStore = store
+	restarted.restoreIntents(ctx)
+	assert.Empty(t, listIntentPIDs(t, restarted))
+	require.NoError(t, NewProcScanner(fakeProc, time.Hour).Watch(canceledContext(), restarted.HandleProcessEvent))
+	assert.ElementsMatch(t, []int{2345}, listIntentPIDs(t, restarted))
+}
+
+// TestDeletedPIDSurvivesRestart tests that a process whose intent was deleted by PID is not bound again after a restart,
+// until it exits and its PID is reused
+func TestDeletedPIDSurvivesRestart(t *testing.T) {
+	logger.InitLogger()
+	ctx := context.Background()
+	fakeProc := setupFakeProcDir(t)
+	store, err := newFileIntentStore(t.TempDir())
+	require.NoError(t, err)
+
+	svc := newTestService()
+	svc.intentStore = store
+	scanner := NewProcScanner(fakeProc, time.Hour)
+	require.NoError(t, scanner.Watch(canceledContext(), svc.HandleProcessEvent))
+	_, err = svc.ProcessIntents(ctx, []*domain.Intent{{PodID: testPodUID, CommandRegex: "^nginx"}})
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteIntentByPID(ctx, testPodUID, 1234))
+	assert.Empty(t, listIntentPIDs(t, svc))
+
+	restarted := newTestService()
+	restarted.intentStore = store
+	restarted.restoreIntents(ctx)
+	scanner = NewProcScanner(fakeProc, time.Hour)
+	require.NoError(t, scanner.Watch(canceledContext(), restarted.HandleProcessEvent))
+	assert.Empty(t, listIntentPIDs(t, restarted), "the deleted process should not be bound again")
+
+	// the process exits and its PID is reused
+	require.NoError(t, os.RemoveAll(filepath.Join(fakeProc, "1234")))
+	require.NoError(t, scanner.scan(ctx, restarted.HandleProcessEvent))
+	addFakeProcess(t, fakeProc, "1234", "nginx")
+	require.NoError(t, scanner.scan(ctx, restarted.HandleProcessEvent))
+	assert.Equal(t, []int{1234}, listIntentPIDs(t, restarted))
+	_, deletedPIDs, err := store.Load()
+	require.NoError(t, err)
+	assert.Empty(t, deletedPIDs)
+}
+
+// canceledContext returns a context that is already canceled, so that a watcher returns after its initial sync
+func canceledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Gthulhu/api/decisionmaker/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+)
+
+const (
+	minManagerSyncBackoff = 2 * time.Second
+	maxManagerSyncBackoff = time.Minute
+)
+
+// SyncIntentsFromManager replaces the intents of the decision maker with the full state of the node held by the manager
+func (svc *Service) SyncIntentsFromManager(ctx context.Context, manager domain.ManagerAdapter, nodeID string) error {
+	intents, err := manager.ListNodeIntents(ctx, nodeID)
+	if err != nil {
+		return fmt.Errorf("list intents of node %s from manager: %w", nodeID, err)
+	}
+	return svc.ReplaceIntents(ctx, intents)
+}
+
+// RunManagerSync syncs the full state from the manager until it succeeds once, retrying with an exponential backoff,
+// then every interval if it is positive. It blocks until ctx is cancelled.
+func (svc *Service) RunManagerSync(ctx context.Context, manager domain.ManagerAdapter, nodeID string, interval time.Duration) {
+	backoff := minManagerSyncBackoff
+	for {
+		err := svc.SyncIntentsFromManager(ctx, manager, nodeID)
+		if err == nil {
+			logger.Logger(ctx).Info().Msgf("synced intents of node %s from manager", nodeID)
+			break
+		}
+		logger.Logger(ctx).Warn().Err(err).Msgf("failed to sync intents from manager, retrying in %s", backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxManagerSyncBackoff)
+	}
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := svc.SyncIntentsFromManager(ctx, manager, nodeID)
+			if err != nil {
+				logger.Logger(ctx).Warn().Err(err).Msg("failed to sync intents from manager")
+			}
+		}
+	}
+}
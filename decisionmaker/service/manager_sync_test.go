@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Gthulhu/api/decisionmaker/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeManager struct {
+	nodeID  string
+	intents []*domain.Intent
+	err     error
+}
+
+func (m *fakeManager) ListNodeIntents(ctx context.Context, nodeID string) ([]*domain.Intent, error) {
+	m.nodeID = nodeID
+	return m.intents, m.err
+}
+
+// TestSyncIntentsFromManager tests that the full state returned by the manager replaces the local intents
+func TestSyncIntentsFromManager(t *testing.T) {
+	logger.InitLogger()
+	ctx := context.Background()
+	fakeProc := setupFakeProcDir(t)
+	svc := newTestService()
+	require.NoError(t, NewProcScanner(fakeProc, time.Hour).Watch(canceledContext(), svc.HandleProcessEvent))
+
+	// an intent pushed before the restart of the manager that it no longer holds
+	_, err := svc.ProcessIntents(ctx, []*domain.Intent{
+		{PodID: "e52d4a2a-6e5f-44d9-a8b8-37ff3daa7413", CommandRegex: "busybox"},
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{5678}, listIntentPIDs(t, svc))
+
+	manager := &fakeManager{intents: []*domain.Intent{
+		{PodID: testPodUID, CommandRegex: "nginx", Priority: 1, ExecutionTime: 1000},
+	}}
+	require.NoError(t, svc.SyncIntentsFromManager(ctx, manager, "node-a"))
+	assert.Equal(t, "node-a", manager.nodeID)
+	assert.ElementsMatch(t, []int{1234}, listIntentPIDs(t, svc))
+	assert.Len(t, svc.retainedIntents(), 1)
+
+	// a failed sync keeps the current state
+	manager.err = errors.New("connection refused")
+	require.Error(t, svc.SyncIntentsFromManager(ctx, manager, "node-a"))
+	assert.ElementsMatch(t, []int{1234}, listIntentPIDs(t, svc))
+}
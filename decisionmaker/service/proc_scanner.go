@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Gthulhu/api/decisionmaker/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+)
+
+// ProcScanner discovers the processes of the pods by scanning /proc periodically,
+// it works on every node and is the fallback of the other discovery backends
+type ProcScanner struct {
+	rootDir  string
+	interval time.Duration
+	// known holds the processes reported by the previous scan, keyed by podUID-pid
+	known map[string]*domain.ProcessEvent
+}
+
+func NewProcScanner(rootDir string, interval time.Duration) *ProcScanner {
+	return &ProcScanner{
+		rootDir:  rootDir,
+		interval: interval,
+		known:    make(map[string]*domain.ProcessEvent),
+	}
+}
+
+func (s *ProcScanner) Name() string {
+	return DiscoveryBackendProc
+}
+
+// Watch scans rootDir every interval and reports the processes that appeared or disappeared since the previous scan
+func (s *ProcScanner) Watch(ctx context.Context, handler domain.ProcessEventHandler) error {
+	err := s.scan(ctx, handler)
+	if err != nil {
+		return err
+	}
+	handler(ctx, &domain.ProcessEvent{Type: domain.ProcessSynced})
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			err := s.scan(ctx, handler)
+			if err != nil {
+				logger.Logger(ctx).Warn().Err(err).Msg("failed to scan processes")
+			}
+		}
+	}
+}
+
+// scan compares the processes found in rootDir, and their threads, with the ones of the previous scan and emits the differences
+func (s *ProcScanner) scan(ctx context.Context, handler domain.ProcessEventHandler) error {
+	podInfos, err := findPodInfoFrom(ctx, s.rootDir)
+	if err != nil {
+		return err
+	}
+	current := make(map[string]*domain.ProcessEvent)
+	for podUID, podInfo := range podInfos {
+		for _, process := range podInfo.Processes {
+			current[fmt.Sprintf("%s-%d", podUID, process.PID)] = &domain.ProcessEvent{
+				Type:    domain.ProcessAppeared,
+				PodUID:  podUID,
+				Process: process,
+			}
+		}
+	}
+	for key, event := range current {
+		known, ok := s.known[key]
+		if !ok {
+			handler(ctx, event)
+		} else if !slices.Equal(known.Process.Threads, event.Process.Threads) {
+			handler(ctx, &domain.ProcessEvent{
+				Type:    domain.ProcessThreadsChanged,
+				PodUID:  event.PodUID,
+				Process: event.Process,
+			})
+		}
+	}
+	for key, event := range s.known {
+		if _, ok := current[key]; !ok {
+			handler(ctx, &domain.ProcessEvent{
+				Type:    domain.ProcessGone,
+				PodUID:  event.PodUID,
+				Process: event.Process,
+			})
+		}
+	}
+	s.known = current
+	return nil
+}
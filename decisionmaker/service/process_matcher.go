@@ -0,0 +1,102 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/Gthulhu/api/decisionmaker/domain"
+)
+
+// processMatcher is a compiled domain.ProcessMatcher
+type processMatcher struct {
+	field string
+	regex *regexp.Regexp
+}
+
+// compileProcessMatchers compiles the command regex of an intent, which applies to the comm of the processes, along with its matchers
+func compileProcessMatchers(commandRegex string, matchers []domain.ProcessMatcher) ([]processMatcher, error) {
+	regex, err := regexp.Compile(commandRegex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid command regex %q: %v", commandRegex, err)
+	}
+	compiled := []processMatcher{{field: domain.ProcessFieldComm, regex: regex}}
+	for _, matcher := range matchers {
+		if _, ok := processFieldValues(domain.PodProcess{}, matcher.Field); !ok {
+			return nil, fmt.Errorf("unknown matcher field %q", matcher.Field)
+		}
+		regex, err := regexp.Compile(matcher.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s matcher regex %q: %v", matcher.Field, matcher.Regex, err)
+		}
+		compiled = append(compiled, processMatcher{field: matcher.Field, regex: regex})
+	}
+	return compiled, nil
+}
+
+// compileThreadRegex compiles the thread regex of an intent, it returns nil when the intent binds whole processes
+func compileThreadRegex(threadRegex string) (*regexp.Regexp, error) {
+	if threadRegex == "" {
+		return nil, nil
+	}
+	regex, err := regexp.Compile(threadRegex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid thread regex %q: %v", threadRegex, err)
+	}
+	return regex, nil
+}
+
+// matchingThreads returns the threads of the process whose name matches the regex
+func matchingThreads(regex *regexp.Regexp, process domain.PodProcess) []domain.Thread {
+	threads := make([]domain.Thread, 0)
+	for _, thread := range process.Threads {
+		if regex.MatchString(thread.Name) {
+			threads = append(threads, thread)
+		}
+	}
+	return threads
+}
+
+// matchProcess reports whether every matcher matches the process
+func matchProcess(matchers []processMatcher, process domain.PodProcess) bool {
+	for _, matcher := range matchers {
+		values, _ := processFieldValues(process, matcher.field)
+		if !slices.ContainsFunc(values, matcher.regex.MatchString) {
+			return false
+		}
+	}
+	return true
+}
+
+// processFieldValues returns the values of a field of the process, a matcher of the field matches if any value matches.
+// It reports false for an unknown field.
+func processFieldValues(process domain.PodProcess, field string) ([]string, bool) {
+	switch field {
+	case domain.ProcessFieldComm:
+		return []string{process.Command}, true
+	case domain.ProcessFieldCmdline:
+		return []string{process.Cmdline}, true
+	case domain.ProcessFieldExe:
+		return []string{process.Exe}, true
+	case domain.ProcessFieldUID:
+		if process.UID < 0 {
+			return nil, true
+		}
+		return []string{strconv.Itoa(process.UID)}, true
+	case domain.ProcessFieldGID:
+		if process.GID < 0 {
+			return nil, true
+		}
+		return []string{strconv.Itoa(process.GID)}, true
+	case domain.ProcessFieldCgroup:
+		return []string{process.Cgroup}, true
+	case domain.ProcessFieldThread:
+		names := make([]string, 0, len(process.Threads))
+		for _, thread := range process.Threads {
+			names = append(names, thread.Name)
+		}
+		return names, true
+	}
+	return nil, false
+}
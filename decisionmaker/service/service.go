@@ -1,14 +1,17 @@
 package service
 
 import (
-	"bufio"
 	"context"
 	"crypto/rsa"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/Gthulhu/api/config"
 	"github.com/Gthulhu/api/decisionmaker/domain"
@@ -20,7 +23,9 @@ import (
 
 type Params struct {
 	fx.In
-	TokenConfig config.TokenConfig
+	TokenConfig     config.TokenConfig
+	DiscoveryConfig config.DiscoveryConfig
+	StoreConfig     config.StoreConfig
 }
 
 func NewService(params Params) (Service, error) {
@@ -30,10 +35,22 @@ func NewService(params Params) (Service, error) {
 	}
 	svc := Service{
 		schedulingIntentsMap: util.NewGenericMap[string, []*domain.SchedulingIntents](),
+		intents:              util.NewGenericMap[string, *domain.Intent](),
+		bindMu:               &sync.RWMutex{},
+		processTable:         newProcessTable(),
+		processWatchers:      newProcessWatchers(params.DiscoveryConfig),
 		metricCollector:      NewMetricCollector(util.GetMachineID()),
 		jwtPrivateKey:        privateKey,
 	}
 
+	if params.StoreConfig.StateDir != "" {
+		svc.intentStore, err = newFileIntentStore(params.StoreConfig.StateDir)
+		if err != nil {
+			return Service{}, fmt.Errorf("failed to initialize intent store: %v", err)
+		}
+		svc.restoreIntents(context.Background())
+	}
+
 	err = prometheus.Register(svc.metricCollector)
 	if err != nil {
 		return Service{}, fmt.Errorf("failed to register metric collector: %v", err)
@@ -43,9 +60,22 @@ func NewService(params Params) (Service, error) {
 
 type Service struct {
 	schedulingIntentsMap *util.GenericMap[string, []*domain.SchedulingIntents]
-	metricCollector      *MetricCollector
-	jwtPrivateKey        *rsa.PrivateKey
-	tokenConfig          config.TokenConfig
+	// intents retains the intents received from the manager so that they can be bound to processes that appear later
+	intents *util.GenericMap[string, *domain.Intent]
+	// bindMu serializes the updates of schedulingIntentsMap, readers take the read lock so that a full replacement is seen atomically
+	bindMu *sync.RWMutex
+	// processTable tracks the processes reported by the process watchers
+	processTable *processTable
+	// processWatchers are the discovery backends in order of preference, the next one is used when a backend cannot run
+	processWatchers []domain.ProcessWatcher
+	// deletedPIDs are the processes per pod whose scheduling intents were deleted by PID, they are not bound again until they exit.
+	// It is guarded by bindMu.
+	deletedPIDs map[string][]int
+	// intentStore persists the retained intents across restarts, it is nil when no state dir is configured
+	intentStore     *fileIntentStore
+	metricCollector *MetricCollector
+	jwtPrivateKey   *rsa.PrivateKey
+	tokenConfig     config.TokenConfig
 }
 
 const (
@@ -55,6 +85,8 @@ const (
 
 // ListAllSchedulingIntents retrieves all stored scheduling intents
 func (svc *Service) ListAllSchedulingIntents(ctx context.Context) ([]*domain.SchedulingIntents, error) {
+	svc.bindMu.RLock()
+	defer svc.bindMu.RUnlock()
 	intents := []*domain.SchedulingIntents{}
 	svc.schedulingIntentsMap.Range(func(key string, value []*domain.SchedulingIntents) bool {
 		intents = append(intents, value...)
@@ -63,15 +95,173 @@ func (svc *Service) ListAllSchedulingIntents(ctx context.Context) ([]*domain.Sch
 	return intents, nil
 }
 
-// ProcessIntents processes a list of scheduling intents and updates the internal map
-func (svc *Service) ProcessIntents(ctx context.Context, intents []*domain.Intent) error {
-	podInfos, err := svc.GetAllPodInfos(ctx)
+// ListPodSchedulingIntents returns the scheduling intents bound to the processes and threads of a pod, ordered by PID and TID
+func (svc *Service) ListPodSchedulingIntents(ctx context.Context, podID string) ([]*domain.SchedulingIntents, error) {
+	svc.bindMu.RLock()
+	defer svc.bindMu.RUnlock()
+	intents := []*domain.SchedulingIntents{}
+	svc.schedulingIntentsMap.Range(func(key string, value []*domain.SchedulingIntents) bool {
+		if strings.HasPrefix(key, podID+"-") {
+			intents = append(intents, value...)
+		}
+		return true
+	})
+	sort.Slice(intents, func(i, j int) bool {
+		if intents[i].PID != intents[j].PID {
+			return intents[i].PID < intents[j].PID
+		}
+		return intents[i].TID < intents[j].TID
+	})
+	return intents, nil
+}
+
+// ProcessIntents processes a list of scheduling intents and updates the internal map,
+// it returns the result of every intent in the order of the given intents
+func (svc *Service) ProcessIntents(ctx context.Context, intents []*domain.Intent) ([]*domain.IntentResult, error) {
+	svc.bindMu.Lock()
+	defer svc.bindMu.Unlock()
+	podInfos, err := svc.currentPodInfos(ctx)
+	if err != nil {
+		return nil, err
+	}
+	podIDs := make(map[string]struct{}, len(intents))
+	for _, intent := range intents {
+		svc.intents.Store(intentKey(intent), intent)
+		podIDs[intent.PodID] = struct{}{}
+	}
+	// the intents already retained for the pods are bound again so that the precedence is resolved against them
+	podIntents := []*domain.Intent{}
+	for _, intent := range svc.retainedIntents() {
+		if _, ok := podIDs[intent.PodID]; ok {
+			podIntents = append(podIntents, intent)
+		}
+	}
+	bound, podResults := svc.bindIntents(ctx, podInfos, podIntents)
+	for key, schedulingIntents := range bound {
+		svc.schedulingIntentsMap.Store(key, schedulingIntents)
+	}
+	resultsByKey := make(map[string]*domain.IntentResult, len(podResults))
+	for i, intent := range podIntents {
+		resultsByKey[intentKey(intent)] = podResults[i]
+	}
+	results := make([]*domain.IntentResult, 0, len(intents))
+	for _, intent := range intents {
+		results = append(results, resultsByKey[intentKey(intent)])
+	}
+	logger.Logger(ctx).Info().Msgf("Discovered pods: %+v", podInfos)
+	err = svc.persistIntents()
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ReplaceIntents replaces every retained intent and scheduling intent with the given intents, e.g. the full state of the node returned by the manager
+func (svc *Service) ReplaceIntents(ctx context.Context, intents []*domain.Intent) error {
+	svc.bindMu.Lock()
+	defer svc.bindMu.Unlock()
+	podInfos, err := svc.currentPodInfos(ctx)
 	if err != nil {
 		return err
 	}
+	svc.intents.Clear()
 	for _, intent := range intents {
-		podInfo := podInfos[intent.PodID]
-		logger.Logger(ctx).Info().Msgf("Processing intent for PodName:%s PodID: %s on NodeID: %s, Process:%+v", intent.PodName, intent.PodID, intent.NodeID, podInfo)
+		svc.intents.Store(intentKey(intent), intent)
+	}
+	desired, _ := svc.bindIntents(ctx, podInfos, svc.retainedIntents())
+	keysToDelete := []string{}
+	svc.schedulingIntentsMap.Range(func(key string, value []*domain.SchedulingIntents) bool {
+		if _, ok := desired[key]; !ok {
+			keysToDelete = append(keysToDelete, key)
+		}
+		return true
+	})
+	for _, key := range keysToDelete {
+		svc.schedulingIntentsMap.Delete(key)
+	}
+	for key, schedulingIntents := range desired {
+		svc.schedulingIntentsMap.Store(key, schedulingIntents)
+	}
+	logger.Logger(ctx).Info().Msgf("Replaced intents with %d intents bound to %d processes", len(intents), len(desired))
+	return svc.persistIntents()
+}
+
+// restoreIntents reloads the intents persisted before a restart, their PIDs are resolved again by the process discovery
+func (svc *Service) restoreIntents(ctx context.Context) {
+	intents, deletedPIDs, err := svc.intentStore.Load()
+	if err != nil {
+		logger.Logger(ctx).Warn().Err(err).Msg("failed to load persisted intents, starting with an empty intent store")
+		return
+	}
+	for _, intent := range intents {
+		svc.intents.Store(intentKey(intent), intent)
+	}
+	svc.deletedPIDs = deletedPIDs
+	logger.Logger(ctx).Info().Msgf("Restored %d persisted intents", len(intents))
+}
+
+// persistIntents saves the retained intents and the deleted processes when an intent store is configured, the caller must hold bindMu
+func (svc *Service) persistIntents() error {
+	if svc.intentStore == nil {
+		return nil
+	}
+	err := svc.intentStore.Save(svc.retainedIntents(), svc.deletedPIDs)
+	if err != nil {
+		return fmt.Errorf("persist intents: %w", err)
+	}
+	return nil
+}
+
+// retainedIntents returns the retained intents ordered by key, so that the binding result is deterministic
+func (svc *Service) retainedIntents() []*domain.Intent {
+	keys := []string{}
+	svc.intents.Range(func(key string, value *domain.Intent) bool {
+		keys = append(keys, key)
+		return true
+	})
+	sort.Strings(keys)
+	intents := make([]*domain.Intent, 0, len(keys))
+	for _, key := range keys {
+		if intent, ok := svc.intents.Load(key); ok {
+			intents = append(intents, intent)
+		}
+	}
+	return intents
+}
+
+// bindIntents maps the intents to the matching processes of their pod, or to the matching threads of those processes for an intent
+// with a thread regex, keyed by schedulingKey. A process or thread matched by several intents is bound to the one that takes
+// precedence. It reports the result of every intent in the order of the given intents.
+func (svc *Service) bindIntents(ctx context.Context, podInfos map[string]*domain.PodInfo, intents []*domain.Intent) (map[string][]*domain.SchedulingIntents, []*domain.IntentResult) {
+	bound := make(map[string][]*domain.SchedulingIntents)
+	owners := make(map[string]*domain.Intent)
+	results := make([]*domain.IntentResult, 0, len(intents))
+	for _, intent := range intents {
+		result := &domain.IntentResult{
+			PodID:        intent.PodID,
+			StrategyID:   intent.StrategyID,
+			CommandRegex: intent.CommandRegex,
+			Matchers:     intent.Matchers,
+			ThreadRegex:  intent.ThreadRegex,
+			ContainerIDs: intent.ContainerIDs,
+			State:        domain.IntentResultNoMatchingProcess,
+		}
+		results = append(results, result)
+		matchers, err := compileProcessMatchers(intent.CommandRegex, intent.Matchers)
+		var threadRegex *regexp.Regexp
+		if err == nil {
+			threadRegex, err = compileThreadRegex(intent.ThreadRegex)
+		}
+		if err != nil {
+			logger.Logger(ctx).Warn().Err(err).Msgf("invalid intent for pod %s", intent.PodID)
+			result.State = domain.IntentResultFailed
+			result.Error = err.Error()
+			continue
+		}
+		processes := matchingProcesses(podInfos[intent.PodID], matchers, intent.ContainerIDs, intent.IncludeDescendants)
+		if len(processes) == 0 {
+			continue
+		}
 		labels := []domain.LabelSelector{}
 		for key, value := range intent.PodLabels {
 			labels = append(labels, domain.LabelSelector{
@@ -79,28 +269,163 @@ func (svc *Service) ProcessIntents(ctx context.Context, intents []*domain.Intent
 				Value: value,
 			})
 		}
-		if podInfo != nil && len(podInfo.Processes) > 0 {
-			for _, process := range podInfo.Processes {
-				if process.Command == pauseCommand {
-					continue
-				}
-				if !regexp.MustCompile(intent.CommandRegex).MatchString(process.Command) {
+		labels = append(labels, intent.Selector...)
+		for _, process := range processes {
+			if slices.Contains(svc.deletedPIDs[intent.PodID], process.PID) {
+				continue
+			}
+			if threadRegex == nil {
+				bindTask(ctx, bound, owners, intent, labels, process.PID, 0)
+				result.MatchedPIDs++
+				continue
+			}
+			threads := matchingThreads(threadRegex, process)
+			if len(threads) == 0 {
+				continue
+			}
+			for _, thread := range threads {
+				bindTask(ctx, bound, owners, intent, labels, process.PID, thread.TID)
+			}
+			result.MatchedPIDs++
+			result.MatchedTIDs += len(threads)
+		}
+		if result.MatchedPIDs > 0 {
+			result.State = domain.IntentResultApplied
+		}
+	}
+	return bound, results
+}
+
+// bindTask binds the intent to a process, or to one of its threads when tid is not 0, unless an intent that takes precedence
+// is already bound to it
+func bindTask(ctx context.Context, bound map[string][]*domain.SchedulingIntents, owners map[string]*domain.Intent, intent *domain.Intent, labels []domain.LabelSelector, pid int, tid int) {
+	schedulingIntent := &domain.SchedulingIntents{
+		Priority:      intent.Priority > 0,
+		ExecutionTime: uint64(intent.ExecutionTime),
+		PID:           pid,
+		TID:           tid,
+		CommandRegex:  intent.CommandRegex,
+		Matchers:      intent.Matchers,
+		ThreadRegex:   intent.ThreadRegex,
+		Selectors:     labels,
+		StrategyID:    intent.StrategyID,
+	}
+	key := schedulingKey(intent.PodID, pid, tid)
+	if owner, ok := owners[key]; ok && domain.ComparePrecedence(owner, intent) > 0 {
+		logger.Logger(ctx).Debug().Msgf("Intent %q of strategy %s overridden by strategy %s for %s", intent.CommandRegex, intent.StrategyID, owner.StrategyID, key)
+		return
+	}
+	logger.Logger(ctx).Debug().Msgf("Bound SchedulingIntent: %+v for %s", schedulingIntent, key)
+	owners[key] = intent
+	bound[key] = []*domain.SchedulingIntents{schedulingIntent}
+}
+
+// schedulingKey identifies the scheduling intent of a process (podID-pid), or of one of its threads (podID-pid-tid) when tid is not 0
+func schedulingKey(podID string, pid int, tid int) string {
+	if tid == 0 {
+		return fmt.Sprintf("%s-%d", podID, pid)
+	}
+	return fmt.Sprintf("%s-%d-%d", podID, pid, tid)
+}
+
+// matchingProcesses returns the processes of the pod matched by every matcher, along with their descendants when includeDescendants
+// is set, and, when container IDs are given, that run in one of those containers; the pause container is never matched
+func matchingProcesses(podInfo *domain.PodInfo, matchers []processMatcher, containerIDs []string, includeDescendants bool) []domain.PodProcess {
+	if podInfo == nil {
+		return nil
+	}
+	processes := make([]domain.PodProcess, 0)
+	for _, process := range podInfo.Processes {
+		if process.Command == pauseCommand || !matchProcess(matchers, process) {
+			continue
+		}
+		processes = append(processes, process)
+	}
+	if includeDescendants {
+		processes = appendDescendants(podInfo, processes)
+	}
+	if len(containerIDs) > 0 {
+		processes = slices.DeleteFunc(processes, func(process domain.PodProcess) bool {
+			return !slices.Contains(containerIDs, process.ContainerID)
+		})
+	}
+	return processes
+}
+
+// appendDescendants appends the descendants of the processes to them, walking the process tree of the pod built from the parent PIDs
+func appendDescendants(podInfo *domain.PodInfo, processes []domain.PodProcess) []domain.PodProcess {
+	children := make(map[int][]domain.PodProcess)
+	for _, process := range podInfo.Processes {
+		children[process.PPID] = append(children[process.PPID], process)
+	}
+	seen := make(map[int]struct{}, len(processes))
+	for _, process := range processes {
+		seen[process.PID] = struct{}{}
+	}
+	for i := 0; i < len(processes); i++ {
+		for _, child := range children[processes[i].PID] {
+			if _, ok := seen[child.PID]; ok || child.Command == pauseCommand {
+				continue
+			}
+			seen[child.PID] = struct{}{}
+			processes = append(processes, child)
+		}
+	}
+	return processes
+}
+
+// PreviewIntents returns the processes every intent would be bound to, without retaining the intents
+func (svc *Service) PreviewIntents(ctx context.Context, intents []*domain.Intent) ([]*domain.IntentPreview, error) {
+	svc.bindMu.RLock()
+	defer svc.bindMu.RUnlock()
+	podInfos, err := svc.currentPodInfos(ctx)
+	if err != nil {
+		return nil, err
+	}
+	previews := make([]*domain.IntentPreview, 0, len(intents))
+	for _, intent := range intents {
+		preview := &domain.IntentPreview{
+			PodID:        intent.PodID,
+			CommandRegex: intent.CommandRegex,
+			Matchers:     intent.Matchers,
+			ThreadRegex:  intent.ThreadRegex,
+		}
+		previews = append(previews, preview)
+		matchers, err := compileProcessMatchers(intent.CommandRegex, intent.Matchers)
+		var threadRegex *regexp.Regexp
+		if err == nil {
+			threadRegex, err = compileThreadRegex(intent.ThreadRegex)
+		}
+		if err != nil {
+			preview.Error = err.Error()
+			continue
+		}
+		for _, process := range matchingProcesses(podInfos[intent.PodID], matchers, intent.ContainerIDs, intent.IncludeDescendants) {
+			if threadRegex != nil {
+				process.Threads = matchingThreads(threadRegex, process)
+				if len(process.Threads) == 0 {
 					continue
 				}
-				schedulingIntent := &domain.SchedulingIntents{
-					Priority:      intent.Priority > 0,
-					ExecutionTime: uint64(intent.ExecutionTime),
-					PID:           process.PID,
-					CommandRegex:  intent.CommandRegex,
-					Selectors:     labels,
-				}
-				logger.Logger(ctx).Info().Msgf("Created SchedulingIntent: %+v for Process PID: %d", schedulingIntent, process.PID)
-				svc.schedulingIntentsMap.Store(fmt.Sprintf("%s-%d", intent.PodID, process.PID), []*domain.SchedulingIntents{schedulingIntent})
 			}
+			preview.Processes = append(preview.Processes, process)
 		}
 	}
-	logger.Logger(ctx).Info().Msgf("Discovered pods: %+v", podInfos)
-	return nil
+	return previews, nil
+}
+
+// intentKey identifies a retained intent, a new intent with the same pod, strategy, command regex, matchers, thread regex and containers
+// replaces the previous one, the intents of different strategies or containers are kept side by side and resolved by their precedence
+func intentKey(intent *domain.Intent) string {
+	key := intent.PodID + "/" + intent.StrategyID + "/" + intent.CommandRegex + domain.MatchersKey(intent.Matchers)
+	if intent.ThreadRegex != "" {
+		key += "/" + intent.ThreadRegex
+	}
+	if len(intent.ContainerIDs) > 0 {
+		containerIDs := slices.Clone(intent.ContainerIDs)
+		slices.Sort(containerIDs)
+		key += "/containers=" + strings.Join(containerIDs, ",")
+	}
+	return key
 }
 
 // GetAllPodInfos retrieves all pod information by scanning the /proc filesystem
@@ -108,8 +433,20 @@ func (svc *Service) GetAllPodInfos(ctx context.Context) (map[string]*domain.PodI
 	return svc.FindPodInfoFrom(ctx, procDir)
 }
 
+// currentPodInfos returns the processes tracked by the process watchers once they are synced, otherwise it scans /proc
+func (svc *Service) currentPodInfos(ctx context.Context) (map[string]*domain.PodInfo, error) {
+	if svc.processTable != nil && svc.processTable.isSynced() {
+		return svc.processTable.snapshot(), nil
+	}
+	return svc.GetAllPodInfos(ctx)
+}
+
 // FindPodInfoFrom scans the given rootDir (e.g., /proc) to find pod information
 func (svc *Service) FindPodInfoFrom(ctx context.Context, rootDir string) (map[string]*domain.PodInfo, error) {
+	return findPodInfoFrom(ctx, rootDir)
+}
+
+func findPodInfoFrom(ctx context.Context, rootDir string) (map[string]*domain.PodInfo, error) {
 	podMap := make(map[string]*domain.PodInfo)
 
 	// Walk through /proc to find all processes
@@ -130,96 +467,40 @@ func (svc *Service) FindPodInfoFrom(ctx context.Context, rootDir string) (map[st
 			continue
 		}
 
-		// Read cgroup information for this process
-		cgroupPath := fmt.Sprintf("%s/%d/cgroup", rootDir, pid)
-		file, err := os.Open(cgroupPath)
+		// Read cgroup information for this process, a process is only reported once even with a line per cgroup v1 hierarchy
+		data, err := os.ReadFile(fmt.Sprintf("%s/%d/cgroup", rootDir, pid))
 		if err != nil {
-			logger.Logger(ctx).Warn().Err(err).Msgf("failed to open cgroup file for pid %d", pid)
+			logger.Logger(ctx).Warn().Err(err).Msgf("failed to read cgroup file for pid %d", pid)
 			continue
 		}
-		scanner := bufio.NewScanner(file)
-		for scanner.Scan() {
-			line := scanner.Text()
-			logger.Logger(ctx).Debug().Msgf("cgroup line for pid %d: %s", pid, line)
-			if strings.Contains(line, "kubepods") {
-				err = svc.parseCgroupToPodInfo(rootDir, line, pid, podMap)
-				if err != nil {
-					logger.Logger(ctx).Warn().Err(err).Msgf("failed to parse cgroup line for pid %d, line:%s", pid, line)
-					break
-				}
-			}
-		}
-		if err := scanner.Err(); err != nil {
-		}
-		_ = file.Close()
-	}
-
-	return podMap, nil
-}
-
-// parseCgroupToPodInfo parses a cgroup line (e.g // 0::/kubelet.slice/kubelet-kubepods.slice/kubelet-kubepods-pod20da609e_6973_4463_a1f9_2db9bcc5becc.slice/cri-containerd-10ec3c89629f71226b227e6510b2d465168b24005bbdcc5d7940517080830635.scope) to extract pod info and updates the podInfoMap
-func (svc *Service) parseCgroupToPodInfo(rootDir string, line string, pid int, podInfoMap map[string]*domain.PodInfo) error {
-	parts := strings.Split(line, ":")
-	if len(parts) >= 3 {
-		cgroupHierarchy := parts[2]
-
-		// Extract pod information
-		podUID, containerID, err := svc.getPodInfoFromCgroup(cgroupHierarchy)
-		if err != nil {
-			return err
+		cgroupPath, podUID, containerID, ok := parseProcCgroup(string(data))
+		if !ok {
+			continue
 		}
-
-		// Get process information
-		process, err := svc.getProcessInfo(rootDir, pid)
+		logger.Logger(ctx).Debug().Msgf("cgroup of pid %d: %s", pid, cgroupPath)
+		process, err := getProcessInfo(rootDir, pid)
 		if err != nil {
-			return err
+			logger.Logger(ctx).Warn().Err(err).Msgf("failed to read process info of pid %d", pid)
+			continue
 		}
 		process.ContainerID = containerID
-
-		// Create or update pod info
-		if podInfo, exists := podInfoMap[podUID]; exists {
+		process.Cgroup = cgroupPath
+		if podInfo, exists := podMap[podUID]; exists {
 			podInfo.Processes = append(podInfo.Processes, process)
 		} else {
-			podInfoMap[podUID] = &domain.PodInfo{
+			podMap[podUID] = &domain.PodInfo{
 				PodUID:    podUID,
 				Processes: []domain.PodProcess{process},
 			}
 		}
 	}
-	return nil
-}
-
-// Support multiple cgroup formats:
-// - systemd: kubelet-kubepods-pod20da609e_6973_4463_a1f9_2db9bcc5becc.slice (underscores)
-// - cgroupfs: /kubepods/burstable/pod31e4e721-a5a0-421a-ae1d-b7971ae30d6e/ (dashes)
-var podRegex = regexp.MustCompile(`pod([0-9a-fA-F]{8}[-_][0-9a-fA-F]{4}[-_][0-9a-fA-F]{4}[-_][0-9a-fA-F]{4}[-_][0-9a-fA-F]{12})`)
-
-// getPodInfoFromCgroup extracts pod information from cgroup path
-func (svc *Service) getPodInfoFromCgroup(cgroupPath string) (podUID string, containerID string, err error) {
-	// Parse cgroup path to extract pod information
-	// 0::/kubelet.slice/kubelet-kubepods.slice/kubelet-kubepods-pod20da609e_6973_4463_a1f9_2db9bcc5becc.slice/cri-containerd-10ec3c89629f71226b227e6510b2d465168b24005bbdcc5d7940517080830635.scope
-	parts := strings.Split(cgroupPath, "/")
-	for _, part := range parts {
-		if podRegex.MatchString(part) {
-			podUID = podRegex.FindStringSubmatch(part)[1]
-			podUID = strings.ReplaceAll(podUID, "_", "-")
-		}
-		if strings.HasPrefix(part, "cri-containerd-") && strings.HasSuffix(part, ".scope") {
-			containerID = strings.TrimPrefix(part, "cri-containerd-")
-			containerID = strings.TrimSuffix(containerID, ".scope")
-		}
-	}
-
-	if podUID == "" {
-		return "", "", fmt.Errorf("pod UID not found in cgroup path")
-	}
 
-	return podUID, containerID, nil
+	return podMap, nil
 }
 
 // getProcessInfo reads process information from /proc/<pid>/
-func (svc *Service) getProcessInfo(rootDir string, pid int) (domain.PodProcess, error) {
-	process := domain.PodProcess{PID: pid}
+func getProcessInfo(rootDir string, pid int) (domain.PodProcess, error) {
+	process := domain.PodProcess{PID: pid, UID: -1, GID: -1}
 
 	// Read command from /proc/<pid>/comm
 	commPath := fmt.Sprintf("/%s/%d/comm", rootDir, pid)
@@ -227,26 +508,93 @@ func (svc *Service) getProcessInfo(rootDir string, pid int) (domain.PodProcess,
 		process.Command = strings.TrimSpace(string(data))
 	}
 
-	// Read PPID from /proc/<pid>/stat
+	// Read PPID from /proc/<pid>/stat, the fields are read after the comm since it may contain spaces and parentheses
 	statPath := fmt.Sprintf("/%s/%d/stat", rootDir, pid)
 	if data, err := os.ReadFile(statPath); err == nil {
-		fields := strings.Fields(string(data))
-		if len(fields) >= 4 {
-			if ppid, err := strconv.Atoi(fields[3]); err == nil {
+		stat := string(data)
+		fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+		if len(fields) >= 2 {
+			if ppid, err := strconv.Atoi(fields[1]); err == nil {
 				process.PPID = ppid
 			}
 		}
 	}
 
+	// Read the arguments from /proc/<pid>/cmdline, they are separated by NUL bytes
+	cmdlinePath := fmt.Sprintf("/%s/%d/cmdline", rootDir, pid)
+	if data, err := os.ReadFile(cmdlinePath); err == nil {
+		process.Cmdline = strings.Join(strings.FieldsFunc(string(data), func(r rune) bool { return r == 0 }), " ")
+	}
+
+	// Read the executable from the /proc/<pid>/exe link, which requires the ptrace access of the process
+	exePath := fmt.Sprintf("/%s/%d/exe", rootDir, pid)
+	if exe, err := os.Readlink(exePath); err == nil {
+		process.Exe = exe
+	}
+
+	// Read the effective UID and GID from /proc/<pid>/status (e.g. Uid:	1000	1000	1000	1000)
+	statusPath := fmt.Sprintf("/%s/%d/status", rootDir, pid)
+	if data, err := os.ReadFile(statusPath); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			key, value, ok := strings.Cut(line, ":")
+			if !ok || (key != "Uid" && key != "Gid") {
+				continue
+			}
+			fields := strings.Fields(value)
+			if len(fields) < 2 {
+				continue
+			}
+			id, err := strconv.Atoi(fields[1])
+			if err != nil {
+				continue
+			}
+			if key == "Uid" {
+				process.UID = id
+			} else {
+				process.GID = id
+			}
+		}
+	}
+
+	process.Threads = getProcessThreads(rootDir, pid)
+
 	return process, nil
 }
 
+// getProcessThreads reads the threads of a process from /proc/<pid>/task/<tid>/comm, ordered by TID
+func getProcessThreads(rootDir string, pid int) []domain.Thread {
+	taskDir := fmt.Sprintf("/%s/%d/task", rootDir, pid)
+	entries, err := os.ReadDir(taskDir)
+	if err != nil {
+		return nil
+	}
+	threads := make([]domain.Thread, 0, len(entries))
+	for _, entry := range entries {
+		tid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		// the thread may have exited since the directory was listed
+		data, err := os.ReadFile(filepath.Join(taskDir, entry.Name(), "comm"))
+		if err != nil {
+			continue
+		}
+		threads = append(threads, domain.Thread{TID: tid, Name: strings.TrimSpace(string(data))})
+	}
+	sort.Slice(threads, func(i, j int) bool {
+		return threads[i].TID < threads[j].TID
+	})
+	return threads
+}
+
 func (svc *Service) UpdateMetrics(ctx context.Context, newMetricSet *domain.MetricSet) {
 	svc.metricCollector.UpdateMetrics(newMetricSet)
 }
 
 // DeleteIntentByPodID deletes all scheduling intents for a specific pod ID
 func (svc *Service) DeleteIntentByPodID(ctx context.Context, podID string) error {
+	svc.bindMu.Lock()
+	defer svc.bindMu.Unlock()
 	keysToDelete := []string{}
 	svc.schedulingIntentsMap.Range(func(key string, value []*domain.SchedulingIntents) bool {
 		if strings.HasPrefix(key, podID+"-") {
@@ -257,20 +605,117 @@ func (svc *Service) DeleteIntentByPodID(ctx context.Context, podID string) error
 	for _, key := range keysToDelete {
 		svc.schedulingIntentsMap.Delete(key)
 	}
+	svc.intents.Range(func(key string, value *domain.Intent) bool {
+		if value.PodID == podID {
+			svc.intents.Delete(key)
+		}
+		return true
+	})
+	delete(svc.deletedPIDs, podID)
 	logger.Logger(ctx).Info().Msgf("Deleted %d scheduling intents for pod ID: %s", len(keysToDelete), podID)
-	return nil
+	return svc.persistIntents()
+}
+
+// DeleteIntentByCommandRegex deletes the intent of a pod with the pod ID, strategy, command regex, matchers, thread regex and containers
+// of the given intent, the processes and threads it was bound to are bound again to the remaining intents of the pod so that they never
+// go unscheduled in between
+func (svc *Service) DeleteIntentByCommandRegex(ctx context.Context, deleted *domain.Intent) error {
+	svc.bindMu.Lock()
+	defer svc.bindMu.Unlock()
+	svc.intents.Delete(intentKey(deleted))
+	keysToDelete := []string{}
+	svc.schedulingIntentsMap.Range(func(key string, value []*domain.SchedulingIntents) bool {
+		if !strings.HasPrefix(key, deleted.PodID+"-") {
+			return true
+		}
+		for _, schedulingIntent := range value {
+			if schedulingIntent.StrategyID == deleted.StrategyID && schedulingIntent.CommandRegex == deleted.CommandRegex &&
+				slices.Equal(schedulingIntent.Matchers, deleted.Matchers) && schedulingIntent.ThreadRegex == deleted.ThreadRegex {
+				keysToDelete = append(keysToDelete, key)
+				break
+			}
+		}
+		return true
+	})
+	for _, key := range keysToDelete {
+		svc.schedulingIntentsMap.Delete(key)
+	}
+
+	remaining := []*domain.Intent{}
+	for _, intent := range svc.retainedIntents() {
+		if intent.PodID == deleted.PodID {
+			remaining = append(remaining, intent)
+		}
+	}
+	if len(keysToDelete) > 0 && len(remaining) > 0 {
+		podInfos, err := svc.currentPodInfos(ctx)
+		if err != nil {
+			return err
+		}
+		bound, _ := svc.bindIntents(ctx, podInfos, remaining)
+		for key, schedulingIntents := range bound {
+			if _, ok := svc.schedulingIntentsMap.Load(key); !ok {
+				svc.schedulingIntentsMap.Store(key, schedulingIntents)
+			}
+		}
+	}
+	logger.Logger(ctx).Info().Msgf("Deleted intent %q of strategy %s of pod ID %s bound to %d processes", deleted.CommandRegex, deleted.StrategyID, deleted.PodID, len(keysToDelete))
+	return svc.persistIntents()
 }
 
-// DeleteIntentByPID deletes a specific scheduling intent by pod ID and PID
+// DeleteIntentByPID deletes the scheduling intents of a process and of its threads by pod ID and PID,
+// the retained intents of the pod are no longer bound to the process until it exits
 func (svc *Service) DeleteIntentByPID(ctx context.Context, podID string, pid int) error {
-	key := fmt.Sprintf("%s-%d", podID, pid)
-	svc.schedulingIntentsMap.Delete(key)
+	svc.bindMu.Lock()
+	defer svc.bindMu.Unlock()
+	key := schedulingKey(podID, pid, 0)
+	svc.deleteProcessSchedulingIntents(podID, pid)
+	if !slices.Contains(svc.deletedPIDs[podID], pid) {
+		if svc.deletedPIDs == nil {
+			svc.deletedPIDs = make(map[string][]int)
+		}
+		svc.deletedPIDs[podID] = append(svc.deletedPIDs[podID], pid)
+	}
 	logger.Logger(ctx).Info().Msgf("Deleted scheduling intent for key: %s", key)
-	return nil
+	return svc.persistIntents()
+}
+
+// forgetDeletedPID lets the retained intents of the pod bind the PID again, once the deleted process exited, it reports whether the PID was deleted
+func (svc *Service) forgetDeletedPID(podID string, pid int) bool {
+	pids := svc.deletedPIDs[podID]
+	idx := slices.Index(pids, pid)
+	if idx < 0 {
+		return false
+	}
+	pids = slices.Delete(pids, idx, idx+1)
+	if len(pids) == 0 {
+		delete(svc.deletedPIDs, podID)
+	} else {
+		svc.deletedPIDs[podID] = pids
+	}
+	return true
+}
+
+// deleteProcessSchedulingIntents deletes the scheduling intents of a process and of its threads, it reports whether any was deleted
+func (svc *Service) deleteProcessSchedulingIntents(podID string, pid int) bool {
+	processKey := schedulingKey(podID, pid, 0)
+	keysToDelete := []string{}
+	svc.schedulingIntentsMap.Range(func(key string, value []*domain.SchedulingIntents) bool {
+		if key == processKey || strings.HasPrefix(key, processKey+"-") {
+			keysToDelete = append(keysToDelete, key)
+		}
+		return true
+	})
+	for _, key := range keysToDelete {
+		svc.schedulingIntentsMap.Delete(key)
+	}
+	return len(keysToDelete) > 0
 }
 
 // DeleteAllIntents clears all scheduling intents
 func (svc *Service) DeleteAllIntents(ctx context.Context) error {
+	svc.bindMu.Lock()
+	defer svc.bindMu.Unlock()
 	keysToDelete := []string{}
 	svc.schedulingIntentsMap.Range(func(key string, value []*domain.SchedulingIntents) bool {
 		keysToDelete = append(keysToDelete, key)
@@ -280,7 +725,9 @@ func (svc *Service) DeleteAllIntents(ctx context.Context) error {
 	for _, key := range keysToDelete {
 		svc.schedulingIntentsMap.Delete(key)
 	}
+	svc.intents.Clear()
+	clear(svc.deletedPIDs)
 
 	logger.Logger(ctx).Info().Msgf("Deleted all %d scheduling intents", len(keysToDelete))
-	return nil
+	return svc.persistIntents()
 }
@@ -2,11 +2,18 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/Gthulhu/api/decisionmaker/domain"
 	"github.com/Gthulhu/api/pkg/logger"
+	"github.com/Gthulhu/api/pkg/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -85,3 +92,530 @@ func TestFindPodInfoFrom(t *testing.T) {
 	require.Len(t, p2.Processes, 1, "should have one process")
 	assert.EqualValues(t, p2.Processes[0].Command, "busybox", "unexpected command")
 }
+
+// addFakeProcess adds a fake process of the pod 20da609e-6973-4463-a1f9-2db9bcc5becc to the fake /proc directory
+func addFakeProcess(t *testing.T, root string, pid string, comm string) {
+	addFakeContainerProcess(t, root, pid, comm, testContainerID)
+}
+
+// addFakeContainerProcess adds a fake process of the given container of the pod 20da609e-6973-4463-a1f9-2db9bcc5becc to the fake /proc directory
+func addFakeContainerProcess(t *testing.T, root string, pid string, comm string, containerID string) {
+	pidDir := filepath.Join(root, pid)
+	require.NoError(t, os.Mkdir(pidDir, 0755))
+	cgroupContent := "0::/kubelet.slice/kubelet-kubepods.slice/kubelet-kubepods-pod20da609e_6973_4463_a1f9_2db9bcc5becc.slice/cri-containerd-" + containerID + ".scope\n"
+	require.NoError(t, os.WriteFile(filepath.Join(pidDir, "cgroup"), []byte(cgroupContent), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(pidDir, "comm"), []byte(comm+"\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(pidDir, "stat"), []byte(pid+" ("+comm+") S 1234 2 3 4 5"), 0644))
+}
+
+// setFakeParent sets the parent of a fake process
+func setFakeParent(t *testing.T, root string, pid string, comm string, ppid int) {
+	stat := fmt.Sprintf("%s (%s) S %d 2 3 4 5", pid, comm, ppid)
+	require.NoError(t, os.WriteFile(filepath.Join(root, pid, "stat"), []byte(stat), 0644))
+}
+
+// addFakeProcessDetails adds the command line, executable, owner and threads of a fake process
+func addFakeProcessDetails(t *testing.T, root string, pid string, cmdline []string, exe string, uid int, threads ...string) {
+	pidDir := filepath.Join(root, pid)
+	require.NoError(t, os.WriteFile(filepath.Join(pidDir, "cmdline"), []byte(strings.Join(cmdline, "\x00")+"\x00"), 0644))
+	require.NoError(t, os.Symlink(exe, filepath.Join(pidDir, "exe")))
+	status := fmt.Sprintf("Name:\t%s\nUid:\t%d\t%d\t%d\t%d\nGid:\t%d\t%d\t%d\t%d\n", filepath.Base(exe), uid, uid, uid, uid, uid, uid, uid, uid)
+	require.NoError(t, os.WriteFile(filepath.Join(pidDir, "status"), []byte(status), 0644))
+	for i, thread := range threads {
+		tid, err := strconv.Atoi(fmt.Sprintf("%s%d", pid, i))
+		require.NoError(t, err)
+		addFakeThread(t, root, pid, tid, thread)
+	}
+}
+
+// addFakeThread adds a thread to a fake process
+func addFakeThread(t *testing.T, root string, pid string, tid int, name string) {
+	taskDir := filepath.Join(root, pid, "task", strconv.Itoa(tid))
+	require.NoError(t, os.MkdirAll(taskDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(taskDir, "comm"), []byte(name+"\n"), 0644))
+}
+
+func newTestService() *Service {
+	return &Service{
+		schedulingIntentsMap: util.NewGenericMap[string, []*domain.SchedulingIntents](),
+		intents:              util.NewGenericMap[string, *domain.Intent](),
+		bindMu:               &sync.RWMutex{},
+		processTable:         newProcessTable(),
+	}
+}
+
+func listIntentPIDs(t *testing.T, svc *Service) []int {
+	intents, err := svc.ListAllSchedulingIntents(context.Background())
+	require.NoError(t, err)
+	pids := []int{}
+	for _, intent := range intents {
+		pids = append(pids, intent.PID)
+	}
+	return pids
+}
+
+// TestProcScannerRebindsIntents tests that the /proc scanner binds retained intents to new processes and evicts dead ones
+func TestProcScannerRebindsIntents(t *testing.T) {
+	logger.InitLogger()
+	ctx := context.Background()
+	fakeProc := setupFakeProcDir(t)
+	svc := newTestService()
+	scanner := NewProcScanner(fakeProc, time.Second)
+
+	svc.intents.Store("20da609e-6973-4463-a1f9-2db9bcc5becc/^nginx", &domain.Intent{
+		PodID:         "20da609e-6973-4463-a1f9-2db9bcc5becc",
+		CommandRegex:  "^nginx",
+		Priority:      1,
+		ExecutionTime: 1000,
+	})
+	require.NoError(t, scanner.scan(ctx, svc.HandleProcessEvent))
+	assert.ElementsMatch(t, []int{1234}, listIntentPIDs(t, svc))
+
+	// a worker forked after the intent was received
+	addFakeProcess(t, fakeProc, "2345", "nginx")
+	addFakeProcess(t, fakeProc, "3456", "sidecar")
+	require.NoError(t, scanner.scan(ctx, svc.HandleProcessEvent))
+	assert.ElementsMatch(t, []int{1234, 2345}, listIntentPIDs(t, svc))
+
+	// the original process exited
+	require.NoError(t, os.RemoveAll(filepath.Join(fakeProc, "1234")))
+	require.NoError(t, scanner.scan(ctx, svc.HandleProcessEvent))
+	assert.ElementsMatch(t, []int{2345}, listIntentPIDs(t, svc))
+
+	// deleting the pod intents stops further bindings
+	require.NoError(t, svc.DeleteIntentByPodID(ctx, "20da609e-6973-4463-a1f9-2db9bcc5becc"))
+	addFakeProcess(t, fakeProc, "4567", "nginx")
+	require.NoError(t, scanner.scan(ctx, svc.HandleProcessEvent))
+	assert.Empty(t, listIntentPIDs(t, svc))
+}
+
+// TestProcessIntentsReportsResults tests that every received intent is acknowledged with the number of processes it matched
+func TestProcessIntentsReportsResults(t *testing.T) {
+	logger.InitLogger()
+	ctx := context.Background()
+	fakeProc := setupFakeProcDir(t)
+	addFakeProcess(t, fakeProc, "2345", "nginx")
+	svc := newTestService()
+	require.NoError(t, NewProcScanner(fakeProc, time.Hour).Watch(canceledContext(), svc.HandleProcessEvent))
+
+	results, err := svc.ProcessIntents(ctx, []*domain.Intent{
+		{PodID: testPodUID, CommandRegex: "^nginx"},
+		{PodID: testPodUID, CommandRegex: "^redis"},
+		{PodID: "0b7f5bd8-5a38-4d64-a2d4-5b8f0bc7e6a1", CommandRegex: "^nginx"},
+		{PodID: testPodUID, CommandRegex: "(nginx"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	assert.Equal(t, &domain.IntentResult{PodID: testPodUID, CommandRegex: "^nginx", State: domain.IntentResultApplied, MatchedPIDs: 2}, results[0])
+	assert.Equal(t, domain.IntentResultNoMatchingProcess, results[1].State)
+	assert.Equal(t, domain.IntentResultNoMatchingProcess, results[2].State, "a pod without process on the node matches no process")
+	assert.Equal(t, domain.IntentResultFailed, results[3].State)
+	assert.Contains(t, results[3].Error, "invalid command regex")
+}
+
+// TestProcessIntentsCarriesSelector tests that the label selector of the strategy is appended to the selectors of the scheduling intents
+func TestProcessIntentsCarriesSelector(t *testing.T) {
+	logger.InitLogger()
+	ctx := context.Background()
+	fakeProc := setupFakeProcDir(t)
+	svc := newTestService()
+	require.NoError(t, NewProcScanner(fakeProc, time.Hour).Watch(canceledContext(), svc.HandleProcessEvent))
+
+	tierSelector := domain.LabelSelector{Key: "tier", Operator: "In", Values: []string{"frontend", "backend"}}
+	_, err := svc.ProcessIntents(ctx, []*domain.Intent{
+		{PodID: testPodUID, CommandRegex: "^nginx", PodLabels: map[string]string{"app": "web"}, Selector: []domain.LabelSelector{tierSelector}},
+	})
+	require.NoError(t, err)
+	intents, err := svc.ListAllSchedulingIntents(ctx)
+	require.NoError(t, err)
+	require.Len(t, intents, 1)
+	assert.Equal(t, []domain.LabelSelector{{Key: "app", Value: "web"}, tierSelector}, intents[0].Selectors)
+}
+
+// TestProcessIntentsPrecedence tests that a process matched by the intents of several strategies is bound to the one that takes precedence,
+// whatever the order the intents are received in
+func TestProcessIntentsPrecedence(t *testing.T) {
+	logger.InitLogger()
+	ctx := context.Background()
+	fakeProc := setupFakeProcDir(t)
+	svc := newTestService()
+	require.NoError(t, NewProcScanner(fakeProc, time.Hour).Watch(canceledContext(), svc.HandleProcessEvent))
+
+	heavy := &domain.Intent{PodID: testPodUID, CommandRegex: "^nginx", ExecutionTime: 1000, StrategyID: "heavy", Weight: 10}
+	specific := &domain.Intent{PodID: testPodUID, CommandRegex: "^nginx$", ExecutionTime: 2000, StrategyID: "specific", Specificity: 3}
+	newer := &domain.Intent{PodID: testPodUID, CommandRegex: "nginx", ExecutionTime: 3000, StrategyID: "newer", Specificity: 3, StrategyCreatedTime: 2000}
+
+	results, err := svc.ProcessIntents(ctx, []*domain.Intent{heavy})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	results, err = svc.ProcessIntents(ctx, []*domain.Intent{specific, newer})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "^nginx$", results[0].CommandRegex)
+	assert.Equal(t, 1, results[0].MatchedPIDs, "an overridden intent still reports its matching processes")
+
+	intents, err := svc.ListAllSchedulingIntents(ctx)
+	require.NoError(t, err)
+	require.Len(t, intents, 1)
+	assert.Equal(t, "heavy", intents[0].StrategyID, "the higher weight should win over the later intents")
+
+	require.NoError(t, svc.DeleteIntentByCommandRegex(ctx, &domain.Intent{PodID: testPodUID, StrategyID: "heavy", CommandRegex: "^nginx"}))
+	intents, err = svc.ListAllSchedulingIntents(ctx)
+	require.NoError(t, err)
+	require.Len(t, intents, 1)
+	assert.Equal(t, "newer", intents[0].StrategyID, "the newest strategy should win a tie of weight and specificity")
+}
+
+// TestProcessIntentsPrecedenceSameRegex tests that the intents of several strategies with the same command regex are retained side by side,
+// so that the one that takes precedence wins whatever the order they are received in
+func TestProcessIntentsPrecedenceSameRegex(t *testing.T) {
+	logger.InitLogger()
+	ctx := context.Background()
+	fakeProc := setupFakeProcDir(t)
+	svc := newTestService()
+	require.NoError(t, NewProcScanner(fakeProc, time.Hour).Watch(canceledContext(), svc.HandleProcessEvent))
+
+	heavy := &domain.Intent{PodID: testPodUID, ExecutionTime: 1000, StrategyID: "heavy", Weight: 10}
+	light := &domain.Intent{PodID: testPodUID, ExecutionTime: 2000, StrategyID: "light", Weight: 1}
+	_, err := svc.ProcessIntents(ctx, []*domain.Intent{heavy})
+	require.NoError(t, err)
+	results, err := svc.ProcessIntents(ctx, []*domain.Intent{light})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "light", results[0].StrategyID)
+
+	intents, err := svc.ListAllSchedulingIntents(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, intents)
+	for _, intent := range intents {
+		assert.Equal(t, "heavy", intent.StrategyID, "the higher weight should win over the later intent with the same command regex")
+	}
+
+	require.NoError(t, svc.DeleteIntentByCommandRegex(ctx, &domain.Intent{PodID: testPodUID, StrategyID: "heavy"}))
+	intents, err = svc.ListAllSchedulingIntents(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, intents)
+	for _, intent := range intents {
+		assert.Equal(t, "light", intent.StrategyID, "the processes should be bound again to the remaining strategy")
+	}
+}
+
+// TestListPodSchedulingIntents tests that only the scheduling intents of the given pod are listed, ordered by PID
+func TestListPodSchedulingIntents(t *testing.T) {
+	logger.InitLogger()
+	ctx := context.Background()
+	fakeProc := setupFakeProcDir(t)
+	addFakeProcess(t, fakeProc, "2345", "nginx")
+	svc := newTestService()
+	require.NoError(t, NewProcScanner(fakeProc, time.Hour).Watch(canceledContext(), svc.HandleProcessEvent))
+
+	_, err := svc.ProcessIntents(ctx, []*domain.Intent{
+		{PodID: testPodUID, CommandRegex: "^nginx", StrategyID: "web"},
+		{PodID: "e52d4a2a-6e5f-44d9-a8b8-37ff3daa7413", CommandRegex: "busybox"},
+	})
+	require.NoError(t, err)
+	intents, err := svc.ListPodSchedulingIntents(ctx, testPodUID)
+	require.NoError(t, err)
+	require.Len(t, intents, 2)
+	assert.Equal(t, 1234, intents[0].PID)
+	assert.Equal(t, 2345, intents[1].PID)
+	assert.Equal(t, "web", intents[0].StrategyID)
+}
+
+// TestProcessIntentsContainerIDs tests that an intent restricted to containers is only bound to the processes of those containers
+func TestProcessIntentsContainerIDs(t *testing.T) {
+	logger.InitLogger()
+	ctx := context.Background()
+	fakeProc := setupFakeProcDir(t)
+	sidecarID := "5f1c0a9d2e7b4c3a8d6e9f0b1a2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e"
+	addFakeProcess(t, fakeProc, "2345", "nginx")
+	addFakeContainerProcess(t, fakeProc, "3456", "envoy", sidecarID)
+	svc := newTestService()
+	require.NoError(t, NewProcScanner(fakeProc, time.Hour).Watch(canceledContext(), svc.HandleProcessEvent))
+
+	results, err := svc.ProcessIntents(ctx, []*domain.Intent{
+		{PodID: testPodUID, CommandRegex: ".", ContainerIDs: []string{testContainerID}, Priority: 1},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, 2, results[0].MatchedPIDs)
+	assert.ElementsMatch(t, []int{1234, 2345}, listIntentPIDs(t, svc), "the sidecar should not be bound")
+
+	previews, err := svc.PreviewIntents(ctx, []*domain.Intent{
+		{PodID: testPodUID, CommandRegex: ".", ContainerIDs: []string{sidecarID}},
+		{PodID: testPodUID, CommandRegex: "."},
+	})
+	require.NoError(t, err)
+	require.Len(t, previews, 2)
+	require.Len(t, previews[0].Processes, 1)
+	assert.Equal(t, 3456, previews[0].Processes[0].PID)
+	assert.Len(t, previews[1].Processes, 3, "an intent without container IDs matches every container")
+}
+
+// TestProcessIntentsContainersSameRegex tests that the intents of a pod with the same command regex for different containers are retained
+// side by side, each one bound to the processes of its containers
+func TestProcessIntentsContainersSameRegex(t *testing.T) {
+	logger.InitLogger()
+	ctx := context.Background()
+	fakeProc := setupFakeProcDir(t)
+	sidecarID := "5f1c0a9d2e7b4c3a8d6e9f0b1a2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e"
+	addFakeContainerProcess(t, fakeProc, "3456", "nginx", sidecarID)
+	svc := newTestService()
+	require.NoError(t, NewProcScanner(fakeProc, time.Hour).Watch(canceledContext(), svc.HandleProcessEvent))
+
+	app := &domain.Intent{PodID: testPodUID, CommandRegex: "^nginx", ContainerIDs: []string{testContainerID}, ExecutionTime: 1000, StrategyID: "web"}
+	sidecar := &domain.Intent{PodID: testPodUID, CommandRegex: "^nginx", ContainerIDs: []string{sidecarID}, ExecutionTime: 2000, StrategyID: "web"}
+	_, err := svc.ProcessIntents(ctx, []*domain.Intent{app})
+	require.NoError(t, err)
+	results, err := svc.ProcessIntents(ctx, []*domain.Intent{sidecar})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, []string{sidecarID}, results[0].ContainerIDs)
+
+	executionTimes := func() map[int]uint64 {
+		intents, err := svc.ListPodSchedulingIntents(ctx, testPodUID)
+		require.NoError(t, err)
+		times := make(map[int]uint64, len(intents))
+		for _, intent := range intents {
+			times[intent.PID] = intent.ExecutionTime
+		}
+		return times
+	}
+	assert.Equal(t, map[int]uint64{1234: 1000, 3456: 2000}, executionTimes(), "the sidecar intent should not replace the app one")
+
+	require.NoError(t, svc.DeleteIntentByCommandRegex(ctx, &domain.Intent{PodID: testPodUID, CommandRegex: "^nginx", ContainerIDs: []string{sidecarID}, StrategyID: "web"}))
+	assert.Equal(t, map[int]uint64{1234: 1000}, executionTimes())
+}
+
+// TestGetProcessInfo tests that the command line, executable, owner, cgroup and threads of the processes are discovered
+func TestGetProcessInfo(t *testing.T) {
+	logger.InitLogger()
+	fakeProc := setupFakeProcDir(t)
+	addFakeProcess(t, fakeProc, "2345", "java")
+	addFakeProcessDetails(t, fakeProc, "2345", []string{"java", "-jar", "/app/a.jar"}, "/usr/bin/java", 1000, "java", "GC Thread#0")
+
+	pods, err := findPodInfoFrom(context.Background(), fakeProc)
+	require.NoError(t, err)
+	var process domain.PodProcess
+	for _, p := range pods[testPodUID].Processes {
+		if p.PID == 2345 {
+			process = p
+		}
+	}
+	assert.Equal(t, "java -jar /app/a.jar", process.Cmdline)
+	assert.Equal(t, "/usr/bin/java", process.Exe)
+	assert.Equal(t, 1000, process.UID)
+	assert.Equal(t, 1000, process.GID)
+	assert.Contains(t, process.Cgroup, "cri-containerd-"+testContainerID+".scope")
+	assert.Equal(t, []domain.Thread{{TID: 23450, Name: "java"}, {TID: 23451, Name: "GC Thread#0"}}, process.Threads)
+
+	process, err = getProcessInfo(fakeProc, 1234)
+	require.NoError(t, err)
+	assert.Equal(t, -1, process.UID, "an unreadable owner should be unknown rather than root")
+}
+
+// TestProcessIntentsMatchers tests that the matchers of an intent are ANDed with its command regex, and that the intents
+// of a pod with the same command regex and different matchers are retained side by side
+func TestProcessIntentsMatchers(t *testing.T) {
+	logger.InitLogger()
+	ctx := context.Background()
+	fakeProc := setupFakeProcDir(t)
+	addFakeProcess(t, fakeProc, "2345", "java")
+	addFakeProcessDetails(t, fakeProc, "2345", []string{"java", "-jar", "/app/a.jar"}, "/usr/bin/java", 1000, "java", "GC Thread#0")
+	addFakeProcess(t, fakeProc, "3456", "java")
+	addFakeProcessDetails(t, fakeProc, "3456", []string{"java", "-jar", "/app/b.jar"}, "/usr/bin/java", 0, "java")
+	svc := newTestService()
+	require.NoError(t, NewProcScanner(fakeProc, time.Hour).Watch(canceledContext(), svc.HandleProcessEvent))
+
+	results, err := svc.ProcessIntents(ctx, []*domain.Intent{
+		{PodID: testPodUID, CommandRegex: "^java$", Matchers: []domain.ProcessMatcher{{Field: domain.ProcessFieldCmdline, Regex: `a\.jar$`}}, StrategyID: "a"},
+		{PodID: testPodUID, CommandRegex: "^java$", Matchers: []domain.ProcessMatcher{{Field: domain.ProcessFieldCmdline, Regex: `b\.jar$`}, {Field: domain.ProcessFieldUID, Regex: "^0$"}}, StrategyID: "b"},
+		{PodID: testPodUID, Matchers: []domain.ProcessMatcher{{Field: domain.ProcessFieldThread, Regex: "^GC"}, {Field: domain.ProcessFieldExe, Regex: "python"}}},
+		{PodID: testPodUID, Matchers: []domain.ProcessMatcher{{Field: "environ", Regex: "."}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	assert.Equal(t, 1, results[0].MatchedPIDs)
+	assert.Equal(t, 1, results[1].MatchedPIDs)
+	assert.Equal(t, domain.IntentResultNoMatchingProcess, results[2].State, "the matchers should be ANDed")
+	assert.Equal(t, domain.IntentResultFailed, results[3].State)
+	assert.Contains(t, results[3].Error, "unknown matcher field")
+
+	intents, err := svc.ListPodSchedulingIntents(ctx, testPodUID)
+	require.NoError(t, err)
+	require.Len(t, intents, 2)
+	assert.Equal(t, 2345, intents[0].PID)
+	assert.Equal(t, "a", intents[0].StrategyID)
+	assert.Equal(t, 3456, intents[1].PID)
+	assert.Equal(t, "b", intents[1].StrategyID)
+
+	require.NoError(t, svc.DeleteIntentByCommandRegex(ctx, &domain.Intent{PodID: testPodUID, StrategyID: "a", CommandRegex: "^java$", Matchers: []domain.ProcessMatcher{{Field: domain.ProcessFieldCmdline, Regex: `a\.jar$`}}}))
+	assert.ElementsMatch(t, []int{3456}, listIntentPIDs(t, svc))
+}
+
+// TestProcessIntentsThreads tests that an intent with a thread regex is bound to the matching threads of its processes,
+// next to the intent bound to the whole process, and that the thread entries follow the threads of the process
+func TestProcessIntentsThreads(t *testing.T) {
+	logger.InitLogger()
+	ctx := context.Background()
+	fakeProc := setupFakeProcDir(t)
+	addFakeProcess(t, fakeProc, "2345", "java")
+	addFakeProcessDetails(t, fakeProc, "2345", []string{"java", "-jar", "/app/a.jar"}, "/usr/bin/java", 1000, "java", "GC Thread#0", "GC Thread#1")
+	svc := newTestService()
+	scanner := NewProcScanner(fakeProc, time.Hour)
+	require.NoError(t, scanner.Watch(canceledContext(), svc.HandleProcessEvent))
+
+	results, err := svc.ProcessIntents(ctx, []*domain.Intent{
+		{PodID: testPodUID, CommandRegex: "^java$", ExecutionTime: 20000000, StrategyID: "process"},
+		{PodID: testPodUID, CommandRegex: "^java$", ThreadRegex: "^GC Thread", Priority: 1, StrategyID: "gc"},
+		{PodID: testPodUID, CommandRegex: "^nginx$", ThreadRegex: "^GC Thread"},
+		{PodID: testPodUID, ThreadRegex: "("},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	assert.Equal(t, domain.IntentResultApplied, results[0].State)
+	assert.Equal(t, 0, results[0].MatchedTIDs)
+	assert.Equal(t, domain.IntentResultApplied, results[1].State)
+	assert.Equal(t, 1, results[1].MatchedPIDs)
+	assert.Equal(t, 2, results[1].MatchedTIDs)
+	assert.Equal(t, domain.IntentResultNoMatchingProcess, results[2].State, "a process without matching threads should not be bound")
+	assert.Equal(t, domain.IntentResultFailed, results[3].State)
+	assert.Contains(t, results[3].Error, "invalid thread regex")
+
+	listTasks := func() [][2]int {
+		intents, err := svc.ListPodSchedulingIntents(ctx, testPodUID)
+		require.NoError(t, err)
+		tasks := [][2]int{}
+		for _, intent := range intents {
+			tasks = append(tasks, [2]int{intent.PID, intent.TID})
+		}
+		return tasks
+	}
+	assert.Equal(t, [][2]int{{2345, 0}, {2345, 23451}, {2345, 23452}}, listTasks())
+	intents, err := svc.ListPodSchedulingIntents(ctx, testPodUID)
+	require.NoError(t, err)
+	assert.Equal(t, "process", intents[0].StrategyID)
+	assert.Equal(t, "gc", intents[1].StrategyID)
+	assert.Equal(t, "^GC Thread", intents[1].ThreadRegex)
+	assert.True(t, intents[1].Priority)
+
+	// a GC thread exited and another one started
+	require.NoError(t, os.RemoveAll(filepath.Join(fakeProc, "2345", "task", "23451")))
+	addFakeThread(t, fakeProc, "2345", 23460, "GC Thread#2")
+	require.NoError(t, scanner.scan(ctx, svc.HandleProcessEvent))
+	assert.Equal(t, [][2]int{{2345, 0}, {2345, 23452}, {2345, 23460}}, listTasks())
+
+	previews, err := svc.PreviewIntents(ctx, []*domain.Intent{{PodID: testPodUID, CommandRegex: "^java$", ThreadRegex: "#2$"}})
+	require.NoError(t, err)
+	require.Len(t, previews[0].Processes, 1)
+	assert.Equal(t, []domain.Thread{{TID: 23460, Name: "GC Thread#2"}}, previews[0].Processes[0].Threads)
+
+	require.NoError(t, svc.DeleteIntentByCommandRegex(ctx, &domain.Intent{PodID: testPodUID, StrategyID: "gc", CommandRegex: "^java$", ThreadRegex: "^GC Thread"}))
+	assert.Equal(t, [][2]int{{2345, 0}}, listTasks())
+
+	require.NoError(t, svc.DeleteIntentByPID(ctx, testPodUID, 2345))
+	assert.Empty(t, listTasks())
+}
+
+// TestProcessIntentsDescendants tests that an intent including descendants is bound to the descendants of the matching processes,
+// including the ones that appear later, and only to them
+func TestProcessIntentsDescendants(t *testing.T) {
+	logger.InitLogger()
+	ctx := context.Background()
+	fakeProc := setupFakeProcDir(t)
+	addFakeProcess(t, fakeProc, "2345", "gunicorn")
+	setFakeParent(t, fakeProc, "2345", "gunicorn", 1)
+	addFakeProcess(t, fakeProc, "3456", "python3")
+	setFakeParent(t, fakeProc, "3456", "python3", 2345)
+	addFakeProcess(t, fakeProc, "4567", "worker (1)")
+	setFakeParent(t, fakeProc, "4567", "worker (1)", 3456)
+	svc := newTestService()
+	scanner := NewProcScanner(fakeProc, time.Hour)
+	require.NoError(t, scanner.Watch(canceledContext(), svc.HandleProcessEvent))
+
+	process, err := getProcessInfo(fakeProc, 4567)
+	require.NoError(t, err)
+	assert.Equal(t, 3456, process.PPID, "the comm of the process should not shift the fields of its stat")
+
+	results, err := svc.ProcessIntents(ctx, []*domain.Intent{
+		{PodID: testPodUID, CommandRegex: "^gunicorn$", IncludeDescendants: true, Priority: 1, StrategyID: "gunicorn"},
+		{PodID: testPodUID, CommandRegex: "^nginx$", StrategyID: "nginx"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, 3, results[0].MatchedPIDs)
+	assert.Equal(t, 1, results[1].MatchedPIDs)
+	assert.ElementsMatch(t, []int{1234, 2345, 3456, 4567}, listIntentPIDs(t, svc))
+
+	// a worker forked by the master after the intent was bound
+	addFakeProcess(t, fakeProc, "6789", "python3")
+	setFakeParent(t, fakeProc, "6789", "python3", 2345)
+	require.NoError(t, scanner.scan(ctx, svc.HandleProcessEvent))
+	intents, err := svc.ListPodSchedulingIntents(ctx, testPodUID)
+	require.NoError(t, err)
+	require.Len(t, intents, 5)
+	assert.Equal(t, 6789, intents[4].PID)
+	assert.Equal(t, "gunicorn", intents[4].StrategyID)
+
+	previews, err := svc.PreviewIntents(ctx, []*domain.Intent{{PodID: testPodUID, CommandRegex: "^python3$", IncludeDescendants: true}})
+	require.NoError(t, err)
+	pids := []int{}
+	for _, process := range previews[0].Processes {
+		pids = append(pids, process.PID)
+	}
+	assert.ElementsMatch(t, []int{3456, 4567, 6789}, pids, "the ancestors of the matching processes should not be included")
+}
+
+// TestPreviewIntents tests that a preview lists the matching processes without binding them
+func TestPreviewIntents(t *testing.T) {
+	logger.InitLogger()
+	ctx := context.Background()
+	fakeProc := setupFakeProcDir(t)
+	addFakeProcess(t, fakeProc, "2345", "nginx")
+	svc := newTestService()
+	require.NoError(t, NewProcScanner(fakeProc, time.Hour).Watch(canceledContext(), svc.HandleProcessEvent))
+
+	previews, err := svc.PreviewIntents(ctx, []*domain.Intent{
+		{PodID: testPodUID, CommandRegex: "^nginx"},
+		{PodID: testPodUID, CommandRegex: "(nginx"},
+	})
+	require.NoError(t, err)
+	require.Len(t, previews, 2)
+	pids := []int{}
+	for _, process := range previews[0].Processes {
+		pids = append(pids, process.PID)
+	}
+	assert.ElementsMatch(t, []int{1234, 2345}, pids)
+	assert.Contains(t, previews[1].Error, "invalid command regex")
+	assert.Empty(t, listIntentPIDs(t, svc), "a preview should not bind any process")
+}
+
+// TestDeleteIntentByCommandRegex tests that the processes of a deleted intent are bound again to the remaining intents of the pod
+func TestDeleteIntentByCommandRegex(t *testing.T) {
+	logger.InitLogger()
+	ctx := context.Background()
+	fakeProc := setupFakeProcDir(t)
+	svc := newTestService()
+	require.NoError(t, NewProcScanner(fakeProc, time.Hour).Watch(canceledContext(), svc.HandleProcessEvent))
+
+	_, err := svc.ProcessIntents(ctx, []*domain.Intent{
+		{PodID: testPodUID, CommandRegex: "^nginx", Priority: 1},
+		{PodID: testPodUID, CommandRegex: "nginx", ExecutionTime: 2000},
+	})
+	require.NoError(t, err)
+	intents, err := svc.ListAllSchedulingIntents(ctx)
+	require.NoError(t, err)
+	require.Len(t, intents, 1)
+	assert.Equal(t, "nginx", intents[0].CommandRegex)
+
+	require.NoError(t, svc.DeleteIntentByCommandRegex(ctx, &domain.Intent{PodID: testPodUID, CommandRegex: "nginx"}))
+	intents, err = svc.ListAllSchedulingIntents(ctx)
+	require.NoError(t, err)
+	require.Len(t, intents, 1)
+	assert.Equal(t, 1234, intents[0].PID)
+	assert.Equal(t, "^nginx", intents[0].CommandRegex)
+	assert.True(t, intents[0].Priority)
+
+	require.NoError(t, svc.DeleteIntentByCommandRegex(ctx, &domain.Intent{PodID: testPodUID, CommandRegex: "^nginx"}))
+	assert.Empty(t, listIntentPIDs(t, svc))
+}
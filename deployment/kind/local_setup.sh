@@ -61,6 +61,7 @@ kubectl apply -n "$NS" -f "$PROJECT_ROOT/deployment/kind/pod/busybox.yaml"
 
 echo "deploy busybox pods"
 
+kubectl apply -f "$PROJECT_ROOT/deployment/k8s/schedulingstrategy-crd.yaml"
 kubectl apply -n "$NS" -f "$PROJECT_ROOT/deployment/kind/manager/service.yaml"
 kubectl apply -n "$NS" -f "$PROJECT_ROOT/deployment/kind/manager/deployment.yaml"
 
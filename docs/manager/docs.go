@@ -76,6 +76,57 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/decisionmaker/intents": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns every schedule intent the decision maker of the node should enforce, used by the decision makers to sync their full state.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DecisionMaker"
+                ],
+                "summary": "List schedule intents of a node",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Node ID, defaults to the node ID of the decision maker token",
+                        "name": "nodeID",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListNodeScheduleIntentsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/intents": {
             "delete": {
                 "security": [
@@ -152,7 +203,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "List schedule intents created by the authenticated user.",
+                "description": "List schedule intents created by the authenticated user. With groupBy=workload the intents are also grouped by the workload controlling their pod.",
                 "consumes": [
                     "application/json"
                 ],
@@ -163,6 +214,14 @@ const docTemplate = `{
                     "Strategies"
                 ],
                 "summary": "List self schedule intents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group the intents, only workload is supported",
+                        "name": "groupBy",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -234,6 +293,81 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/pods/policy": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns every strategy and intent touching a pod ordered by precedence, the winning intent and the scheduling the decision maker of the node enforces per PID. The pod is given by its UID, or by its namespace and name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Strategies"
+                ],
+                "summary": "Get the effective policy of a pod",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pod UID",
+                        "name": "podID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace of the pod, with podName",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the pod, with namespace",
+                        "name": "podName",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_EffectivePolicyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/roles": {
             "get": {
                 "security": [
@@ -455,13 +589,13 @@ const docTemplate = `{
             }
         },
         "/api/v1/strategies": {
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new schedule strategy.",
+                "description": "Replace the criteria and scheduling parameters of a schedule strategy, only the changed intents are propagated to the decision makers.",
                 "consumes": [
                     "application/json"
                 ],
@@ -471,7 +605,7 @@ const docTemplate = `{
                 "tags": [
                     "Strategies"
                 ],
-                "summary": "Create schedule strategy",
+                "summary": "Update schedule strategy",
                 "parameters": [
                     {
                         "description": "Schedule strategy payload",
@@ -479,7 +613,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.CreateScheduleStrategyRequest"
+                            "$ref": "#/definitions/rest.UpdateScheduleStrategyRequest"
                         }
                     }
                 ],
@@ -487,7 +621,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-github_com_Gthulhu_api_manager_rest_EmptyResponse"
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ScheduleStrategyResponse"
                         }
                     },
                     "400": {
@@ -508,6 +642,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -516,13 +656,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a schedule strategy and its associated intents.",
+                "description": "Create a new schedule strategy, the response reports the other strategies targeting the same pods with a different setting.",
                 "consumes": [
                     "application/json"
                 ],
@@ -532,15 +672,15 @@ const docTemplate = `{
                 "tags": [
                     "Strategies"
                 ],
-                "summary": "Delete schedule strategy",
+                "summary": "Create schedule strategy",
                 "parameters": [
                     {
-                        "description": "Strategy ID to delete",
+                        "description": "Schedule strategy payload",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.DeleteScheduleStrategyRequest"
+                            "$ref": "#/definitions/rest.CreateScheduleStrategyRequest"
                         }
                     }
                 ],
@@ -548,7 +688,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-github_com_Gthulhu_api_manager_rest_EmptyResponse"
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ScheduleStrategyResponse"
                         }
                     },
                     "400": {
@@ -569,12 +709,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -582,16 +716,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/strategies/self": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "List schedule strategies created by the authenticated user.",
+                "description": "Delete a schedule strategy and its associated intents.",
                 "consumes": [
                     "application/json"
                 ],
@@ -601,12 +733,23 @@ const docTemplate = `{
                 "tags": [
                     "Strategies"
                 ],
-                "summary": "List self schedule strategies",
+                "summary": "Delete schedule strategy",
+                "parameters": [
+                    {
+                        "description": "Strategy ID to delete",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.DeleteScheduleStrategyRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListSchedulerStrategiesResponse"
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-github_com_Gthulhu_api_manager_rest_EmptyResponse"
                         }
                     },
                     "400": {
@@ -627,6 +770,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -636,26 +785,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users": {
+        "/api/v1/strategies/bundle": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve user list.",
+                "description": "Export every strategy of a strategy namespace as a bundle document that can be applied back, in YAML or JSON.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/yaml"
                 ],
                 "tags": [
-                    "Users"
+                    "Strategies"
+                ],
+                "summary": "Export strategy bundle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Strategy namespace to export",
+                        "name": "strategyNamespace",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or yaml",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List users",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListUsersResponse"
+                            "$ref": "#/definitions/rest.StrategyBundle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
                         }
                     },
                     "401": {
@@ -664,6 +835,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -678,33 +855,46 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new user account.",
+                "description": "Create and update the strategies of the bundle namespace to match a YAML or JSON bundle, with prune the strategies missing from the bundle are deleted. Applying the same bundle again changes nothing. With dryRun only the changes are reported.",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "application/yaml"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Strategies"
                 ],
-                "summary": "Create user",
+                "summary": "Apply strategy bundle",
                 "parameters": [
                     {
-                        "description": "User payload",
+                        "description": "Strategy bundle",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.CreateUserRequest"
+                            "$ref": "#/definitions/rest.StrategyBundle"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only report the changes",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete the strategies of the namespace missing from the bundle",
+                        "name": "prune",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-github_com_Gthulhu_api_manager_rest_EmptyResponse"
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ApplyStrategyBundleResponse"
                         }
                     },
                     "400": {
@@ -734,14 +924,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/password": {
-            "put": {
+        "/api/v1/strategies/preview": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Reset another user's password.",
+                "description": "Resolve the pods a schedule strategy would target, grouped by node, without creating it. With includeProcesses the decision makers report the processes matching the command regex.",
                 "consumes": [
                     "application/json"
                 ],
@@ -749,17 +939,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Strategies"
                 ],
-                "summary": "Reset user password",
+                "summary": "Preview schedule strategy",
                 "parameters": [
                     {
-                        "description": "Reset payload",
+                        "description": "Schedule strategy payload",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.ResetPasswordRequest"
+                            "$ref": "#/definitions/rest.PreviewScheduleStrategyRequest"
                         }
                     }
                 ],
@@ -767,7 +957,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-github_com_Gthulhu_api_manager_rest_EmptyResponse"
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_PreviewScheduleStrategyResponse"
                         }
                     },
                     "400": {
@@ -797,14 +987,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/permissions": {
-            "put": {
+        "/api/v1/strategies/self": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a user's roles or status.",
+                "description": "List schedule strategies created by the authenticated user.",
                 "consumes": [
                     "application/json"
                 ],
@@ -812,25 +1002,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
-                ],
-                "summary": "Update user roles and status",
-                "parameters": [
-                    {
-                        "description": "Permissions payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.UpdateUserPermissionsRequest"
-                        }
-                    }
+                    "Strategies"
                 ],
+                "summary": "List self schedule strategies",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-github_com_Gthulhu_api_manager_rest_EmptyResponse"
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListSchedulerStrategiesResponse"
                         }
                     },
                     "400": {
@@ -860,26 +1039,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/self": {
+        "/api/v1/strategy-templates": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve profile of the authenticated user.",
+                "description": "List the strategy templates, including the default ones.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "StrategyTemplates"
                 ],
-                "summary": "Get current user",
+                "summary": "List strategy templates",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_GetSelfUserResponse"
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListStrategyTemplatesResponse"
                         }
                     },
                     "401": {
@@ -888,6 +1067,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -895,16 +1080,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/users/self/password": {
+            },
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update current user's password.",
+                "description": "Replace a strategy template, with propagate the strategies derived from it are updated and their intents sent to the decision makers.",
                 "consumes": [
                     "application/json"
                 ],
@@ -912,17 +1095,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "StrategyTemplates"
                 ],
-                "summary": "Change own password",
+                "summary": "Update strategy template",
                 "parameters": [
                     {
-                        "description": "Password payload",
+                        "description": "Strategy template payload",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.ChangePasswordRequest"
+                            "$ref": "#/definitions/rest.UpdateStrategyTemplateRequest"
                         }
                     }
                 ],
@@ -930,7 +1113,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-github_com_Gthulhu_api_manager_rest_EmptyResponse"
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_UpdateStrategyTemplateResponse"
                         }
                     },
                     "400": {
@@ -945,88 +1128,582 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
                             "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/health": {
-            "get": {
-                "description": "Basic health check for readiness probes.",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a named preset of priority and execution time, strategies can be created from it.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "System"
+                    "StrategyTemplates"
+                ],
+                "summary": "Create strategy template",
+                "parameters": [
+                    {
+                        "description": "Strategy template payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.CreateStrategyTemplateRequest"
+                        }
+                    }
                 ],
-                "summary": "Health check",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.HealthResponse"
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_StrategyTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a strategy template no strategy is derived from.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StrategyTemplates"
+                ],
+                "summary": "Delete strategy template",
+                "parameters": [
+                    {
+                        "description": "Delete strategy template payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.DeleteStrategyTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-github_com_Gthulhu_api_manager_rest_EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/version": {
+        "/api/v1/users": {
             "get": {
-                "description": "Returns service version and exposed endpoints.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve user list.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "System"
+                    "Users"
                 ],
-                "summary": "Get service version",
+                "summary": "List users",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.VersionResponse"
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListUsersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new user account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create user",
+                "parameters": [
+                    {
+                        "description": "User payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-github_com_Gthulhu_api_manager_rest_EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.IntentState": {
-            "type": "integer",
-            "format": "int32",
-            "enum": [
-                0,
-                1,
-                2
-            ],
-            "x-enum-varnames": [
-                "IntentStateUnknown",
-                "IntentStateInitialized",
-                "IntentStateSent"
-            ]
         },
-        "domain.PermissionKey": {
-            "type": "string",
-            "enum": [
-                "user.create",
-                "user.read",
-                "user.permission.update",
-                "user.password.reset",
-                "role.create",
-                "role.read",
-                "role.update",
-                "role.delete",
-                "permission.read",
-                "schedule_strategy.create",
-                "schedule_strategy.read",
+        "/api/v1/users/password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reset another user's password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Reset user password",
+                "parameters": [
+                    {
+                        "description": "Reset payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-github_com_Gthulhu_api_manager_rest_EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/permissions": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a user's roles or status.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user roles and status",
+                "parameters": [
+                    {
+                        "description": "Permissions payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.UpdateUserPermissionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-github_com_Gthulhu_api_manager_rest_EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/self": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve profile of the authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_GetSelfUserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/self/password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update current user's password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change own password",
+                "parameters": [
+                    {
+                        "description": "Password payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.SuccessResponse-github_com_Gthulhu_api_manager_rest_EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Basic health check for readiness probes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/version": {
+            "get": {
+                "description": "Returns service version and exposed endpoints.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get service version",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.VersionResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.IntentState": {
+            "type": "integer",
+            "format": "int32",
+            "enum": [
+                0,
+                1,
+                2,
+                3,
+                4,
+                5,
+                6,
+                7,
+                8
+            ],
+            "x-enum-varnames": [
+                "IntentStateUnknown",
+                "IntentStateInitialized",
+                "IntentStateSent",
+                "IntentStateFailed",
+                "IntentStateApplied",
+                "IntentStatePartiallyApplied",
+                "IntentStateNoMatchingProcess",
+                "IntentStateExpired",
+                "IntentStateScheduled"
+            ]
+        },
+        "domain.PermissionKey": {
+            "type": "string",
+            "enum": [
+                "user.create",
+                "user.read",
+                "user.permission.update",
+                "user.password.reset",
+                "role.create",
+                "role.read",
+                "role.update",
+                "role.delete",
+                "permission.read",
+                "schedule_strategy.create",
+                "schedule_strategy.read",
+                "schedule_strategy.update",
                 "schedule_strategy.delete",
+                "schedule_strategy.apply",
                 "schedule_intent.read",
-                "schedule_intent.delete"
+                "schedule_intent.delete",
+                "strategy_template.create",
+                "strategy_template.read",
+                "strategy_template.update",
+                "strategy_template.delete"
             ],
             "x-enum-varnames": [
                 "CreateUser",
@@ -1040,255 +1717,1089 @@ const docTemplate = `{
                 "PermissionRead",
                 "ScheduleStrategyCreate",
                 "ScheduleStrategyRead",
+                "ScheduleStrategyUpdate",
                 "ScheduleStrategyDelete",
+                "ScheduleStrategyApply",
                 "ScheduleIntentRead",
-                "ScheduleIntentDelete"
+                "ScheduleIntentDelete",
+                "StrategyTemplateCreate",
+                "StrategyTemplateRead",
+                "StrategyTemplateUpdate",
+                "StrategyTemplateDelete"
+            ]
+        },
+        "domain.UserStatus": {
+            "type": "integer",
+            "format": "int32",
+            "enum": [
+                1,
+                2,
+                3
+            ],
+            "x-enum-varnames": [
+                "UserStatusActive",
+                "UserStatusInactive",
+                "UserStatusWaitChangePassword"
             ]
         },
-        "domain.UserStatus": {
-            "type": "integer",
-            "format": "int32",
-            "enum": [
-                1,
-                2,
-                3
-            ],
-            "x-enum-varnames": [
-                "UserStatusActive",
-                "UserStatusInactive",
-                "UserStatusWaitChangePassword"
-            ]
+        "github_com_Gthulhu_api_decisionmaker_rest.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "service": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_decisionmaker_rest.VersionResponse": {
+            "type": "object",
+            "properties": {
+                "endpoints": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.EmptyResponse": {
+            "type": "object"
+        },
+        "github_com_Gthulhu_api_manager_rest.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "service": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.LabelSelector": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.PreviewProcess": {
+            "type": "object",
+            "properties": {
+                "cmdline": {
+                    "type": "string"
+                },
+                "command": {
+                    "type": "string"
+                },
+                "containerID": {
+                    "type": "string"
+                },
+                "exe": {
+                    "type": "string"
+                },
+                "pid": {
+                    "type": "integer"
+                },
+                "tids": {
+                    "description": "threads matching the thread regex of the strategy",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.ProcessMatcher": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "regex": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.SuccessResponse-github_com_Gthulhu_api_manager_rest_EmptyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.EmptyResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ApplyStrategyBundleResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/rest.ApplyStrategyBundleResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_EffectivePolicyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/rest.EffectivePolicyResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_GetSelfUserResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/rest.GetSelfUserResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListNodeScheduleIntentsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/rest.ListNodeScheduleIntentsResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListPermissionsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/rest.ListPermissionsResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListRolesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/rest.ListRolesResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListScheduleIntentsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/rest.ListScheduleIntentsResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListSchedulerStrategiesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/rest.ListSchedulerStrategiesResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListStrategyTemplatesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/rest.ListStrategyTemplatesResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListUsersResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/rest.ListUsersResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_LoginResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/rest.LoginResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_PreviewScheduleStrategyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/rest.PreviewScheduleStrategyResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ScheduleStrategyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/rest.ScheduleStrategyResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_StrategyTemplate": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/rest.StrategyTemplate"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_UpdateStrategyTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/rest.UpdateStrategyTemplateResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Gthulhu_api_manager_rest.VersionResponse": {
+            "type": "object",
+            "properties": {
+                "endpoints": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.ApplyStrategyBundleResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "applied changes, or the ones that would be applied on a dry run",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.StrategyChange"
+                    }
+                },
+                "dryRun": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "rest.BundleStrategy": {
+            "type": "object",
+            "properties": {
+                "activateAt": {
+                    "type": "integer"
+                },
+                "commandRegex": {
+                    "type": "string"
+                },
+                "containerNames": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "containerRegex": {
+                    "type": "string"
+                },
+                "executionTime": {
+                    "type": "integer"
+                },
+                "expireAt": {
+                    "type": "integer"
+                },
+                "includeDescendants": {
+                    "type": "boolean"
+                },
+                "k8sNamespace": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "labelSelectors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.LabelSelector"
+                    }
+                },
+                "matchExpressions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.LabelSelectorRequirement"
+                    }
+                },
+                "matchLabels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespaceSelector": {
+                    "$ref": "#/definitions/rest.LabelSelectorSpec"
+                },
+                "nodeSelector": {
+                    "$ref": "#/definitions/rest.LabelSelectorSpec"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "processMatchers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ProcessMatcher"
+                    }
+                },
+                "template": {
+                    "description": "the template priority and execution time override the ones of the strategy",
+                    "type": "string"
+                },
+                "threadRegex": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "integer"
+                },
+                "window": {
+                    "$ref": "#/definitions/rest.RecurringWindow"
+                },
+                "workload": {
+                    "$ref": "#/definitions/rest.WorkloadRef"
+                }
+            }
+        },
+        "rest.ChangePasswordRequest": {
+            "type": "object",
+            "properties": {
+                "newPassword": {
+                    "type": "string"
+                },
+                "oldPassword": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.CreateRoleRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rolePolicies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.RolePolicy"
+                    }
+                }
+            }
+        },
+        "rest.CreateScheduleStrategyRequest": {
+            "type": "object",
+            "properties": {
+                "activateAt": {
+                    "description": "unix milli time before which the strategy is not enforced",
+                    "type": "integer"
+                },
+                "commandRegex": {
+                    "type": "string"
+                },
+                "containerNames": {
+                    "description": "targets the containers with these names instead of every container of the pods",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "containerRegex": {
+                    "description": "targets the containers whose name matches, ORed with ContainerNames",
+                    "type": "string"
+                },
+                "executionTime": {
+                    "type": "integer"
+                },
+                "expireAt": {
+                    "description": "unix milli time from which the strategy is no longer enforced",
+                    "type": "integer"
+                },
+                "includeDescendants": {
+                    "description": "the descendants of the matching processes are targeted too",
+                    "type": "boolean"
+                },
+                "k8sNamespace": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "labelSelectors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.LabelSelector"
+                    }
+                },
+                "matchExpressions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.LabelSelectorRequirement"
+                    }
+                },
+                "matchLabels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "identifies the strategy in the bundles of its strategy namespace",
+                    "type": "string"
+                },
+                "namespaceSelector": {
+                    "$ref": "#/definitions/rest.LabelSelectorSpec"
+                },
+                "nodeSelector": {
+                    "$ref": "#/definitions/rest.LabelSelectorSpec"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "processMatchers": {
+                    "description": "ANDed with the command regex, which applies to the comm of the processes",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ProcessMatcher"
+                    }
+                },
+                "strategyNamespace": {
+                    "type": "string"
+                },
+                "templateId": {
+                    "description": "template providing the priority and execution time, which override the ones of the request",
+                    "type": "string"
+                },
+                "threadRegex": {
+                    "description": "the matching threads of the processes are targeted instead of the processes",
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "precedence over the other strategies targeting the same processes, higher wins",
+                    "type": "integer"
+                },
+                "window": {
+                    "description": "the strategy is only enforced inside the window",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.RecurringWindow"
+                        }
+                    ]
+                },
+                "workload": {
+                    "description": "targets the pods controlled by the workload",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.WorkloadRef"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.CreateStrategyTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "executionTime": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.CreateUserRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.DeleteRoleRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.DeleteScheduleIntentsRequest": {
+            "type": "object",
+            "properties": {
+                "intentIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "rest.DeleteScheduleStrategyRequest": {
+            "type": "object",
+            "properties": {
+                "strategyId": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.DeleteStrategyTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "templateId": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.EffectivePolicyResponse": {
+            "type": "object",
+            "properties": {
+                "decisionMakerError": {
+                    "description": "why the processes could not be queried",
+                    "type": "string"
+                },
+                "intents": {
+                    "description": "Intents are ordered by precedence, a process matched by several intents is bound to the first one",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ScheduleIntent"
+                    }
+                },
+                "k8sNamespace": {
+                    "type": "string"
+                },
+                "nodeId": {
+                    "type": "string"
+                },
+                "podId": {
+                    "type": "string"
+                },
+                "podName": {
+                    "type": "string"
+                },
+                "processes": {
+                    "description": "scheduling enforced per PID and TID by the decision maker of the node",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ProcessScheduling"
+                    }
+                },
+                "strategies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ScheduleStrategy"
+                    }
+                },
+                "winningIntentId": {
+                    "type": "string"
+                }
+            }
         },
-        "github_com_Gthulhu_api_decisionmaker_rest.HealthResponse": {
+        "rest.GetSelfUserResponse": {
             "type": "object",
             "properties": {
-                "service": {
+                "id": {
                     "type": "string"
                 },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "status": {
-                    "type": "string"
+                    "$ref": "#/definitions/domain.UserStatus"
                 },
-                "timestamp": {
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "github_com_Gthulhu_api_decisionmaker_rest.VersionResponse": {
+        "rest.LabelSelectorRequirement": {
             "type": "object",
             "properties": {
-                "endpoints": {
+                "key": {
                     "type": "string"
                 },
-                "message": {
+                "operator": {
+                    "description": "In, NotIn, Exists or DoesNotExist",
                     "type": "string"
                 },
-                "version": {
-                    "type": "string"
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "github_com_Gthulhu_api_manager_rest.EmptyResponse": {
-            "type": "object"
-        },
-        "github_com_Gthulhu_api_manager_rest.ErrorResponse": {
+        "rest.LabelSelectorSpec": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string"
+                "matchExpressions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.LabelSelectorRequirement"
+                    }
                 },
-                "success": {
-                    "type": "boolean"
+                "matchLabels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "github_com_Gthulhu_api_manager_rest.HealthResponse": {
+        "rest.ListNodeScheduleIntentsResponse": {
             "type": "object",
             "properties": {
-                "service": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
+                "intents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.NodeScheduleIntent"
+                    }
                 },
-                "timestamp": {
+                "nodeID": {
                     "type": "string"
                 }
             }
         },
-        "github_com_Gthulhu_api_manager_rest.LabelSelector": {
+        "rest.ListPermissionsResponse": {
             "type": "object",
             "properties": {
-                "key": {
-                    "type": "string"
-                },
-                "value": {
-                    "type": "string"
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "description": {
+                                "type": "string"
+                            },
+                            "key": {
+                                "$ref": "#/definitions/domain.PermissionKey"
+                            }
+                        }
+                    }
                 }
             }
         },
-        "github_com_Gthulhu_api_manager_rest.SuccessResponse-github_com_Gthulhu_api_manager_rest_EmptyResponse": {
+        "rest.ListRolesResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.EmptyResponse"
-                },
-                "success": {
-                    "type": "boolean"
-                },
-                "timestamp": {
-                    "type": "string"
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "description": {
+                                "type": "string"
+                            },
+                            "id": {
+                                "type": "string"
+                            },
+                            "name": {
+                                "type": "string"
+                            },
+                            "rolePolicy": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/rest.RolePolicy"
+                                }
+                            }
+                        }
+                    }
                 }
             }
         },
-        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_GetSelfUserResponse": {
+        "rest.ListScheduleIntentsResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/rest.GetSelfUserResponse"
-                },
-                "success": {
-                    "type": "boolean"
+                "intents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ScheduleIntent"
+                    }
                 },
-                "timestamp": {
-                    "type": "string"
+                "workloads": {
+                    "description": "the intents grouped by workload, with groupBy=workload",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.WorkloadIntents"
+                    }
                 }
             }
         },
-        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListPermissionsResponse": {
+        "rest.ListSchedulerStrategiesResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/rest.ListPermissionsResponse"
-                },
-                "success": {
-                    "type": "boolean"
+                "strategies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ScheduleStrategy"
+                    }
+                }
+            }
+        },
+        "rest.ListStrategyTemplatesResponse": {
+            "type": "object",
+            "properties": {
+                "templates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.StrategyTemplate"
+                    }
+                }
+            }
+        },
+        "rest.ListUsersResponse": {
+            "type": "object",
+            "properties": {
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "id": {
+                                "type": "string"
+                            },
+                            "roles": {
+                                "type": "array",
+                                "items": {
+                                    "type": "string"
+                                }
+                            },
+                            "status": {
+                                "$ref": "#/definitions/domain.UserStatus"
+                            },
+                            "username": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "rest.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
                 },
-                "timestamp": {
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListRolesResponse": {
+        "rest.LoginResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/rest.ListRolesResponse"
-                },
-                "success": {
-                    "type": "boolean"
-                },
-                "timestamp": {
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListScheduleIntentsResponse": {
+        "rest.NodePreview": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/rest.ListScheduleIntentsResponse"
-                },
-                "success": {
-                    "type": "boolean"
+                "error": {
+                    "type": "string"
                 },
-                "timestamp": {
+                "nodeID": {
                     "type": "string"
+                },
+                "pods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PodPreview"
+                    }
                 }
             }
         },
-        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListSchedulerStrategiesResponse": {
+        "rest.NodeScheduleIntent": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/rest.ListSchedulerStrategiesResponse"
+                "commandRegex": {
+                    "type": "string"
                 },
-                "success": {
+                "containerIDs": {
+                    "description": "containers of the pod the intent is restricted to, every container if empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "executionTime": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "includeDescendants": {
+                    "description": "the descendants of the matching processes are targeted too",
                     "type": "boolean"
                 },
-                "timestamp": {
-                    "type": "string"
-                }
-            }
-        },
-        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_ListUsersResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/rest.ListUsersResponse"
+                "k8sNamespace": {
+                    "type": "string"
+                },
+                "nodeID": {
+                    "type": "string"
+                },
+                "podID": {
+                    "type": "string"
+                },
+                "podLabels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "podName": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "processMatchers": {
+                    "description": "ANDed with the command regex",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ProcessMatcher"
+                    }
+                },
+                "selector": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.LabelSelectorRequirement"
+                    }
+                },
+                "specificity": {
+                    "type": "integer"
                 },
-                "success": {
-                    "type": "boolean"
+                "strategyCreatedTime": {
+                    "description": "unix milli, the newest strategy wins a tie of weight and specificity",
+                    "type": "integer"
                 },
-                "timestamp": {
+                "strategyID": {
                     "type": "string"
-                }
-            }
-        },
-        "github_com_Gthulhu_api_manager_rest.SuccessResponse-rest_LoginResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/rest.LoginResponse"
                 },
-                "success": {
-                    "type": "boolean"
-                },
-                "timestamp": {
+                "threadRegex": {
+                    "description": "the matching threads of the processes are targeted instead of the processes",
                     "type": "string"
+                },
+                "weight": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_Gthulhu_api_manager_rest.VersionResponse": {
+        "rest.PodPreview": {
             "type": "object",
             "properties": {
-                "endpoints": {
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PreviewContainer"
+                    }
+                },
+                "k8sNamespace": {
                     "type": "string"
                 },
-                "message": {
+                "podID": {
                     "type": "string"
                 },
-                "version": {
+                "podName": {
                     "type": "string"
+                },
+                "processes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.PreviewProcess"
+                    }
                 }
             }
         },
-        "rest.ChangePasswordRequest": {
+        "rest.PreviewContainer": {
             "type": "object",
             "properties": {
-                "newPassword": {
+                "containerID": {
                     "type": "string"
                 },
-                "oldPassword": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "rest.CreateRoleRequest": {
+        "rest.PreviewScheduleStrategyRequest": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
+                "activateAt": {
+                    "description": "unix milli time before which the strategy is not enforced",
+                    "type": "integer"
                 },
-                "name": {
+                "commandRegex": {
                     "type": "string"
                 },
-                "rolePolicies": {
+                "containerNames": {
+                    "description": "targets the containers with these names instead of every container of the pods",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/rest.RolePolicy"
+                        "type": "string"
                     }
-                }
-            }
-        },
-        "rest.CreateScheduleStrategyRequest": {
-            "type": "object",
-            "properties": {
-                "commandRegex": {
+                },
+                "containerRegex": {
+                    "description": "targets the containers whose name matches, ORed with ContainerNames",
                     "type": "string"
                 },
                 "executionTime": {
                     "type": "integer"
                 },
+                "expireAt": {
+                    "description": "unix milli time from which the strategy is no longer enforced",
+                    "type": "integer"
+                },
+                "includeDescendants": {
+                    "description": "the descendants of the matching processes are targeted too",
+                    "type": "boolean"
+                },
+                "includeProcesses": {
+                    "description": "ask the decision makers which processes would match",
+                    "type": "boolean"
+                },
                 "k8sNamespace": {
                     "type": "array",
                     "items": {
@@ -1301,220 +2812,409 @@ const docTemplate = `{
                         "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.LabelSelector"
                     }
                 },
+                "matchExpressions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.LabelSelectorRequirement"
+                    }
+                },
+                "matchLabels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "identifies the strategy in the bundles of its strategy namespace",
+                    "type": "string"
+                },
+                "namespaceSelector": {
+                    "$ref": "#/definitions/rest.LabelSelectorSpec"
+                },
+                "nodeSelector": {
+                    "$ref": "#/definitions/rest.LabelSelectorSpec"
+                },
                 "priority": {
                     "type": "integer"
                 },
+                "processMatchers": {
+                    "description": "ANDed with the command regex, which applies to the comm of the processes",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ProcessMatcher"
+                    }
+                },
                 "strategyNamespace": {
                     "type": "string"
+                },
+                "templateId": {
+                    "description": "template providing the priority and execution time, which override the ones of the request",
+                    "type": "string"
+                },
+                "threadRegex": {
+                    "description": "the matching threads of the processes are targeted instead of the processes",
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "precedence over the other strategies targeting the same processes, higher wins",
+                    "type": "integer"
+                },
+                "window": {
+                    "description": "the strategy is only enforced inside the window",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.RecurringWindow"
+                        }
+                    ]
+                },
+                "workload": {
+                    "description": "targets the pods controlled by the workload",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.WorkloadRef"
+                        }
+                    ]
                 }
             }
         },
-        "rest.CreateUserRequest": {
+        "rest.PreviewScheduleStrategyResponse": {
             "type": "object",
             "properties": {
-                "password": {
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.NodePreview"
+                    }
+                },
+                "podCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.ProcessScheduling": {
+            "type": "object",
+            "properties": {
+                "commandRegex": {
                     "type": "string"
                 },
-                "username": {
+                "executionTime": {
+                    "type": "integer"
+                },
+                "pid": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "boolean"
+                },
+                "strategyId": {
+                    "description": "strategy of the intent that took precedence for the process",
                     "type": "string"
+                },
+                "tid": {
+                    "description": "thread the scheduling is enforced for, it overrides the scheduling of its process",
+                    "type": "integer"
                 }
             }
         },
-        "rest.DeleteRoleRequest": {
+        "rest.RecurringWindow": {
             "type": "object",
             "properties": {
-                "id": {
+                "cron": {
                     "type": "string"
+                },
+                "durationSec": {
+                    "type": "integer"
                 }
             }
         },
-        "rest.DeleteScheduleIntentsRequest": {
+        "rest.ResetPasswordRequest": {
             "type": "object",
             "properties": {
-                "intentIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "newPassword": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "string"
                 }
             }
         },
-        "rest.DeleteScheduleStrategyRequest": {
+        "rest.RolePolicy": {
             "type": "object",
             "properties": {
-                "strategyId": {
+                "k8sNamespace": {
                     "type": "string"
+                },
+                "permissionKey": {
+                    "$ref": "#/definitions/domain.PermissionKey"
+                },
+                "policyNamespace": {
+                    "type": "string"
+                },
+                "self": {
+                    "type": "boolean"
                 }
             }
         },
-        "rest.GetSelfUserResponse": {
+        "rest.ScheduleIntent": {
             "type": "object",
             "properties": {
-                "id": {
+                "commandRegex": {
                     "type": "string"
                 },
-                "roles": {
+                "containerIDs": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "status": {
-                    "$ref": "#/definitions/domain.UserStatus"
+                "deliveryAttempts": {
+                    "type": "integer"
                 },
-                "username": {
+                "executionTime": {
+                    "type": "integer"
+                },
+                "id": {
                     "type": "string"
-                }
-            }
-        },
-        "rest.ListPermissionsResponse": {
-            "type": "object",
-            "properties": {
-                "permissions": {
+                },
+                "includeDescendants": {
+                    "type": "boolean"
+                },
+                "k8sNamespace": {
+                    "type": "string"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "matchedPIDs": {
+                    "type": "integer"
+                },
+                "nodeID": {
+                    "type": "string"
+                },
+                "podID": {
+                    "type": "string"
+                },
+                "podLabels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "processMatchers": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "description": {
-                                "type": "string"
-                            },
-                            "key": {
-                                "$ref": "#/definitions/domain.PermissionKey"
-                            }
-                        }
+                        "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ProcessMatcher"
+                    }
+                },
+                "selector": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.LabelSelectorRequirement"
                     }
+                },
+                "specificity": {
+                    "type": "integer"
+                },
+                "state": {
+                    "$ref": "#/definitions/domain.IntentState"
+                },
+                "strategyID": {
+                    "type": "string"
+                },
+                "threadRegex": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "integer"
+                },
+                "workload": {
+                    "$ref": "#/definitions/rest.WorkloadRef"
                 }
             }
         },
-        "rest.ListRolesResponse": {
+        "rest.ScheduleStrategy": {
             "type": "object",
             "properties": {
-                "roles": {
+                "activateAt": {
+                    "type": "integer"
+                },
+                "commandRegex": {
+                    "type": "string"
+                },
+                "containerNames": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "containerRegex": {
+                    "type": "string"
+                },
+                "executionTime": {
+                    "type": "integer"
+                },
+                "expireAt": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "includeDescendants": {
+                    "type": "boolean"
+                },
+                "k8sNamespace": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "labelSelectors": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "description": {
-                                "type": "string"
-                            },
-                            "id": {
-                                "type": "string"
-                            },
-                            "name": {
-                                "type": "string"
-                            },
-                            "rolePolicy": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/rest.RolePolicy"
-                                }
-                            }
-                        }
+                        "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.LabelSelector"
                     }
-                }
-            }
-        },
-        "rest.ListScheduleIntentsResponse": {
-            "type": "object",
-            "properties": {
-                "intents": {
+                },
+                "matchExpressions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/rest.ScheduleIntent"
+                        "$ref": "#/definitions/rest.LabelSelectorRequirement"
                     }
-                }
-            }
-        },
-        "rest.ListSchedulerStrategiesResponse": {
-            "type": "object",
-            "properties": {
-                "strategies": {
+                },
+                "matchLabels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespaceSelector": {
+                    "$ref": "#/definitions/rest.LabelSelectorSpec"
+                },
+                "nodeSelector": {
+                    "$ref": "#/definitions/rest.LabelSelectorSpec"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "processMatchers": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/rest.ScheduleStrategy"
+                        "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ProcessMatcher"
                     }
+                },
+                "strategyNamespace": {
+                    "type": "string"
+                },
+                "templateID": {
+                    "type": "string"
+                },
+                "threadRegex": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "integer"
+                },
+                "window": {
+                    "$ref": "#/definitions/rest.RecurringWindow"
+                },
+                "workload": {
+                    "$ref": "#/definitions/rest.WorkloadRef"
                 }
             }
         },
-        "rest.ListUsersResponse": {
+        "rest.ScheduleStrategyResponse": {
             "type": "object",
             "properties": {
-                "users": {
+                "conflicts": {
+                    "description": "other strategies targeting some of the same pods with a different priority or execution time",
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "id": {
-                                "type": "string"
-                            },
-                            "roles": {
-                                "type": "array",
-                                "items": {
-                                    "type": "string"
-                                }
-                            },
-                            "status": {
-                                "$ref": "#/definitions/domain.UserStatus"
-                            },
-                            "username": {
-                                "type": "string"
-                            }
-                        }
+                        "$ref": "#/definitions/rest.StrategyConflict"
                     }
+                },
+                "strategyId": {
+                    "type": "string"
                 }
             }
         },
-        "rest.LoginRequest": {
+        "rest.StrategyBundle": {
             "type": "object",
             "properties": {
-                "password": {
+                "apiVersion": {
                     "type": "string"
                 },
-                "username": {
+                "kind": {
                     "type": "string"
-                }
-            }
-        },
-        "rest.LoginResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
+                },
+                "strategies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.BundleStrategy"
+                    }
+                },
+                "strategyNamespace": {
                     "type": "string"
                 }
             }
         },
-        "rest.ResetPasswordRequest": {
+        "rest.StrategyChange": {
             "type": "object",
             "properties": {
-                "newPassword": {
+                "action": {
+                    "description": "create, update or delete",
                     "type": "string"
                 },
-                "userID": {
+                "fields": {
+                    "description": "fields of an updated strategy that change",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "strategyId": {
                     "type": "string"
                 }
             }
         },
-        "rest.RolePolicy": {
+        "rest.StrategyConflict": {
             "type": "object",
             "properties": {
-                "k8sNamespace": {
-                    "type": "string"
+                "executionTime": {
+                    "type": "integer"
                 },
-                "permissionKey": {
-                    "$ref": "#/definitions/domain.PermissionKey"
+                "podIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "policyNamespace": {
+                "priority": {
+                    "type": "integer"
+                },
+                "strategyId": {
                     "type": "string"
                 },
-                "self": {
+                "takesPrecedence": {
+                    "description": "the conflicting strategy wins on those pods: higher weight, then more specific selector, then newer",
                     "type": "boolean"
+                },
+                "weight": {
+                    "type": "integer"
                 }
             }
         },
-        "rest.ScheduleIntent": {
+        "rest.StrategyTemplate": {
             "type": "object",
             "properties": {
-                "commandRegex": {
+                "description": {
                     "type": "string"
                 },
                 "executionTime": {
@@ -1523,43 +3223,65 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
-                "k8sNamespace": {
-                    "type": "string"
-                },
-                "nodeID": {
-                    "type": "string"
-                },
-                "podID": {
+                "name": {
                     "type": "string"
                 },
-                "podLabels": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
-                },
                 "priority": {
                     "type": "integer"
+                }
+            }
+        },
+        "rest.UpdateRoleRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
                 },
-                "state": {
-                    "$ref": "#/definitions/domain.IntentState"
+                "id": {
+                    "type": "string"
                 },
-                "strategyID": {
+                "name": {
                     "type": "string"
+                },
+                "rolePolicy": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.RolePolicy"
+                    }
                 }
             }
         },
-        "rest.ScheduleStrategy": {
+        "rest.UpdateScheduleStrategyRequest": {
             "type": "object",
             "properties": {
+                "activateAt": {
+                    "description": "unix milli time before which the strategy is not enforced",
+                    "type": "integer"
+                },
                 "commandRegex": {
                     "type": "string"
                 },
+                "containerNames": {
+                    "description": "targets the containers with these names instead of every container of the pods",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "containerRegex": {
+                    "description": "targets the containers whose name matches, ORed with ContainerNames",
+                    "type": "string"
+                },
                 "executionTime": {
                     "type": "integer"
                 },
-                "id": {
-                    "type": "string"
+                "expireAt": {
+                    "description": "unix milli time from which the strategy is no longer enforced",
+                    "type": "integer"
+                },
+                "includeDescendants": {
+                    "description": "the descendants of the matching processes are targeted too",
+                    "type": "boolean"
                 },
                 "k8sNamespace": {
                     "type": "array",
@@ -1573,30 +3295,106 @@ const docTemplate = `{
                         "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.LabelSelector"
                     }
                 },
+                "matchExpressions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.LabelSelectorRequirement"
+                    }
+                },
+                "matchLabels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "identifies the strategy in the bundles of its strategy namespace",
+                    "type": "string"
+                },
+                "namespaceSelector": {
+                    "$ref": "#/definitions/rest.LabelSelectorSpec"
+                },
+                "nodeSelector": {
+                    "$ref": "#/definitions/rest.LabelSelectorSpec"
+                },
                 "priority": {
                     "type": "integer"
                 },
+                "processMatchers": {
+                    "description": "ANDed with the command regex, which applies to the comm of the processes",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Gthulhu_api_manager_rest.ProcessMatcher"
+                    }
+                },
+                "strategyId": {
+                    "type": "string"
+                },
                 "strategyNamespace": {
                     "type": "string"
+                },
+                "templateId": {
+                    "description": "template providing the priority and execution time, which override the ones of the request",
+                    "type": "string"
+                },
+                "threadRegex": {
+                    "description": "the matching threads of the processes are targeted instead of the processes",
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "precedence over the other strategies targeting the same processes, higher wins",
+                    "type": "integer"
+                },
+                "window": {
+                    "description": "the strategy is only enforced inside the window",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.RecurringWindow"
+                        }
+                    ]
+                },
+                "workload": {
+                    "description": "targets the pods controlled by the workload",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.WorkloadRef"
+                        }
+                    ]
                 }
             }
         },
-        "rest.UpdateRoleRequest": {
+        "rest.UpdateStrategyTemplateRequest": {
             "type": "object",
             "properties": {
                 "description": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "executionTime": {
+                    "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
-                "rolePolicy": {
+                "priority": {
+                    "type": "integer"
+                },
+                "propagate": {
+                    "description": "also update the strategies derived from the template",
+                    "type": "boolean"
+                },
+                "templateId": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.UpdateStrategyTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "propagatedStrategyIds": {
+                    "description": "strategies updated with the new priority and execution time",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/rest.RolePolicy"
+                        "type": "string"
                     }
                 }
             }
@@ -1617,6 +3415,34 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "rest.WorkloadIntents": {
+            "type": "object",
+            "properties": {
+                "intents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ScheduleIntent"
+                    }
+                },
+                "workload": {
+                    "$ref": "#/definitions/rest.WorkloadRef"
+                }
+            }
+        },
+        "rest.WorkloadRef": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	eventWorkers     = 4
+	eventTimeout     = 30 * time.Second
+	maxEventRequeues = 5
+
+	podEventQueueName              = "pod-events"
+	strategyResourceEventQueueName = "strategy-resource-events"
+)
+
+// eventQueue decouples an informer from the reconciliation of its events. The informer callbacks only record the latest event of
+// an object and enqueue its key, the workers reconcile the objects from the queue, which never hands the same key to two workers
+// at once, and retry the failed objects with a rate limited backoff.
+type eventQueue[E comparable] struct {
+	name      string
+	queue     workqueue.TypedRateLimitingInterface[string]
+	key       func(event E) string
+	reconcile func(ctx context.Context, event E) error
+	mu        sync.Mutex
+	// events holds the latest event of every queued object, an older event of an object is superseded before it is reconciled
+	events map[string]E
+	wg     sync.WaitGroup
+}
+
+func newEventQueue[E comparable](name string, key func(event E) string, reconcile func(ctx context.Context, event E) error) *eventQueue[E] {
+	return &eventQueue[E]{
+		name: name,
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: name},
+		),
+		key:       key,
+		reconcile: reconcile,
+		events:    make(map[string]E),
+	}
+}
+
+// newPodEventQueue reconciles the pod events by pod UID
+func newPodEventQueue(reconcile func(ctx context.Context, event *domain.PodEvent) error) *eventQueue[*domain.PodEvent] {
+	return newEventQueue(podEventQueueName, func(event *domain.PodEvent) string {
+		if event == nil || event.Pod == nil {
+			return ""
+		}
+		return event.Pod.PodID
+	}, reconcile)
+}
+
+// newStrategyResourceEventQueue reconciles the SchedulingStrategy resource events by namespace and name
+func newStrategyResourceEventQueue(reconcile func(ctx context.Context, event *domain.StrategyResourceEvent) error) *eventQueue[*domain.StrategyResourceEvent] {
+	return newEventQueue(strategyResourceEventQueueName, func(event *domain.StrategyResourceEvent) string {
+		if event == nil || event.Resource == nil || event.Resource.Name == "" {
+			return ""
+		}
+		return event.Resource.Namespace + "/" + event.Resource.Name
+	}, reconcile)
+}
+
+// add records the event as the latest of its object and enqueues the object, it never blocks on the reconciliation
+func (q *eventQueue[E]) add(_ context.Context, event E) {
+	key := q.key(event)
+	if key == "" {
+		return
+	}
+	q.mu.Lock()
+	q.events[key] = event
+	q.mu.Unlock()
+	q.queue.Add(key)
+}
+
+// run starts the workers, they stop once the queue is shut down
+func (q *eventQueue[E]) run(workers int) {
+	for range workers {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			for q.processNext() {
+			}
+		}()
+	}
+}
+
+// shutdown stops accepting events and waits for the workers to finish the objects they are reconciling
+func (q *eventQueue[E]) shutdown() {
+	q.queue.ShutDown()
+	q.wg.Wait()
+}
+
+// processNext reconciles the next object of the queue with its latest event, it reports false once the queue is shut down
+func (q *eventQueue[E]) processNext() bool {
+	key, quit := q.queue.Get()
+	if quit {
+		return false
+	}
+	defer q.queue.Done(key)
+
+	q.mu.Lock()
+	event, ok := q.events[key]
+	q.mu.Unlock()
+	if !ok {
+		q.queue.Forget(key)
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), eventTimeout)
+	defer cancel()
+	err := q.reconcile(ctx, event)
+	if err != nil && q.queue.NumRequeues(key) < maxEventRequeues {
+		logger.Logger(ctx).Warn().Err(err).Msgf("failed to reconcile %s from the %s queue, retrying", key, q.name)
+		q.queue.AddRateLimited(key)
+		return true
+	}
+	if err != nil {
+		logger.Logger(ctx).Error().Err(err).Msgf("giving up reconciling %s from the %s queue after %d retries", key, q.name, maxEventRequeues)
+	}
+	q.queue.Forget(key)
+	q.mu.Lock()
+	// a newer event recorded during the reconciliation is kept, the object is already queued again for it
+	if q.events[key] == event {
+		delete(q.events, key)
+	}
+	q.mu.Unlock()
+	return true
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPodEventQueueSerializesPods tests that a pod is never reconciled by two workers at once and that its latest event wins
+func TestPodEventQueueSerializesPods(t *testing.T) {
+	logger.InitLogger()
+	var (
+		mu       sync.Mutex
+		running  = make(map[string]bool)
+		overlaps atomic.Int32
+		last     = make(map[string]domain.PodEventType)
+	)
+	release := make(chan struct{})
+	queue := newPodEventQueue(func(_ context.Context, event *domain.PodEvent) error {
+		mu.Lock()
+		if running[event.Pod.PodID] {
+			overlaps.Add(1)
+		}
+		running[event.Pod.PodID] = true
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		running[event.Pod.PodID] = false
+		last[event.Pod.PodID] = event.Type
+		mu.Unlock()
+		return nil
+	})
+	queue.run(eventWorkers)
+
+	ctx := context.Background()
+	queue.add(ctx, &domain.PodEvent{Type: domain.PodEventAdded, Pod: &domain.Pod{PodID: "pod-1"}})
+	queue.add(ctx, &domain.PodEvent{Type: domain.PodEventAdded, Pod: &domain.Pod{PodID: "pod-2"}})
+	// the events of a pod being reconciled are queued behind it instead of running in parallel
+	queue.add(ctx, &domain.PodEvent{Type: domain.PodEventUpdated, Pod: &domain.Pod{PodID: "pod-1"}})
+	queue.add(ctx, &domain.PodEvent{Type: domain.PodEventDeleted, Pod: &domain.Pod{PodID: "pod-1"}})
+	close(release)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return last["pod-1"] == domain.PodEventDeleted && last["pod-2"] == domain.PodEventAdded
+	}, time.Second, 10*time.Millisecond)
+	queue.shutdown()
+	assert.Zero(t, overlaps.Load())
+	assert.Empty(t, queue.events)
+}
+
+// TestPodEventQueueRetries tests that a failed pod is reconciled again until it succeeds
+func TestPodEventQueueRetries(t *testing.T) {
+	logger.InitLogger()
+	var calls atomic.Int32
+	queue := newPodEventQueue(func(_ context.Context, _ *domain.PodEvent) error {
+		if calls.Add(1) < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	queue.run(1)
+
+	queue.add(context.Background(), &domain.PodEvent{Type: domain.PodEventAdded, Pod: &domain.Pod{PodID: "pod-1"}})
+	require.Eventually(t, func() bool { return calls.Load() == 3 }, 5*time.Second, 10*time.Millisecond)
+	queue.shutdown()
+	assert.Empty(t, queue.events)
+	assert.Zero(t, queue.queue.NumRequeues("pod-1"))
+}
+
+// TestStrategyResourceEventQueueSupersedesEvents tests that the SchedulingStrategy resources are queued by namespace and name,
+// so that the delete of a resource recreated meanwhile is superseded by the add of the new one
+func TestStrategyResourceEventQueueSupersedesEvents(t *testing.T) {
+	logger.InitLogger()
+	var (
+		mu     sync.Mutex
+		events []*domain.StrategyResourceEvent
+	)
+	queue := newStrategyResourceEventQueue(func(_ context.Context, event *domain.StrategyResourceEvent) error {
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+		return nil
+	})
+
+	ctx := context.Background()
+	recreated := &domain.StrategyResourceEvent{
+		Type:     domain.StrategyResourceAdded,
+		Resource: &domain.StrategyResource{StrategyResourceRef: domain.StrategyResourceRef{UID: "uid-2", Namespace: "team-a", Name: "web"}},
+	}
+	other := &domain.StrategyResourceEvent{
+		Type:     domain.StrategyResourceAdded,
+		Resource: &domain.StrategyResource{StrategyResourceRef: domain.StrategyResourceRef{UID: "uid-3", Namespace: "team-b", Name: "web"}},
+	}
+	queue.add(ctx, &domain.StrategyResourceEvent{
+		Type:     domain.StrategyResourceDeleted,
+		Resource: &domain.StrategyResource{StrategyResourceRef: domain.StrategyResourceRef{UID: "uid-1", Namespace: "team-a", Name: "web"}},
+	})
+	queue.add(ctx, recreated)
+	queue.add(ctx, other)
+	queue.run(eventWorkers)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(events) == 2
+	}, time.Second, 10*time.Millisecond)
+	queue.shutdown()
+	assert.ElementsMatch(t, []*domain.StrategyResourceEvent{recreated, other}, events)
+	assert.Empty(t, queue.events)
+}
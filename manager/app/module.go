@@ -33,6 +33,12 @@ func ConfigModule(cfg config.ManageConfig) (fx.Option, error) {
 		fx.Provide(func(managerCfg config.ManageConfig) config.K8SConfig {
 			return managerCfg.K8S
 		}),
+		fx.Provide(func(managerCfg config.ManageConfig) config.DeliveryConfig {
+			return managerCfg.Delivery
+		}),
+		fx.Provide(func(managerCfg config.ManageConfig) config.ScheduleConfig {
+			return managerCfg.Schedule
+		}),
 	), nil
 }
 
@@ -41,8 +47,11 @@ func AdapterModule() (fx.Option, error) {
 	return fx.Options(
 		fx.Provide(func(k8sConfig config.K8SConfig) (domain.K8SAdapter, error) {
 			return k8sadapter.NewAdapter(k8sadapter.Options{
-				KubeConfigPath: k8sConfig.KubeConfigPath,
-				InCluster:      k8sConfig.IsInCluster,
+				KubeConfigPath:         k8sConfig.KubeConfigPath,
+				InCluster:              k8sConfig.IsInCluster,
+				StrategyResources:      k8sConfig.StrategyResources,
+				StrategyResourceResync: k8sConfig.StrategyResourceResync(),
+				AnnotationNamespaces:   k8sConfig.AnnotationNamespaces,
 			})
 		}),
 		fx.Provide(client.NewDecisionMakerClient),
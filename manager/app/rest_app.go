@@ -2,13 +2,16 @@ package app
 
 import (
 	"context"
+	"time"
 
 	"github.com/Gthulhu/api/config"
+	"github.com/Gthulhu/api/manager/domain"
 	"github.com/Gthulhu/api/manager/migration"
 	"github.com/Gthulhu/api/manager/rest"
 	"github.com/Gthulhu/api/pkg/logger"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/fx"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 func NewRestApp(configName string, configDirPath string) (*fx.App, error) {
@@ -40,6 +43,10 @@ func NewRestApp(configName string, configDirPath string) (*fx.App, error) {
 	app := fx.New(
 		handlerModule,
 		fx.Invoke(migration.RunMongoMigration),
+		fx.Invoke(StartIntentReconciler),
+		fx.Invoke(StartStrategyResourceController),
+		fx.Invoke(StartIntentDelivery),
+		fx.Invoke(StartStrategyScheduler),
 		fx.Invoke(StartRestApp),
 	)
 	return app, nil
@@ -73,3 +80,112 @@ func StartRestApp(lc fx.Lifecycle, cfg config.ServerConfig, handler *rest.Handle
 
 	return nil
 }
+
+// StartIntentReconciler feeds pod informer events into the service so that stored strategies keep following pod churn.
+// The events go through a queue reconciling a pod at a time, so that the informer is never blocked by the reconciliation.
+// The annotation strategy is created first when pods may request their scheduling with annotations, so that the replayed pods match it.
+func StartIntentReconciler(lc fx.Lifecycle, cfg config.K8SConfig, k8sAdapter domain.K8SAdapter, svc domain.Service) {
+	queue := newPodEventQueue(svc.ReconcilePodEvent)
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			queue.run(eventWorkers)
+			go func() {
+				if len(cfg.AnnotationNamespaces) > 0 {
+					ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+					if err := svc.EnsureAnnotationStrategy(ctx); err != nil {
+						logger.Logger(ctx).Warn().Err(err).Msg("failed to create the annotation strategy, pod annotations are ignored")
+					}
+					cancel()
+				}
+				k8sAdapter.AddPodEventHandler(queue.add)
+			}()
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			queue.shutdown()
+			return nil
+		},
+	})
+}
+
+// StartStrategyResourceController feeds the SchedulingStrategy resource events into the service, which mirrors the resources into strategies.
+// The events go through a queue reconciling a resource at a time, and the strategies of the resources deleted while the Manager was down
+// are pruned once the resources are cached, since their delete events are never delivered.
+func StartStrategyResourceController(lc fx.Lifecycle, cfg config.K8SConfig, k8sAdapter domain.K8SAdapter, svc domain.Service) {
+	if !cfg.StrategyResources {
+		return
+	}
+	queue := newStrategyResourceEventQueue(svc.ReconcileStrategyResource)
+	pruneCtx, cancelPrune := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			queue.run(eventWorkers)
+			go func() {
+				k8sAdapter.AddStrategyResourceHandler(queue.add)
+				backoff := wait.Backoff{Duration: time.Second, Factor: 2, Steps: maxEventRequeues}
+				err := wait.ExponentialBackoffWithContext(pruneCtx, backoff, func(ctx context.Context) (bool, error) {
+					if err := svc.PruneStrategyResources(ctx); err != nil {
+						logger.Logger(ctx).Warn().Err(err).Msg("failed to prune the strategies of deleted SchedulingStrategy resources, retrying")
+						return false, nil
+					}
+					return true, nil
+				})
+				if err != nil && pruneCtx.Err() == nil {
+					logger.Logger(pruneCtx).Error().Err(err).Msg("giving up pruning the strategies of deleted SchedulingStrategy resources")
+				}
+			}()
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancelPrune()
+			queue.shutdown()
+			return nil
+		},
+	})
+}
+
+// StartIntentDelivery runs the queue retrying the delivery of the pending schedule intents to the decision makers
+func StartIntentDelivery(lc fx.Lifecycle, svc domain.Service) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				svc.RunIntentDelivery(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
+
+// StartStrategyScheduler runs the scheduler sending and removing the intents of the time-bounded strategies
+func StartStrategyScheduler(lc fx.Lifecycle, svc domain.Service) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				svc.RunStrategyScheduler(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
@@ -6,11 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
 	cache "github.com/Code-Hex/go-generics-cache"
 	"github.com/Gthulhu/api/config"
+	dmdomain "github.com/Gthulhu/api/decisionmaker/domain"
 	dmrest "github.com/Gthulhu/api/decisionmaker/rest"
 	"github.com/Gthulhu/api/manager/domain"
 	"github.com/Gthulhu/api/pkg/logger"
@@ -33,50 +35,239 @@ type DecisionMakerClient struct {
 	tokenCache     *cache.Cache[string, string]
 }
 
-func (dm *DecisionMakerClient) SendSchedulingIntent(ctx context.Context, decisionMaker *domain.DecisionMakerPod, intents []*domain.ScheduleIntent) error {
+func (dm *DecisionMakerClient) SendSchedulingIntent(ctx context.Context, decisionMaker *domain.DecisionMakerPod, intents []*domain.ScheduleIntent) ([]*domain.IntentResult, error) {
 	token, err := dm.GetToken(ctx, decisionMaker)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	logger.Logger(ctx).Debug().Msgf("Sending %d scheduling intents to decision maker pod (host:%s nodeID:%s port:%d)", len(intents), decisionMaker.Host, decisionMaker.NodeID, decisionMaker.Port)
 
+	jsonBody, err := json.Marshal(toHandleIntentsRequest(intents))
+	if err != nil {
+		return nil, err
+	}
+	endpoint := "http://" + decisionMaker.Host + ":" + strconv.Itoa(decisionMaker.Port) + "/api/v1/intents"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := dm.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("decision maker %s returned non-OK status: %s", decisionMaker, resp.Status)
+	}
+
+	var intentsResp dmrest.SuccessResponse[dmrest.HandleIntentsResponse]
+	err = json.NewDecoder(resp.Body).Decode(&intentsResp)
+	if err != nil {
+		return nil, fmt.Errorf("decode response of decision maker %s: %w", decisionMaker, err)
+	}
+	// decision makers that predate the acknowledgements answer without data
+	if intentsResp.Data == nil {
+		return nil, nil
+	}
+	results := make([]*domain.IntentResult, 0, len(intentsResp.Data.Results))
+	for _, result := range intentsResp.Data.Results {
+		results = append(results, &domain.IntentResult{
+			PodID:           result.PodID,
+			StrategyID:      result.StrategyID,
+			CommandRegex:    result.CommandRegex,
+			ProcessMatchers: toDomainProcessMatchers(result.Matchers),
+			ThreadRegex:     result.ThreadRegex,
+			ContainerIDs:    result.ContainerIDs,
+			State:           intentResultState(result.State),
+			MatchedPIDs:     result.MatchedPIDs,
+			Error:           result.Error,
+		})
+	}
+	return results, nil
+}
+
+func toHandleIntentsRequest(intents []*domain.ScheduleIntent) dmrest.HandleIntentsRequest {
 	reqPayload := dmrest.HandleIntentsRequest{
 		Intents: make([]dmrest.Intent, 0, len(intents)),
 	}
 	for _, intent := range intents {
 		reqPayload.Intents = append(reqPayload.Intents, dmrest.Intent{
-			PodName:       intent.PodName,
-			PodID:         intent.PodID,
-			NodeID:        intent.NodeID,
-			K8sNamespace:  intent.K8sNamespace,
-			CommandRegex:  intent.CommandRegex,
-			Priority:      intent.Priority,
-			ExecutionTime: intent.ExecutionTime,
-			PodLabels:     intent.PodLabels,
+			PodName:             intent.PodName,
+			PodID:               intent.PodID,
+			NodeID:              intent.NodeID,
+			K8sNamespace:        intent.K8sNamespace,
+			CommandRegex:        intent.CommandRegex,
+			Matchers:            toDMProcessMatchers(intent.ProcessMatchers),
+			ThreadRegex:         intent.ThreadRegex,
+			IncludeDescendants:  intent.IncludeDescendants,
+			ContainerIDs:        intent.ContainerIDs,
+			Priority:            intent.Priority,
+			ExecutionTime:       intent.ExecutionTime,
+			PodLabels:           intent.PodLabels,
+			Selector:            toDMLabelSelectors(intent.Selector),
+			StrategyID:          intent.StrategyID.Hex(),
+			Weight:              intent.Weight,
+			Specificity:         intent.Specificity,
+			StrategyCreatedTime: intent.StrategyCreatedTime,
 		})
 	}
+	return reqPayload
+}
 
-	jsonBody, err := json.Marshal(reqPayload)
+func toDMProcessMatchers(matchers []domain.ProcessMatcher) []dmrest.ProcessMatcher {
+	if len(matchers) == 0 {
+		return nil
+	}
+	dmMatchers := make([]dmrest.ProcessMatcher, 0, len(matchers))
+	for _, matcher := range matchers {
+		dmMatchers = append(dmMatchers, dmrest.ProcessMatcher{Field: matcher.Field, Regex: matcher.Regex})
+	}
+	return dmMatchers
+}
+
+func toDomainProcessMatchers(matchers []dmrest.ProcessMatcher) []domain.ProcessMatcher {
+	if len(matchers) == 0 {
+		return nil
+	}
+	domainMatchers := make([]domain.ProcessMatcher, 0, len(matchers))
+	for _, matcher := range matchers {
+		domainMatchers = append(domainMatchers, domain.ProcessMatcher{Field: matcher.Field, Regex: matcher.Regex})
+	}
+	return domainMatchers
+}
+
+func toDMLabelSelectors(requirements []domain.LabelSelectorRequirement) []dmrest.LabelSelector {
+	selectors := make([]dmrest.LabelSelector, 0, len(requirements))
+	for _, requirement := range requirements {
+		selectors = append(selectors, dmrest.LabelSelector{
+			Key:      requirement.Key,
+			Operator: requirement.Operator,
+			Values:   requirement.Values,
+		})
+	}
+	return selectors
+}
+
+func (dm *DecisionMakerClient) PreviewSchedulingIntents(ctx context.Context, decisionMaker *domain.DecisionMakerPod, intents []*domain.ScheduleIntent) ([]*domain.IntentPreview, error) {
+	token, err := dm.GetToken(ctx, decisionMaker)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	endpoint := "http://" + decisionMaker.Host + ":" + strconv.Itoa(decisionMaker.Port) + "/api/v1/intents"
+
+	jsonBody, err := json.Marshal(toHandleIntentsRequest(intents))
+	if err != nil {
+		return nil, err
+	}
+	endpoint := "http://" + decisionMaker.Host + ":" + strconv.Itoa(decisionMaker.Port) + "/api/v1/intents/preview"
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(jsonBody))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
 	resp, err := dm.Client.Do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("decision maker %s returned non-OK status: %s", decisionMaker, resp.Status)
+		return nil, fmt.Errorf("decision maker %s returned non-OK status: %s", decisionMaker, resp.Status)
+	}
+
+	var previewResp dmrest.SuccessResponse[dmrest.PreviewIntentsResponse]
+	err = json.NewDecoder(resp.Body).Decode(&previewResp)
+	if err != nil {
+		return nil, fmt.Errorf("decode response of decision maker %s: %w", decisionMaker, err)
+	}
+	if previewResp.Data == nil {
+		return nil, nil
+	}
+	previews := make([]*domain.IntentPreview, 0, len(previewResp.Data.Previews))
+	for _, preview := range previewResp.Data.Previews {
+		intentPreview := &domain.IntentPreview{
+			PodID:        preview.PodID,
+			CommandRegex: preview.CommandRegex,
+			Processes:    make([]*domain.ProcessPreview, 0, len(preview.Processes)),
+			Error:        preview.Error,
+		}
+		for _, process := range preview.Processes {
+			processPreview := &domain.ProcessPreview{
+				PID:         process.PID,
+				Command:     process.Command,
+				ContainerID: process.ContainerID,
+				Cmdline:     process.Cmdline,
+				Exe:         process.Exe,
+			}
+			if preview.ThreadRegex != "" {
+				for _, thread := range process.Threads {
+					processPreview.TIDs = append(processPreview.TIDs, thread.TID)
+				}
+			}
+			intentPreview.Processes = append(intentPreview.Processes, processPreview)
+		}
+		previews = append(previews, intentPreview)
+	}
+	return previews, nil
+}
+
+func (dm *DecisionMakerClient) ListPodSchedulingIntents(ctx context.Context, decisionMaker *domain.DecisionMakerPod, podID string) ([]*domain.ProcessScheduling, error) {
+	token, err := dm.GetToken(ctx, decisionMaker)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := "http://" + decisionMaker.Host + ":" + strconv.Itoa(decisionMaker.Port) + "/api/v1/scheduling/pods?podID=" + url.QueryEscape(podID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := dm.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("decision maker %s returned non-OK status: %s", decisionMaker, resp.Status)
+	}
+
+	var podResp dmrest.SuccessResponse[dmrest.PodSchedulingIntentsResponse]
+	err = json.NewDecoder(resp.Body).Decode(&podResp)
+	if err != nil {
+		return nil, fmt.Errorf("decode response of decision maker %s: %w", decisionMaker, err)
+	}
+	if podResp.Data == nil {
+		return nil, nil
+	}
+	processes := make([]*domain.ProcessScheduling, 0, len(podResp.Data.Scheduling))
+	for _, intent := range podResp.Data.Scheduling {
+		processes = append(processes, &domain.ProcessScheduling{
+			PID:           intent.PID,
+			TID:           intent.TID,
+			Priority:      intent.Priority,
+			ExecutionTime: intent.ExecutionTime,
+			CommandRegex:  intent.CommandRegex,
+			StrategyID:    intent.StrategyID,
+		})
+	}
+	return processes, nil
+}
+
+// intentResultState converts the state reported by the decision maker to an intent state
+func intentResultState(state string) domain.IntentState {
+	switch dmdomain.IntentResultState(state) {
+	case dmdomain.IntentResultApplied:
+		return domain.IntentStateApplied
+	case dmdomain.IntentResultNoMatchingProcess:
+		return domain.IntentStateNoMatchingProcess
+	case dmdomain.IntentResultFailed:
+		return domain.IntentStateFailed
+	default:
+		return domain.IntentStateSent
 	}
-	return nil
 }
 
 func (dm *DecisionMakerClient) GetToken(ctx context.Context, decisionMaker *domain.DecisionMakerPod) (string, error) {
@@ -128,58 +319,56 @@ func (dm *DecisionMakerClient) DeleteSchedulingIntents(ctx context.Context, deci
 
 	logger.Logger(ctx).Debug().Msgf("Deleting scheduling intents from decision maker pod (host:%s nodeID:%s port:%d)", decisionMaker.Host, decisionMaker.NodeID, decisionMaker.Port)
 
-	// If All is true, delete all intents; otherwise delete by PodIDs one by one
+	// If All is true, delete all intents; otherwise delete by PodIDs and intents one by one
 	if req.All {
-		deleteReq := dmrest.DeleteIntentRequest{
-			All: true,
-		}
-		jsonBody, err := json.Marshal(deleteReq)
-		if err != nil {
-			return err
-		}
-		endpoint := "http://" + decisionMaker.Host + ":" + strconv.Itoa(decisionMaker.Port) + "/api/v1/intents"
-		httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, bytes.NewBuffer(jsonBody))
-		if err != nil {
-			return err
-		}
-		httpReq.Header.Set("Content-Type", "application/json")
-		httpReq.Header.Set("Authorization", "Bearer "+token)
-		resp, err := dm.Client.Do(httpReq)
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("decision maker %s returned non-OK status: %s", decisionMaker, resp.Status)
-		}
-		return nil
+		return dm.sendDeleteIntentRequest(ctx, decisionMaker, token, dmrest.DeleteIntentRequest{All: true})
 	}
 
 	// Delete intents by PodID
 	for _, podID := range req.PodIDs {
-		deleteReq := dmrest.DeleteIntentRequest{
-			PodID: podID,
-		}
-		jsonBody, err := json.Marshal(deleteReq)
-		if err != nil {
-			return err
-		}
-		endpoint := "http://" + decisionMaker.Host + ":" + strconv.Itoa(decisionMaker.Port) + "/api/v1/intents"
-		httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, bytes.NewBuffer(jsonBody))
+		err = dm.sendDeleteIntentRequest(ctx, decisionMaker, token, dmrest.DeleteIntentRequest{PodID: podID})
 		if err != nil {
 			return err
 		}
-		httpReq.Header.Set("Content-Type", "application/json")
-		httpReq.Header.Set("Authorization", "Bearer "+token)
-		resp, err := dm.Client.Do(httpReq)
+	}
+
+	// Delete single intents by PodID, strategy, command regex, matchers, thread regex and containers
+	for _, intent := range req.Intents {
+		err = dm.sendDeleteIntentRequest(ctx, decisionMaker, token, dmrest.DeleteIntentRequest{
+			PodID:        intent.PodID,
+			StrategyID:   intent.StrategyID.Hex(),
+			CommandRegex: &intent.CommandRegex,
+			Matchers:     toDMProcessMatchers(intent.ProcessMatchers),
+			ThreadRegex:  intent.ThreadRegex,
+			ContainerIDs: intent.ContainerIDs,
+		})
 		if err != nil {
 			return err
 		}
-		resp.Body.Close()
-		if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("decision maker %s returned non-OK status for podID %s: %s", decisionMaker, podID, resp.Status)
-		}
 	}
 
 	return nil
 }
+
+func (dm *DecisionMakerClient) sendDeleteIntentRequest(ctx context.Context, decisionMaker *domain.DecisionMakerPod, token string, deleteReq dmrest.DeleteIntentRequest) error {
+	jsonBody, err := json.Marshal(deleteReq)
+	if err != nil {
+		return err
+	}
+	endpoint := "http://" + decisionMaker.Host + ":" + strconv.Itoa(decisionMaker.Port) + "/api/v1/intents"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+	resp, err := dm.Client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("decision maker %s returned non-OK status for podID %s: %s", decisionMaker, deleteReq.PodID, resp.Status)
+	}
+	return nil
+}
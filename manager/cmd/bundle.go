@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Gthulhu/api/manager/rest"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	BundleCmd.PersistentFlags().String("server", "http://localhost:8080", "Manager base URL")
+	BundleCmd.PersistentFlags().String("token", "", "Bearer token, defaults to the GTHULHU_TOKEN environment variable")
+
+	exportBundleCmd.Flags().StringP("namespace", "n", "", "Strategy namespace to export")
+	exportBundleCmd.Flags().StringP("file", "f", "", "Output file, defaults to stdout")
+	exportBundleCmd.Flags().String("format", "yaml", "Output format, yaml or json")
+	_ = exportBundleCmd.MarkFlagRequired("namespace")
+
+	applyBundleCmd.Flags().StringP("file", "f", "", "Bundle file in YAML or JSON, - reads stdin")
+	applyBundleCmd.Flags().Bool("dry-run", false, "Only print the changes")
+	applyBundleCmd.Flags().Bool("prune", false, "Delete the strategies of the namespace missing from the bundle")
+	_ = applyBundleCmd.MarkFlagRequired("file")
+
+	BundleCmd.AddCommand(exportBundleCmd, applyBundleCmd)
+	ManagerCmd.AddCommand(BundleCmd)
+}
+
+// BundleCmd exports and applies the strategy bundles of a running manager
+var BundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Export and apply strategy bundles",
+}
+
+var exportBundleCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the strategies of a strategy namespace as a bundle",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace, _ := cmd.Flags().GetString("namespace")
+		file, _ := cmd.Flags().GetString("file")
+		format, _ := cmd.Flags().GetString("format")
+
+		query := url.Values{"strategyNamespace": {namespace}, "format": {format}}
+		data, err := doBundleRequest(cmd, http.MethodGet, query, nil)
+		if err != nil {
+			return err
+		}
+		if file == "" {
+			_, err = cmd.OutOrStdout().Write(data)
+			return err
+		}
+		return os.WriteFile(file, data, 0o644)
+	},
+}
+
+var applyBundleCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a bundle to the strategies of its strategy namespace",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		prune, _ := cmd.Flags().GetBool("prune")
+
+		var body []byte
+		var err error
+		if file == "-" {
+			body, err = io.ReadAll(cmd.InOrStdin())
+		} else {
+			body, err = os.ReadFile(file)
+		}
+		if err != nil {
+			return fmt.Errorf("read bundle: %w", err)
+		}
+
+		query := url.Values{"dryRun": {fmt.Sprint(dryRun)}, "prune": {fmt.Sprint(prune)}}
+		data, err := doBundleRequest(cmd, http.MethodPost, query, body)
+		if err != nil {
+			return err
+		}
+		var resp rest.SuccessResponse[rest.ApplyStrategyBundleResponse]
+		err = json.Unmarshal(data, &resp)
+		if err != nil {
+			return fmt.Errorf("decode apply response: %w", err)
+		}
+		if resp.Data == nil {
+			return errors.New("empty apply response")
+		}
+		printBundleChanges(cmd.OutOrStdout(), resp.Data)
+		return nil
+	},
+}
+
+func printBundleChanges(w io.Writer, resp *rest.ApplyStrategyBundleResponse) {
+	if len(resp.Changes) == 0 {
+		fmt.Fprintln(w, "no changes")
+		return
+	}
+	symbols := map[string]string{"create": "+", "update": "~", "delete": "-"}
+	for _, change := range resp.Changes {
+		line := fmt.Sprintf("%s %s", symbols[change.Action], change.Name)
+		if len(change.Fields) > 0 {
+			line += " (" + strings.Join(change.Fields, ", ") + ")"
+		}
+		fmt.Fprintln(w, line)
+	}
+	if resp.DryRun {
+		fmt.Fprintf(w, "dry run: %d changes not applied\n", len(resp.Changes))
+	}
+}
+
+func doBundleRequest(cmd *cobra.Command, method string, query url.Values, body []byte) ([]byte, error) {
+	server, _ := cmd.Flags().GetString("server")
+	token, _ := cmd.Flags().GetString("token")
+	if token == "" {
+		token = os.Getenv("GTHULHU_TOKEN")
+	}
+
+	req, err := http.NewRequestWithContext(cmd.Context(), method, strings.TrimSuffix(server, "/")+"/api/v1/strategies/bundle?"+query.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/yaml")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var errResp rest.ErrorResponse
+		if json.Unmarshal(data, &errResp) == nil && errResp.Error != "" {
+			return nil, fmt.Errorf("manager returned %d: %s", resp.StatusCode, errResp.Error)
+		}
+		return nil, fmt.Errorf("manager returned %d", resp.StatusCode)
+	}
+	return data, nil
+}
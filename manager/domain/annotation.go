@@ -0,0 +1,38 @@
+package domain
+
+const (
+	// PriorityAnnotation requests the priority of the processes of a pod, "high" or "normal"
+	PriorityAnnotation = "gthulhu.io/priority"
+	// ExecutionTimeAnnotation requests the execution time of the processes of a pod as a duration, e.g. "5ms"
+	ExecutionTimeAnnotation = "gthulhu.io/execution-time"
+)
+
+const (
+	// AnnotationStrategyNamespace is the strategy namespace of the system strategies, it is reserved
+	AnnotationStrategyNamespace = "system"
+	// AnnotationStrategyName names the system strategy owning the intents synthesized from the pod annotations
+	AnnotationStrategyName = "annotation"
+)
+
+// SchedulingHints are the scheduling parameters requested by the annotations of a pod
+type SchedulingHints struct {
+	Priority      int
+	ExecutionTime int64 // nanoseconds
+}
+
+// NewAnnotationStrategy returns the system strategy targeting the pods with scheduling hints,
+// its intents take their priority and execution time from the hints of every pod
+func NewAnnotationStrategy() *ScheduleStrategy {
+	return &ScheduleStrategy{
+		BaseEntity:        NewBaseEntity(nil, nil),
+		StrategyNamespace: AnnotationStrategyNamespace,
+		Name:              AnnotationStrategyName,
+		Annotation:        true,
+	}
+}
+
+// IsReservedStrategyNamespace reports whether the strategies of the strategy namespace are managed by the Manager itself,
+// they cannot be created through the API or bundles
+func IsReservedStrategyNamespace(strategyNamespace string) bool {
+	return strategyNamespace == AnnotationStrategyNamespace || IsResourceStrategyNamespace(strategyNamespace)
+}
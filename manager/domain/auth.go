@@ -12,6 +12,13 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
+// DecisionMakerClaims represents the claims of a token self-signed by a decision maker with its private key
+type DecisionMakerClaims struct {
+	ClientID string `json:"client_id"`
+	NodeID   string `json:"node_id"`
+	jwt.RegisteredClaims
+}
+
 func (c *Claims) GetBsonObjectUID() (bson.ObjectID, error) {
 	return bson.ObjectIDFromHex(c.UID)
 }
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// StrategyBundle is the declarative state of the strategies of a strategy namespace, the strategies are identified by their name
+type StrategyBundle struct {
+	StrategyNamespace string
+	Entries           []*StrategyBundleEntry
+}
+
+// StrategyBundleEntry is a strategy of a bundle, its template is referenced by name so that a bundle can be applied to another Manager
+type StrategyBundleEntry struct {
+	Template string
+	Strategy *ScheduleStrategy
+}
+
+// Validate reports whether the bundle has a strategy namespace and uniquely named strategies
+func (b *StrategyBundle) Validate() error {
+	if b.StrategyNamespace == "" {
+		return errors.New("the strategy namespace of the bundle is required")
+	}
+	names := make(map[string]struct{}, len(b.Entries))
+	for i, entry := range b.Entries {
+		if entry.Strategy == nil || entry.Strategy.Name == "" {
+			return fmt.Errorf("strategy %d of the bundle has no name", i)
+		}
+		if _, ok := names[entry.Strategy.Name]; ok {
+			return fmt.Errorf("strategy %s is declared twice in the bundle", entry.Strategy.Name)
+		}
+		names[entry.Strategy.Name] = struct{}{}
+	}
+	return nil
+}
+
+// BundleKey identifies the strategy in a bundle: its name, or its ID for the strategies created without a name
+func (s *ScheduleStrategy) BundleKey() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.ID.Hex()
+}
+
+type StrategyChangeAction string
+
+const (
+	StrategyChangeCreate StrategyChangeAction = "create"
+	StrategyChangeUpdate StrategyChangeAction = "update"
+	StrategyChangeDelete StrategyChangeAction = "delete"
+)
+
+// StrategyChange is a difference between a bundle and the strategies of its namespace
+type StrategyChange struct {
+	Action     StrategyChangeAction
+	Name       string
+	StrategyID bson.ObjectID // zero for a strategy to create
+	Fields     []string      // fields of an updated strategy that change
+}
+
+// ApplyBundleOptions controls how a bundle converges the strategies of its namespace
+type ApplyBundleOptions struct {
+	// Prune deletes the strategies of the namespace missing from the bundle
+	Prune bool
+	// DryRun only reports the changes
+	DryRun bool
+}
+
+// DiffStrategySpec returns the fields whose value differs between the two strategies, ignoring the identity and the bookkeeping fields.
+// Empty and missing lists, maps and selectors are equal.
+func DiffStrategySpec(current, desired *ScheduleStrategy) []string {
+	fields := []struct {
+		name       string
+		cur, desir any
+	}{
+		{"name", current.Name, desired.Name},
+		{"labelSelectors", current.LabelSelectors, desired.LabelSelectors},
+		{"matchLabels", current.MatchLabels, desired.MatchLabels},
+		{"matchExpressions", current.MatchExpressions, desired.MatchExpressions},
+		{"k8sNamespace", current.K8sNamespace, desired.K8sNamespace},
+		{"namespaceSelector", current.NamespaceSelector.Requirements(), desired.NamespaceSelector.Requirements()},
+		{"nodeSelector", current.NodeSelector.Requirements(), desired.NodeSelector.Requirements()},
+		{"workload", current.Workload, desired.Workload},
+		{"containerNames", current.ContainerNames, desired.ContainerNames},
+		{"containerRegex", current.ContainerRegex, desired.ContainerRegex},
+		{"commandRegex", current.CommandRegex, desired.CommandRegex},
+		{"processMatchers", current.ProcessMatchers, desired.ProcessMatchers},
+		{"threadRegex", current.ThreadRegex, desired.ThreadRegex},
+		{"includeDescendants", current.IncludeDescendants, desired.IncludeDescendants},
+		{"priority", current.Priority, desired.Priority},
+		{"executionTime", current.ExecutionTime, desired.ExecutionTime},
+		{"weight", current.Weight, desired.Weight},
+		{"activateAt", current.ActivateAt, desired.ActivateAt},
+		{"expireAt", current.ExpireAt, desired.ExpireAt},
+		{"window", current.Window, desired.Window},
+		{"templateID", current.TemplateID, desired.TemplateID},
+	}
+	changed := []string{}
+	for _, field := range fields {
+		if !equalSpecValue(field.cur, field.desir) {
+			changed = append(changed, field.name)
+		}
+	}
+	return changed
+}
+
+func equalSpecValue(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == reflect.Slice || va.Kind() == reflect.Map {
+		if va.Len() == 0 && vb.Len() == 0 {
+			return true
+		}
+	}
+	if requirementsA, ok := a.([]LabelSelectorRequirement); ok {
+		return slices.EqualFunc(requirementsA, b.([]LabelSelectorRequirement), func(x, y LabelSelectorRequirement) bool {
+			return x.Key == y.Key && x.Operator == y.Operator && slices.Equal(x.Values, y.Values)
+		})
+	}
+	if labelsA, ok := a.(map[string]string); ok {
+		return maps.Equal(labelsA, b.(map[string]string))
+	}
+	return reflect.DeepEqual(a, b)
+}
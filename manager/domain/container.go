@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ContainerMatcher selects the containers of a pod by their name and their command, a nil matcher selects every container
+type ContainerMatcher struct {
+	names        []string
+	nameRegex    *regexp.Regexp
+	commandRegex *regexp.Regexp
+}
+
+// NewContainerMatcher compiles the criteria of a matcher, it returns nil when there is no criteria.
+// A container matches when its name is one of the names or matches the name regex, and its command matches the command regex.
+func NewContainerMatcher(names []string, nameRegex string, commandRegex string) (*ContainerMatcher, error) {
+	if len(names) == 0 && nameRegex == "" && commandRegex == "" {
+		return nil, nil
+	}
+	matcher := &ContainerMatcher{names: names}
+	var err error
+	if nameRegex != "" {
+		matcher.nameRegex, err = regexp.Compile(nameRegex)
+		if err != nil {
+			return nil, fmt.Errorf("compile container regex: %w", err)
+		}
+	}
+	if commandRegex != "" {
+		matcher.commandRegex, err = regexp.Compile(commandRegex)
+		if err != nil {
+			return nil, fmt.Errorf("compile command regex: %w", err)
+		}
+	}
+	return matcher, nil
+}
+
+// SelectsByName reports whether the matcher names containers, such a matcher only selects the started containers
+// as their processes are told apart by the ID of their container
+func (m *ContainerMatcher) SelectsByName() bool {
+	return m != nil && (len(m.names) > 0 || m.nameRegex != nil)
+}
+
+func (m *ContainerMatcher) Matches(container Container) bool {
+	if m == nil {
+		return true
+	}
+	if m.SelectsByName() {
+		if container.ContainerID == "" {
+			return false
+		}
+		if !slices.Contains(m.names, container.Name) && (m.nameRegex == nil || !m.nameRegex.MatchString(container.Name)) {
+			return false
+		}
+	}
+	return m.commandRegex == nil || m.commandRegex.MatchString(strings.Join(container.Command, " "))
+}
+
+// MatchingContainers returns the containers the matcher selects
+func (m *ContainerMatcher) MatchingContainers(containers []Container) []Container {
+	result := make([]Container, 0, len(containers))
+	for _, container := range containers {
+		if m.Matches(container) {
+			result = append(result, container)
+		}
+	}
+	return result
+}
+
+// TargetsContainers reports whether the strategy only targets the containers it names instead of every container of the pods
+func (s *ScheduleStrategy) TargetsContainers() bool {
+	return len(s.ContainerNames) > 0 || s.ContainerRegex != ""
+}
+
+// ContainerMatcher returns the matcher of the containers targeted by the strategy
+func (s *ScheduleStrategy) ContainerMatcher() (*ContainerMatcher, error) {
+	return NewContainerMatcher(s.ContainerNames, s.ContainerRegex, s.CommandRegex)
+}
+
+// RuntimeContainerID strips the runtime scheme of the ID of a container status (e.g. containerd://<id>),
+// leaving the ID the container runtime uses in the cgroup path of the processes of the container
+func RuntimeContainerID(containerID string) string {
+	if _, id, ok := strings.Cut(containerID, "://"); ok {
+		return id
+	}
+	return containerID
+}
@@ -14,9 +14,15 @@ const (
 	PermissionRead         PermissionKey = "permission.read"
 	ScheduleStrategyCreate PermissionKey = "schedule_strategy.create"
 	ScheduleStrategyRead   PermissionKey = "schedule_strategy.read"
+	ScheduleStrategyUpdate PermissionKey = "schedule_strategy.update"
 	ScheduleStrategyDelete PermissionKey = "schedule_strategy.delete"
+	ScheduleStrategyApply  PermissionKey = "schedule_strategy.apply"
 	ScheduleIntentRead     PermissionKey = "schedule_intent.read"
 	ScheduleIntentDelete   PermissionKey = "schedule_intent.delete"
+	StrategyTemplateCreate PermissionKey = "strategy_template.create"
+	StrategyTemplateRead   PermissionKey = "strategy_template.read"
+	StrategyTemplateUpdate PermissionKey = "strategy_template.update"
+	StrategyTemplateDelete PermissionKey = "strategy_template.delete"
 )
 
 const (
@@ -36,5 +42,55 @@ type IntentState int8
 const (
 	IntentStateUnknown IntentState = iota
 	IntentStateInitialized
+	// IntentStateSent means the decision maker accepted the intent without reporting whether it matched any process
 	IntentStateSent
+	// IntentStateFailed is terminal, the delivery was given up after too many attempts or the decision maker rejected the intent
+	IntentStateFailed
+	// IntentStateApplied means every decision maker of the node bound the intent to at least one process
+	IntentStateApplied
+	// IntentStatePartiallyApplied means only some of the decision makers of the node bound the intent to a process
+	IntentStatePartiallyApplied
+	// IntentStateNoMatchingProcess means the intent was received but no process of the pod matched it
+	IntentStateNoMatchingProcess
+	// IntentStateExpired means the intent is no longer in effect because its strategy expired
+	IntentStateExpired
+	// IntentStateScheduled means the strategy of the intent is not active yet or outside its recurring window
+	IntentStateScheduled
+)
+
+var intentStateNames = map[IntentState]string{
+	IntentStateUnknown:           "Unknown",
+	IntentStateInitialized:       "Initialized",
+	IntentStateSent:              "Sent",
+	IntentStateFailed:            "Failed",
+	IntentStateApplied:           "Applied",
+	IntentStatePartiallyApplied:  "PartiallyApplied",
+	IntentStateNoMatchingProcess: "NoMatchingProcess",
+	IntentStateExpired:           "Expired",
+	IntentStateScheduled:         "Scheduled",
+}
+
+func (s IntentState) String() string {
+	if name, ok := intentStateNames[s]; ok {
+		return name
+	}
+	return intentStateNames[IntentStateUnknown]
+}
+
+type PodEventType int8
+
+const (
+	PodEventUnknown PodEventType = iota
+	PodEventAdded
+	PodEventUpdated
+	PodEventDeleted
+)
+
+type StrategyResourceEventType int8
+
+const (
+	StrategyResourceEventUnknown StrategyResourceEventType = iota
+	StrategyResourceAdded
+	StrategyResourceUpdated
+	StrategyResourceDeleted
 )
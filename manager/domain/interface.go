@@ -37,6 +37,13 @@ type QueryStrategyOptions struct {
 	K8SNamespaces []string
 	Result        []*ScheduleStrategy
 	CreatorIDs    []bson.ObjectID
+	// TimeBounded only returns the strategies with an activation time, an expiry time or a recurring window
+	TimeBounded        bool
+	TemplateIDs        []bson.ObjectID
+	StrategyNamespaces []string
+	Names              []string
+	ResourceUIDs       []string // UIDs of the SchedulingStrategy resources the strategies are mirrored from
+	FromResources      bool     // only returns the strategies mirrored from SchedulingStrategy resources
 }
 
 type QueryIntentOptions struct {
@@ -45,6 +52,7 @@ type QueryIntentOptions struct {
 	StrategyIDs   []bson.ObjectID
 	States        []IntentState
 	PodIDs        []string
+	NodeIDs       []string
 	Result        []*ScheduleIntent
 	CreatorIDs    []bson.ObjectID
 }
@@ -63,12 +71,22 @@ type Repository interface {
 	QueryAuditLogs(ctx context.Context, opt *QueryAuditLogOptions) error
 
 	InsertStrategyAndIntents(ctx context.Context, strategy *ScheduleStrategy, intents []*ScheduleIntent) error
+	InsertIntents(ctx context.Context, intents []*ScheduleIntent) error
+	UpdateStrategy(ctx context.Context, strategy *ScheduleStrategy) error
+	UpdateIntents(ctx context.Context, intents []*ScheduleIntent) error
 	BatchUpdateIntentsState(ctx context.Context, intentIDs []bson.ObjectID, newState IntentState) error
+	BatchUpdateIntentsDelivery(ctx context.Context, intentIDs []bson.ObjectID, delivery IntentDelivery) error
+	BulkUpdateIntentsState(ctx context.Context, updates []*IntentStateUpdate) error
 	QueryStrategies(ctx context.Context, opt *QueryStrategyOptions) error
 	QueryIntents(ctx context.Context, opt *QueryIntentOptions) error
 	DeleteStrategy(ctx context.Context, strategyID bson.ObjectID) error
 	DeleteIntents(ctx context.Context, intentIDs []bson.ObjectID) error
 	DeleteIntentsByStrategyID(ctx context.Context, strategyID bson.ObjectID) error
+
+	InsertStrategyTemplate(ctx context.Context, template *StrategyTemplate) error
+	UpdateStrategyTemplate(ctx context.Context, template *StrategyTemplate) error
+	QueryStrategyTemplates(ctx context.Context, opt *QueryStrategyTemplateOptions) error
+	DeleteStrategyTemplate(ctx context.Context, templateID bson.ObjectID) error
 }
 
 type Service interface {
@@ -79,6 +97,7 @@ type Service interface {
 	ResetPassword(ctx context.Context, operator *Claims, id, newPassword string) error
 	UpdateUserPermissions(ctx context.Context, operator *Claims, id string, opt UpdateUserPermissionsOptions) error
 	VerifyJWTToken(ctx context.Context, tokenString string, permissionKey PermissionKey) (Claims, RolePolicy, error)
+	VerifyDecisionMakerToken(ctx context.Context, tokenString string) (DecisionMakerClaims, error)
 	QueryUsers(ctx context.Context, opt *QueryUserOptions) error
 
 	CreateRole(ctx context.Context, operator *Claims, role *Role) error
@@ -87,17 +106,49 @@ type Service interface {
 	QueryRoles(ctx context.Context, opt *QueryRoleOptions) error
 	QueryPermissions(ctx context.Context, opt *QueryPermissionOptions) error
 
-	CreateScheduleStrategy(ctx context.Context, operator *Claims, strategy *ScheduleStrategy) error
+	// CreateScheduleStrategy creates the strategy and reports the other strategies targeting the same pods with different settings
+	CreateScheduleStrategy(ctx context.Context, operator *Claims, strategy *ScheduleStrategy) ([]*StrategyConflict, error)
 	ListScheduleStrategies(ctx context.Context, filterOpts *QueryStrategyOptions) error
 	ListScheduleIntents(ctx context.Context, filterOpts *QueryIntentOptions) error
+	UpdateScheduleStrategy(ctx context.Context, operator *Claims, strategyID string, strategy *ScheduleStrategy) ([]*StrategyConflict, error)
+	PreviewScheduleStrategy(ctx context.Context, strategy *ScheduleStrategy, withProcesses bool) (*StrategyPreview, error)
+	// GetEffectivePolicy explains which strategies and intents apply to a pod and what its decision maker enforces
+	GetEffectivePolicy(ctx context.Context, query *EffectivePolicyQuery) (*EffectivePolicy, error)
 	DeleteScheduleStrategy(ctx context.Context, operator *Claims, strategyID string) error
 	DeleteScheduleIntents(ctx context.Context, operator *Claims, intentIDs []string) error
+	// ExportStrategyBundle returns the strategies of a strategy namespace as a bundle
+	ExportStrategyBundle(ctx context.Context, strategyNamespace string) (*StrategyBundle, error)
+	// ApplyStrategyBundle creates, updates and optionally prunes the strategies of the bundle namespace so that they match the bundle
+	ApplyStrategyBundle(ctx context.Context, operator *Claims, bundle *StrategyBundle, opts ApplyBundleOptions) ([]*StrategyChange, error)
+	CreateStrategyTemplate(ctx context.Context, operator *Claims, template *StrategyTemplate) error
+	ListStrategyTemplates(ctx context.Context, filterOpts *QueryStrategyTemplateOptions) error
+	// UpdateStrategyTemplate updates the template and, when propagate is set, the strategies derived from it; it returns the updated strategies
+	UpdateStrategyTemplate(ctx context.Context, operator *Claims, templateID string, template *StrategyTemplate, propagate bool) ([]bson.ObjectID, error)
+	DeleteStrategyTemplate(ctx context.Context, operator *Claims, templateID string) error
+	ReconcilePodEvent(ctx context.Context, event *PodEvent) error
+	// EnsureAnnotationStrategy creates the system strategy owning the intents synthesized from the pod annotations
+	EnsureAnnotationStrategy(ctx context.Context) error
+	// ReconcileStrategyResource mirrors a SchedulingStrategy resource into a strategy and writes the state of the strategy back to its status
+	ReconcileStrategyResource(ctx context.Context, event *StrategyResourceEvent) error
+	// PruneStrategyResources deletes the strategies of the SchedulingStrategy resources that no longer exist
+	PruneStrategyResources(ctx context.Context) error
+	RunIntentDelivery(ctx context.Context)
+	// RunStrategyScheduler activates and expires the intents of the time-bounded strategies until ctx is cancelled
+	RunStrategyScheduler(ctx context.Context)
 }
 
 type QueryPodsOptions struct {
-	K8SNamespace   []string
-	LabelSelectors []LabelSelector
-	CommandRegex   string
+	K8SNamespace          []string
+	NamespaceRequirements []LabelSelectorRequirement // labels of the pod namespaces, ANDed with K8SNamespace
+	LabelSelectors        []LabelSelector
+	LabelRequirements     []LabelSelectorRequirement // ANDed with the label selectors
+	NodeRequirements      []LabelSelectorRequirement // labels of the node of the pods, unscheduled pods never match
+	CommandRegex          string
+	ContainerNames        []string     // names of the containers, ORed with ContainerRegex, only the started containers match
+	ContainerRegex        string       // regex of the container names
+	PodIDs                []string     // pod UIDs, any pod if empty
+	PodNames              []string     // pod names, any pod if empty
+	Workload              *WorkloadRef // workload controlling the pods, any pod if nil
 }
 
 type QueryDecisionMakerPodsOptions struct {
@@ -109,14 +160,28 @@ type QueryDecisionMakerPodsOptions struct {
 type K8SAdapter interface {
 	QueryPods(ctx context.Context, opt *QueryPodsOptions) ([]*Pod, error)
 	QueryDecisionMakerPods(ctx context.Context, opt *QueryDecisionMakerPodsOptions) ([]*DecisionMakerPod, error)
+	// AddPodEventHandler registers a handler for pod add/update/delete events, pods already in the cache are replayed as add events
+	AddPodEventHandler(handler PodEventHandler)
+	// AddStrategyResourceHandler registers a handler for SchedulingStrategy resource events, resources already in the cache are replayed as add events
+	AddStrategyResourceHandler(handler StrategyResourceHandler)
+	// UpdateStrategyResourceStatus writes the status of a SchedulingStrategy resource, unless it is unchanged
+	UpdateStrategyResourceStatus(ctx context.Context, resource *StrategyResourceRef, status *StrategyResourceStatus) error
+	// StrategyResourceUIDs waits for the SchedulingStrategy resources to be cached and returns their UIDs
+	StrategyResourceUIDs(ctx context.Context) ([]string, error)
 }
 
 type DeleteIntentsRequest struct {
-	PodIDs []string // Delete all intents for these pods
-	All    bool     // If true, deletes all intents on the decision maker
+	PodIDs  []string          // Delete all intents for these pods
+	Intents []*ScheduleIntent // Delete only these intents, identified by pod ID, strategy, command regex, process matchers, thread regex and containers
+	All     bool              // If true, deletes all intents on the decision maker
 }
 
 type DecisionMakerAdapter interface {
-	SendSchedulingIntent(ctx context.Context, decisionMaker *DecisionMakerPod, intents []*ScheduleIntent) error
+	// SendSchedulingIntent returns the acknowledgement of every intent, or no result when the decision maker does not report them
+	SendSchedulingIntent(ctx context.Context, decisionMaker *DecisionMakerPod, intents []*ScheduleIntent) ([]*IntentResult, error)
 	DeleteSchedulingIntents(ctx context.Context, decisionMaker *DecisionMakerPod, req *DeleteIntentsRequest) error
+	// PreviewSchedulingIntents returns the processes the decision maker would bind every intent to, without retaining the intents
+	PreviewSchedulingIntents(ctx context.Context, decisionMaker *DecisionMakerPod, intents []*ScheduleIntent) ([]*IntentPreview, error)
+	// ListPodSchedulingIntents returns the scheduling the decision maker currently enforces for the processes of the pod
+	ListPodSchedulingIntents(ctx context.Context, decisionMaker *DecisionMakerPod, podID string) ([]*ProcessScheduling, error)
 }
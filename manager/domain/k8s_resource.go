@@ -1,5 +1,7 @@
 package domain
 
+import "context"
+
 type DecisionMakerPod struct {
 	NodeID string
 	Port   int
@@ -12,12 +14,16 @@ func (d *DecisionMakerPod) String() string {
 }
 
 type Pod struct {
-	Name         string
-	K8SNamespace string
-	Labels       map[string]string
-	PodID        string
-	NodeID       string
-	Containers   []Container
+	Name            string
+	K8SNamespace    string
+	NamespaceLabels map[string]string // labels of the namespace of the pod
+	Labels          map[string]string
+	PodID           string
+	NodeID          string
+	NodeLabels      map[string]string // labels of the node of the pod
+	Containers      []Container
+	Workload        *WorkloadRef     // workload controlling the pod, nil for a bare pod
+	Hints           *SchedulingHints // requested by the annotations of the pod, only in the namespaces allowed to
 }
 
 func (p *Pod) LabelsToSelectors() []LabelSelector {
@@ -36,3 +42,13 @@ type Container struct {
 	Name        string
 	Command     []string
 }
+
+// PodEvent describes a change of a pod observed by the K8S adapter
+type PodEvent struct {
+	Type PodEventType
+	Pod  *Pod
+}
+
+// PodEventHandler is called by the K8S adapter for every pod event, on the informer goroutine or concurrently with it for the replayed pods,
+// so it must not block
+type PodEventHandler func(ctx context.Context, event *PodEvent)
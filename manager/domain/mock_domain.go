@@ -38,6 +38,69 @@ func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
 	return &MockRepository_Expecter{mock: &_m.Mock}
 }
 
+// BatchUpdateIntentsDelivery provides a mock function for the type MockRepository
+func (_mock *MockRepository) BatchUpdateIntentsDelivery(ctx context.Context, intentIDs []bson.ObjectID, delivery IntentDelivery) error {
+	ret := _mock.Called(ctx, intentIDs, delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchUpdateIntentsDelivery")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []bson.ObjectID, IntentDelivery) error); ok {
+		r0 = returnFunc(ctx, intentIDs, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_BatchUpdateIntentsDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchUpdateIntentsDelivery'
+type MockRepository_BatchUpdateIntentsDelivery_Call struct {
+	*mock.Call
+}
+
+// BatchUpdateIntentsDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - intentIDs []bson.ObjectID
+//   - delivery IntentDelivery
+func (_e *MockRepository_Expecter) BatchUpdateIntentsDelivery(ctx interface{}, intentIDs interface{}, delivery interface{}) *MockRepository_BatchUpdateIntentsDelivery_Call {
+	return &MockRepository_BatchUpdateIntentsDelivery_Call{Call: _e.mock.On("BatchUpdateIntentsDelivery", ctx, intentIDs, delivery)}
+}
+
+func (_c *MockRepository_BatchUpdateIntentsDelivery_Call) Run(run func(ctx context.Context, intentIDs []bson.ObjectID, delivery IntentDelivery)) *MockRepository_BatchUpdateIntentsDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []bson.ObjectID
+		if args[1] != nil {
+			arg1 = args[1].([]bson.ObjectID)
+		}
+		var arg2 IntentDelivery
+		if args[2] != nil {
+			arg2 = args[2].(IntentDelivery)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_BatchUpdateIntentsDelivery_Call) Return(err error) *MockRepository_BatchUpdateIntentsDelivery_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_BatchUpdateIntentsDelivery_Call) RunAndReturn(run func(ctx context.Context, intentIDs []bson.ObjectID, delivery IntentDelivery) error) *MockRepository_BatchUpdateIntentsDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // BatchUpdateIntentsState provides a mock function for the type MockRepository
 func (_mock *MockRepository) BatchUpdateIntentsState(ctx context.Context, intentIDs []bson.ObjectID, newState IntentState) error {
 	ret := _mock.Called(ctx, intentIDs, newState)
@@ -101,6 +164,63 @@ func (_c *MockRepository_BatchUpdateIntentsState_Call) RunAndReturn(run func(ctx
 	return _c
 }
 
+// BulkUpdateIntentsState provides a mock function for the type MockRepository
+func (_mock *MockRepository) BulkUpdateIntentsState(ctx context.Context, updates []*IntentStateUpdate) error {
+	ret := _mock.Called(ctx, updates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkUpdateIntentsState")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*IntentStateUpdate) error); ok {
+		r0 = returnFunc(ctx, updates)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_BulkUpdateIntentsState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkUpdateIntentsState'
+type MockRepository_BulkUpdateIntentsState_Call struct {
+	*mock.Call
+}
+
+// BulkUpdateIntentsState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - updates []*IntentStateUpdate
+func (_e *MockRepository_Expecter) BulkUpdateIntentsState(ctx interface{}, updates interface{}) *MockRepository_BulkUpdateIntentsState_Call {
+	return &MockRepository_BulkUpdateIntentsState_Call{Call: _e.mock.On("BulkUpdateIntentsState", ctx, updates)}
+}
+
+func (_c *MockRepository_BulkUpdateIntentsState_Call) Run(run func(ctx context.Context, updates []*IntentStateUpdate)) *MockRepository_BulkUpdateIntentsState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*IntentStateUpdate
+		if args[1] != nil {
+			arg1 = args[1].([]*IntentStateUpdate)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_BulkUpdateIntentsState_Call) Return(err error) *MockRepository_BulkUpdateIntentsState_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_BulkUpdateIntentsState_Call) RunAndReturn(run func(ctx context.Context, updates []*IntentStateUpdate) error) *MockRepository_BulkUpdateIntentsState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateAuditLog provides a mock function for the type MockRepository
 func (_mock *MockRepository) CreateAuditLog(ctx context.Context, log *AuditLog) error {
 	ret := _mock.Called(ctx, log)
@@ -329,107 +449,101 @@ func (_c *MockRepository_CreateUser_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
-// InsertStrategyAndIntents provides a mock function for the type MockRepository
-func (_mock *MockRepository) InsertStrategyAndIntents(ctx context.Context, strategy *ScheduleStrategy, intents []*ScheduleIntent) error {
-	ret := _mock.Called(ctx, strategy, intents)
+// DeleteIntents provides a mock function for the type MockRepository
+func (_mock *MockRepository) DeleteIntents(ctx context.Context, intentIDs []bson.ObjectID) error {
+	ret := _mock.Called(ctx, intentIDs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InsertStrategyAndIntents")
+		panic("no return value specified for DeleteIntents")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *ScheduleStrategy, []*ScheduleIntent) error); ok {
-		r0 = returnFunc(ctx, strategy, intents)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []bson.ObjectID) error); ok {
+		r0 = returnFunc(ctx, intentIDs)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_InsertStrategyAndIntents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertStrategyAndIntents'
-type MockRepository_InsertStrategyAndIntents_Call struct {
+// MockRepository_DeleteIntents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIntents'
+type MockRepository_DeleteIntents_Call struct {
 	*mock.Call
 }
 
-// InsertStrategyAndIntents is a helper method to define mock.On call
+// DeleteIntents is a helper method to define mock.On call
 //   - ctx context.Context
-//   - strategy *ScheduleStrategy
-//   - intents []*ScheduleIntent
-func (_e *MockRepository_Expecter) InsertStrategyAndIntents(ctx interface{}, strategy interface{}, intents interface{}) *MockRepository_InsertStrategyAndIntents_Call {
-	return &MockRepository_InsertStrategyAndIntents_Call{Call: _e.mock.On("InsertStrategyAndIntents", ctx, strategy, intents)}
+//   - intentIDs []bson.ObjectID
+func (_e *MockRepository_Expecter) DeleteIntents(ctx interface{}, intentIDs interface{}) *MockRepository_DeleteIntents_Call {
+	return &MockRepository_DeleteIntents_Call{Call: _e.mock.On("DeleteIntents", ctx, intentIDs)}
 }
 
-func (_c *MockRepository_InsertStrategyAndIntents_Call) Run(run func(ctx context.Context, strategy *ScheduleStrategy, intents []*ScheduleIntent)) *MockRepository_InsertStrategyAndIntents_Call {
+func (_c *MockRepository_DeleteIntents_Call) Run(run func(ctx context.Context, intentIDs []bson.ObjectID)) *MockRepository_DeleteIntents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *ScheduleStrategy
+		var arg1 []bson.ObjectID
 		if args[1] != nil {
-			arg1 = args[1].(*ScheduleStrategy)
-		}
-		var arg2 []*ScheduleIntent
-		if args[2] != nil {
-			arg2 = args[2].([]*ScheduleIntent)
+			arg1 = args[1].([]bson.ObjectID)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_InsertStrategyAndIntents_Call) Return(err error) *MockRepository_InsertStrategyAndIntents_Call {
+func (_c *MockRepository_DeleteIntents_Call) Return(err error) *MockRepository_DeleteIntents_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_InsertStrategyAndIntents_Call) RunAndReturn(run func(ctx context.Context, strategy *ScheduleStrategy, intents []*ScheduleIntent) error) *MockRepository_InsertStrategyAndIntents_Call {
+func (_c *MockRepository_DeleteIntents_Call) RunAndReturn(run func(ctx context.Context, intentIDs []bson.ObjectID) error) *MockRepository_DeleteIntents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryAuditLogs provides a mock function for the type MockRepository
-func (_mock *MockRepository) QueryAuditLogs(ctx context.Context, opt *QueryAuditLogOptions) error {
-	ret := _mock.Called(ctx, opt)
+// DeleteIntentsByStrategyID provides a mock function for the type MockRepository
+func (_mock *MockRepository) DeleteIntentsByStrategyID(ctx context.Context, strategyID bson.ObjectID) error {
+	ret := _mock.Called(ctx, strategyID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryAuditLogs")
+		panic("no return value specified for DeleteIntentsByStrategyID")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryAuditLogOptions) error); ok {
-		r0 = returnFunc(ctx, opt)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = returnFunc(ctx, strategyID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_QueryAuditLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryAuditLogs'
-type MockRepository_QueryAuditLogs_Call struct {
+// MockRepository_DeleteIntentsByStrategyID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIntentsByStrategyID'
+type MockRepository_DeleteIntentsByStrategyID_Call struct {
 	*mock.Call
 }
 
-// QueryAuditLogs is a helper method to define mock.On call
+// DeleteIntentsByStrategyID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - opt *QueryAuditLogOptions
-func (_e *MockRepository_Expecter) QueryAuditLogs(ctx interface{}, opt interface{}) *MockRepository_QueryAuditLogs_Call {
-	return &MockRepository_QueryAuditLogs_Call{Call: _e.mock.On("QueryAuditLogs", ctx, opt)}
+//   - strategyID bson.ObjectID
+func (_e *MockRepository_Expecter) DeleteIntentsByStrategyID(ctx interface{}, strategyID interface{}) *MockRepository_DeleteIntentsByStrategyID_Call {
+	return &MockRepository_DeleteIntentsByStrategyID_Call{Call: _e.mock.On("DeleteIntentsByStrategyID", ctx, strategyID)}
 }
 
-func (_c *MockRepository_QueryAuditLogs_Call) Run(run func(ctx context.Context, opt *QueryAuditLogOptions)) *MockRepository_QueryAuditLogs_Call {
+func (_c *MockRepository_DeleteIntentsByStrategyID_Call) Run(run func(ctx context.Context, strategyID bson.ObjectID)) *MockRepository_DeleteIntentsByStrategyID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *QueryAuditLogOptions
+		var arg1 bson.ObjectID
 		if args[1] != nil {
-			arg1 = args[1].(*QueryAuditLogOptions)
+			arg1 = args[1].(bson.ObjectID)
 		}
 		run(
 			arg0,
@@ -439,54 +553,54 @@ func (_c *MockRepository_QueryAuditLogs_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockRepository_QueryAuditLogs_Call) Return(err error) *MockRepository_QueryAuditLogs_Call {
+func (_c *MockRepository_DeleteIntentsByStrategyID_Call) Return(err error) *MockRepository_DeleteIntentsByStrategyID_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_QueryAuditLogs_Call) RunAndReturn(run func(ctx context.Context, opt *QueryAuditLogOptions) error) *MockRepository_QueryAuditLogs_Call {
+func (_c *MockRepository_DeleteIntentsByStrategyID_Call) RunAndReturn(run func(ctx context.Context, strategyID bson.ObjectID) error) *MockRepository_DeleteIntentsByStrategyID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryIntents provides a mock function for the type MockRepository
-func (_mock *MockRepository) QueryIntents(ctx context.Context, opt *QueryIntentOptions) error {
-	ret := _mock.Called(ctx, opt)
+// DeleteStrategy provides a mock function for the type MockRepository
+func (_mock *MockRepository) DeleteStrategy(ctx context.Context, strategyID bson.ObjectID) error {
+	ret := _mock.Called(ctx, strategyID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryIntents")
+		panic("no return value specified for DeleteStrategy")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryIntentOptions) error); ok {
-		r0 = returnFunc(ctx, opt)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = returnFunc(ctx, strategyID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_QueryIntents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryIntents'
-type MockRepository_QueryIntents_Call struct {
+// MockRepository_DeleteStrategy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteStrategy'
+type MockRepository_DeleteStrategy_Call struct {
 	*mock.Call
 }
 
-// QueryIntents is a helper method to define mock.On call
+// DeleteStrategy is a helper method to define mock.On call
 //   - ctx context.Context
-//   - opt *QueryIntentOptions
-func (_e *MockRepository_Expecter) QueryIntents(ctx interface{}, opt interface{}) *MockRepository_QueryIntents_Call {
-	return &MockRepository_QueryIntents_Call{Call: _e.mock.On("QueryIntents", ctx, opt)}
+//   - strategyID bson.ObjectID
+func (_e *MockRepository_Expecter) DeleteStrategy(ctx interface{}, strategyID interface{}) *MockRepository_DeleteStrategy_Call {
+	return &MockRepository_DeleteStrategy_Call{Call: _e.mock.On("DeleteStrategy", ctx, strategyID)}
 }
 
-func (_c *MockRepository_QueryIntents_Call) Run(run func(ctx context.Context, opt *QueryIntentOptions)) *MockRepository_QueryIntents_Call {
+func (_c *MockRepository_DeleteStrategy_Call) Run(run func(ctx context.Context, strategyID bson.ObjectID)) *MockRepository_DeleteStrategy_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *QueryIntentOptions
+		var arg1 bson.ObjectID
 		if args[1] != nil {
-			arg1 = args[1].(*QueryIntentOptions)
+			arg1 = args[1].(bson.ObjectID)
 		}
 		run(
 			arg0,
@@ -496,54 +610,54 @@ func (_c *MockRepository_QueryIntents_Call) Run(run func(ctx context.Context, op
 	return _c
 }
 
-func (_c *MockRepository_QueryIntents_Call) Return(err error) *MockRepository_QueryIntents_Call {
+func (_c *MockRepository_DeleteStrategy_Call) Return(err error) *MockRepository_DeleteStrategy_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_QueryIntents_Call) RunAndReturn(run func(ctx context.Context, opt *QueryIntentOptions) error) *MockRepository_QueryIntents_Call {
+func (_c *MockRepository_DeleteStrategy_Call) RunAndReturn(run func(ctx context.Context, strategyID bson.ObjectID) error) *MockRepository_DeleteStrategy_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryPermissions provides a mock function for the type MockRepository
-func (_mock *MockRepository) QueryPermissions(ctx context.Context, opt *QueryPermissionOptions) error {
-	ret := _mock.Called(ctx, opt)
+// DeleteStrategyTemplate provides a mock function for the type MockRepository
+func (_mock *MockRepository) DeleteStrategyTemplate(ctx context.Context, templateID bson.ObjectID) error {
+	ret := _mock.Called(ctx, templateID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryPermissions")
+		panic("no return value specified for DeleteStrategyTemplate")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryPermissionOptions) error); ok {
-		r0 = returnFunc(ctx, opt)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bson.ObjectID) error); ok {
+		r0 = returnFunc(ctx, templateID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_QueryPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryPermissions'
-type MockRepository_QueryPermissions_Call struct {
+// MockRepository_DeleteStrategyTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteStrategyTemplate'
+type MockRepository_DeleteStrategyTemplate_Call struct {
 	*mock.Call
 }
 
-// QueryPermissions is a helper method to define mock.On call
+// DeleteStrategyTemplate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - opt *QueryPermissionOptions
-func (_e *MockRepository_Expecter) QueryPermissions(ctx interface{}, opt interface{}) *MockRepository_QueryPermissions_Call {
-	return &MockRepository_QueryPermissions_Call{Call: _e.mock.On("QueryPermissions", ctx, opt)}
+//   - templateID bson.ObjectID
+func (_e *MockRepository_Expecter) DeleteStrategyTemplate(ctx interface{}, templateID interface{}) *MockRepository_DeleteStrategyTemplate_Call {
+	return &MockRepository_DeleteStrategyTemplate_Call{Call: _e.mock.On("DeleteStrategyTemplate", ctx, templateID)}
 }
 
-func (_c *MockRepository_QueryPermissions_Call) Run(run func(ctx context.Context, opt *QueryPermissionOptions)) *MockRepository_QueryPermissions_Call {
+func (_c *MockRepository_DeleteStrategyTemplate_Call) Run(run func(ctx context.Context, templateID bson.ObjectID)) *MockRepository_DeleteStrategyTemplate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *QueryPermissionOptions
+		var arg1 bson.ObjectID
 		if args[1] != nil {
-			arg1 = args[1].(*QueryPermissionOptions)
+			arg1 = args[1].(bson.ObjectID)
 		}
 		run(
 			arg0,
@@ -553,54 +667,54 @@ func (_c *MockRepository_QueryPermissions_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *MockRepository_QueryPermissions_Call) Return(err error) *MockRepository_QueryPermissions_Call {
+func (_c *MockRepository_DeleteStrategyTemplate_Call) Return(err error) *MockRepository_DeleteStrategyTemplate_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_QueryPermissions_Call) RunAndReturn(run func(ctx context.Context, opt *QueryPermissionOptions) error) *MockRepository_QueryPermissions_Call {
+func (_c *MockRepository_DeleteStrategyTemplate_Call) RunAndReturn(run func(ctx context.Context, templateID bson.ObjectID) error) *MockRepository_DeleteStrategyTemplate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryRoles provides a mock function for the type MockRepository
-func (_mock *MockRepository) QueryRoles(ctx context.Context, opt *QueryRoleOptions) error {
-	ret := _mock.Called(ctx, opt)
+// InsertIntents provides a mock function for the type MockRepository
+func (_mock *MockRepository) InsertIntents(ctx context.Context, intents []*ScheduleIntent) error {
+	ret := _mock.Called(ctx, intents)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryRoles")
+		panic("no return value specified for InsertIntents")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryRoleOptions) error); ok {
-		r0 = returnFunc(ctx, opt)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*ScheduleIntent) error); ok {
+		r0 = returnFunc(ctx, intents)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_QueryRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryRoles'
-type MockRepository_QueryRoles_Call struct {
+// MockRepository_InsertIntents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertIntents'
+type MockRepository_InsertIntents_Call struct {
 	*mock.Call
 }
 
-// QueryRoles is a helper method to define mock.On call
+// InsertIntents is a helper method to define mock.On call
 //   - ctx context.Context
-//   - opt *QueryRoleOptions
-func (_e *MockRepository_Expecter) QueryRoles(ctx interface{}, opt interface{}) *MockRepository_QueryRoles_Call {
-	return &MockRepository_QueryRoles_Call{Call: _e.mock.On("QueryRoles", ctx, opt)}
+//   - intents []*ScheduleIntent
+func (_e *MockRepository_Expecter) InsertIntents(ctx interface{}, intents interface{}) *MockRepository_InsertIntents_Call {
+	return &MockRepository_InsertIntents_Call{Call: _e.mock.On("InsertIntents", ctx, intents)}
 }
 
-func (_c *MockRepository_QueryRoles_Call) Run(run func(ctx context.Context, opt *QueryRoleOptions)) *MockRepository_QueryRoles_Call {
+func (_c *MockRepository_InsertIntents_Call) Run(run func(ctx context.Context, intents []*ScheduleIntent)) *MockRepository_InsertIntents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *QueryRoleOptions
+		var arg1 []*ScheduleIntent
 		if args[1] != nil {
-			arg1 = args[1].(*QueryRoleOptions)
+			arg1 = args[1].([]*ScheduleIntent)
 		}
 		run(
 			arg0,
@@ -610,111 +724,117 @@ func (_c *MockRepository_QueryRoles_Call) Run(run func(ctx context.Context, opt
 	return _c
 }
 
-func (_c *MockRepository_QueryRoles_Call) Return(err error) *MockRepository_QueryRoles_Call {
+func (_c *MockRepository_InsertIntents_Call) Return(err error) *MockRepository_InsertIntents_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_QueryRoles_Call) RunAndReturn(run func(ctx context.Context, opt *QueryRoleOptions) error) *MockRepository_QueryRoles_Call {
+func (_c *MockRepository_InsertIntents_Call) RunAndReturn(run func(ctx context.Context, intents []*ScheduleIntent) error) *MockRepository_InsertIntents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryStrategies provides a mock function for the type MockRepository
-func (_mock *MockRepository) QueryStrategies(ctx context.Context, opt *QueryStrategyOptions) error {
-	ret := _mock.Called(ctx, opt)
+// InsertStrategyAndIntents provides a mock function for the type MockRepository
+func (_mock *MockRepository) InsertStrategyAndIntents(ctx context.Context, strategy *ScheduleStrategy, intents []*ScheduleIntent) error {
+	ret := _mock.Called(ctx, strategy, intents)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryStrategies")
+		panic("no return value specified for InsertStrategyAndIntents")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryStrategyOptions) error); ok {
-		r0 = returnFunc(ctx, opt)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *ScheduleStrategy, []*ScheduleIntent) error); ok {
+		r0 = returnFunc(ctx, strategy, intents)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_QueryStrategies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryStrategies'
-type MockRepository_QueryStrategies_Call struct {
+// MockRepository_InsertStrategyAndIntents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertStrategyAndIntents'
+type MockRepository_InsertStrategyAndIntents_Call struct {
 	*mock.Call
 }
 
-// QueryStrategies is a helper method to define mock.On call
+// InsertStrategyAndIntents is a helper method to define mock.On call
 //   - ctx context.Context
-//   - opt *QueryStrategyOptions
-func (_e *MockRepository_Expecter) QueryStrategies(ctx interface{}, opt interface{}) *MockRepository_QueryStrategies_Call {
-	return &MockRepository_QueryStrategies_Call{Call: _e.mock.On("QueryStrategies", ctx, opt)}
+//   - strategy *ScheduleStrategy
+//   - intents []*ScheduleIntent
+func (_e *MockRepository_Expecter) InsertStrategyAndIntents(ctx interface{}, strategy interface{}, intents interface{}) *MockRepository_InsertStrategyAndIntents_Call {
+	return &MockRepository_InsertStrategyAndIntents_Call{Call: _e.mock.On("InsertStrategyAndIntents", ctx, strategy, intents)}
 }
 
-func (_c *MockRepository_QueryStrategies_Call) Run(run func(ctx context.Context, opt *QueryStrategyOptions)) *MockRepository_QueryStrategies_Call {
+func (_c *MockRepository_InsertStrategyAndIntents_Call) Run(run func(ctx context.Context, strategy *ScheduleStrategy, intents []*ScheduleIntent)) *MockRepository_InsertStrategyAndIntents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *QueryStrategyOptions
+		var arg1 *ScheduleStrategy
 		if args[1] != nil {
-			arg1 = args[1].(*QueryStrategyOptions)
+			arg1 = args[1].(*ScheduleStrategy)
+		}
+		var arg2 []*ScheduleIntent
+		if args[2] != nil {
+			arg2 = args[2].([]*ScheduleIntent)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_QueryStrategies_Call) Return(err error) *MockRepository_QueryStrategies_Call {
+func (_c *MockRepository_InsertStrategyAndIntents_Call) Return(err error) *MockRepository_InsertStrategyAndIntents_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_QueryStrategies_Call) RunAndReturn(run func(ctx context.Context, opt *QueryStrategyOptions) error) *MockRepository_QueryStrategies_Call {
+func (_c *MockRepository_InsertStrategyAndIntents_Call) RunAndReturn(run func(ctx context.Context, strategy *ScheduleStrategy, intents []*ScheduleIntent) error) *MockRepository_InsertStrategyAndIntents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryUsers provides a mock function for the type MockRepository
-func (_mock *MockRepository) QueryUsers(ctx context.Context, opt *QueryUserOptions) error {
-	ret := _mock.Called(ctx, opt)
+// InsertStrategyTemplate provides a mock function for the type MockRepository
+func (_mock *MockRepository) InsertStrategyTemplate(ctx context.Context, template *StrategyTemplate) error {
+	ret := _mock.Called(ctx, template)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryUsers")
+		panic("no return value specified for InsertStrategyTemplate")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryUserOptions) error); ok {
-		r0 = returnFunc(ctx, opt)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *StrategyTemplate) error); ok {
+		r0 = returnFunc(ctx, template)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_QueryUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryUsers'
-type MockRepository_QueryUsers_Call struct {
+// MockRepository_InsertStrategyTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertStrategyTemplate'
+type MockRepository_InsertStrategyTemplate_Call struct {
 	*mock.Call
 }
 
-// QueryUsers is a helper method to define mock.On call
+// InsertStrategyTemplate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - opt *QueryUserOptions
-func (_e *MockRepository_Expecter) QueryUsers(ctx interface{}, opt interface{}) *MockRepository_QueryUsers_Call {
-	return &MockRepository_QueryUsers_Call{Call: _e.mock.On("QueryUsers", ctx, opt)}
+//   - template *StrategyTemplate
+func (_e *MockRepository_Expecter) InsertStrategyTemplate(ctx interface{}, template interface{}) *MockRepository_InsertStrategyTemplate_Call {
+	return &MockRepository_InsertStrategyTemplate_Call{Call: _e.mock.On("InsertStrategyTemplate", ctx, template)}
 }
 
-func (_c *MockRepository_QueryUsers_Call) Run(run func(ctx context.Context, opt *QueryUserOptions)) *MockRepository_QueryUsers_Call {
+func (_c *MockRepository_InsertStrategyTemplate_Call) Run(run func(ctx context.Context, template *StrategyTemplate)) *MockRepository_InsertStrategyTemplate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *QueryUserOptions
+		var arg1 *StrategyTemplate
 		if args[1] != nil {
-			arg1 = args[1].(*QueryUserOptions)
+			arg1 = args[1].(*StrategyTemplate)
 		}
 		run(
 			arg0,
@@ -724,54 +844,54 @@ func (_c *MockRepository_QueryUsers_Call) Run(run func(ctx context.Context, opt
 	return _c
 }
 
-func (_c *MockRepository_QueryUsers_Call) Return(err error) *MockRepository_QueryUsers_Call {
+func (_c *MockRepository_InsertStrategyTemplate_Call) Return(err error) *MockRepository_InsertStrategyTemplate_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_QueryUsers_Call) RunAndReturn(run func(ctx context.Context, opt *QueryUserOptions) error) *MockRepository_QueryUsers_Call {
+func (_c *MockRepository_InsertStrategyTemplate_Call) RunAndReturn(run func(ctx context.Context, template *StrategyTemplate) error) *MockRepository_InsertStrategyTemplate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdatePermission provides a mock function for the type MockRepository
-func (_mock *MockRepository) UpdatePermission(ctx context.Context, permission *Permission) error {
-	ret := _mock.Called(ctx, permission)
+// QueryAuditLogs provides a mock function for the type MockRepository
+func (_mock *MockRepository) QueryAuditLogs(ctx context.Context, opt *QueryAuditLogOptions) error {
+	ret := _mock.Called(ctx, opt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdatePermission")
+		panic("no return value specified for QueryAuditLogs")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *Permission) error); ok {
-		r0 = returnFunc(ctx, permission)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryAuditLogOptions) error); ok {
+		r0 = returnFunc(ctx, opt)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_UpdatePermission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePermission'
-type MockRepository_UpdatePermission_Call struct {
+// MockRepository_QueryAuditLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryAuditLogs'
+type MockRepository_QueryAuditLogs_Call struct {
 	*mock.Call
 }
 
-// UpdatePermission is a helper method to define mock.On call
+// QueryAuditLogs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - permission *Permission
-func (_e *MockRepository_Expecter) UpdatePermission(ctx interface{}, permission interface{}) *MockRepository_UpdatePermission_Call {
-	return &MockRepository_UpdatePermission_Call{Call: _e.mock.On("UpdatePermission", ctx, permission)}
+//   - opt *QueryAuditLogOptions
+func (_e *MockRepository_Expecter) QueryAuditLogs(ctx interface{}, opt interface{}) *MockRepository_QueryAuditLogs_Call {
+	return &MockRepository_QueryAuditLogs_Call{Call: _e.mock.On("QueryAuditLogs", ctx, opt)}
 }
 
-func (_c *MockRepository_UpdatePermission_Call) Run(run func(ctx context.Context, permission *Permission)) *MockRepository_UpdatePermission_Call {
+func (_c *MockRepository_QueryAuditLogs_Call) Run(run func(ctx context.Context, opt *QueryAuditLogOptions)) *MockRepository_QueryAuditLogs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *Permission
+		var arg1 *QueryAuditLogOptions
 		if args[1] != nil {
-			arg1 = args[1].(*Permission)
+			arg1 = args[1].(*QueryAuditLogOptions)
 		}
 		run(
 			arg0,
@@ -781,54 +901,54 @@ func (_c *MockRepository_UpdatePermission_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *MockRepository_UpdatePermission_Call) Return(err error) *MockRepository_UpdatePermission_Call {
+func (_c *MockRepository_QueryAuditLogs_Call) Return(err error) *MockRepository_QueryAuditLogs_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_UpdatePermission_Call) RunAndReturn(run func(ctx context.Context, permission *Permission) error) *MockRepository_UpdatePermission_Call {
+func (_c *MockRepository_QueryAuditLogs_Call) RunAndReturn(run func(ctx context.Context, opt *QueryAuditLogOptions) error) *MockRepository_QueryAuditLogs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateRole provides a mock function for the type MockRepository
-func (_mock *MockRepository) UpdateRole(ctx context.Context, role *Role) error {
-	ret := _mock.Called(ctx, role)
+// QueryIntents provides a mock function for the type MockRepository
+func (_mock *MockRepository) QueryIntents(ctx context.Context, opt *QueryIntentOptions) error {
+	ret := _mock.Called(ctx, opt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateRole")
+		panic("no return value specified for QueryIntents")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *Role) error); ok {
-		r0 = returnFunc(ctx, role)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryIntentOptions) error); ok {
+		r0 = returnFunc(ctx, opt)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
-type MockRepository_UpdateRole_Call struct {
+// MockRepository_QueryIntents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryIntents'
+type MockRepository_QueryIntents_Call struct {
 	*mock.Call
 }
 
-// UpdateRole is a helper method to define mock.On call
+// QueryIntents is a helper method to define mock.On call
 //   - ctx context.Context
-//   - role *Role
-func (_e *MockRepository_Expecter) UpdateRole(ctx interface{}, role interface{}) *MockRepository_UpdateRole_Call {
-	return &MockRepository_UpdateRole_Call{Call: _e.mock.On("UpdateRole", ctx, role)}
+//   - opt *QueryIntentOptions
+func (_e *MockRepository_Expecter) QueryIntents(ctx interface{}, opt interface{}) *MockRepository_QueryIntents_Call {
+	return &MockRepository_QueryIntents_Call{Call: _e.mock.On("QueryIntents", ctx, opt)}
 }
 
-func (_c *MockRepository_UpdateRole_Call) Run(run func(ctx context.Context, role *Role)) *MockRepository_UpdateRole_Call {
+func (_c *MockRepository_QueryIntents_Call) Run(run func(ctx context.Context, opt *QueryIntentOptions)) *MockRepository_QueryIntents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *Role
+		var arg1 *QueryIntentOptions
 		if args[1] != nil {
-			arg1 = args[1].(*Role)
+			arg1 = args[1].(*QueryIntentOptions)
 		}
 		run(
 			arg0,
@@ -838,54 +958,54 @@ func (_c *MockRepository_UpdateRole_Call) Run(run func(ctx context.Context, role
 	return _c
 }
 
-func (_c *MockRepository_UpdateRole_Call) Return(err error) *MockRepository_UpdateRole_Call {
+func (_c *MockRepository_QueryIntents_Call) Return(err error) *MockRepository_QueryIntents_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_UpdateRole_Call) RunAndReturn(run func(ctx context.Context, role *Role) error) *MockRepository_UpdateRole_Call {
+func (_c *MockRepository_QueryIntents_Call) RunAndReturn(run func(ctx context.Context, opt *QueryIntentOptions) error) *MockRepository_QueryIntents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateUser provides a mock function for the type MockRepository
-func (_mock *MockRepository) UpdateUser(ctx context.Context, user *User) error {
-	ret := _mock.Called(ctx, user)
+// QueryPermissions provides a mock function for the type MockRepository
+func (_mock *MockRepository) QueryPermissions(ctx context.Context, opt *QueryPermissionOptions) error {
+	ret := _mock.Called(ctx, opt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateUser")
+		panic("no return value specified for QueryPermissions")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *User) error); ok {
-		r0 = returnFunc(ctx, user)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryPermissionOptions) error); ok {
+		r0 = returnFunc(ctx, opt)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
-type MockRepository_UpdateUser_Call struct {
+// MockRepository_QueryPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryPermissions'
+type MockRepository_QueryPermissions_Call struct {
 	*mock.Call
 }
 
-// UpdateUser is a helper method to define mock.On call
+// QueryPermissions is a helper method to define mock.On call
 //   - ctx context.Context
-//   - user *User
-func (_e *MockRepository_Expecter) UpdateUser(ctx interface{}, user interface{}) *MockRepository_UpdateUser_Call {
-	return &MockRepository_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, user)}
+//   - opt *QueryPermissionOptions
+func (_e *MockRepository_Expecter) QueryPermissions(ctx interface{}, opt interface{}) *MockRepository_QueryPermissions_Call {
+	return &MockRepository_QueryPermissions_Call{Call: _e.mock.On("QueryPermissions", ctx, opt)}
 }
 
-func (_c *MockRepository_UpdateUser_Call) Run(run func(ctx context.Context, user *User)) *MockRepository_UpdateUser_Call {
+func (_c *MockRepository_QueryPermissions_Call) Run(run func(ctx context.Context, opt *QueryPermissionOptions)) *MockRepository_QueryPermissions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *User
+		var arg1 *QueryPermissionOptions
 		if args[1] != nil {
-			arg1 = args[1].(*User)
+			arg1 = args[1].(*QueryPermissionOptions)
 		}
 		run(
 			arg0,
@@ -895,471 +1015,396 @@ func (_c *MockRepository_UpdateUser_Call) Run(run func(ctx context.Context, user
 	return _c
 }
 
-func (_c *MockRepository_UpdateUser_Call) Return(err error) *MockRepository_UpdateUser_Call {
+func (_c *MockRepository_QueryPermissions_Call) Return(err error) *MockRepository_QueryPermissions_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_UpdateUser_Call) RunAndReturn(run func(ctx context.Context, user *User) error) *MockRepository_UpdateUser_Call {
+func (_c *MockRepository_QueryPermissions_Call) RunAndReturn(run func(ctx context.Context, opt *QueryPermissionOptions) error) *MockRepository_QueryPermissions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockService {
-	mock := &MockService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockService is an autogenerated mock type for the Service type
-type MockService struct {
-	mock.Mock
-}
-
-type MockService_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockService) EXPECT() *MockService_Expecter {
-	return &MockService_Expecter{mock: &_m.Mock}
-}
-
-// ChangePassword provides a mock function for the type MockService
-func (_mock *MockService) ChangePassword(ctx context.Context, user *Claims, oldPassword string, newPassword string) error {
-	ret := _mock.Called(ctx, user, oldPassword, newPassword)
+// QueryRoles provides a mock function for the type MockRepository
+func (_mock *MockRepository) QueryRoles(ctx context.Context, opt *QueryRoleOptions) error {
+	ret := _mock.Called(ctx, opt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ChangePassword")
+		panic("no return value specified for QueryRoles")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string, string) error); ok {
-		r0 = returnFunc(ctx, user, oldPassword, newPassword)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryRoleOptions) error); ok {
+		r0 = returnFunc(ctx, opt)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
-type MockService_ChangePassword_Call struct {
+// MockRepository_QueryRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryRoles'
+type MockRepository_QueryRoles_Call struct {
 	*mock.Call
 }
 
-// ChangePassword is a helper method to define mock.On call
+// QueryRoles is a helper method to define mock.On call
 //   - ctx context.Context
-//   - user *Claims
-//   - oldPassword string
-//   - newPassword string
-func (_e *MockService_Expecter) ChangePassword(ctx interface{}, user interface{}, oldPassword interface{}, newPassword interface{}) *MockService_ChangePassword_Call {
-	return &MockService_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, user, oldPassword, newPassword)}
+//   - opt *QueryRoleOptions
+func (_e *MockRepository_Expecter) QueryRoles(ctx interface{}, opt interface{}) *MockRepository_QueryRoles_Call {
+	return &MockRepository_QueryRoles_Call{Call: _e.mock.On("QueryRoles", ctx, opt)}
 }
 
-func (_c *MockService_ChangePassword_Call) Run(run func(ctx context.Context, user *Claims, oldPassword string, newPassword string)) *MockService_ChangePassword_Call {
+func (_c *MockRepository_QueryRoles_Call) Run(run func(ctx context.Context, opt *QueryRoleOptions)) *MockRepository_QueryRoles_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *Claims
+		var arg1 *QueryRoleOptions
 		if args[1] != nil {
-			arg1 = args[1].(*Claims)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg1 = args[1].(*QueryRoleOptions)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_ChangePassword_Call) Return(err error) *MockService_ChangePassword_Call {
+func (_c *MockRepository_QueryRoles_Call) Return(err error) *MockRepository_QueryRoles_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_ChangePassword_Call) RunAndReturn(run func(ctx context.Context, user *Claims, oldPassword string, newPassword string) error) *MockService_ChangePassword_Call {
+func (_c *MockRepository_QueryRoles_Call) RunAndReturn(run func(ctx context.Context, opt *QueryRoleOptions) error) *MockRepository_QueryRoles_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateAdminUserIfNotExists provides a mock function for the type MockService
-func (_mock *MockService) CreateAdminUserIfNotExists(ctx context.Context, username string, password string) error {
-	ret := _mock.Called(ctx, username, password)
+// QueryStrategies provides a mock function for the type MockRepository
+func (_mock *MockRepository) QueryStrategies(ctx context.Context, opt *QueryStrategyOptions) error {
+	ret := _mock.Called(ctx, opt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateAdminUserIfNotExists")
+		panic("no return value specified for QueryStrategies")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, username, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryStrategyOptions) error); ok {
+		r0 = returnFunc(ctx, opt)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_CreateAdminUserIfNotExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAdminUserIfNotExists'
-type MockService_CreateAdminUserIfNotExists_Call struct {
+// MockRepository_QueryStrategies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryStrategies'
+type MockRepository_QueryStrategies_Call struct {
 	*mock.Call
 }
 
-// CreateAdminUserIfNotExists is a helper method to define mock.On call
+// QueryStrategies is a helper method to define mock.On call
 //   - ctx context.Context
-//   - username string
-//   - password string
-func (_e *MockService_Expecter) CreateAdminUserIfNotExists(ctx interface{}, username interface{}, password interface{}) *MockService_CreateAdminUserIfNotExists_Call {
-	return &MockService_CreateAdminUserIfNotExists_Call{Call: _e.mock.On("CreateAdminUserIfNotExists", ctx, username, password)}
+//   - opt *QueryStrategyOptions
+func (_e *MockRepository_Expecter) QueryStrategies(ctx interface{}, opt interface{}) *MockRepository_QueryStrategies_Call {
+	return &MockRepository_QueryStrategies_Call{Call: _e.mock.On("QueryStrategies", ctx, opt)}
 }
 
-func (_c *MockService_CreateAdminUserIfNotExists_Call) Run(run func(ctx context.Context, username string, password string)) *MockService_CreateAdminUserIfNotExists_Call {
+func (_c *MockRepository_QueryStrategies_Call) Run(run func(ctx context.Context, opt *QueryStrategyOptions)) *MockRepository_QueryStrategies_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *QueryStrategyOptions
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].(*QueryStrategyOptions)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_CreateAdminUserIfNotExists_Call) Return(err error) *MockService_CreateAdminUserIfNotExists_Call {
+func (_c *MockRepository_QueryStrategies_Call) Return(err error) *MockRepository_QueryStrategies_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_CreateAdminUserIfNotExists_Call) RunAndReturn(run func(ctx context.Context, username string, password string) error) *MockService_CreateAdminUserIfNotExists_Call {
+func (_c *MockRepository_QueryStrategies_Call) RunAndReturn(run func(ctx context.Context, opt *QueryStrategyOptions) error) *MockRepository_QueryStrategies_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateNewUser provides a mock function for the type MockService
-func (_mock *MockService) CreateNewUser(ctx context.Context, operator *Claims, username string, password string) error {
-	ret := _mock.Called(ctx, operator, username, password)
+// QueryStrategyTemplates provides a mock function for the type MockRepository
+func (_mock *MockRepository) QueryStrategyTemplates(ctx context.Context, opt *QueryStrategyTemplateOptions) error {
+	ret := _mock.Called(ctx, opt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateNewUser")
+		panic("no return value specified for QueryStrategyTemplates")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string, string) error); ok {
-		r0 = returnFunc(ctx, operator, username, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryStrategyTemplateOptions) error); ok {
+		r0 = returnFunc(ctx, opt)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_CreateNewUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNewUser'
-type MockService_CreateNewUser_Call struct {
+// MockRepository_QueryStrategyTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryStrategyTemplates'
+type MockRepository_QueryStrategyTemplates_Call struct {
 	*mock.Call
 }
 
-// CreateNewUser is a helper method to define mock.On call
+// QueryStrategyTemplates is a helper method to define mock.On call
 //   - ctx context.Context
-//   - operator *Claims
-//   - username string
-//   - password string
-func (_e *MockService_Expecter) CreateNewUser(ctx interface{}, operator interface{}, username interface{}, password interface{}) *MockService_CreateNewUser_Call {
-	return &MockService_CreateNewUser_Call{Call: _e.mock.On("CreateNewUser", ctx, operator, username, password)}
+//   - opt *QueryStrategyTemplateOptions
+func (_e *MockRepository_Expecter) QueryStrategyTemplates(ctx interface{}, opt interface{}) *MockRepository_QueryStrategyTemplates_Call {
+	return &MockRepository_QueryStrategyTemplates_Call{Call: _e.mock.On("QueryStrategyTemplates", ctx, opt)}
 }
 
-func (_c *MockService_CreateNewUser_Call) Run(run func(ctx context.Context, operator *Claims, username string, password string)) *MockService_CreateNewUser_Call {
+func (_c *MockRepository_QueryStrategyTemplates_Call) Run(run func(ctx context.Context, opt *QueryStrategyTemplateOptions)) *MockRepository_QueryStrategyTemplates_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *Claims
+		var arg1 *QueryStrategyTemplateOptions
 		if args[1] != nil {
-			arg1 = args[1].(*Claims)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg1 = args[1].(*QueryStrategyTemplateOptions)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_CreateNewUser_Call) Return(err error) *MockService_CreateNewUser_Call {
+func (_c *MockRepository_QueryStrategyTemplates_Call) Return(err error) *MockRepository_QueryStrategyTemplates_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_CreateNewUser_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, username string, password string) error) *MockService_CreateNewUser_Call {
+func (_c *MockRepository_QueryStrategyTemplates_Call) RunAndReturn(run func(ctx context.Context, opt *QueryStrategyTemplateOptions) error) *MockRepository_QueryStrategyTemplates_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateRole provides a mock function for the type MockService
-func (_mock *MockService) CreateRole(ctx context.Context, operator *Claims, role *Role) error {
-	ret := _mock.Called(ctx, operator, role)
+// QueryUsers provides a mock function for the type MockRepository
+func (_mock *MockRepository) QueryUsers(ctx context.Context, opt *QueryUserOptions) error {
+	ret := _mock.Called(ctx, opt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateRole")
+		panic("no return value specified for QueryUsers")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, *Role) error); ok {
-		r0 = returnFunc(ctx, operator, role)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryUserOptions) error); ok {
+		r0 = returnFunc(ctx, opt)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_CreateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRole'
-type MockService_CreateRole_Call struct {
+// MockRepository_QueryUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryUsers'
+type MockRepository_QueryUsers_Call struct {
 	*mock.Call
 }
 
-// CreateRole is a helper method to define mock.On call
+// QueryUsers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - operator *Claims
-//   - role *Role
-func (_e *MockService_Expecter) CreateRole(ctx interface{}, operator interface{}, role interface{}) *MockService_CreateRole_Call {
-	return &MockService_CreateRole_Call{Call: _e.mock.On("CreateRole", ctx, operator, role)}
+//   - opt *QueryUserOptions
+func (_e *MockRepository_Expecter) QueryUsers(ctx interface{}, opt interface{}) *MockRepository_QueryUsers_Call {
+	return &MockRepository_QueryUsers_Call{Call: _e.mock.On("QueryUsers", ctx, opt)}
 }
 
-func (_c *MockService_CreateRole_Call) Run(run func(ctx context.Context, operator *Claims, role *Role)) *MockService_CreateRole_Call {
+func (_c *MockRepository_QueryUsers_Call) Run(run func(ctx context.Context, opt *QueryUserOptions)) *MockRepository_QueryUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *Claims
+		var arg1 *QueryUserOptions
 		if args[1] != nil {
-			arg1 = args[1].(*Claims)
-		}
-		var arg2 *Role
-		if args[2] != nil {
-			arg2 = args[2].(*Role)
+			arg1 = args[1].(*QueryUserOptions)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_CreateRole_Call) Return(err error) *MockService_CreateRole_Call {
+func (_c *MockRepository_QueryUsers_Call) Return(err error) *MockRepository_QueryUsers_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_CreateRole_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, role *Role) error) *MockService_CreateRole_Call {
+func (_c *MockRepository_QueryUsers_Call) RunAndReturn(run func(ctx context.Context, opt *QueryUserOptions) error) *MockRepository_QueryUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateScheduleStrategy provides a mock function for the type MockService
-func (_mock *MockService) CreateScheduleStrategy(ctx context.Context, operator *Claims, strategy *ScheduleStrategy) error {
-	ret := _mock.Called(ctx, operator, strategy)
+// UpdateIntents provides a mock function for the type MockRepository
+func (_mock *MockRepository) UpdateIntents(ctx context.Context, intents []*ScheduleIntent) error {
+	ret := _mock.Called(ctx, intents)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateScheduleStrategy")
+		panic("no return value specified for UpdateIntents")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, *ScheduleStrategy) error); ok {
-		r0 = returnFunc(ctx, operator, strategy)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*ScheduleIntent) error); ok {
+		r0 = returnFunc(ctx, intents)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_CreateScheduleStrategy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateScheduleStrategy'
-type MockService_CreateScheduleStrategy_Call struct {
+// MockRepository_UpdateIntents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateIntents'
+type MockRepository_UpdateIntents_Call struct {
 	*mock.Call
 }
 
-// CreateScheduleStrategy is a helper method to define mock.On call
+// UpdateIntents is a helper method to define mock.On call
 //   - ctx context.Context
-//   - operator *Claims
-//   - strategy *ScheduleStrategy
-func (_e *MockService_Expecter) CreateScheduleStrategy(ctx interface{}, operator interface{}, strategy interface{}) *MockService_CreateScheduleStrategy_Call {
-	return &MockService_CreateScheduleStrategy_Call{Call: _e.mock.On("CreateScheduleStrategy", ctx, operator, strategy)}
+//   - intents []*ScheduleIntent
+func (_e *MockRepository_Expecter) UpdateIntents(ctx interface{}, intents interface{}) *MockRepository_UpdateIntents_Call {
+	return &MockRepository_UpdateIntents_Call{Call: _e.mock.On("UpdateIntents", ctx, intents)}
 }
 
-func (_c *MockService_CreateScheduleStrategy_Call) Run(run func(ctx context.Context, operator *Claims, strategy *ScheduleStrategy)) *MockService_CreateScheduleStrategy_Call {
+func (_c *MockRepository_UpdateIntents_Call) Run(run func(ctx context.Context, intents []*ScheduleIntent)) *MockRepository_UpdateIntents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *Claims
+		var arg1 []*ScheduleIntent
 		if args[1] != nil {
-			arg1 = args[1].(*Claims)
-		}
-		var arg2 *ScheduleStrategy
-		if args[2] != nil {
-			arg2 = args[2].(*ScheduleStrategy)
+			arg1 = args[1].([]*ScheduleIntent)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_CreateScheduleStrategy_Call) Return(err error) *MockService_CreateScheduleStrategy_Call {
+func (_c *MockRepository_UpdateIntents_Call) Return(err error) *MockRepository_UpdateIntents_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_CreateScheduleStrategy_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, strategy *ScheduleStrategy) error) *MockService_CreateScheduleStrategy_Call {
+func (_c *MockRepository_UpdateIntents_Call) RunAndReturn(run func(ctx context.Context, intents []*ScheduleIntent) error) *MockRepository_UpdateIntents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteRole provides a mock function for the type MockService
-func (_mock *MockService) DeleteRole(ctx context.Context, operator *Claims, roleID string) error {
-	ret := _mock.Called(ctx, operator, roleID)
+// UpdatePermission provides a mock function for the type MockRepository
+func (_mock *MockRepository) UpdatePermission(ctx context.Context, permission *Permission) error {
+	ret := _mock.Called(ctx, permission)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteRole")
+		panic("no return value specified for UpdatePermission")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string) error); ok {
-		r0 = returnFunc(ctx, operator, roleID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Permission) error); ok {
+		r0 = returnFunc(ctx, permission)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_DeleteRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRole'
-type MockService_DeleteRole_Call struct {
+// MockRepository_UpdatePermission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePermission'
+type MockRepository_UpdatePermission_Call struct {
 	*mock.Call
 }
 
-// DeleteRole is a helper method to define mock.On call
+// UpdatePermission is a helper method to define mock.On call
 //   - ctx context.Context
-//   - operator *Claims
-//   - roleID string
-func (_e *MockService_Expecter) DeleteRole(ctx interface{}, operator interface{}, roleID interface{}) *MockService_DeleteRole_Call {
-	return &MockService_DeleteRole_Call{Call: _e.mock.On("DeleteRole", ctx, operator, roleID)}
+//   - permission *Permission
+func (_e *MockRepository_Expecter) UpdatePermission(ctx interface{}, permission interface{}) *MockRepository_UpdatePermission_Call {
+	return &MockRepository_UpdatePermission_Call{Call: _e.mock.On("UpdatePermission", ctx, permission)}
 }
 
-func (_c *MockService_DeleteRole_Call) Run(run func(ctx context.Context, operator *Claims, roleID string)) *MockService_DeleteRole_Call {
+func (_c *MockRepository_UpdatePermission_Call) Run(run func(ctx context.Context, permission *Permission)) *MockRepository_UpdatePermission_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *Claims
+		var arg1 *Permission
 		if args[1] != nil {
-			arg1 = args[1].(*Claims)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].(*Permission)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_DeleteRole_Call) Return(err error) *MockService_DeleteRole_Call {
+func (_c *MockRepository_UpdatePermission_Call) Return(err error) *MockRepository_UpdatePermission_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_DeleteRole_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, roleID string) error) *MockService_DeleteRole_Call {
+func (_c *MockRepository_UpdatePermission_Call) RunAndReturn(run func(ctx context.Context, permission *Permission) error) *MockRepository_UpdatePermission_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListScheduleIntents provides a mock function for the type MockService
-func (_mock *MockService) ListScheduleIntents(ctx context.Context, filterOpts *QueryIntentOptions) error {
-	ret := _mock.Called(ctx, filterOpts)
+// UpdateRole provides a mock function for the type MockRepository
+func (_mock *MockRepository) UpdateRole(ctx context.Context, role *Role) error {
+	ret := _mock.Called(ctx, role)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListScheduleIntents")
+		panic("no return value specified for UpdateRole")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryIntentOptions) error); ok {
-		r0 = returnFunc(ctx, filterOpts)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Role) error); ok {
+		r0 = returnFunc(ctx, role)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_ListScheduleIntents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListScheduleIntents'
-type MockService_ListScheduleIntents_Call struct {
+// MockRepository_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
+type MockRepository_UpdateRole_Call struct {
 	*mock.Call
 }
 
-// ListScheduleIntents is a helper method to define mock.On call
+// UpdateRole is a helper method to define mock.On call
 //   - ctx context.Context
-//   - filterOpts *QueryIntentOptions
-func (_e *MockService_Expecter) ListScheduleIntents(ctx interface{}, filterOpts interface{}) *MockService_ListScheduleIntents_Call {
-	return &MockService_ListScheduleIntents_Call{Call: _e.mock.On("ListScheduleIntents", ctx, filterOpts)}
+//   - role *Role
+func (_e *MockRepository_Expecter) UpdateRole(ctx interface{}, role interface{}) *MockRepository_UpdateRole_Call {
+	return &MockRepository_UpdateRole_Call{Call: _e.mock.On("UpdateRole", ctx, role)}
 }
 
-func (_c *MockService_ListScheduleIntents_Call) Run(run func(ctx context.Context, filterOpts *QueryIntentOptions)) *MockService_ListScheduleIntents_Call {
+func (_c *MockRepository_UpdateRole_Call) Run(run func(ctx context.Context, role *Role)) *MockRepository_UpdateRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *QueryIntentOptions
+		var arg1 *Role
 		if args[1] != nil {
-			arg1 = args[1].(*QueryIntentOptions)
+			arg1 = args[1].(*Role)
 		}
 		run(
 			arg0,
@@ -1369,54 +1414,54 @@ func (_c *MockService_ListScheduleIntents_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *MockService_ListScheduleIntents_Call) Return(err error) *MockService_ListScheduleIntents_Call {
+func (_c *MockRepository_UpdateRole_Call) Return(err error) *MockRepository_UpdateRole_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_ListScheduleIntents_Call) RunAndReturn(run func(ctx context.Context, filterOpts *QueryIntentOptions) error) *MockService_ListScheduleIntents_Call {
+func (_c *MockRepository_UpdateRole_Call) RunAndReturn(run func(ctx context.Context, role *Role) error) *MockRepository_UpdateRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListScheduleStrategies provides a mock function for the type MockService
-func (_mock *MockService) ListScheduleStrategies(ctx context.Context, filterOpts *QueryStrategyOptions) error {
-	ret := _mock.Called(ctx, filterOpts)
+// UpdateStrategy provides a mock function for the type MockRepository
+func (_mock *MockRepository) UpdateStrategy(ctx context.Context, strategy *ScheduleStrategy) error {
+	ret := _mock.Called(ctx, strategy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListScheduleStrategies")
+		panic("no return value specified for UpdateStrategy")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryStrategyOptions) error); ok {
-		r0 = returnFunc(ctx, filterOpts)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *ScheduleStrategy) error); ok {
+		r0 = returnFunc(ctx, strategy)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_ListScheduleStrategies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListScheduleStrategies'
-type MockService_ListScheduleStrategies_Call struct {
+// MockRepository_UpdateStrategy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStrategy'
+type MockRepository_UpdateStrategy_Call struct {
 	*mock.Call
 }
 
-// ListScheduleStrategies is a helper method to define mock.On call
+// UpdateStrategy is a helper method to define mock.On call
 //   - ctx context.Context
-//   - filterOpts *QueryStrategyOptions
-func (_e *MockService_Expecter) ListScheduleStrategies(ctx interface{}, filterOpts interface{}) *MockService_ListScheduleStrategies_Call {
-	return &MockService_ListScheduleStrategies_Call{Call: _e.mock.On("ListScheduleStrategies", ctx, filterOpts)}
+//   - strategy *ScheduleStrategy
+func (_e *MockRepository_Expecter) UpdateStrategy(ctx interface{}, strategy interface{}) *MockRepository_UpdateStrategy_Call {
+	return &MockRepository_UpdateStrategy_Call{Call: _e.mock.On("UpdateStrategy", ctx, strategy)}
 }
 
-func (_c *MockService_ListScheduleStrategies_Call) Run(run func(ctx context.Context, filterOpts *QueryStrategyOptions)) *MockService_ListScheduleStrategies_Call {
+func (_c *MockRepository_UpdateStrategy_Call) Run(run func(ctx context.Context, strategy *ScheduleStrategy)) *MockRepository_UpdateStrategy_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *QueryStrategyOptions
+		var arg1 *ScheduleStrategy
 		if args[1] != nil {
-			arg1 = args[1].(*QueryStrategyOptions)
+			arg1 = args[1].(*ScheduleStrategy)
 		}
 		run(
 			arg0,
@@ -1426,126 +1471,111 @@ func (_c *MockService_ListScheduleStrategies_Call) Run(run func(ctx context.Cont
 	return _c
 }
 
-func (_c *MockService_ListScheduleStrategies_Call) Return(err error) *MockService_ListScheduleStrategies_Call {
+func (_c *MockRepository_UpdateStrategy_Call) Return(err error) *MockRepository_UpdateStrategy_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_ListScheduleStrategies_Call) RunAndReturn(run func(ctx context.Context, filterOpts *QueryStrategyOptions) error) *MockService_ListScheduleStrategies_Call {
+func (_c *MockRepository_UpdateStrategy_Call) RunAndReturn(run func(ctx context.Context, strategy *ScheduleStrategy) error) *MockRepository_UpdateStrategy_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Login provides a mock function for the type MockService
-func (_mock *MockService) Login(ctx context.Context, email string, password string) (string, error) {
-	ret := _mock.Called(ctx, email, password)
+// UpdateStrategyTemplate provides a mock function for the type MockRepository
+func (_mock *MockRepository) UpdateStrategyTemplate(ctx context.Context, template *StrategyTemplate) error {
+	ret := _mock.Called(ctx, template)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Login")
+		panic("no return value specified for UpdateStrategyTemplate")
 	}
 
-	var r0 string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return returnFunc(ctx, email, password)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = returnFunc(ctx, email, password)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, email, password)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *StrategyTemplate) error); ok {
+		r0 = returnFunc(ctx, template)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockService_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
-type MockService_Login_Call struct {
+// MockRepository_UpdateStrategyTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStrategyTemplate'
+type MockRepository_UpdateStrategyTemplate_Call struct {
 	*mock.Call
 }
 
-// Login is a helper method to define mock.On call
+// UpdateStrategyTemplate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - email string
-//   - password string
-func (_e *MockService_Expecter) Login(ctx interface{}, email interface{}, password interface{}) *MockService_Login_Call {
-	return &MockService_Login_Call{Call: _e.mock.On("Login", ctx, email, password)}
+//   - template *StrategyTemplate
+func (_e *MockRepository_Expecter) UpdateStrategyTemplate(ctx interface{}, template interface{}) *MockRepository_UpdateStrategyTemplate_Call {
+	return &MockRepository_UpdateStrategyTemplate_Call{Call: _e.mock.On("UpdateStrategyTemplate", ctx, template)}
 }
 
-func (_c *MockService_Login_Call) Run(run func(ctx context.Context, email string, password string)) *MockService_Login_Call {
+func (_c *MockRepository_UpdateStrategyTemplate_Call) Run(run func(ctx context.Context, template *StrategyTemplate)) *MockRepository_UpdateStrategyTemplate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *StrategyTemplate
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].(*StrategyTemplate)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_Login_Call) Return(token string, err error) *MockService_Login_Call {
-	_c.Call.Return(token, err)
+func (_c *MockRepository_UpdateStrategyTemplate_Call) Return(err error) *MockRepository_UpdateStrategyTemplate_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_Login_Call) RunAndReturn(run func(ctx context.Context, email string, password string) (string, error)) *MockService_Login_Call {
+func (_c *MockRepository_UpdateStrategyTemplate_Call) RunAndReturn(run func(ctx context.Context, template *StrategyTemplate) error) *MockRepository_UpdateStrategyTemplate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryPermissions provides a mock function for the type MockService
-func (_mock *MockService) QueryPermissions(ctx context.Context, opt *QueryPermissionOptions) error {
-	ret := _mock.Called(ctx, opt)
+// UpdateUser provides a mock function for the type MockRepository
+func (_mock *MockRepository) UpdateUser(ctx context.Context, user *User) error {
+	ret := _mock.Called(ctx, user)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryPermissions")
+		panic("no return value specified for UpdateUser")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryPermissionOptions) error); ok {
-		r0 = returnFunc(ctx, opt)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *User) error); ok {
+		r0 = returnFunc(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_QueryPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryPermissions'
-type MockService_QueryPermissions_Call struct {
+// MockRepository_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
+type MockRepository_UpdateUser_Call struct {
 	*mock.Call
 }
 
-// QueryPermissions is a helper method to define mock.On call
+// UpdateUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - opt *QueryPermissionOptions
-func (_e *MockService_Expecter) QueryPermissions(ctx interface{}, opt interface{}) *MockService_QueryPermissions_Call {
-	return &MockService_QueryPermissions_Call{Call: _e.mock.On("QueryPermissions", ctx, opt)}
+//   - user *User
+func (_e *MockRepository_Expecter) UpdateUser(ctx interface{}, user interface{}) *MockRepository_UpdateUser_Call {
+	return &MockRepository_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, user)}
 }
 
-func (_c *MockService_QueryPermissions_Call) Run(run func(ctx context.Context, opt *QueryPermissionOptions)) *MockService_QueryPermissions_Call {
+func (_c *MockRepository_UpdateUser_Call) Run(run func(ctx context.Context, user *User)) *MockRepository_UpdateUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *QueryPermissionOptions
+		var arg1 *User
 		if args[1] != nil {
-			arg1 = args[1].(*QueryPermissionOptions)
+			arg1 = args[1].(*User)
 		}
 		run(
 			arg0,
@@ -1555,231 +1585,287 @@ func (_c *MockService_QueryPermissions_Call) Run(run func(ctx context.Context, o
 	return _c
 }
 
-func (_c *MockService_QueryPermissions_Call) Return(err error) *MockService_QueryPermissions_Call {
+func (_c *MockRepository_UpdateUser_Call) Return(err error) *MockRepository_UpdateUser_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_QueryPermissions_Call) RunAndReturn(run func(ctx context.Context, opt *QueryPermissionOptions) error) *MockService_QueryPermissions_Call {
+func (_c *MockRepository_UpdateUser_Call) RunAndReturn(run func(ctx context.Context, user *User) error) *MockRepository_UpdateUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryRoles provides a mock function for the type MockService
-func (_mock *MockService) QueryRoles(ctx context.Context, opt *QueryRoleOptions) error {
-	ret := _mock.Called(ctx, opt)
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+type MockService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockService) EXPECT() *MockService_Expecter {
+	return &MockService_Expecter{mock: &_m.Mock}
+}
+
+// ApplyStrategyBundle provides a mock function for the type MockService
+func (_mock *MockService) ApplyStrategyBundle(ctx context.Context, operator *Claims, bundle *StrategyBundle, opts ApplyBundleOptions) ([]*StrategyChange, error) {
+	ret := _mock.Called(ctx, operator, bundle, opts)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryRoles")
+		panic("no return value specified for ApplyStrategyBundle")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryRoleOptions) error); ok {
-		r0 = returnFunc(ctx, opt)
+	var r0 []*StrategyChange
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, *StrategyBundle, ApplyBundleOptions) ([]*StrategyChange, error)); ok {
+		return returnFunc(ctx, operator, bundle, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, *StrategyBundle, ApplyBundleOptions) []*StrategyChange); ok {
+		r0 = returnFunc(ctx, operator, bundle, opts)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*StrategyChange)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *Claims, *StrategyBundle, ApplyBundleOptions) error); ok {
+		r1 = returnFunc(ctx, operator, bundle, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockService_QueryRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryRoles'
-type MockService_QueryRoles_Call struct {
+// MockService_ApplyStrategyBundle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyStrategyBundle'
+type MockService_ApplyStrategyBundle_Call struct {
 	*mock.Call
 }
 
-// QueryRoles is a helper method to define mock.On call
+// ApplyStrategyBundle is a helper method to define mock.On call
 //   - ctx context.Context
-//   - opt *QueryRoleOptions
-func (_e *MockService_Expecter) QueryRoles(ctx interface{}, opt interface{}) *MockService_QueryRoles_Call {
-	return &MockService_QueryRoles_Call{Call: _e.mock.On("QueryRoles", ctx, opt)}
+//   - operator *Claims
+//   - bundle *StrategyBundle
+//   - opts ApplyBundleOptions
+func (_e *MockService_Expecter) ApplyStrategyBundle(ctx interface{}, operator interface{}, bundle interface{}, opts interface{}) *MockService_ApplyStrategyBundle_Call {
+	return &MockService_ApplyStrategyBundle_Call{Call: _e.mock.On("ApplyStrategyBundle", ctx, operator, bundle, opts)}
 }
 
-func (_c *MockService_QueryRoles_Call) Run(run func(ctx context.Context, opt *QueryRoleOptions)) *MockService_QueryRoles_Call {
+func (_c *MockService_ApplyStrategyBundle_Call) Run(run func(ctx context.Context, operator *Claims, bundle *StrategyBundle, opts ApplyBundleOptions)) *MockService_ApplyStrategyBundle_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *QueryRoleOptions
+		var arg1 *Claims
 		if args[1] != nil {
-			arg1 = args[1].(*QueryRoleOptions)
+			arg1 = args[1].(*Claims)
+		}
+		var arg2 *StrategyBundle
+		if args[2] != nil {
+			arg2 = args[2].(*StrategyBundle)
+		}
+		var arg3 ApplyBundleOptions
+		if args[3] != nil {
+			arg3 = args[3].(ApplyBundleOptions)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_QueryRoles_Call) Return(err error) *MockService_QueryRoles_Call {
-	_c.Call.Return(err)
+func (_c *MockService_ApplyStrategyBundle_Call) Return(strategyChanges []*StrategyChange, err error) *MockService_ApplyStrategyBundle_Call {
+	_c.Call.Return(strategyChanges, err)
 	return _c
 }
 
-func (_c *MockService_QueryRoles_Call) RunAndReturn(run func(ctx context.Context, opt *QueryRoleOptions) error) *MockService_QueryRoles_Call {
+func (_c *MockService_ApplyStrategyBundle_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, bundle *StrategyBundle, opts ApplyBundleOptions) ([]*StrategyChange, error)) *MockService_ApplyStrategyBundle_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryUsers provides a mock function for the type MockService
-func (_mock *MockService) QueryUsers(ctx context.Context, opt *QueryUserOptions) error {
-	ret := _mock.Called(ctx, opt)
+// ChangePassword provides a mock function for the type MockService
+func (_mock *MockService) ChangePassword(ctx context.Context, user *Claims, oldPassword string, newPassword string) error {
+	ret := _mock.Called(ctx, user, oldPassword, newPassword)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryUsers")
+		panic("no return value specified for ChangePassword")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryUserOptions) error); ok {
-		r0 = returnFunc(ctx, opt)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string, string) error); ok {
+		r0 = returnFunc(ctx, user, oldPassword, newPassword)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_QueryUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryUsers'
-type MockService_QueryUsers_Call struct {
+// MockService_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type MockService_ChangePassword_Call struct {
 	*mock.Call
 }
 
-// QueryUsers is a helper method to define mock.On call
+// ChangePassword is a helper method to define mock.On call
 //   - ctx context.Context
-//   - opt *QueryUserOptions
-func (_e *MockService_Expecter) QueryUsers(ctx interface{}, opt interface{}) *MockService_QueryUsers_Call {
-	return &MockService_QueryUsers_Call{Call: _e.mock.On("QueryUsers", ctx, opt)}
+//   - user *Claims
+//   - oldPassword string
+//   - newPassword string
+func (_e *MockService_Expecter) ChangePassword(ctx interface{}, user interface{}, oldPassword interface{}, newPassword interface{}) *MockService_ChangePassword_Call {
+	return &MockService_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, user, oldPassword, newPassword)}
 }
 
-func (_c *MockService_QueryUsers_Call) Run(run func(ctx context.Context, opt *QueryUserOptions)) *MockService_QueryUsers_Call {
+func (_c *MockService_ChangePassword_Call) Run(run func(ctx context.Context, user *Claims, oldPassword string, newPassword string)) *MockService_ChangePassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *QueryUserOptions
+		var arg1 *Claims
 		if args[1] != nil {
-			arg1 = args[1].(*QueryUserOptions)
+			arg1 = args[1].(*Claims)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_QueryUsers_Call) Return(err error) *MockService_QueryUsers_Call {
+func (_c *MockService_ChangePassword_Call) Return(err error) *MockService_ChangePassword_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_QueryUsers_Call) RunAndReturn(run func(ctx context.Context, opt *QueryUserOptions) error) *MockService_QueryUsers_Call {
+func (_c *MockService_ChangePassword_Call) RunAndReturn(run func(ctx context.Context, user *Claims, oldPassword string, newPassword string) error) *MockService_ChangePassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ResetPassword provides a mock function for the type MockService
-func (_mock *MockService) ResetPassword(ctx context.Context, operator *Claims, id string, newPassword string) error {
-	ret := _mock.Called(ctx, operator, id, newPassword)
+// CreateAdminUserIfNotExists provides a mock function for the type MockService
+func (_mock *MockService) CreateAdminUserIfNotExists(ctx context.Context, username string, password string) error {
+	ret := _mock.Called(ctx, username, password)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ResetPassword")
+		panic("no return value specified for CreateAdminUserIfNotExists")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string, string) error); ok {
-		r0 = returnFunc(ctx, operator, id, newPassword)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, username, password)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
-type MockService_ResetPassword_Call struct {
+// MockService_CreateAdminUserIfNotExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAdminUserIfNotExists'
+type MockService_CreateAdminUserIfNotExists_Call struct {
 	*mock.Call
 }
 
-// ResetPassword is a helper method to define mock.On call
+// CreateAdminUserIfNotExists is a helper method to define mock.On call
 //   - ctx context.Context
-//   - operator *Claims
-//   - id string
-//   - newPassword string
-func (_e *MockService_Expecter) ResetPassword(ctx interface{}, operator interface{}, id interface{}, newPassword interface{}) *MockService_ResetPassword_Call {
-	return &MockService_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, operator, id, newPassword)}
+//   - username string
+//   - password string
+func (_e *MockService_Expecter) CreateAdminUserIfNotExists(ctx interface{}, username interface{}, password interface{}) *MockService_CreateAdminUserIfNotExists_Call {
+	return &MockService_CreateAdminUserIfNotExists_Call{Call: _e.mock.On("CreateAdminUserIfNotExists", ctx, username, password)}
 }
 
-func (_c *MockService_ResetPassword_Call) Run(run func(ctx context.Context, operator *Claims, id string, newPassword string)) *MockService_ResetPassword_Call {
+func (_c *MockService_CreateAdminUserIfNotExists_Call) Run(run func(ctx context.Context, username string, password string)) *MockService_CreateAdminUserIfNotExists_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *Claims
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*Claims)
+			arg1 = args[1].(string)
 		}
 		var arg2 string
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_ResetPassword_Call) Return(err error) *MockService_ResetPassword_Call {
+func (_c *MockService_CreateAdminUserIfNotExists_Call) Return(err error) *MockService_CreateAdminUserIfNotExists_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_ResetPassword_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, id string, newPassword string) error) *MockService_ResetPassword_Call {
+func (_c *MockService_CreateAdminUserIfNotExists_Call) RunAndReturn(run func(ctx context.Context, username string, password string) error) *MockService_CreateAdminUserIfNotExists_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateRole provides a mock function for the type MockService
-func (_mock *MockService) UpdateRole(ctx context.Context, operator *Claims, roleID string, opt UpdateRoleOptions) error {
-	ret := _mock.Called(ctx, operator, roleID, opt)
+// CreateNewUser provides a mock function for the type MockService
+func (_mock *MockService) CreateNewUser(ctx context.Context, operator *Claims, username string, password string) error {
+	ret := _mock.Called(ctx, operator, username, password)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateRole")
+		panic("no return value specified for CreateNewUser")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string, UpdateRoleOptions) error); ok {
-		r0 = returnFunc(ctx, operator, roleID, opt)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string, string) error); ok {
+		r0 = returnFunc(ctx, operator, username, password)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
-type MockService_UpdateRole_Call struct {
+// MockService_CreateNewUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNewUser'
+type MockService_CreateNewUser_Call struct {
 	*mock.Call
 }
 
-// UpdateRole is a helper method to define mock.On call
+// CreateNewUser is a helper method to define mock.On call
 //   - ctx context.Context
 //   - operator *Claims
-//   - roleID string
-//   - opt UpdateRoleOptions
-func (_e *MockService_Expecter) UpdateRole(ctx interface{}, operator interface{}, roleID interface{}, opt interface{}) *MockService_UpdateRole_Call {
-	return &MockService_UpdateRole_Call{Call: _e.mock.On("UpdateRole", ctx, operator, roleID, opt)}
+//   - username string
+//   - password string
+func (_e *MockService_Expecter) CreateNewUser(ctx interface{}, operator interface{}, username interface{}, password interface{}) *MockService_CreateNewUser_Call {
+	return &MockService_CreateNewUser_Call{Call: _e.mock.On("CreateNewUser", ctx, operator, username, password)}
 }
 
-func (_c *MockService_UpdateRole_Call) Run(run func(ctx context.Context, operator *Claims, roleID string, opt UpdateRoleOptions)) *MockService_UpdateRole_Call {
+func (_c *MockService_CreateNewUser_Call) Run(run func(ctx context.Context, operator *Claims, username string, password string)) *MockService_CreateNewUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1793,9 +1879,9 @@ func (_c *MockService_UpdateRole_Call) Run(run func(ctx context.Context, operato
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 UpdateRoleOptions
+		var arg3 string
 		if args[3] != nil {
-			arg3 = args[3].(UpdateRoleOptions)
+			arg3 = args[3].(string)
 		}
 		run(
 			arg0,
@@ -1807,48 +1893,47 @@ func (_c *MockService_UpdateRole_Call) Run(run func(ctx context.Context, operato
 	return _c
 }
 
-func (_c *MockService_UpdateRole_Call) Return(err error) *MockService_UpdateRole_Call {
+func (_c *MockService_CreateNewUser_Call) Return(err error) *MockService_CreateNewUser_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_UpdateRole_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, roleID string, opt UpdateRoleOptions) error) *MockService_UpdateRole_Call {
+func (_c *MockService_CreateNewUser_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, username string, password string) error) *MockService_CreateNewUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateUserPermissions provides a mock function for the type MockService
-func (_mock *MockService) UpdateUserPermissions(ctx context.Context, operator *Claims, id string, opt UpdateUserPermissionsOptions) error {
-	ret := _mock.Called(ctx, operator, id, opt)
+// CreateRole provides a mock function for the type MockService
+func (_mock *MockService) CreateRole(ctx context.Context, operator *Claims, role *Role) error {
+	ret := _mock.Called(ctx, operator, role)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateUserPermissions")
+		panic("no return value specified for CreateRole")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string, UpdateUserPermissionsOptions) error); ok {
-		r0 = returnFunc(ctx, operator, id, opt)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, *Role) error); ok {
+		r0 = returnFunc(ctx, operator, role)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_UpdateUserPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserPermissions'
-type MockService_UpdateUserPermissions_Call struct {
+// MockService_CreateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRole'
+type MockService_CreateRole_Call struct {
 	*mock.Call
 }
 
-// UpdateUserPermissions is a helper method to define mock.On call
+// CreateRole is a helper method to define mock.On call
 //   - ctx context.Context
 //   - operator *Claims
-//   - id string
-//   - opt UpdateUserPermissionsOptions
-func (_e *MockService_Expecter) UpdateUserPermissions(ctx interface{}, operator interface{}, id interface{}, opt interface{}) *MockService_UpdateUserPermissions_Call {
-	return &MockService_UpdateUserPermissions_Call{Call: _e.mock.On("UpdateUserPermissions", ctx, operator, id, opt)}
+//   - role *Role
+func (_e *MockService_Expecter) CreateRole(ctx interface{}, operator interface{}, role interface{}) *MockService_CreateRole_Call {
+	return &MockService_CreateRole_Call{Call: _e.mock.On("CreateRole", ctx, operator, role)}
 }
 
-func (_c *MockService_UpdateUserPermissions_Call) Run(run func(ctx context.Context, operator *Claims, id string, opt UpdateUserPermissionsOptions)) *MockService_UpdateUserPermissions_Call {
+func (_c *MockService_CreateRole_Call) Run(run func(ctx context.Context, operator *Claims, role *Role)) *MockService_CreateRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1858,92 +1943,83 @@ func (_c *MockService_UpdateUserPermissions_Call) Run(run func(ctx context.Conte
 		if args[1] != nil {
 			arg1 = args[1].(*Claims)
 		}
-		var arg2 string
+		var arg2 *Role
 		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 UpdateUserPermissionsOptions
-		if args[3] != nil {
-			arg3 = args[3].(UpdateUserPermissionsOptions)
+			arg2 = args[2].(*Role)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_UpdateUserPermissions_Call) Return(err error) *MockService_UpdateUserPermissions_Call {
+func (_c *MockService_CreateRole_Call) Return(err error) *MockService_CreateRole_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_UpdateUserPermissions_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, id string, opt UpdateUserPermissionsOptions) error) *MockService_UpdateUserPermissions_Call {
+func (_c *MockService_CreateRole_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, role *Role) error) *MockService_CreateRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// VerifyJWTToken provides a mock function for the type MockService
-func (_mock *MockService) VerifyJWTToken(ctx context.Context, tokenString string, permissionKey PermissionKey) (Claims, RolePolicy, error) {
-	ret := _mock.Called(ctx, tokenString, permissionKey)
+// CreateScheduleStrategy provides a mock function for the type MockService
+func (_mock *MockService) CreateScheduleStrategy(ctx context.Context, operator *Claims, strategy *ScheduleStrategy) ([]*StrategyConflict, error) {
+	ret := _mock.Called(ctx, operator, strategy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for VerifyJWTToken")
+		panic("no return value specified for CreateScheduleStrategy")
 	}
 
-	var r0 Claims
-	var r1 RolePolicy
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, PermissionKey) (Claims, RolePolicy, error)); ok {
-		return returnFunc(ctx, tokenString, permissionKey)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, PermissionKey) Claims); ok {
-		r0 = returnFunc(ctx, tokenString, permissionKey)
-	} else {
-		r0 = ret.Get(0).(Claims)
+	var r0 []*StrategyConflict
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, *ScheduleStrategy) ([]*StrategyConflict, error)); ok {
+		return returnFunc(ctx, operator, strategy)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, PermissionKey) RolePolicy); ok {
-		r1 = returnFunc(ctx, tokenString, permissionKey)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, *ScheduleStrategy) []*StrategyConflict); ok {
+		r0 = returnFunc(ctx, operator, strategy)
 	} else {
-		r1 = ret.Get(1).(RolePolicy)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*StrategyConflict)
+		}
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string, PermissionKey) error); ok {
-		r2 = returnFunc(ctx, tokenString, permissionKey)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *Claims, *ScheduleStrategy) error); ok {
+		r1 = returnFunc(ctx, operator, strategy)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockService_VerifyJWTToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyJWTToken'
-type MockService_VerifyJWTToken_Call struct {
+// MockService_CreateScheduleStrategy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateScheduleStrategy'
+type MockService_CreateScheduleStrategy_Call struct {
 	*mock.Call
 }
 
-// VerifyJWTToken is a helper method to define mock.On call
+// CreateScheduleStrategy is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tokenString string
-//   - permissionKey PermissionKey
-func (_e *MockService_Expecter) VerifyJWTToken(ctx interface{}, tokenString interface{}, permissionKey interface{}) *MockService_VerifyJWTToken_Call {
-	return &MockService_VerifyJWTToken_Call{Call: _e.mock.On("VerifyJWTToken", ctx, tokenString, permissionKey)}
+//   - operator *Claims
+//   - strategy *ScheduleStrategy
+func (_e *MockService_Expecter) CreateScheduleStrategy(ctx interface{}, operator interface{}, strategy interface{}) *MockService_CreateScheduleStrategy_Call {
+	return &MockService_CreateScheduleStrategy_Call{Call: _e.mock.On("CreateScheduleStrategy", ctx, operator, strategy)}
 }
 
-func (_c *MockService_VerifyJWTToken_Call) Run(run func(ctx context.Context, tokenString string, permissionKey PermissionKey)) *MockService_VerifyJWTToken_Call {
+func (_c *MockService_CreateScheduleStrategy_Call) Run(run func(ctx context.Context, operator *Claims, strategy *ScheduleStrategy)) *MockService_CreateScheduleStrategy_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *Claims
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*Claims)
 		}
-		var arg2 PermissionKey
+		var arg2 *ScheduleStrategy
 		if args[2] != nil {
-			arg2 = args[2].(PermissionKey)
+			arg2 = args[2].(*ScheduleStrategy)
 		}
 		run(
 			arg0,
@@ -1954,175 +2030,2132 @@ func (_c *MockService_VerifyJWTToken_Call) Run(run func(ctx context.Context, tok
 	return _c
 }
 
-func (_c *MockService_VerifyJWTToken_Call) Return(claims Claims, rolePolicy RolePolicy, err error) *MockService_VerifyJWTToken_Call {
-	_c.Call.Return(claims, rolePolicy, err)
+func (_c *MockService_CreateScheduleStrategy_Call) Return(strategyConflicts []*StrategyConflict, err error) *MockService_CreateScheduleStrategy_Call {
+	_c.Call.Return(strategyConflicts, err)
 	return _c
 }
 
-func (_c *MockService_VerifyJWTToken_Call) RunAndReturn(run func(ctx context.Context, tokenString string, permissionKey PermissionKey) (Claims, RolePolicy, error)) *MockService_VerifyJWTToken_Call {
+func (_c *MockService_CreateScheduleStrategy_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, strategy *ScheduleStrategy) ([]*StrategyConflict, error)) *MockService_CreateScheduleStrategy_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockK8SAdapter creates a new instance of MockK8SAdapter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockK8SAdapter(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockK8SAdapter {
-	mock := &MockK8SAdapter{}
-	mock.Mock.Test(t)
+// CreateStrategyTemplate provides a mock function for the type MockService
+func (_mock *MockService) CreateStrategyTemplate(ctx context.Context, operator *Claims, template *StrategyTemplate) error {
+	ret := _mock.Called(ctx, operator, template)
 
-	t.Cleanup(func() { mock.AssertExpectations(t) })
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStrategyTemplate")
+	}
 
-	return mock
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, *StrategyTemplate) error); ok {
+		r0 = returnFunc(ctx, operator, template)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
 }
 
-// MockK8SAdapter is an autogenerated mock type for the K8SAdapter type
-type MockK8SAdapter struct {
-	mock.Mock
+// MockService_CreateStrategyTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStrategyTemplate'
+type MockService_CreateStrategyTemplate_Call struct {
+	*mock.Call
 }
 
-type MockK8SAdapter_Expecter struct {
-	mock *mock.Mock
+// CreateStrategyTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operator *Claims
+//   - template *StrategyTemplate
+func (_e *MockService_Expecter) CreateStrategyTemplate(ctx interface{}, operator interface{}, template interface{}) *MockService_CreateStrategyTemplate_Call {
+	return &MockService_CreateStrategyTemplate_Call{Call: _e.mock.On("CreateStrategyTemplate", ctx, operator, template)}
 }
 
-func (_m *MockK8SAdapter) EXPECT() *MockK8SAdapter_Expecter {
+func (_c *MockService_CreateStrategyTemplate_Call) Run(run func(ctx context.Context, operator *Claims, template *StrategyTemplate)) *MockService_CreateStrategyTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *Claims
+		if args[1] != nil {
+			arg1 = args[1].(*Claims)
+		}
+		var arg2 *StrategyTemplate
+		if args[2] != nil {
+			arg2 = args[2].(*StrategyTemplate)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_CreateStrategyTemplate_Call) Return(err error) *MockService_CreateStrategyTemplate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_CreateStrategyTemplate_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, template *StrategyTemplate) error) *MockService_CreateStrategyTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRole provides a mock function for the type MockService
+func (_mock *MockService) DeleteRole(ctx context.Context, operator *Claims, roleID string) error {
+	ret := _mock.Called(ctx, operator, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRole")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string) error); ok {
+		r0 = returnFunc(ctx, operator, roleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_DeleteRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRole'
+type MockService_DeleteRole_Call struct {
+	*mock.Call
+}
+
+// DeleteRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operator *Claims
+//   - roleID string
+func (_e *MockService_Expecter) DeleteRole(ctx interface{}, operator interface{}, roleID interface{}) *MockService_DeleteRole_Call {
+	return &MockService_DeleteRole_Call{Call: _e.mock.On("DeleteRole", ctx, operator, roleID)}
+}
+
+func (_c *MockService_DeleteRole_Call) Run(run func(ctx context.Context, operator *Claims, roleID string)) *MockService_DeleteRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *Claims
+		if args[1] != nil {
+			arg1 = args[1].(*Claims)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteRole_Call) Return(err error) *MockService_DeleteRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_DeleteRole_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, roleID string) error) *MockService_DeleteRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteScheduleIntents provides a mock function for the type MockService
+func (_mock *MockService) DeleteScheduleIntents(ctx context.Context, operator *Claims, intentIDs []string) error {
+	ret := _mock.Called(ctx, operator, intentIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteScheduleIntents")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, []string) error); ok {
+		r0 = returnFunc(ctx, operator, intentIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_DeleteScheduleIntents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteScheduleIntents'
+type MockService_DeleteScheduleIntents_Call struct {
+	*mock.Call
+}
+
+// DeleteScheduleIntents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operator *Claims
+//   - intentIDs []string
+func (_e *MockService_Expecter) DeleteScheduleIntents(ctx interface{}, operator interface{}, intentIDs interface{}) *MockService_DeleteScheduleIntents_Call {
+	return &MockService_DeleteScheduleIntents_Call{Call: _e.mock.On("DeleteScheduleIntents", ctx, operator, intentIDs)}
+}
+
+func (_c *MockService_DeleteScheduleIntents_Call) Run(run func(ctx context.Context, operator *Claims, intentIDs []string)) *MockService_DeleteScheduleIntents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *Claims
+		if args[1] != nil {
+			arg1 = args[1].(*Claims)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteScheduleIntents_Call) Return(err error) *MockService_DeleteScheduleIntents_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_DeleteScheduleIntents_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, intentIDs []string) error) *MockService_DeleteScheduleIntents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteScheduleStrategy provides a mock function for the type MockService
+func (_mock *MockService) DeleteScheduleStrategy(ctx context.Context, operator *Claims, strategyID string) error {
+	ret := _mock.Called(ctx, operator, strategyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteScheduleStrategy")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string) error); ok {
+		r0 = returnFunc(ctx, operator, strategyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_DeleteScheduleStrategy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteScheduleStrategy'
+type MockService_DeleteScheduleStrategy_Call struct {
+	*mock.Call
+}
+
+// DeleteScheduleStrategy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operator *Claims
+//   - strategyID string
+func (_e *MockService_Expecter) DeleteScheduleStrategy(ctx interface{}, operator interface{}, strategyID interface{}) *MockService_DeleteScheduleStrategy_Call {
+	return &MockService_DeleteScheduleStrategy_Call{Call: _e.mock.On("DeleteScheduleStrategy", ctx, operator, strategyID)}
+}
+
+func (_c *MockService_DeleteScheduleStrategy_Call) Run(run func(ctx context.Context, operator *Claims, strategyID string)) *MockService_DeleteScheduleStrategy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *Claims
+		if args[1] != nil {
+			arg1 = args[1].(*Claims)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteScheduleStrategy_Call) Return(err error) *MockService_DeleteScheduleStrategy_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_DeleteScheduleStrategy_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, strategyID string) error) *MockService_DeleteScheduleStrategy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteStrategyTemplate provides a mock function for the type MockService
+func (_mock *MockService) DeleteStrategyTemplate(ctx context.Context, operator *Claims, templateID string) error {
+	ret := _mock.Called(ctx, operator, templateID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStrategyTemplate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string) error); ok {
+		r0 = returnFunc(ctx, operator, templateID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_DeleteStrategyTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteStrategyTemplate'
+type MockService_DeleteStrategyTemplate_Call struct {
+	*mock.Call
+}
+
+// DeleteStrategyTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operator *Claims
+//   - templateID string
+func (_e *MockService_Expecter) DeleteStrategyTemplate(ctx interface{}, operator interface{}, templateID interface{}) *MockService_DeleteStrategyTemplate_Call {
+	return &MockService_DeleteStrategyTemplate_Call{Call: _e.mock.On("DeleteStrategyTemplate", ctx, operator, templateID)}
+}
+
+func (_c *MockService_DeleteStrategyTemplate_Call) Run(run func(ctx context.Context, operator *Claims, templateID string)) *MockService_DeleteStrategyTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *Claims
+		if args[1] != nil {
+			arg1 = args[1].(*Claims)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteStrategyTemplate_Call) Return(err error) *MockService_DeleteStrategyTemplate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_DeleteStrategyTemplate_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, templateID string) error) *MockService_DeleteStrategyTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureAnnotationStrategy provides a mock function for the type MockService
+func (_mock *MockService) EnsureAnnotationStrategy(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureAnnotationStrategy")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_EnsureAnnotationStrategy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureAnnotationStrategy'
+type MockService_EnsureAnnotationStrategy_Call struct {
+	*mock.Call
+}
+
+// EnsureAnnotationStrategy is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockService_Expecter) EnsureAnnotationStrategy(ctx interface{}) *MockService_EnsureAnnotationStrategy_Call {
+	return &MockService_EnsureAnnotationStrategy_Call{Call: _e.mock.On("EnsureAnnotationStrategy", ctx)}
+}
+
+func (_c *MockService_EnsureAnnotationStrategy_Call) Run(run func(ctx context.Context)) *MockService_EnsureAnnotationStrategy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_EnsureAnnotationStrategy_Call) Return(err error) *MockService_EnsureAnnotationStrategy_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_EnsureAnnotationStrategy_Call) RunAndReturn(run func(ctx context.Context) error) *MockService_EnsureAnnotationStrategy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportStrategyBundle provides a mock function for the type MockService
+func (_mock *MockService) ExportStrategyBundle(ctx context.Context, strategyNamespace string) (*StrategyBundle, error) {
+	ret := _mock.Called(ctx, strategyNamespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportStrategyBundle")
+	}
+
+	var r0 *StrategyBundle
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*StrategyBundle, error)); ok {
+		return returnFunc(ctx, strategyNamespace)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *StrategyBundle); ok {
+		r0 = returnFunc(ctx, strategyNamespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*StrategyBundle)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, strategyNamespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_ExportStrategyBundle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportStrategyBundle'
+type MockService_ExportStrategyBundle_Call struct {
+	*mock.Call
+}
+
+// ExportStrategyBundle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - strategyNamespace string
+func (_e *MockService_Expecter) ExportStrategyBundle(ctx interface{}, strategyNamespace interface{}) *MockService_ExportStrategyBundle_Call {
+	return &MockService_ExportStrategyBundle_Call{Call: _e.mock.On("ExportStrategyBundle", ctx, strategyNamespace)}
+}
+
+func (_c *MockService_ExportStrategyBundle_Call) Run(run func(ctx context.Context, strategyNamespace string)) *MockService_ExportStrategyBundle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ExportStrategyBundle_Call) Return(strategyBundle *StrategyBundle, err error) *MockService_ExportStrategyBundle_Call {
+	_c.Call.Return(strategyBundle, err)
+	return _c
+}
+
+func (_c *MockService_ExportStrategyBundle_Call) RunAndReturn(run func(ctx context.Context, strategyNamespace string) (*StrategyBundle, error)) *MockService_ExportStrategyBundle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEffectivePolicy provides a mock function for the type MockService
+func (_mock *MockService) GetEffectivePolicy(ctx context.Context, query *EffectivePolicyQuery) (*EffectivePolicy, error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEffectivePolicy")
+	}
+
+	var r0 *EffectivePolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *EffectivePolicyQuery) (*EffectivePolicy, error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *EffectivePolicyQuery) *EffectivePolicy); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*EffectivePolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *EffectivePolicyQuery) error); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetEffectivePolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEffectivePolicy'
+type MockService_GetEffectivePolicy_Call struct {
+	*mock.Call
+}
+
+// GetEffectivePolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *EffectivePolicyQuery
+func (_e *MockService_Expecter) GetEffectivePolicy(ctx interface{}, query interface{}) *MockService_GetEffectivePolicy_Call {
+	return &MockService_GetEffectivePolicy_Call{Call: _e.mock.On("GetEffectivePolicy", ctx, query)}
+}
+
+func (_c *MockService_GetEffectivePolicy_Call) Run(run func(ctx context.Context, query *EffectivePolicyQuery)) *MockService_GetEffectivePolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *EffectivePolicyQuery
+		if args[1] != nil {
+			arg1 = args[1].(*EffectivePolicyQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetEffectivePolicy_Call) Return(effectivePolicy *EffectivePolicy, err error) *MockService_GetEffectivePolicy_Call {
+	_c.Call.Return(effectivePolicy, err)
+	return _c
+}
+
+func (_c *MockService_GetEffectivePolicy_Call) RunAndReturn(run func(ctx context.Context, query *EffectivePolicyQuery) (*EffectivePolicy, error)) *MockService_GetEffectivePolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListScheduleIntents provides a mock function for the type MockService
+func (_mock *MockService) ListScheduleIntents(ctx context.Context, filterOpts *QueryIntentOptions) error {
+	ret := _mock.Called(ctx, filterOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListScheduleIntents")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryIntentOptions) error); ok {
+		r0 = returnFunc(ctx, filterOpts)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_ListScheduleIntents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListScheduleIntents'
+type MockService_ListScheduleIntents_Call struct {
+	*mock.Call
+}
+
+// ListScheduleIntents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filterOpts *QueryIntentOptions
+func (_e *MockService_Expecter) ListScheduleIntents(ctx interface{}, filterOpts interface{}) *MockService_ListScheduleIntents_Call {
+	return &MockService_ListScheduleIntents_Call{Call: _e.mock.On("ListScheduleIntents", ctx, filterOpts)}
+}
+
+func (_c *MockService_ListScheduleIntents_Call) Run(run func(ctx context.Context, filterOpts *QueryIntentOptions)) *MockService_ListScheduleIntents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *QueryIntentOptions
+		if args[1] != nil {
+			arg1 = args[1].(*QueryIntentOptions)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ListScheduleIntents_Call) Return(err error) *MockService_ListScheduleIntents_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_ListScheduleIntents_Call) RunAndReturn(run func(ctx context.Context, filterOpts *QueryIntentOptions) error) *MockService_ListScheduleIntents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListScheduleStrategies provides a mock function for the type MockService
+func (_mock *MockService) ListScheduleStrategies(ctx context.Context, filterOpts *QueryStrategyOptions) error {
+	ret := _mock.Called(ctx, filterOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListScheduleStrategies")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryStrategyOptions) error); ok {
+		r0 = returnFunc(ctx, filterOpts)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_ListScheduleStrategies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListScheduleStrategies'
+type MockService_ListScheduleStrategies_Call struct {
+	*mock.Call
+}
+
+// ListScheduleStrategies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filterOpts *QueryStrategyOptions
+func (_e *MockService_Expecter) ListScheduleStrategies(ctx interface{}, filterOpts interface{}) *MockService_ListScheduleStrategies_Call {
+	return &MockService_ListScheduleStrategies_Call{Call: _e.mock.On("ListScheduleStrategies", ctx, filterOpts)}
+}
+
+func (_c *MockService_ListScheduleStrategies_Call) Run(run func(ctx context.Context, filterOpts *QueryStrategyOptions)) *MockService_ListScheduleStrategies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *QueryStrategyOptions
+		if args[1] != nil {
+			arg1 = args[1].(*QueryStrategyOptions)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ListScheduleStrategies_Call) Return(err error) *MockService_ListScheduleStrategies_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_ListScheduleStrategies_Call) RunAndReturn(run func(ctx context.Context, filterOpts *QueryStrategyOptions) error) *MockService_ListScheduleStrategies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListStrategyTemplates provides a mock function for the type MockService
+func (_mock *MockService) ListStrategyTemplates(ctx context.Context, filterOpts *QueryStrategyTemplateOptions) error {
+	ret := _mock.Called(ctx, filterOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStrategyTemplates")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryStrategyTemplateOptions) error); ok {
+		r0 = returnFunc(ctx, filterOpts)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_ListStrategyTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStrategyTemplates'
+type MockService_ListStrategyTemplates_Call struct {
+	*mock.Call
+}
+
+// ListStrategyTemplates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filterOpts *QueryStrategyTemplateOptions
+func (_e *MockService_Expecter) ListStrategyTemplates(ctx interface{}, filterOpts interface{}) *MockService_ListStrategyTemplates_Call {
+	return &MockService_ListStrategyTemplates_Call{Call: _e.mock.On("ListStrategyTemplates", ctx, filterOpts)}
+}
+
+func (_c *MockService_ListStrategyTemplates_Call) Run(run func(ctx context.Context, filterOpts *QueryStrategyTemplateOptions)) *MockService_ListStrategyTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *QueryStrategyTemplateOptions
+		if args[1] != nil {
+			arg1 = args[1].(*QueryStrategyTemplateOptions)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ListStrategyTemplates_Call) Return(err error) *MockService_ListStrategyTemplates_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_ListStrategyTemplates_Call) RunAndReturn(run func(ctx context.Context, filterOpts *QueryStrategyTemplateOptions) error) *MockService_ListStrategyTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Login provides a mock function for the type MockService
+func (_mock *MockService) Login(ctx context.Context, email string, password string) (string, error) {
+	ret := _mock.Called(ctx, email, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return returnFunc(ctx, email, password)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = returnFunc(ctx, email, password)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, email, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
+type MockService_Login_Call struct {
+	*mock.Call
+}
+
+// Login is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - password string
+func (_e *MockService_Expecter) Login(ctx interface{}, email interface{}, password interface{}) *MockService_Login_Call {
+	return &MockService_Login_Call{Call: _e.mock.On("Login", ctx, email, password)}
+}
+
+func (_c *MockService_Login_Call) Run(run func(ctx context.Context, email string, password string)) *MockService_Login_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_Login_Call) Return(token string, err error) *MockService_Login_Call {
+	_c.Call.Return(token, err)
+	return _c
+}
+
+func (_c *MockService_Login_Call) RunAndReturn(run func(ctx context.Context, email string, password string) (string, error)) *MockService_Login_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PreviewScheduleStrategy provides a mock function for the type MockService
+func (_mock *MockService) PreviewScheduleStrategy(ctx context.Context, strategy *ScheduleStrategy, withProcesses bool) (*StrategyPreview, error) {
+	ret := _mock.Called(ctx, strategy, withProcesses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewScheduleStrategy")
+	}
+
+	var r0 *StrategyPreview
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *ScheduleStrategy, bool) (*StrategyPreview, error)); ok {
+		return returnFunc(ctx, strategy, withProcesses)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *ScheduleStrategy, bool) *StrategyPreview); ok {
+		r0 = returnFunc(ctx, strategy, withProcesses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*StrategyPreview)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *ScheduleStrategy, bool) error); ok {
+		r1 = returnFunc(ctx, strategy, withProcesses)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_PreviewScheduleStrategy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewScheduleStrategy'
+type MockService_PreviewScheduleStrategy_Call struct {
+	*mock.Call
+}
+
+// PreviewScheduleStrategy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - strategy *ScheduleStrategy
+//   - withProcesses bool
+func (_e *MockService_Expecter) PreviewScheduleStrategy(ctx interface{}, strategy interface{}, withProcesses interface{}) *MockService_PreviewScheduleStrategy_Call {
+	return &MockService_PreviewScheduleStrategy_Call{Call: _e.mock.On("PreviewScheduleStrategy", ctx, strategy, withProcesses)}
+}
+
+func (_c *MockService_PreviewScheduleStrategy_Call) Run(run func(ctx context.Context, strategy *ScheduleStrategy, withProcesses bool)) *MockService_PreviewScheduleStrategy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *ScheduleStrategy
+		if args[1] != nil {
+			arg1 = args[1].(*ScheduleStrategy)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_PreviewScheduleStrategy_Call) Return(strategyPreview *StrategyPreview, err error) *MockService_PreviewScheduleStrategy_Call {
+	_c.Call.Return(strategyPreview, err)
+	return _c
+}
+
+func (_c *MockService_PreviewScheduleStrategy_Call) RunAndReturn(run func(ctx context.Context, strategy *ScheduleStrategy, withProcesses bool) (*StrategyPreview, error)) *MockService_PreviewScheduleStrategy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PruneStrategyResources provides a mock function for the type MockService
+func (_mock *MockService) PruneStrategyResources(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneStrategyResources")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_PruneStrategyResources_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneStrategyResources'
+type MockService_PruneStrategyResources_Call struct {
+	*mock.Call
+}
+
+// PruneStrategyResources is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockService_Expecter) PruneStrategyResources(ctx interface{}) *MockService_PruneStrategyResources_Call {
+	return &MockService_PruneStrategyResources_Call{Call: _e.mock.On("PruneStrategyResources", ctx)}
+}
+
+func (_c *MockService_PruneStrategyResources_Call) Run(run func(ctx context.Context)) *MockService_PruneStrategyResources_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_PruneStrategyResources_Call) Return(err error) *MockService_PruneStrategyResources_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_PruneStrategyResources_Call) RunAndReturn(run func(ctx context.Context) error) *MockService_PruneStrategyResources_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryPermissions provides a mock function for the type MockService
+func (_mock *MockService) QueryPermissions(ctx context.Context, opt *QueryPermissionOptions) error {
+	ret := _mock.Called(ctx, opt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryPermissions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryPermissionOptions) error); ok {
+		r0 = returnFunc(ctx, opt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_QueryPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryPermissions'
+type MockService_QueryPermissions_Call struct {
+	*mock.Call
+}
+
+// QueryPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opt *QueryPermissionOptions
+func (_e *MockService_Expecter) QueryPermissions(ctx interface{}, opt interface{}) *MockService_QueryPermissions_Call {
+	return &MockService_QueryPermissions_Call{Call: _e.mock.On("QueryPermissions", ctx, opt)}
+}
+
+func (_c *MockService_QueryPermissions_Call) Run(run func(ctx context.Context, opt *QueryPermissionOptions)) *MockService_QueryPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *QueryPermissionOptions
+		if args[1] != nil {
+			arg1 = args[1].(*QueryPermissionOptions)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_QueryPermissions_Call) Return(err error) *MockService_QueryPermissions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_QueryPermissions_Call) RunAndReturn(run func(ctx context.Context, opt *QueryPermissionOptions) error) *MockService_QueryPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryRoles provides a mock function for the type MockService
+func (_mock *MockService) QueryRoles(ctx context.Context, opt *QueryRoleOptions) error {
+	ret := _mock.Called(ctx, opt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryRoles")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryRoleOptions) error); ok {
+		r0 = returnFunc(ctx, opt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_QueryRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryRoles'
+type MockService_QueryRoles_Call struct {
+	*mock.Call
+}
+
+// QueryRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opt *QueryRoleOptions
+func (_e *MockService_Expecter) QueryRoles(ctx interface{}, opt interface{}) *MockService_QueryRoles_Call {
+	return &MockService_QueryRoles_Call{Call: _e.mock.On("QueryRoles", ctx, opt)}
+}
+
+func (_c *MockService_QueryRoles_Call) Run(run func(ctx context.Context, opt *QueryRoleOptions)) *MockService_QueryRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *QueryRoleOptions
+		if args[1] != nil {
+			arg1 = args[1].(*QueryRoleOptions)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_QueryRoles_Call) Return(err error) *MockService_QueryRoles_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_QueryRoles_Call) RunAndReturn(run func(ctx context.Context, opt *QueryRoleOptions) error) *MockService_QueryRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryUsers provides a mock function for the type MockService
+func (_mock *MockService) QueryUsers(ctx context.Context, opt *QueryUserOptions) error {
+	ret := _mock.Called(ctx, opt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryUsers")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryUserOptions) error); ok {
+		r0 = returnFunc(ctx, opt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_QueryUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryUsers'
+type MockService_QueryUsers_Call struct {
+	*mock.Call
+}
+
+// QueryUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opt *QueryUserOptions
+func (_e *MockService_Expecter) QueryUsers(ctx interface{}, opt interface{}) *MockService_QueryUsers_Call {
+	return &MockService_QueryUsers_Call{Call: _e.mock.On("QueryUsers", ctx, opt)}
+}
+
+func (_c *MockService_QueryUsers_Call) Run(run func(ctx context.Context, opt *QueryUserOptions)) *MockService_QueryUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *QueryUserOptions
+		if args[1] != nil {
+			arg1 = args[1].(*QueryUserOptions)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_QueryUsers_Call) Return(err error) *MockService_QueryUsers_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_QueryUsers_Call) RunAndReturn(run func(ctx context.Context, opt *QueryUserOptions) error) *MockService_QueryUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReconcilePodEvent provides a mock function for the type MockService
+func (_mock *MockService) ReconcilePodEvent(ctx context.Context, event *PodEvent) error {
+	ret := _mock.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcilePodEvent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *PodEvent) error); ok {
+		r0 = returnFunc(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_ReconcilePodEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReconcilePodEvent'
+type MockService_ReconcilePodEvent_Call struct {
+	*mock.Call
+}
+
+// ReconcilePodEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event *PodEvent
+func (_e *MockService_Expecter) ReconcilePodEvent(ctx interface{}, event interface{}) *MockService_ReconcilePodEvent_Call {
+	return &MockService_ReconcilePodEvent_Call{Call: _e.mock.On("ReconcilePodEvent", ctx, event)}
+}
+
+func (_c *MockService_ReconcilePodEvent_Call) Run(run func(ctx context.Context, event *PodEvent)) *MockService_ReconcilePodEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *PodEvent
+		if args[1] != nil {
+			arg1 = args[1].(*PodEvent)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ReconcilePodEvent_Call) Return(err error) *MockService_ReconcilePodEvent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_ReconcilePodEvent_Call) RunAndReturn(run func(ctx context.Context, event *PodEvent) error) *MockService_ReconcilePodEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReconcileStrategyResource provides a mock function for the type MockService
+func (_mock *MockService) ReconcileStrategyResource(ctx context.Context, event *StrategyResourceEvent) error {
+	ret := _mock.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileStrategyResource")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *StrategyResourceEvent) error); ok {
+		r0 = returnFunc(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_ReconcileStrategyResource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReconcileStrategyResource'
+type MockService_ReconcileStrategyResource_Call struct {
+	*mock.Call
+}
+
+// ReconcileStrategyResource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event *StrategyResourceEvent
+func (_e *MockService_Expecter) ReconcileStrategyResource(ctx interface{}, event interface{}) *MockService_ReconcileStrategyResource_Call {
+	return &MockService_ReconcileStrategyResource_Call{Call: _e.mock.On("ReconcileStrategyResource", ctx, event)}
+}
+
+func (_c *MockService_ReconcileStrategyResource_Call) Run(run func(ctx context.Context, event *StrategyResourceEvent)) *MockService_ReconcileStrategyResource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *StrategyResourceEvent
+		if args[1] != nil {
+			arg1 = args[1].(*StrategyResourceEvent)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ReconcileStrategyResource_Call) Return(err error) *MockService_ReconcileStrategyResource_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_ReconcileStrategyResource_Call) RunAndReturn(run func(ctx context.Context, event *StrategyResourceEvent) error) *MockService_ReconcileStrategyResource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPassword provides a mock function for the type MockService
+func (_mock *MockService) ResetPassword(ctx context.Context, operator *Claims, id string, newPassword string) error {
+	ret := _mock.Called(ctx, operator, id, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string, string) error); ok {
+		r0 = returnFunc(ctx, operator, id, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type MockService_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operator *Claims
+//   - id string
+//   - newPassword string
+func (_e *MockService_Expecter) ResetPassword(ctx interface{}, operator interface{}, id interface{}, newPassword interface{}) *MockService_ResetPassword_Call {
+	return &MockService_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, operator, id, newPassword)}
+}
+
+func (_c *MockService_ResetPassword_Call) Run(run func(ctx context.Context, operator *Claims, id string, newPassword string)) *MockService_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *Claims
+		if args[1] != nil {
+			arg1 = args[1].(*Claims)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ResetPassword_Call) Return(err error) *MockService_ResetPassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_ResetPassword_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, id string, newPassword string) error) *MockService_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunIntentDelivery provides a mock function for the type MockService
+func (_mock *MockService) RunIntentDelivery(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockService_RunIntentDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunIntentDelivery'
+type MockService_RunIntentDelivery_Call struct {
+	*mock.Call
+}
+
+// RunIntentDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockService_Expecter) RunIntentDelivery(ctx interface{}) *MockService_RunIntentDelivery_Call {
+	return &MockService_RunIntentDelivery_Call{Call: _e.mock.On("RunIntentDelivery", ctx)}
+}
+
+func (_c *MockService_RunIntentDelivery_Call) Run(run func(ctx context.Context)) *MockService_RunIntentDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_RunIntentDelivery_Call) Return() *MockService_RunIntentDelivery_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockService_RunIntentDelivery_Call) RunAndReturn(run func(ctx context.Context)) *MockService_RunIntentDelivery_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RunStrategyScheduler provides a mock function for the type MockService
+func (_mock *MockService) RunStrategyScheduler(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockService_RunStrategyScheduler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunStrategyScheduler'
+type MockService_RunStrategyScheduler_Call struct {
+	*mock.Call
+}
+
+// RunStrategyScheduler is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockService_Expecter) RunStrategyScheduler(ctx interface{}) *MockService_RunStrategyScheduler_Call {
+	return &MockService_RunStrategyScheduler_Call{Call: _e.mock.On("RunStrategyScheduler", ctx)}
+}
+
+func (_c *MockService_RunStrategyScheduler_Call) Run(run func(ctx context.Context)) *MockService_RunStrategyScheduler_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_RunStrategyScheduler_Call) Return() *MockService_RunStrategyScheduler_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockService_RunStrategyScheduler_Call) RunAndReturn(run func(ctx context.Context)) *MockService_RunStrategyScheduler_Call {
+	_c.Run(run)
+	return _c
+}
+
+// UpdateRole provides a mock function for the type MockService
+func (_mock *MockService) UpdateRole(ctx context.Context, operator *Claims, roleID string, opt UpdateRoleOptions) error {
+	ret := _mock.Called(ctx, operator, roleID, opt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRole")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string, UpdateRoleOptions) error); ok {
+		r0 = returnFunc(ctx, operator, roleID, opt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
+type MockService_UpdateRole_Call struct {
+	*mock.Call
+}
+
+// UpdateRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operator *Claims
+//   - roleID string
+//   - opt UpdateRoleOptions
+func (_e *MockService_Expecter) UpdateRole(ctx interface{}, operator interface{}, roleID interface{}, opt interface{}) *MockService_UpdateRole_Call {
+	return &MockService_UpdateRole_Call{Call: _e.mock.On("UpdateRole", ctx, operator, roleID, opt)}
+}
+
+func (_c *MockService_UpdateRole_Call) Run(run func(ctx context.Context, operator *Claims, roleID string, opt UpdateRoleOptions)) *MockService_UpdateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *Claims
+		if args[1] != nil {
+			arg1 = args[1].(*Claims)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 UpdateRoleOptions
+		if args[3] != nil {
+			arg3 = args[3].(UpdateRoleOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateRole_Call) Return(err error) *MockService_UpdateRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_UpdateRole_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, roleID string, opt UpdateRoleOptions) error) *MockService_UpdateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateScheduleStrategy provides a mock function for the type MockService
+func (_mock *MockService) UpdateScheduleStrategy(ctx context.Context, operator *Claims, strategyID string, strategy *ScheduleStrategy) ([]*StrategyConflict, error) {
+	ret := _mock.Called(ctx, operator, strategyID, strategy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateScheduleStrategy")
+	}
+
+	var r0 []*StrategyConflict
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string, *ScheduleStrategy) ([]*StrategyConflict, error)); ok {
+		return returnFunc(ctx, operator, strategyID, strategy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string, *ScheduleStrategy) []*StrategyConflict); ok {
+		r0 = returnFunc(ctx, operator, strategyID, strategy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*StrategyConflict)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *Claims, string, *ScheduleStrategy) error); ok {
+		r1 = returnFunc(ctx, operator, strategyID, strategy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_UpdateScheduleStrategy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateScheduleStrategy'
+type MockService_UpdateScheduleStrategy_Call struct {
+	*mock.Call
+}
+
+// UpdateScheduleStrategy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operator *Claims
+//   - strategyID string
+//   - strategy *ScheduleStrategy
+func (_e *MockService_Expecter) UpdateScheduleStrategy(ctx interface{}, operator interface{}, strategyID interface{}, strategy interface{}) *MockService_UpdateScheduleStrategy_Call {
+	return &MockService_UpdateScheduleStrategy_Call{Call: _e.mock.On("UpdateScheduleStrategy", ctx, operator, strategyID, strategy)}
+}
+
+func (_c *MockService_UpdateScheduleStrategy_Call) Run(run func(ctx context.Context, operator *Claims, strategyID string, strategy *ScheduleStrategy)) *MockService_UpdateScheduleStrategy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *Claims
+		if args[1] != nil {
+			arg1 = args[1].(*Claims)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *ScheduleStrategy
+		if args[3] != nil {
+			arg3 = args[3].(*ScheduleStrategy)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateScheduleStrategy_Call) Return(strategyConflicts []*StrategyConflict, err error) *MockService_UpdateScheduleStrategy_Call {
+	_c.Call.Return(strategyConflicts, err)
+	return _c
+}
+
+func (_c *MockService_UpdateScheduleStrategy_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, strategyID string, strategy *ScheduleStrategy) ([]*StrategyConflict, error)) *MockService_UpdateScheduleStrategy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStrategyTemplate provides a mock function for the type MockService
+func (_mock *MockService) UpdateStrategyTemplate(ctx context.Context, operator *Claims, templateID string, template *StrategyTemplate, propagate bool) ([]bson.ObjectID, error) {
+	ret := _mock.Called(ctx, operator, templateID, template, propagate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStrategyTemplate")
+	}
+
+	var r0 []bson.ObjectID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string, *StrategyTemplate, bool) ([]bson.ObjectID, error)); ok {
+		return returnFunc(ctx, operator, templateID, template, propagate)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string, *StrategyTemplate, bool) []bson.ObjectID); ok {
+		r0 = returnFunc(ctx, operator, templateID, template, propagate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bson.ObjectID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *Claims, string, *StrategyTemplate, bool) error); ok {
+		r1 = returnFunc(ctx, operator, templateID, template, propagate)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_UpdateStrategyTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStrategyTemplate'
+type MockService_UpdateStrategyTemplate_Call struct {
+	*mock.Call
+}
+
+// UpdateStrategyTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operator *Claims
+//   - templateID string
+//   - template *StrategyTemplate
+//   - propagate bool
+func (_e *MockService_Expecter) UpdateStrategyTemplate(ctx interface{}, operator interface{}, templateID interface{}, template interface{}, propagate interface{}) *MockService_UpdateStrategyTemplate_Call {
+	return &MockService_UpdateStrategyTemplate_Call{Call: _e.mock.On("UpdateStrategyTemplate", ctx, operator, templateID, template, propagate)}
+}
+
+func (_c *MockService_UpdateStrategyTemplate_Call) Run(run func(ctx context.Context, operator *Claims, templateID string, template *StrategyTemplate, propagate bool)) *MockService_UpdateStrategyTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *Claims
+		if args[1] != nil {
+			arg1 = args[1].(*Claims)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *StrategyTemplate
+		if args[3] != nil {
+			arg3 = args[3].(*StrategyTemplate)
+		}
+		var arg4 bool
+		if args[4] != nil {
+			arg4 = args[4].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateStrategyTemplate_Call) Return(objectIDs []bson.ObjectID, err error) *MockService_UpdateStrategyTemplate_Call {
+	_c.Call.Return(objectIDs, err)
+	return _c
+}
+
+func (_c *MockService_UpdateStrategyTemplate_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, templateID string, template *StrategyTemplate, propagate bool) ([]bson.ObjectID, error)) *MockService_UpdateStrategyTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserPermissions provides a mock function for the type MockService
+func (_mock *MockService) UpdateUserPermissions(ctx context.Context, operator *Claims, id string, opt UpdateUserPermissionsOptions) error {
+	ret := _mock.Called(ctx, operator, id, opt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserPermissions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Claims, string, UpdateUserPermissionsOptions) error); ok {
+		r0 = returnFunc(ctx, operator, id, opt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_UpdateUserPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserPermissions'
+type MockService_UpdateUserPermissions_Call struct {
+	*mock.Call
+}
+
+// UpdateUserPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operator *Claims
+//   - id string
+//   - opt UpdateUserPermissionsOptions
+func (_e *MockService_Expecter) UpdateUserPermissions(ctx interface{}, operator interface{}, id interface{}, opt interface{}) *MockService_UpdateUserPermissions_Call {
+	return &MockService_UpdateUserPermissions_Call{Call: _e.mock.On("UpdateUserPermissions", ctx, operator, id, opt)}
+}
+
+func (_c *MockService_UpdateUserPermissions_Call) Run(run func(ctx context.Context, operator *Claims, id string, opt UpdateUserPermissionsOptions)) *MockService_UpdateUserPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *Claims
+		if args[1] != nil {
+			arg1 = args[1].(*Claims)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 UpdateUserPermissionsOptions
+		if args[3] != nil {
+			arg3 = args[3].(UpdateUserPermissionsOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateUserPermissions_Call) Return(err error) *MockService_UpdateUserPermissions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_UpdateUserPermissions_Call) RunAndReturn(run func(ctx context.Context, operator *Claims, id string, opt UpdateUserPermissionsOptions) error) *MockService_UpdateUserPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyDecisionMakerToken provides a mock function for the type MockService
+func (_mock *MockService) VerifyDecisionMakerToken(ctx context.Context, tokenString string) (DecisionMakerClaims, error) {
+	ret := _mock.Called(ctx, tokenString)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyDecisionMakerToken")
+	}
+
+	var r0 DecisionMakerClaims
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (DecisionMakerClaims, error)); ok {
+		return returnFunc(ctx, tokenString)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) DecisionMakerClaims); ok {
+		r0 = returnFunc(ctx, tokenString)
+	} else {
+		r0 = ret.Get(0).(DecisionMakerClaims)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenString)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_VerifyDecisionMakerToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyDecisionMakerToken'
+type MockService_VerifyDecisionMakerToken_Call struct {
+	*mock.Call
+}
+
+// VerifyDecisionMakerToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenString string
+func (_e *MockService_Expecter) VerifyDecisionMakerToken(ctx interface{}, tokenString interface{}) *MockService_VerifyDecisionMakerToken_Call {
+	return &MockService_VerifyDecisionMakerToken_Call{Call: _e.mock.On("VerifyDecisionMakerToken", ctx, tokenString)}
+}
+
+func (_c *MockService_VerifyDecisionMakerToken_Call) Run(run func(ctx context.Context, tokenString string)) *MockService_VerifyDecisionMakerToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_VerifyDecisionMakerToken_Call) Return(decisionMakerClaims DecisionMakerClaims, err error) *MockService_VerifyDecisionMakerToken_Call {
+	_c.Call.Return(decisionMakerClaims, err)
+	return _c
+}
+
+func (_c *MockService_VerifyDecisionMakerToken_Call) RunAndReturn(run func(ctx context.Context, tokenString string) (DecisionMakerClaims, error)) *MockService_VerifyDecisionMakerToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyJWTToken provides a mock function for the type MockService
+func (_mock *MockService) VerifyJWTToken(ctx context.Context, tokenString string, permissionKey PermissionKey) (Claims, RolePolicy, error) {
+	ret := _mock.Called(ctx, tokenString, permissionKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyJWTToken")
+	}
+
+	var r0 Claims
+	var r1 RolePolicy
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, PermissionKey) (Claims, RolePolicy, error)); ok {
+		return returnFunc(ctx, tokenString, permissionKey)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, PermissionKey) Claims); ok {
+		r0 = returnFunc(ctx, tokenString, permissionKey)
+	} else {
+		r0 = ret.Get(0).(Claims)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, PermissionKey) RolePolicy); ok {
+		r1 = returnFunc(ctx, tokenString, permissionKey)
+	} else {
+		r1 = ret.Get(1).(RolePolicy)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, PermissionKey) error); ok {
+		r2 = returnFunc(ctx, tokenString, permissionKey)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockService_VerifyJWTToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyJWTToken'
+type MockService_VerifyJWTToken_Call struct {
+	*mock.Call
+}
+
+// VerifyJWTToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenString string
+//   - permissionKey PermissionKey
+func (_e *MockService_Expecter) VerifyJWTToken(ctx interface{}, tokenString interface{}, permissionKey interface{}) *MockService_VerifyJWTToken_Call {
+	return &MockService_VerifyJWTToken_Call{Call: _e.mock.On("VerifyJWTToken", ctx, tokenString, permissionKey)}
+}
+
+func (_c *MockService_VerifyJWTToken_Call) Run(run func(ctx context.Context, tokenString string, permissionKey PermissionKey)) *MockService_VerifyJWTToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 PermissionKey
+		if args[2] != nil {
+			arg2 = args[2].(PermissionKey)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_VerifyJWTToken_Call) Return(claims Claims, rolePolicy RolePolicy, err error) *MockService_VerifyJWTToken_Call {
+	_c.Call.Return(claims, rolePolicy, err)
+	return _c
+}
+
+func (_c *MockService_VerifyJWTToken_Call) RunAndReturn(run func(ctx context.Context, tokenString string, permissionKey PermissionKey) (Claims, RolePolicy, error)) *MockService_VerifyJWTToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockK8SAdapter creates a new instance of MockK8SAdapter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockK8SAdapter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockK8SAdapter {
+	mock := &MockK8SAdapter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockK8SAdapter is an autogenerated mock type for the K8SAdapter type
+type MockK8SAdapter struct {
+	mock.Mock
+}
+
+type MockK8SAdapter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockK8SAdapter) EXPECT() *MockK8SAdapter_Expecter {
 	return &MockK8SAdapter_Expecter{mock: &_m.Mock}
 }
 
-// QueryDecisionMakerPods provides a mock function for the type MockK8SAdapter
-func (_mock *MockK8SAdapter) QueryDecisionMakerPods(ctx context.Context, opt *QueryDecisionMakerPodsOptions) ([]*DecisionMakerPod, error) {
-	ret := _mock.Called(ctx, opt)
+// AddPodEventHandler provides a mock function for the type MockK8SAdapter
+func (_mock *MockK8SAdapter) AddPodEventHandler(handler PodEventHandler) {
+	_mock.Called(handler)
+	return
+}
+
+// MockK8SAdapter_AddPodEventHandler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPodEventHandler'
+type MockK8SAdapter_AddPodEventHandler_Call struct {
+	*mock.Call
+}
+
+// AddPodEventHandler is a helper method to define mock.On call
+//   - handler PodEventHandler
+func (_e *MockK8SAdapter_Expecter) AddPodEventHandler(handler interface{}) *MockK8SAdapter_AddPodEventHandler_Call {
+	return &MockK8SAdapter_AddPodEventHandler_Call{Call: _e.mock.On("AddPodEventHandler", handler)}
+}
+
+func (_c *MockK8SAdapter_AddPodEventHandler_Call) Run(run func(handler PodEventHandler)) *MockK8SAdapter_AddPodEventHandler_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 PodEventHandler
+		if args[0] != nil {
+			arg0 = args[0].(PodEventHandler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockK8SAdapter_AddPodEventHandler_Call) Return() *MockK8SAdapter_AddPodEventHandler_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockK8SAdapter_AddPodEventHandler_Call) RunAndReturn(run func(handler PodEventHandler)) *MockK8SAdapter_AddPodEventHandler_Call {
+	_c.Run(run)
+	return _c
+}
+
+// AddStrategyResourceHandler provides a mock function for the type MockK8SAdapter
+func (_mock *MockK8SAdapter) AddStrategyResourceHandler(handler StrategyResourceHandler) {
+	_mock.Called(handler)
+	return
+}
+
+// MockK8SAdapter_AddStrategyResourceHandler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddStrategyResourceHandler'
+type MockK8SAdapter_AddStrategyResourceHandler_Call struct {
+	*mock.Call
+}
+
+// AddStrategyResourceHandler is a helper method to define mock.On call
+//   - handler StrategyResourceHandler
+func (_e *MockK8SAdapter_Expecter) AddStrategyResourceHandler(handler interface{}) *MockK8SAdapter_AddStrategyResourceHandler_Call {
+	return &MockK8SAdapter_AddStrategyResourceHandler_Call{Call: _e.mock.On("AddStrategyResourceHandler", handler)}
+}
+
+func (_c *MockK8SAdapter_AddStrategyResourceHandler_Call) Run(run func(handler StrategyResourceHandler)) *MockK8SAdapter_AddStrategyResourceHandler_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 StrategyResourceHandler
+		if args[0] != nil {
+			arg0 = args[0].(StrategyResourceHandler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockK8SAdapter_AddStrategyResourceHandler_Call) Return() *MockK8SAdapter_AddStrategyResourceHandler_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockK8SAdapter_AddStrategyResourceHandler_Call) RunAndReturn(run func(handler StrategyResourceHandler)) *MockK8SAdapter_AddStrategyResourceHandler_Call {
+	_c.Run(run)
+	return _c
+}
+
+// QueryDecisionMakerPods provides a mock function for the type MockK8SAdapter
+func (_mock *MockK8SAdapter) QueryDecisionMakerPods(ctx context.Context, opt *QueryDecisionMakerPodsOptions) ([]*DecisionMakerPod, error) {
+	ret := _mock.Called(ctx, opt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryDecisionMakerPods")
+	}
+
+	var r0 []*DecisionMakerPod
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryDecisionMakerPodsOptions) ([]*DecisionMakerPod, error)); ok {
+		return returnFunc(ctx, opt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryDecisionMakerPodsOptions) []*DecisionMakerPod); ok {
+		r0 = returnFunc(ctx, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*DecisionMakerPod)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *QueryDecisionMakerPodsOptions) error); ok {
+		r1 = returnFunc(ctx, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockK8SAdapter_QueryDecisionMakerPods_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryDecisionMakerPods'
+type MockK8SAdapter_QueryDecisionMakerPods_Call struct {
+	*mock.Call
+}
+
+// QueryDecisionMakerPods is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opt *QueryDecisionMakerPodsOptions
+func (_e *MockK8SAdapter_Expecter) QueryDecisionMakerPods(ctx interface{}, opt interface{}) *MockK8SAdapter_QueryDecisionMakerPods_Call {
+	return &MockK8SAdapter_QueryDecisionMakerPods_Call{Call: _e.mock.On("QueryDecisionMakerPods", ctx, opt)}
+}
+
+func (_c *MockK8SAdapter_QueryDecisionMakerPods_Call) Run(run func(ctx context.Context, opt *QueryDecisionMakerPodsOptions)) *MockK8SAdapter_QueryDecisionMakerPods_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *QueryDecisionMakerPodsOptions
+		if args[1] != nil {
+			arg1 = args[1].(*QueryDecisionMakerPodsOptions)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockK8SAdapter_QueryDecisionMakerPods_Call) Return(decisionMakerPods []*DecisionMakerPod, err error) *MockK8SAdapter_QueryDecisionMakerPods_Call {
+	_c.Call.Return(decisionMakerPods, err)
+	return _c
+}
+
+func (_c *MockK8SAdapter_QueryDecisionMakerPods_Call) RunAndReturn(run func(ctx context.Context, opt *QueryDecisionMakerPodsOptions) ([]*DecisionMakerPod, error)) *MockK8SAdapter_QueryDecisionMakerPods_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryPods provides a mock function for the type MockK8SAdapter
+func (_mock *MockK8SAdapter) QueryPods(ctx context.Context, opt *QueryPodsOptions) ([]*Pod, error) {
+	ret := _mock.Called(ctx, opt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryPods")
+	}
+
+	var r0 []*Pod
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryPodsOptions) ([]*Pod, error)); ok {
+		return returnFunc(ctx, opt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryPodsOptions) []*Pod); ok {
+		r0 = returnFunc(ctx, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Pod)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *QueryPodsOptions) error); ok {
+		r1 = returnFunc(ctx, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockK8SAdapter_QueryPods_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryPods'
+type MockK8SAdapter_QueryPods_Call struct {
+	*mock.Call
+}
+
+// QueryPods is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opt *QueryPodsOptions
+func (_e *MockK8SAdapter_Expecter) QueryPods(ctx interface{}, opt interface{}) *MockK8SAdapter_QueryPods_Call {
+	return &MockK8SAdapter_QueryPods_Call{Call: _e.mock.On("QueryPods", ctx, opt)}
+}
+
+func (_c *MockK8SAdapter_QueryPods_Call) Run(run func(ctx context.Context, opt *QueryPodsOptions)) *MockK8SAdapter_QueryPods_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *QueryPodsOptions
+		if args[1] != nil {
+			arg1 = args[1].(*QueryPodsOptions)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockK8SAdapter_QueryPods_Call) Return(pods []*Pod, err error) *MockK8SAdapter_QueryPods_Call {
+	_c.Call.Return(pods, err)
+	return _c
+}
+
+func (_c *MockK8SAdapter_QueryPods_Call) RunAndReturn(run func(ctx context.Context, opt *QueryPodsOptions) ([]*Pod, error)) *MockK8SAdapter_QueryPods_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StrategyResourceUIDs provides a mock function for the type MockK8SAdapter
+func (_mock *MockK8SAdapter) StrategyResourceUIDs(ctx context.Context) ([]string, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryDecisionMakerPods")
+		panic("no return value specified for StrategyResourceUIDs")
 	}
 
-	var r0 []*DecisionMakerPod
+	var r0 []string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryDecisionMakerPodsOptions) ([]*DecisionMakerPod, error)); ok {
-		return returnFunc(ctx, opt)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryDecisionMakerPodsOptions) []*DecisionMakerPod); ok {
-		r0 = returnFunc(ctx, opt)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*DecisionMakerPod)
+			r0 = ret.Get(0).([]string)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *QueryDecisionMakerPodsOptions) error); ok {
-		r1 = returnFunc(ctx, opt)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockK8SAdapter_QueryDecisionMakerPods_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryDecisionMakerPods'
-type MockK8SAdapter_QueryDecisionMakerPods_Call struct {
+// MockK8SAdapter_StrategyResourceUIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StrategyResourceUIDs'
+type MockK8SAdapter_StrategyResourceUIDs_Call struct {
 	*mock.Call
 }
 
-// QueryDecisionMakerPods is a helper method to define mock.On call
+// StrategyResourceUIDs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - opt *QueryDecisionMakerPodsOptions
-func (_e *MockK8SAdapter_Expecter) QueryDecisionMakerPods(ctx interface{}, opt interface{}) *MockK8SAdapter_QueryDecisionMakerPods_Call {
-	return &MockK8SAdapter_QueryDecisionMakerPods_Call{Call: _e.mock.On("QueryDecisionMakerPods", ctx, opt)}
+func (_e *MockK8SAdapter_Expecter) StrategyResourceUIDs(ctx interface{}) *MockK8SAdapter_StrategyResourceUIDs_Call {
+	return &MockK8SAdapter_StrategyResourceUIDs_Call{Call: _e.mock.On("StrategyResourceUIDs", ctx)}
 }
 
-func (_c *MockK8SAdapter_QueryDecisionMakerPods_Call) Run(run func(ctx context.Context, opt *QueryDecisionMakerPodsOptions)) *MockK8SAdapter_QueryDecisionMakerPods_Call {
+func (_c *MockK8SAdapter_StrategyResourceUIDs_Call) Run(run func(ctx context.Context)) *MockK8SAdapter_StrategyResourceUIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *QueryDecisionMakerPodsOptions
-		if args[1] != nil {
-			arg1 = args[1].(*QueryDecisionMakerPodsOptions)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockK8SAdapter_QueryDecisionMakerPods_Call) Return(decisionMakerPods []*DecisionMakerPod, err error) *MockK8SAdapter_QueryDecisionMakerPods_Call {
-	_c.Call.Return(decisionMakerPods, err)
+func (_c *MockK8SAdapter_StrategyResourceUIDs_Call) Return(strings []string, err error) *MockK8SAdapter_StrategyResourceUIDs_Call {
+	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *MockK8SAdapter_QueryDecisionMakerPods_Call) RunAndReturn(run func(ctx context.Context, opt *QueryDecisionMakerPodsOptions) ([]*DecisionMakerPod, error)) *MockK8SAdapter_QueryDecisionMakerPods_Call {
+func (_c *MockK8SAdapter_StrategyResourceUIDs_Call) RunAndReturn(run func(ctx context.Context) ([]string, error)) *MockK8SAdapter_StrategyResourceUIDs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryPods provides a mock function for the type MockK8SAdapter
-func (_mock *MockK8SAdapter) QueryPods(ctx context.Context, opt *QueryPodsOptions) ([]*Pod, error) {
-	ret := _mock.Called(ctx, opt)
+// UpdateStrategyResourceStatus provides a mock function for the type MockK8SAdapter
+func (_mock *MockK8SAdapter) UpdateStrategyResourceStatus(ctx context.Context, resource *StrategyResourceRef, status *StrategyResourceStatus) error {
+	ret := _mock.Called(ctx, resource, status)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryPods")
+		panic("no return value specified for UpdateStrategyResourceStatus")
 	}
 
-	var r0 []*Pod
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryPodsOptions) ([]*Pod, error)); ok {
-		return returnFunc(ctx, opt)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *QueryPodsOptions) []*Pod); ok {
-		r0 = returnFunc(ctx, opt)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*Pod)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *QueryPodsOptions) error); ok {
-		r1 = returnFunc(ctx, opt)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *StrategyResourceRef, *StrategyResourceStatus) error); ok {
+		r0 = returnFunc(ctx, resource, status)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockK8SAdapter_QueryPods_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryPods'
-type MockK8SAdapter_QueryPods_Call struct {
+// MockK8SAdapter_UpdateStrategyResourceStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStrategyResourceStatus'
+type MockK8SAdapter_UpdateStrategyResourceStatus_Call struct {
 	*mock.Call
 }
 
-// QueryPods is a helper method to define mock.On call
+// UpdateStrategyResourceStatus is a helper method to define mock.On call
 //   - ctx context.Context
-//   - opt *QueryPodsOptions
-func (_e *MockK8SAdapter_Expecter) QueryPods(ctx interface{}, opt interface{}) *MockK8SAdapter_QueryPods_Call {
-	return &MockK8SAdapter_QueryPods_Call{Call: _e.mock.On("QueryPods", ctx, opt)}
+//   - resource *StrategyResourceRef
+//   - status *StrategyResourceStatus
+func (_e *MockK8SAdapter_Expecter) UpdateStrategyResourceStatus(ctx interface{}, resource interface{}, status interface{}) *MockK8SAdapter_UpdateStrategyResourceStatus_Call {
+	return &MockK8SAdapter_UpdateStrategyResourceStatus_Call{Call: _e.mock.On("UpdateStrategyResourceStatus", ctx, resource, status)}
 }
 
-func (_c *MockK8SAdapter_QueryPods_Call) Run(run func(ctx context.Context, opt *QueryPodsOptions)) *MockK8SAdapter_QueryPods_Call {
+func (_c *MockK8SAdapter_UpdateStrategyResourceStatus_Call) Run(run func(ctx context.Context, resource *StrategyResourceRef, status *StrategyResourceStatus)) *MockK8SAdapter_UpdateStrategyResourceStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *QueryPodsOptions
+		var arg1 *StrategyResourceRef
 		if args[1] != nil {
-			arg1 = args[1].(*QueryPodsOptions)
+			arg1 = args[1].(*StrategyResourceRef)
+		}
+		var arg2 *StrategyResourceStatus
+		if args[2] != nil {
+			arg2 = args[2].(*StrategyResourceStatus)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockK8SAdapter_QueryPods_Call) Return(pods []*Pod, err error) *MockK8SAdapter_QueryPods_Call {
-	_c.Call.Return(pods, err)
+func (_c *MockK8SAdapter_UpdateStrategyResourceStatus_Call) Return(err error) *MockK8SAdapter_UpdateStrategyResourceStatus_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockK8SAdapter_QueryPods_Call) RunAndReturn(run func(ctx context.Context, opt *QueryPodsOptions) ([]*Pod, error)) *MockK8SAdapter_QueryPods_Call {
+func (_c *MockK8SAdapter_UpdateStrategyResourceStatus_Call) RunAndReturn(run func(ctx context.Context, resource *StrategyResourceRef, status *StrategyResourceStatus) error) *MockK8SAdapter_UpdateStrategyResourceStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2154,37 +4187,185 @@ func (_m *MockDecisionMakerAdapter) EXPECT() *MockDecisionMakerAdapter_Expecter
 	return &MockDecisionMakerAdapter_Expecter{mock: &_m.Mock}
 }
 
-// SendSchedulingIntent provides a mock function for the type MockDecisionMakerAdapter
-func (_mock *MockDecisionMakerAdapter) SendSchedulingIntent(ctx context.Context, decisionMaker *DecisionMakerPod, intents []*ScheduleIntent) error {
-	ret := _mock.Called(ctx, decisionMaker, intents)
+// DeleteSchedulingIntents provides a mock function for the type MockDecisionMakerAdapter
+func (_mock *MockDecisionMakerAdapter) DeleteSchedulingIntents(ctx context.Context, decisionMaker *DecisionMakerPod, req *DeleteIntentsRequest) error {
+	ret := _mock.Called(ctx, decisionMaker, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SendSchedulingIntent")
+		panic("no return value specified for DeleteSchedulingIntents")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *DecisionMakerPod, []*ScheduleIntent) error); ok {
-		r0 = returnFunc(ctx, decisionMaker, intents)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *DecisionMakerPod, *DeleteIntentsRequest) error); ok {
+		r0 = returnFunc(ctx, decisionMaker, req)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockDecisionMakerAdapter_SendSchedulingIntent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendSchedulingIntent'
-type MockDecisionMakerAdapter_SendSchedulingIntent_Call struct {
+// MockDecisionMakerAdapter_DeleteSchedulingIntents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSchedulingIntents'
+type MockDecisionMakerAdapter_DeleteSchedulingIntents_Call struct {
 	*mock.Call
 }
 
-// SendSchedulingIntent is a helper method to define mock.On call
+// DeleteSchedulingIntents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - decisionMaker *DecisionMakerPod
+//   - req *DeleteIntentsRequest
+func (_e *MockDecisionMakerAdapter_Expecter) DeleteSchedulingIntents(ctx interface{}, decisionMaker interface{}, req interface{}) *MockDecisionMakerAdapter_DeleteSchedulingIntents_Call {
+	return &MockDecisionMakerAdapter_DeleteSchedulingIntents_Call{Call: _e.mock.On("DeleteSchedulingIntents", ctx, decisionMaker, req)}
+}
+
+func (_c *MockDecisionMakerAdapter_DeleteSchedulingIntents_Call) Run(run func(ctx context.Context, decisionMaker *DecisionMakerPod, req *DeleteIntentsRequest)) *MockDecisionMakerAdapter_DeleteSchedulingIntents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *DecisionMakerPod
+		if args[1] != nil {
+			arg1 = args[1].(*DecisionMakerPod)
+		}
+		var arg2 *DeleteIntentsRequest
+		if args[2] != nil {
+			arg2 = args[2].(*DeleteIntentsRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDecisionMakerAdapter_DeleteSchedulingIntents_Call) Return(err error) *MockDecisionMakerAdapter_DeleteSchedulingIntents_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDecisionMakerAdapter_DeleteSchedulingIntents_Call) RunAndReturn(run func(ctx context.Context, decisionMaker *DecisionMakerPod, req *DeleteIntentsRequest) error) *MockDecisionMakerAdapter_DeleteSchedulingIntents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPodSchedulingIntents provides a mock function for the type MockDecisionMakerAdapter
+func (_mock *MockDecisionMakerAdapter) ListPodSchedulingIntents(ctx context.Context, decisionMaker *DecisionMakerPod, podID string) ([]*ProcessScheduling, error) {
+	ret := _mock.Called(ctx, decisionMaker, podID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPodSchedulingIntents")
+	}
+
+	var r0 []*ProcessScheduling
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *DecisionMakerPod, string) ([]*ProcessScheduling, error)); ok {
+		return returnFunc(ctx, decisionMaker, podID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *DecisionMakerPod, string) []*ProcessScheduling); ok {
+		r0 = returnFunc(ctx, decisionMaker, podID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ProcessScheduling)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *DecisionMakerPod, string) error); ok {
+		r1 = returnFunc(ctx, decisionMaker, podID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDecisionMakerAdapter_ListPodSchedulingIntents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPodSchedulingIntents'
+type MockDecisionMakerAdapter_ListPodSchedulingIntents_Call struct {
+	*mock.Call
+}
+
+// ListPodSchedulingIntents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - decisionMaker *DecisionMakerPod
+//   - podID string
+func (_e *MockDecisionMakerAdapter_Expecter) ListPodSchedulingIntents(ctx interface{}, decisionMaker interface{}, podID interface{}) *MockDecisionMakerAdapter_ListPodSchedulingIntents_Call {
+	return &MockDecisionMakerAdapter_ListPodSchedulingIntents_Call{Call: _e.mock.On("ListPodSchedulingIntents", ctx, decisionMaker, podID)}
+}
+
+func (_c *MockDecisionMakerAdapter_ListPodSchedulingIntents_Call) Run(run func(ctx context.Context, decisionMaker *DecisionMakerPod, podID string)) *MockDecisionMakerAdapter_ListPodSchedulingIntents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *DecisionMakerPod
+		if args[1] != nil {
+			arg1 = args[1].(*DecisionMakerPod)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDecisionMakerAdapter_ListPodSchedulingIntents_Call) Return(processSchedulings []*ProcessScheduling, err error) *MockDecisionMakerAdapter_ListPodSchedulingIntents_Call {
+	_c.Call.Return(processSchedulings, err)
+	return _c
+}
+
+func (_c *MockDecisionMakerAdapter_ListPodSchedulingIntents_Call) RunAndReturn(run func(ctx context.Context, decisionMaker *DecisionMakerPod, podID string) ([]*ProcessScheduling, error)) *MockDecisionMakerAdapter_ListPodSchedulingIntents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PreviewSchedulingIntents provides a mock function for the type MockDecisionMakerAdapter
+func (_mock *MockDecisionMakerAdapter) PreviewSchedulingIntents(ctx context.Context, decisionMaker *DecisionMakerPod, intents []*ScheduleIntent) ([]*IntentPreview, error) {
+	ret := _mock.Called(ctx, decisionMaker, intents)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewSchedulingIntents")
+	}
+
+	var r0 []*IntentPreview
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *DecisionMakerPod, []*ScheduleIntent) ([]*IntentPreview, error)); ok {
+		return returnFunc(ctx, decisionMaker, intents)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *DecisionMakerPod, []*ScheduleIntent) []*IntentPreview); ok {
+		r0 = returnFunc(ctx, decisionMaker, intents)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*IntentPreview)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *DecisionMakerPod, []*ScheduleIntent) error); ok {
+		r1 = returnFunc(ctx, decisionMaker, intents)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDecisionMakerAdapter_PreviewSchedulingIntents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewSchedulingIntents'
+type MockDecisionMakerAdapter_PreviewSchedulingIntents_Call struct {
+	*mock.Call
+}
+
+// PreviewSchedulingIntents is a helper method to define mock.On call
 //   - ctx context.Context
 //   - decisionMaker *DecisionMakerPod
 //   - intents []*ScheduleIntent
-func (_e *MockDecisionMakerAdapter_Expecter) SendSchedulingIntent(ctx interface{}, decisionMaker interface{}, intents interface{}) *MockDecisionMakerAdapter_SendSchedulingIntent_Call {
-	return &MockDecisionMakerAdapter_SendSchedulingIntent_Call{Call: _e.mock.On("SendSchedulingIntent", ctx, decisionMaker, intents)}
+func (_e *MockDecisionMakerAdapter_Expecter) PreviewSchedulingIntents(ctx interface{}, decisionMaker interface{}, intents interface{}) *MockDecisionMakerAdapter_PreviewSchedulingIntents_Call {
+	return &MockDecisionMakerAdapter_PreviewSchedulingIntents_Call{Call: _e.mock.On("PreviewSchedulingIntents", ctx, decisionMaker, intents)}
 }
 
-func (_c *MockDecisionMakerAdapter_SendSchedulingIntent_Call) Run(run func(ctx context.Context, decisionMaker *DecisionMakerPod, intents []*ScheduleIntent)) *MockDecisionMakerAdapter_SendSchedulingIntent_Call {
+func (_c *MockDecisionMakerAdapter_PreviewSchedulingIntents_Call) Run(run func(ctx context.Context, decisionMaker *DecisionMakerPod, intents []*ScheduleIntent)) *MockDecisionMakerAdapter_PreviewSchedulingIntents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2207,47 +4388,58 @@ func (_c *MockDecisionMakerAdapter_SendSchedulingIntent_Call) Run(run func(ctx c
 	return _c
 }
 
-func (_c *MockDecisionMakerAdapter_SendSchedulingIntent_Call) Return(err error) *MockDecisionMakerAdapter_SendSchedulingIntent_Call {
-	_c.Call.Return(err)
+func (_c *MockDecisionMakerAdapter_PreviewSchedulingIntents_Call) Return(intentPreviews []*IntentPreview, err error) *MockDecisionMakerAdapter_PreviewSchedulingIntents_Call {
+	_c.Call.Return(intentPreviews, err)
 	return _c
 }
 
-func (_c *MockDecisionMakerAdapter_SendSchedulingIntent_Call) RunAndReturn(run func(ctx context.Context, decisionMaker *DecisionMakerPod, intents []*ScheduleIntent) error) *MockDecisionMakerAdapter_SendSchedulingIntent_Call {
+func (_c *MockDecisionMakerAdapter_PreviewSchedulingIntents_Call) RunAndReturn(run func(ctx context.Context, decisionMaker *DecisionMakerPod, intents []*ScheduleIntent) ([]*IntentPreview, error)) *MockDecisionMakerAdapter_PreviewSchedulingIntents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteSchedulingIntents provides a mock function for the type MockDecisionMakerAdapter
-func (_mock *MockDecisionMakerAdapter) DeleteSchedulingIntents(ctx context.Context, decisionMaker *DecisionMakerPod, req *DeleteIntentsRequest) error {
-	ret := _mock.Called(ctx, decisionMaker, req)
+// SendSchedulingIntent provides a mock function for the type MockDecisionMakerAdapter
+func (_mock *MockDecisionMakerAdapter) SendSchedulingIntent(ctx context.Context, decisionMaker *DecisionMakerPod, intents []*ScheduleIntent) ([]*IntentResult, error) {
+	ret := _mock.Called(ctx, decisionMaker, intents)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteSchedulingIntents")
+		panic("no return value specified for SendSchedulingIntent")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *DecisionMakerPod, *DeleteIntentsRequest) error); ok {
-		r0 = returnFunc(ctx, decisionMaker, req)
+	var r0 []*IntentResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *DecisionMakerPod, []*ScheduleIntent) ([]*IntentResult, error)); ok {
+		return returnFunc(ctx, decisionMaker, intents)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *DecisionMakerPod, []*ScheduleIntent) []*IntentResult); ok {
+		r0 = returnFunc(ctx, decisionMaker, intents)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*IntentResult)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *DecisionMakerPod, []*ScheduleIntent) error); ok {
+		r1 = returnFunc(ctx, decisionMaker, intents)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDecisionMakerAdapter_DeleteSchedulingIntents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSchedulingIntents'
-type MockDecisionMakerAdapter_DeleteSchedulingIntents_Call struct {
+// MockDecisionMakerAdapter_SendSchedulingIntent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendSchedulingIntent'
+type MockDecisionMakerAdapter_SendSchedulingIntent_Call struct {
 	*mock.Call
 }
 
-// DeleteSchedulingIntents is a helper method to define mock.On call
+// SendSchedulingIntent is a helper method to define mock.On call
 //   - ctx context.Context
 //   - decisionMaker *DecisionMakerPod
-//   - req *DeleteIntentsRequest
-func (_e *MockDecisionMakerAdapter_Expecter) DeleteSchedulingIntents(ctx interface{}, decisionMaker interface{}, req interface{}) *MockDecisionMakerAdapter_DeleteSchedulingIntents_Call {
-	return &MockDecisionMakerAdapter_DeleteSchedulingIntents_Call{Call: _e.mock.On("DeleteSchedulingIntents", ctx, decisionMaker, req)}
+//   - intents []*ScheduleIntent
+func (_e *MockDecisionMakerAdapter_Expecter) SendSchedulingIntent(ctx interface{}, decisionMaker interface{}, intents interface{}) *MockDecisionMakerAdapter_SendSchedulingIntent_Call {
+	return &MockDecisionMakerAdapter_SendSchedulingIntent_Call{Call: _e.mock.On("SendSchedulingIntent", ctx, decisionMaker, intents)}
 }
 
-func (_c *MockDecisionMakerAdapter_DeleteSchedulingIntents_Call) Run(run func(ctx context.Context, decisionMaker *DecisionMakerPod, req *DeleteIntentsRequest)) *MockDecisionMakerAdapter_DeleteSchedulingIntents_Call {
+func (_c *MockDecisionMakerAdapter_SendSchedulingIntent_Call) Run(run func(ctx context.Context, decisionMaker *DecisionMakerPod, intents []*ScheduleIntent)) *MockDecisionMakerAdapter_SendSchedulingIntent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2257,9 +4449,9 @@ func (_c *MockDecisionMakerAdapter_DeleteSchedulingIntents_Call) Run(run func(ct
 		if args[1] != nil {
 			arg1 = args[1].(*DecisionMakerPod)
 		}
-		var arg2 *DeleteIntentsRequest
+		var arg2 []*ScheduleIntent
 		if args[2] != nil {
-			arg2 = args[2].(*DeleteIntentsRequest)
+			arg2 = args[2].([]*ScheduleIntent)
 		}
 		run(
 			arg0,
@@ -2270,12 +4462,12 @@ func (_c *MockDecisionMakerAdapter_DeleteSchedulingIntents_Call) Run(run func(ct
 	return _c
 }
 
-func (_c *MockDecisionMakerAdapter_DeleteSchedulingIntents_Call) Return(err error) *MockDecisionMakerAdapter_DeleteSchedulingIntents_Call {
-	_c.Call.Return(err)
+func (_c *MockDecisionMakerAdapter_SendSchedulingIntent_Call) Return(intentResults []*IntentResult, err error) *MockDecisionMakerAdapter_SendSchedulingIntent_Call {
+	_c.Call.Return(intentResults, err)
 	return _c
 }
 
-func (_c *MockDecisionMakerAdapter_DeleteSchedulingIntents_Call) RunAndReturn(run func(ctx context.Context, decisionMaker *DecisionMakerPod, req *DeleteIntentsRequest) error) *MockDecisionMakerAdapter_DeleteSchedulingIntents_Call {
+func (_c *MockDecisionMakerAdapter_SendSchedulingIntent_Call) RunAndReturn(run func(ctx context.Context, decisionMaker *DecisionMakerPod, intents []*ScheduleIntent) ([]*IntentResult, error)) *MockDecisionMakerAdapter_SendSchedulingIntent_Call {
 	_c.Call.Return(run)
 	return _c
 }
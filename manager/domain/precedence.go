@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"cmp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Specificity counts the criteria of the strategy: every pod, namespace and node label requirement,
+// the namespace list, the command regex, the workload, the containers, every process matcher and the thread regex.
+// A more specific strategy takes precedence over a broader one of the same weight.
+func (s *ScheduleStrategy) Specificity() int {
+	specificity := len(s.LabelRequirements()) + len(s.NamespaceSelector.Requirements()) + len(s.NodeSelector.Requirements())
+	if len(s.K8sNamespace) > 0 {
+		specificity++
+	}
+	if s.CommandRegex != "" {
+		specificity++
+	}
+	if s.Workload != nil {
+		specificity++
+	}
+	if s.TargetsContainers() {
+		specificity++
+	}
+	specificity += len(s.ProcessMatchers)
+	if s.ThreadRegex != "" {
+		specificity++
+	}
+	return specificity
+}
+
+// CompareIntentPrecedence orders two intents targeting the same process, it returns a positive number when a takes precedence over b.
+// The higher weight wins, then the more specific strategy, then the newest strategy; the strategy ID, the command regex,
+// the process matchers and the thread regex break the remaining ties so that the decision makers always pick the same intent.
+func CompareIntentPrecedence(a, b *ScheduleIntent) int {
+	if c := cmp.Compare(a.Weight, b.Weight); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Specificity, b.Specificity); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.StrategyCreatedTime, b.StrategyCreatedTime); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.StrategyID.Hex(), b.StrategyID.Hex()); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.CommandRegex, b.CommandRegex); c != 0 {
+		return c
+	}
+	if c := strings.Compare(MatchersKey(a.ProcessMatchers), MatchersKey(b.ProcessMatchers)); c != 0 {
+		return c
+	}
+	return strings.Compare(a.ThreadRegex, b.ThreadRegex)
+}
+
+// InEffect reports whether the intent is enforced or about to be, failed and dormant intents never bind a process
+func (i *ScheduleIntent) InEffect() bool {
+	return i.State != IntentStateFailed && !i.Dormant()
+}
+
+// Dormant reports whether the strategy of the intent is outside its active time, the intent is kept off the decision makers
+func (i *ScheduleIntent) Dormant() bool {
+	return i.State == IntentStateScheduled || i.State == IntentStateExpired
+}
+
+// StrategyConflict is another strategy targeting some pods of a strategy with a different priority or execution time
+type StrategyConflict struct {
+	StrategyID    bson.ObjectID
+	PodIDs        []string
+	Priority      int
+	ExecutionTime int64
+	Weight        int
+	// TakesPrecedence reports whether the conflicting strategy wins over the strategy on those pods
+	TakesPrecedence bool
+}
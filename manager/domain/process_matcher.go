@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// fields of a process a ProcessMatcher applies to
+const (
+	ProcessFieldComm    = "comm"    // command name, truncated to 15 characters by the kernel
+	ProcessFieldCmdline = "cmdline" // full command line, the arguments separated by spaces
+	ProcessFieldExe     = "exe"     // path of the executable
+	ProcessFieldUID     = "uid"     // effective user ID
+	ProcessFieldGID     = "gid"     // effective group ID
+	ProcessFieldCgroup  = "cgroup"  // cgroup path
+	ProcessFieldThread  = "thread"  // name of any thread of the process
+)
+
+var processFields = []string{
+	ProcessFieldComm,
+	ProcessFieldCmdline,
+	ProcessFieldExe,
+	ProcessFieldUID,
+	ProcessFieldGID,
+	ProcessFieldCgroup,
+	ProcessFieldThread,
+}
+
+// ProcessMatcher matches a regex against a field of the processes on the decision makers,
+// the matchers of a strategy are ANDed with its command regex, which applies to the comm of the processes
+type ProcessMatcher struct {
+	Field string `bson:"field"`
+	Regex string `bson:"regex"`
+}
+
+// ValidateProcessMatchers reports whether every matcher applies to a known field with a valid regex
+func ValidateProcessMatchers(matchers []ProcessMatcher) error {
+	for _, matcher := range matchers {
+		if !slices.Contains(processFields, matcher.Field) {
+			return fmt.Errorf("unknown field %q, expected one of %s", matcher.Field, strings.Join(processFields, ", "))
+		}
+		_, err := regexp.Compile(matcher.Regex)
+		if err != nil {
+			return fmt.Errorf("%s: %w", matcher.Field, err)
+		}
+	}
+	return nil
+}
+
+// MatchersKey identifies a list of matchers the same way the decision makers do, it is empty when there is none
+func MatchersKey(matchers []ProcessMatcher) string {
+	var key strings.Builder
+	for _, matcher := range matchers {
+		key.WriteString("&" + matcher.Field + "=" + matcher.Regex)
+	}
+	return key.String()
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Gthulhu/api/pkg/util"
+)
+
+// RecurringWindow activates a strategy for DurationSec seconds every time the cron expression fires, the cron expression is evaluated in UTC
+type RecurringWindow struct {
+	Cron        string `bson:"cron,omitempty"`
+	DurationSec int64  `bson:"durationSec,omitempty"`
+}
+
+// contains reports whether a window started by the cron expression is open at the given time
+func (w *RecurringWindow) contains(now time.Time) bool {
+	schedule, err := util.ParseCron(w.Cron)
+	if err != nil {
+		return false
+	}
+	// the window containing now started in (now-duration, now]
+	start := schedule.Next(now.UTC().Add(-time.Duration(w.DurationSec) * time.Second))
+	return !start.IsZero() && !start.After(now)
+}
+
+// IsTimeBounded reports whether the strategy has an activation time, an expiry time or a recurring window
+func (s *ScheduleStrategy) IsTimeBounded() bool {
+	return s.ActivateAt > 0 || s.ExpireAt > 0 || s.Window != nil
+}
+
+// ValidateSchedule reports whether the activation and expiry times and the recurring window of the strategy are consistent
+func (s *ScheduleStrategy) ValidateSchedule() error {
+	if s.ActivateAt > 0 && s.ExpireAt > 0 && s.ExpireAt <= s.ActivateAt {
+		return errors.New("expireAt must be after activateAt")
+	}
+	if s.Window == nil {
+		return nil
+	}
+	if s.Window.DurationSec <= 0 {
+		return errors.New("the duration of the recurring window must be positive")
+	}
+	_, err := util.ParseCron(s.Window.Cron)
+	if err != nil {
+		return fmt.Errorf("recurring window: %w", err)
+	}
+	return nil
+}
+
+// IntentStateAt returns the state of the intents of the strategy at the given time: initialized while the strategy is active,
+// scheduled before its activation or outside its recurring window, and expired once it expired
+func (s *ScheduleStrategy) IntentStateAt(now time.Time) IntentState {
+	if s.ExpireAt > 0 && now.UnixMilli() >= s.ExpireAt {
+		return IntentStateExpired
+	}
+	if s.ActivateAt > 0 && now.UnixMilli() < s.ActivateAt {
+		return IntentStateScheduled
+	}
+	if s.Window != nil && !s.Window.contains(now) {
+		return IntentStateScheduled
+	}
+	return IntentStateInitialized
+}
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// label selector operators, the same as metav1.LabelSelectorOperator
+const (
+	LabelSelectorOpIn           = string(metav1.LabelSelectorOpIn)
+	LabelSelectorOpNotIn        = string(metav1.LabelSelectorOpNotIn)
+	LabelSelectorOpExists       = string(metav1.LabelSelectorOpExists)
+	LabelSelectorOpDoesNotExist = string(metav1.LabelSelectorOpDoesNotExist)
+)
+
+// LabelSelectorRequirement mirrors metav1.LabelSelectorRequirement, a != selector is a NotIn requirement with a single value
+type LabelSelectorRequirement struct {
+	Key      string   `bson:"key,omitempty"`
+	Operator string   `bson:"operator,omitempty"`
+	Values   []string `bson:"values,omitempty"`
+}
+
+// LabelSelectorSpec mirrors metav1.LabelSelector
+type LabelSelectorSpec struct {
+	MatchLabels      map[string]string          `bson:"matchLabels,omitempty"`
+	MatchExpressions []LabelSelectorRequirement `bson:"matchExpressions,omitempty"`
+}
+
+// Requirements returns the match labels as In requirements with a single value, followed by the match expressions
+func (s *LabelSelectorSpec) Requirements() []LabelSelectorRequirement {
+	if s == nil {
+		return nil
+	}
+	return append(matchLabelsAsRequirements(s.MatchLabels), s.MatchExpressions...)
+}
+
+// LabelRequirements returns every label selector of the strategy as requirements: the key/value label selectors and
+// the match labels are In requirements with a single value, a label selector without value is an Exists requirement
+func (s *ScheduleStrategy) LabelRequirements() []LabelSelectorRequirement {
+	requirements := labelSelectorsAsRequirements(s.LabelSelectors)
+	requirements = append(requirements, matchLabelsAsRequirements(s.MatchLabels)...)
+	return append(requirements, s.MatchExpressions...)
+}
+
+// ValidateLabelSelector reports whether the pod, namespace and node label selectors of the strategy are valid kubernetes label selectors
+// its workload, if any, is fully identified and its container, command and thread regexes and process matchers are valid
+func (s *ScheduleStrategy) ValidateLabelSelector() error {
+	_, err := NewK8SLabelSelector(s.LabelRequirements())
+	if err != nil {
+		return err
+	}
+	_, err = NewK8SLabelSelector(s.NamespaceSelector.Requirements())
+	if err != nil {
+		return fmt.Errorf("namespace selector: %w", err)
+	}
+	_, err = NewK8SLabelSelector(s.NodeSelector.Requirements())
+	if err != nil {
+		return fmt.Errorf("node selector: %w", err)
+	}
+	err = s.Workload.Validate()
+	if err != nil {
+		return fmt.Errorf("workload: %w", err)
+	}
+	_, err = s.ContainerMatcher()
+	if err != nil {
+		return fmt.Errorf("containers: %w", err)
+	}
+	err = ValidateProcessMatchers(s.ProcessMatchers)
+	if err != nil {
+		return fmt.Errorf("process matchers: %w", err)
+	}
+	_, err = regexp.Compile(s.ThreadRegex)
+	if err != nil {
+		return fmt.Errorf("thread regex: %w", err)
+	}
+	return nil
+}
+
+func matchLabelsAsRequirements(matchLabels map[string]string) []LabelSelectorRequirement {
+	keys := make([]string, 0, len(matchLabels))
+	for key := range matchLabels {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	requirements := make([]LabelSelectorRequirement, 0, len(keys))
+	for _, key := range keys {
+		requirements = append(requirements, LabelSelectorRequirement{
+			Key:      key,
+			Operator: LabelSelectorOpIn,
+			Values:   []string{matchLabels[key]},
+		})
+	}
+	return requirements
+}
+
+func labelSelectorsAsRequirements(selectors []LabelSelector) []LabelSelectorRequirement {
+	requirements := make([]LabelSelectorRequirement, 0, len(selectors))
+	for _, selector := range selectors {
+		if selector.Key == "" {
+			continue
+		}
+		if selector.Value == "" {
+			requirements = append(requirements, LabelSelectorRequirement{Key: selector.Key, Operator: LabelSelectorOpExists})
+			continue
+		}
+		requirements = append(requirements, LabelSelectorRequirement{
+			Key:      selector.Key,
+			Operator: LabelSelectorOpIn,
+			Values:   []string{selector.Value},
+		})
+	}
+	return requirements
+}
+
+// NewK8SLabelSelector converts the requirements with metav1.LabelSelectorAsSelector, which validates the keys, operators and values.
+// No requirement selects everything.
+func NewK8SLabelSelector(requirements []LabelSelectorRequirement) (labels.Selector, error) {
+	labelSelector := &metav1.LabelSelector{
+		MatchExpressions: make([]metav1.LabelSelectorRequirement, 0, len(requirements)),
+	}
+	for _, requirement := range requirements {
+		labelSelector.MatchExpressions = append(labelSelector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      requirement.Key,
+			Operator: metav1.LabelSelectorOperator(requirement.Operator),
+			Values:   requirement.Values,
+		})
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector: %w", err)
+	}
+	return selector, nil
+}
+
+// K8SLabelSelector combines the label selectors and the label requirements of the query
+func (o *QueryPodsOptions) K8SLabelSelector() (labels.Selector, error) {
+	return NewK8SLabelSelector(append(labelSelectorsAsRequirements(o.LabelSelectors), o.LabelRequirements...))
+}
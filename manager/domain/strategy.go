@@ -1,48 +1,254 @@
 package domain
 
 import (
+	"slices"
+	"time"
+
 	"github.com/Gthulhu/api/pkg/util"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 type ScheduleStrategy struct {
-	BaseEntity        `bson:",inline"`
-	StrategyNamespace string          `bson:"strategyNamespace,omitempty"`
-	LabelSelectors    []LabelSelector `bson:"labelSelectors,omitempty"`
-	K8sNamespace      []string        `bson:"k8sNamespace,omitempty"`
-	CommandRegex      string          `bson:"commandRegex,omitempty"`
-	Priority          int             `bson:"priority,omitempty"`
-	ExecutionTime     int64           `bson:"executionTime,omitempty"`
+	BaseEntity         `bson:",inline"`
+	StrategyNamespace  string                     `bson:"strategyNamespace,omitempty"`
+	Name               string                     `bson:"name,omitempty"` // identifies the strategy in the bundles of its strategy namespace
+	LabelSelectors     []LabelSelector            `bson:"labelSelectors,omitempty"`
+	MatchLabels        map[string]string          `bson:"matchLabels,omitempty"`
+	MatchExpressions   []LabelSelectorRequirement `bson:"matchExpressions,omitempty"`
+	K8sNamespace       []string                   `bson:"k8sNamespace,omitempty"`
+	NamespaceSelector  *LabelSelectorSpec         `bson:"namespaceSelector,omitempty"` // selects the namespaces by their labels, ANDed with K8sNamespace
+	NodeSelector       *LabelSelectorSpec         `bson:"nodeSelector,omitempty"`      // selects the nodes the pods run on by their labels
+	Workload           *WorkloadRef               `bson:"workload,omitempty"`          // selects the pods controlled by the workload
+	ContainerNames     []string                   `bson:"containerNames,omitempty"`    // targets the containers with these names instead of every container of the pods
+	ContainerRegex     string                     `bson:"containerRegex,omitempty"`    // targets the containers whose name matches, ORed with ContainerNames
+	CommandRegex       string                     `bson:"commandRegex,omitempty"`
+	ProcessMatchers    []ProcessMatcher           `bson:"processMatchers,omitempty"`    // ANDed with the command regex
+	ThreadRegex        string                     `bson:"threadRegex,omitempty"`        // targets the matching threads of the processes instead of the processes
+	IncludeDescendants bool                       `bson:"includeDescendants,omitempty"` // the descendants of the matching processes are targeted too
+	Priority           int                        `bson:"priority,omitempty"`
+	ExecutionTime      int64                      `bson:"executionTime,omitempty"`
+	Weight             int                        `bson:"weight,omitempty"`     // precedence over the other strategies targeting the same processes, higher wins
+	ActivateAt         int64                      `bson:"activateAt,omitempty"` // unix milli time before which the strategy is not enforced
+	ExpireAt           int64                      `bson:"expireAt,omitempty"`   // unix milli time from which the strategy is no longer enforced
+	Window             *RecurringWindow           `bson:"window,omitempty"`     // the strategy is only enforced inside the window
+	TemplateID         bson.ObjectID              `bson:"templateID,omitempty"` // template the priority and execution time are derived from
+	Resource           *StrategyResourceRef       `bson:"resource,omitempty"`   // SchedulingStrategy resource the strategy is mirrored from
+	Annotation         bool                       `bson:"annotation,omitempty"` // targets the pods with scheduling hints instead of selecting them, see NewAnnotationStrategy
+}
+
+// PodsQuery returns the options to query the pods targeted by the strategy
+func (s *ScheduleStrategy) PodsQuery() *QueryPodsOptions {
+	return &QueryPodsOptions{
+		K8SNamespace:          s.K8sNamespace,
+		NamespaceRequirements: s.NamespaceSelector.Requirements(),
+		NodeRequirements:      s.NodeSelector.Requirements(),
+		LabelRequirements:     s.LabelRequirements(),
+		CommandRegex:          s.CommandRegex,
+		ContainerNames:        s.ContainerNames,
+		ContainerRegex:        s.ContainerRegex,
+		Workload:              s.Workload,
+	}
+}
+
+// MatchesPod reports whether the pod is selected by the strategy, using the same rules as K8SAdapter.QueryPods:
+// the pod must live in one of the strategy namespaces (any namespace if empty) whose labels match the namespace selector,
+// run on a node whose labels match the node selector, be controlled by the workload if any, match the label selectors and
+// match expressions and, if a command regex or containers are set, have a matching container. The annotation strategy matches the pods with scheduling hints.
+func (s *ScheduleStrategy) MatchesPod(pod *Pod) bool {
+	if pod == nil {
+		return false
+	}
+	if s.Annotation {
+		return pod.Hints != nil
+	}
+	if len(s.K8sNamespace) > 0 && !slices.Contains(s.K8sNamespace, pod.K8SNamespace) {
+		return false
+	}
+	nsSelector, err := NewK8SLabelSelector(s.NamespaceSelector.Requirements())
+	if err != nil || !nsSelector.Matches(labels.Set(pod.NamespaceLabels)) {
+		return false
+	}
+	if s.NodeSelector != nil {
+		nodeSelector, err := NewK8SLabelSelector(s.NodeSelector.Requirements())
+		if err != nil || pod.NodeID == "" || !nodeSelector.Matches(labels.Set(pod.NodeLabels)) {
+			return false
+		}
+	}
+	if s.Workload != nil && !s.Workload.Equal(pod.Workload) {
+		return false
+	}
+	selector, err := NewK8SLabelSelector(s.LabelRequirements())
+	if err != nil || !selector.Matches(labels.Set(pod.Labels)) {
+		return false
+	}
+	matcher, err := s.ContainerMatcher()
+	if err != nil {
+		return false
+	}
+	return matcher == nil || len(matcher.MatchingContainers(pod.Containers)) > 0
 }
 
 func NewScheduleIntent(strategy *ScheduleStrategy, pod *Pod) ScheduleIntent {
-	return ScheduleIntent{
-		BaseEntity:    NewBaseEntity(util.Ptr(strategy.CreatorID), util.Ptr(strategy.UpdaterID)),
-		StrategyID:    strategy.ID,
-		PodID:         pod.PodID,
-		NodeID:        pod.NodeID,
-		K8sNamespace:  pod.K8SNamespace,
-		CommandRegex:  strategy.CommandRegex,
-		Priority:      strategy.Priority,
-		ExecutionTime: strategy.ExecutionTime,
-		PodLabels:     pod.Labels,
-		State:         IntentStateInitialized,
-		PodName:       pod.Name,
+	intent := ScheduleIntent{
+		BaseEntity:          NewBaseEntity(util.Ptr(strategy.CreatorID), util.Ptr(strategy.UpdaterID)),
+		StrategyID:          strategy.ID,
+		PodID:               pod.PodID,
+		NodeID:              pod.NodeID,
+		K8sNamespace:        pod.K8SNamespace,
+		Workload:            pod.Workload,
+		CommandRegex:        strategy.CommandRegex,
+		ProcessMatchers:     strategy.ProcessMatchers,
+		ThreadRegex:         strategy.ThreadRegex,
+		IncludeDescendants:  strategy.IncludeDescendants,
+		Priority:            strategy.Priority,
+		ExecutionTime:       strategy.ExecutionTime,
+		PodLabels:           pod.Labels,
+		Selector:            strategy.LabelRequirements(),
+		State:               strategy.IntentStateAt(time.Now()),
+		PodName:             pod.Name,
+		Weight:              strategy.Weight,
+		Specificity:         strategy.Specificity(),
+		StrategyCreatedTime: strategy.CreatedTime,
+	}
+	if strategy.Annotation && pod.Hints != nil {
+		intent.Priority = pod.Hints.Priority
+		intent.ExecutionTime = pod.Hints.ExecutionTime
 	}
+	if strategy.TargetsContainers() {
+		intent.ContainerIDs = make([]string, 0)
+		if matcher, err := strategy.ContainerMatcher(); err == nil {
+			for _, container := range matcher.MatchingContainers(pod.Containers) {
+				intent.ContainerIDs = append(intent.ContainerIDs, RuntimeContainerID(container.ContainerID))
+			}
+		}
+	}
+	return intent
 }
 
 type ScheduleIntent struct {
-	BaseEntity    `bson:",inline"`
-	StrategyID    bson.ObjectID     `bson:"strategyID,omitempty"`
-	PodID         string            `bson:"podID,omitempty"`
-	PodName       string            `bson:"podName,omitempty"`
-	NodeID        string            `bson:"nodeID,omitempty"`
-	K8sNamespace  string            `bson:"k8sNamespace,omitempty"`
-	CommandRegex  string            `bson:"commandRegex,omitempty"`
-	Priority      int               `bson:"priority,omitempty"`
-	ExecutionTime int64             `bson:"executionTime,omitempty"`
-	PodLabels     map[string]string `bson:"podLabels,omitempty"`
-	State         IntentState       `bson:"state,omitempty"`
+	BaseEntity          `bson:",inline"`
+	StrategyID          bson.ObjectID              `bson:"strategyID,omitempty"`
+	PodID               string                     `bson:"podID,omitempty"`
+	PodName             string                     `bson:"podName,omitempty"`
+	NodeID              string                     `bson:"nodeID,omitempty"`
+	K8sNamespace        string                     `bson:"k8sNamespace,omitempty"`
+	Workload            *WorkloadRef               `bson:"workload,omitempty"` // workload controlling the pod
+	CommandRegex        string                     `bson:"commandRegex,omitempty"`
+	ProcessMatchers     []ProcessMatcher           `bson:"processMatchers,omitempty"`    // ANDed with the command regex
+	ThreadRegex         string                     `bson:"threadRegex,omitempty"`        // the decision makers bind the matching threads of the processes
+	IncludeDescendants  bool                       `bson:"includeDescendants,omitempty"` // the descendants of the matching processes are targeted too
+	ContainerIDs        []string                   `bson:"containerIDs,omitempty"`       // containers of the pod the intent is restricted to, every container if empty
+	Priority            int                        `bson:"priority,omitempty"`
+	ExecutionTime       int64                      `bson:"executionTime,omitempty"`
+	PodLabels           map[string]string          `bson:"podLabels,omitempty"`
+	Selector            []LabelSelectorRequirement `bson:"selector,omitempty"`    // label selector of the strategy that targeted the pod
+	Weight              int                        `bson:"weight,omitempty"`      // precedence of the strategy, see CompareIntentPrecedence
+	Specificity         int                        `bson:"specificity,omitempty"` // criteria count of the strategy
+	StrategyCreatedTime int64                      `bson:"strategyCreatedTime,omitempty"`
+	State               IntentState                `bson:"state,omitempty"`
+	DeliveryAttempts    int                        `bson:"deliveryAttempts,omitempty"` // failed deliveries to the decision maker of the node
+	NextDeliveryTime    int64                      `bson:"nextDeliveryTime,omitempty"` // unix milli time before which the delivery is not retried
+	LastError           string                     `bson:"lastError,omitempty"`        // error of the last failed delivery or reported by the decision maker
+	MatchedPIDs         int                        `bson:"matchedPIDs,omitempty"`      // processes bound to the intent by the decision makers
+}
+
+// IntentDelivery is the outcome of a failed delivery attempt of schedule intents
+type IntentDelivery struct {
+	State            IntentState
+	DeliveryAttempts int
+	NextDeliveryTime int64
+	LastError        string
+}
+
+// IntentStateUpdate is the state of a schedule intent acknowledged by the decision makers of its node
+type IntentStateUpdate struct {
+	IntentID    bson.ObjectID
+	State       IntentState
+	MatchedPIDs int
+	LastError   string
+}
+
+// IntentResult is the acknowledgement of an intent by a decision maker, intents are identified by their pod ID, strategy,
+// command regex, process matchers, thread regex and containers
+type IntentResult struct {
+	PodID           string
+	StrategyID      string // hex ID of the strategy of the intent
+	CommandRegex    string
+	ProcessMatchers []ProcessMatcher
+	ThreadRegex     string
+	ContainerIDs    []string
+	State           IntentState
+	MatchedPIDs     int
+	Error           string
+}
+
+// IntentPreview lists the processes a decision maker would bind an intent to
+type IntentPreview struct {
+	PodID        string
+	CommandRegex string
+	Processes    []*ProcessPreview
+	Error        string
+}
+
+type ProcessPreview struct {
+	PID         int
+	Command     string
+	ContainerID string
+	Cmdline     string
+	Exe         string
+	TIDs        []int // threads the intent would be bound to, with a thread regex
+}
+
+// StrategyPreview is the resolution of a strategy that is not created, grouped by node
+type StrategyPreview struct {
+	Nodes []*NodePreview
+}
+
+type NodePreview struct {
+	NodeID string
+	Pods   []*PodPreview
+	Error  string // why the processes of the node could not be previewed
+}
+
+type PodPreview struct {
+	PodID        string
+	PodName      string
+	K8sNamespace string
+	Containers   []Container
+	Processes    []*ProcessPreview // only previewed on request
+}
+
+// EffectivePolicyQuery identifies a pod by its UID, or by its namespace and name
+type EffectivePolicyQuery struct {
+	PodID        string
+	K8sNamespace string
+	PodName      string
+}
+
+// EffectivePolicy is every strategy and intent touching a pod and the scheduling its decision maker enforces per PID
+type EffectivePolicy struct {
+	PodID        string
+	PodName      string
+	K8sNamespace string
+	NodeID       string
+	Strategies   []*ScheduleStrategy
+	// Intents are ordered by precedence, a process matched by several intents is bound to the first one
+	Intents []*ScheduleIntent
+	// WinningIntentID is the intent that takes precedence, zero when no intent is in effect
+	WinningIntentID    bson.ObjectID
+	Processes          []*ProcessScheduling
+	DecisionMakerError string // why the processes could not be queried from the decision maker
+}
+
+// ProcessScheduling is the scheduling a decision maker enforces for a process
+type ProcessScheduling struct {
+	PID           int
+	TID           int // thread of the process the scheduling is enforced for, 0 for the whole process
+	Priority      bool
+	ExecutionTime uint64
+	CommandRegex  string
+	StrategyID    string // strategy of the intent that took precedence for the process
 }
 
 type LabelSelector struct {
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"strings"
+)
+
+// resourceStrategyNamespacePrefix prefixes the strategy namespace of the strategies mirrored from SchedulingStrategy resources
+const resourceStrategyNamespacePrefix = "k8s/"
+
+// ResourceStrategyNamespace returns the strategy namespace of the strategies mirrored from the SchedulingStrategy resources of a kubernetes namespace
+func ResourceStrategyNamespace(k8sNamespace string) string {
+	return resourceStrategyNamespacePrefix + k8sNamespace
+}
+
+// IsResourceStrategyNamespace reports whether the strategies of the strategy namespace are managed by SchedulingStrategy resources
+func IsResourceStrategyNamespace(strategyNamespace string) bool {
+	return strings.HasPrefix(strategyNamespace, resourceStrategyNamespacePrefix)
+}
+
+// StrategyResourceRef identifies the SchedulingStrategy resource a strategy is mirrored from
+type StrategyResourceRef struct {
+	UID       string `bson:"uid"`
+	Namespace string `bson:"namespace"`
+	Name      string `bson:"name"`
+}
+
+// StrategyResource is a SchedulingStrategy custom resource, a strategy declared in the namespace of the pods it targets
+type StrategyResource struct {
+	StrategyResourceRef
+	Generation int64
+	Template   string            // name of the template the priority and execution time are derived from
+	Spec       *ScheduleStrategy // selectors and scheduling parameters of the resource
+	SpecErr    error             // the spec could not be decoded
+}
+
+// StrategyResourceEvent describes a change of a SchedulingStrategy resource observed by the K8S adapter,
+// a periodic resync is notified as an update
+type StrategyResourceEvent struct {
+	Type     StrategyResourceEventType
+	Resource *StrategyResource
+}
+
+// StrategyResourceHandler is called by the K8S adapter for every SchedulingStrategy resource event
+type StrategyResourceHandler func(ctx context.Context, event *StrategyResourceEvent)
+
+const (
+	StrategyResourcePhaseReady   = "Ready"
+	StrategyResourcePhaseInvalid = "Invalid"
+)
+
+// StrategyResourceStatus is the state of the strategy mirrored from a SchedulingStrategy resource, written back to the resource
+type StrategyResourceStatus struct {
+	ObservedGeneration int64
+	Phase              string
+	Message            string
+	StrategyID         string
+	MatchedPods        int
+	Nodes              []string
+	IntentStates       map[string]int // number of intents in every state
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "go.mongodb.org/mongo-driver/v2/bson"
+
+// StrategyTemplate is a named preset of scheduling parameters, a strategy created from a template takes its priority and execution time
+type StrategyTemplate struct {
+	BaseEntity    `bson:",inline"`
+	Name          string `bson:"name,omitempty"`
+	Description   string `bson:"description,omitempty"`
+	Priority      int    `bson:"priority,omitempty"`
+	ExecutionTime int64  `bson:"executionTime,omitempty"`
+}
+
+// ApplyTo derives the strategy from the template, the scheduling parameters of the template replace the ones of the strategy
+func (t *StrategyTemplate) ApplyTo(strategy *ScheduleStrategy) {
+	strategy.TemplateID = t.ID
+	strategy.Priority = t.Priority
+	strategy.ExecutionTime = t.ExecutionTime
+}
+
+type QueryStrategyTemplateOptions struct {
+	IDs    []bson.ObjectID
+	Names  []string
+	Result []*StrategyTemplate
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// kinds of the workloads a strategy can target
+const (
+	WorkloadKindDeployment  = "Deployment"
+	WorkloadKindReplicaSet  = "ReplicaSet"
+	WorkloadKindStatefulSet = "StatefulSet"
+	WorkloadKindDaemonSet   = "DaemonSet"
+	WorkloadKindJob         = "Job"
+	WorkloadKindCronJob     = "CronJob"
+)
+
+var workloadKinds = []string{
+	WorkloadKindDeployment,
+	WorkloadKindReplicaSet,
+	WorkloadKindStatefulSet,
+	WorkloadKindDaemonSet,
+	WorkloadKindJob,
+	WorkloadKindCronJob,
+}
+
+// WorkloadRef identifies the workload controlling a pod: the top of the chain of its controller owner references,
+// a pod of a Deployment is owned by a ReplicaSet owned by the Deployment
+type WorkloadRef struct {
+	Kind      string `bson:"kind"`
+	Namespace string `bson:"namespace"`
+	Name      string `bson:"name"`
+}
+
+// Validate reports whether the workload has a supported kind, a namespace and a name
+func (w *WorkloadRef) Validate() error {
+	if w == nil {
+		return nil
+	}
+	if !slices.Contains(workloadKinds, w.Kind) {
+		return fmt.Errorf("unsupported workload kind %q", w.Kind)
+	}
+	if w.Namespace == "" || w.Name == "" {
+		return errors.New("the namespace and name of the workload are required")
+	}
+	return nil
+}
+
+// Equal reports whether both refer to the same workload, nil only equals nil
+func (w *WorkloadRef) Equal(other *WorkloadRef) bool {
+	if w == nil || other == nil {
+		return w == other
+	}
+	return *w == *other
+}
+
+func (w *WorkloadRef) String() string {
+	return w.Kind + "/" + w.Namespace + "/" + w.Name
+}
@@ -3,7 +3,8 @@ package k8sadapter
 import (
 	"context"
 	"fmt"
-	"regexp"
+	"maps"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -14,6 +15,8 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -28,16 +31,40 @@ var (
 type Options struct {
 	KubeConfigPath string
 	InCluster      bool
+	// StrategyResources watches the SchedulingStrategy custom resources, the CRD must be installed
+	StrategyResources      bool
+	StrategyResourceResync time.Duration
+	// AnnotationNamespaces are the namespaces whose pods may request their scheduling with annotations
+	AnnotationNamespaces []string
 }
 
 type Adapter struct {
 	client         kubernetes.Interface
 	podCache       map[string]apiv1.Pod
 	podCacheMu     sync.RWMutex
+	nsCache        map[string]apiv1.Namespace
+	nsCacheMu      sync.RWMutex
+	nodeCache      map[string]apiv1.Node
+	nodeCacheMu    sync.RWMutex
+	ownerCache     map[types.UID]*metav1.OwnerReference // controller of every ReplicaSet and Job
+	ownerCacheMu   sync.RWMutex
+	podHandlers    []domain.PodEventHandler
+	podHandlersMu  sync.RWMutex
 	stopCh         chan struct{}
 	startWatcher   sync.Once
 	stopWatcher    sync.Once
 	cacheHasSynced atomic.Bool
+
+	annotationNamespaces []string
+
+	dynamicClient        dynamic.Interface
+	resourceCache        map[string]*domain.StrategyResource
+	resourceCacheMu      sync.RWMutex
+	resourceHandlers     []domain.StrategyResourceHandler
+	resourceHandlersMu   sync.RWMutex
+	startResourceWatcher sync.Once
+	resourceSynced       chan struct{} // closed once the SchedulingStrategy resources are cached
+	resourceSyncedOnce   sync.Once
 }
 
 func NewAdapter(opt Options) (*Adapter, error) {
@@ -56,12 +83,24 @@ func NewAdapter(opt Options) (*Adapter, error) {
 	}
 
 	adapter := &Adapter{
-		client:   client,
-		podCache: make(map[string]apiv1.Pod),
-		stopCh:   make(chan struct{}),
+		client:    client,
+		podCache:  make(map[string]apiv1.Pod),
+		nsCache:   make(map[string]apiv1.Namespace),
+		nodeCache: make(map[string]apiv1.Node),
+		stopCh:    make(chan struct{}),
+
+		annotationNamespaces: opt.AnnotationNamespaces,
 	}
 	adapter.startPodWatcher()
 
+	if opt.StrategyResources {
+		adapter.dynamicClient, err = dynamic.NewForConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("create kubernetes dynamic client: %w", err)
+		}
+		adapter.startStrategyResourceWatcher(opt.StrategyResourceResync)
+	}
+
 	return adapter, nil
 }
 
@@ -99,6 +138,7 @@ func (a *Adapter) startPodWatcher() {
 				}
 				logger.Logger(context.Background()).Debug().Msgf("pod added: %s/%s", pod.Namespace, pod.Name)
 				a.setPodCache(*pod)
+				a.notifyPodEvent(domain.PodEventAdded, *pod)
 			},
 			UpdateFunc: func(_, newObj interface{}) {
 				pod, ok := newObj.(*apiv1.Pod)
@@ -107,23 +147,113 @@ func (a *Adapter) startPodWatcher() {
 				}
 				logger.Logger(context.Background()).Debug().Msgf("pod updated: %s/%s", pod.Namespace, pod.Name)
 				a.setPodCache(*pod)
+				a.notifyPodEvent(domain.PodEventUpdated, *pod)
 			},
 			DeleteFunc: func(obj interface{}) {
 				switch pod := obj.(type) {
 				case *apiv1.Pod:
 					logger.Logger(context.Background()).Debug().Msgf("pod deleted: %s/%s", pod.Namespace, pod.Name)
 					a.deletePodCache(string(pod.UID))
+					a.notifyPodEvent(domain.PodEventDeleted, *pod)
 				case cache.DeletedFinalStateUnknown:
 					if p, ok := pod.Obj.(*apiv1.Pod); ok {
 						a.deletePodCache(string(p.UID))
+						a.notifyPodEvent(domain.PodEventDeleted, *p)
+					}
+				}
+			},
+		})
+
+		// a label change of a namespace is replayed as an update of each of its pods,
+		// so that the strategies selecting namespaces by label are reconciled like any pod change
+		nsInformer := informerFactory.Core().V1().Namespaces().Informer()
+		nsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				ns, ok := obj.(*apiv1.Namespace)
+				if !ok {
+					return
+				}
+				a.setNamespaceCache(*ns)
+				// the pods of the namespace may have been seen before the namespace itself
+				if len(ns.Labels) > 0 {
+					a.notifyNamespacePods(ns.Name)
+				}
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				oldNs, ok := oldObj.(*apiv1.Namespace)
+				if !ok {
+					return
+				}
+				ns, ok := newObj.(*apiv1.Namespace)
+				if !ok {
+					return
+				}
+				a.setNamespaceCache(*ns)
+				if !maps.Equal(oldNs.Labels, ns.Labels) {
+					logger.Logger(context.Background()).Debug().Msgf("namespace labels updated: %s", ns.Name)
+					a.notifyNamespacePods(ns.Name)
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				switch ns := obj.(type) {
+				case *apiv1.Namespace:
+					a.deleteNamespaceCache(ns.Name)
+				case cache.DeletedFinalStateUnknown:
+					if n, ok := ns.Obj.(*apiv1.Namespace); ok {
+						a.deleteNamespaceCache(n.Name)
+					}
+				}
+			},
+		})
+
+		// likewise a label change of a node is replayed as an update of each of the pods running on it
+		nodeInformer := informerFactory.Core().V1().Nodes().Informer()
+		nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				node, ok := obj.(*apiv1.Node)
+				if !ok {
+					return
+				}
+				a.setNodeCache(*node)
+				if len(node.Labels) > 0 {
+					a.notifyNodePods(node.Name)
+				}
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				oldNode, ok := oldObj.(*apiv1.Node)
+				if !ok {
+					return
+				}
+				node, ok := newObj.(*apiv1.Node)
+				if !ok {
+					return
+				}
+				a.setNodeCache(*node)
+				if !maps.Equal(oldNode.Labels, node.Labels) {
+					logger.Logger(context.Background()).Debug().Msgf("node labels updated: %s", node.Name)
+					a.notifyNodePods(node.Name)
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				switch node := obj.(type) {
+				case *apiv1.Node:
+					a.deleteNodeCache(node.Name)
+				case cache.DeletedFinalStateUnknown:
+					if n, ok := node.Obj.(*apiv1.Node); ok {
+						a.deleteNodeCache(n.Name)
 					}
 				}
 			},
 		})
 
+		rsInformer := informerFactory.Apps().V1().ReplicaSets().Informer()
+		a.watchOwners(rsInformer)
+		jobInformer := informerFactory.Batch().V1().Jobs().Informer()
+		a.watchOwners(jobInformer)
+
 		informerFactory.Start(a.stopCh)
 
-		synced := cache.WaitForCacheSync(a.stopCh, podInformer.HasSynced)
+		synced := cache.WaitForCacheSync(a.stopCh, podInformer.HasSynced, nsInformer.HasSynced, nodeInformer.HasSynced, rsInformer.HasSynced, jobInformer.HasSynced)
 		a.cacheHasSynced.Store(synced)
 		logger.Logger(context.Background()).Info().Msg("starting k8s pod watcher")
 	})
@@ -137,6 +267,48 @@ func (a *Adapter) StopPodWatcher() {
 	})
 }
 
+// AddPodEventHandler registers a handler that is called for every pod added, updated or deleted by the informer.
+// Pods that are already cached are replayed to the new handler as add events.
+func (a *Adapter) AddPodEventHandler(handler domain.PodEventHandler) {
+	if handler == nil {
+		return
+	}
+	a.podHandlersMu.Lock()
+	a.podHandlers = append(a.podHandlers, handler)
+	a.podHandlersMu.Unlock()
+
+	a.podCacheMu.RLock()
+	pods := make([]apiv1.Pod, 0, len(a.podCache))
+	for _, pod := range a.podCache {
+		pods = append(pods, pod)
+	}
+	a.podCacheMu.RUnlock()
+
+	for _, pod := range pods {
+		handler(context.Background(), &domain.PodEvent{
+			Type: domain.PodEventAdded,
+			Pod:  a.toDomainPod(pod, buildContainers(pod, nil)),
+		})
+	}
+}
+
+func (a *Adapter) notifyPodEvent(eventType domain.PodEventType, pod apiv1.Pod) {
+	a.podHandlersMu.RLock()
+	handlers := append([]domain.PodEventHandler{}, a.podHandlers...)
+	a.podHandlersMu.RUnlock()
+	if len(handlers) == 0 {
+		return
+	}
+
+	event := &domain.PodEvent{
+		Type: eventType,
+		Pod:  a.toDomainPod(pod, buildContainers(pod, nil)),
+	}
+	for _, handler := range handlers {
+		handler(context.Background(), event)
+	}
+}
+
 func (a *Adapter) QueryPods(ctx context.Context, opt *domain.QueryPodsOptions) ([]*domain.Pod, error) {
 	if opt == nil {
 		return nil, domain.ErrNilQueryInput
@@ -145,19 +317,34 @@ func (a *Adapter) QueryPods(ctx context.Context, opt *domain.QueryPodsOptions) (
 		return nil, domain.ErrNoClient
 	}
 
-	labelSelector := buildLabelSelector(opt.LabelSelectors)
-	namespaces := opt.K8SNamespace
+	selector, err := opt.K8SLabelSelector()
+	if err != nil {
+		return nil, err
+	}
+	labelSelector := selector.String()
+	k8sNamespaces := opt.K8SNamespace
+	if opt.Workload != nil {
+		if len(k8sNamespaces) > 0 && !slices.Contains(k8sNamespaces, opt.Workload.Namespace) {
+			return []*domain.Pod{}, nil
+		}
+		k8sNamespaces = []string{opt.Workload.Namespace}
+	}
+	namespaces, err := a.selectNamespaces(ctx, k8sNamespaces, opt.NamespaceRequirements)
+	if err != nil {
+		return nil, err
+	}
 	if len(namespaces) == 0 {
-		namespaces = []string{metav1.NamespaceAll}
+		return []*domain.Pod{}, nil
 	}
 
-	var cmdRegex *regexp.Regexp
-	if opt.CommandRegex != "" {
-		re, err := regexp.Compile(opt.CommandRegex)
-		if err != nil {
-			return nil, fmt.Errorf("compile command regex: %w", err)
-		}
-		cmdRegex = re
+	matcher, err := domain.NewContainerMatcher(opt.ContainerNames, opt.ContainerRegex, opt.CommandRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeNames, err := a.selectNodes(ctx, opt.NodeRequirements)
+	if err != nil {
+		return nil, err
 	}
 
 	pods, err := a.listPods(ctx, namespaces, labelSelector)
@@ -167,21 +354,26 @@ func (a *Adapter) QueryPods(ctx context.Context, opt *domain.QueryPodsOptions) (
 	results := make([]*domain.Pod, 0, len(pods))
 
 	for _, pod := range pods {
-		containers := buildContainers(pod, cmdRegex)
-		if cmdRegex != nil && len(containers) == 0 {
+		if nodeNames != nil {
+			if _, ok := nodeNames[pod.Spec.NodeName]; !ok {
+				continue
+			}
+		}
+		if len(opt.PodIDs) > 0 && !slices.Contains(opt.PodIDs, string(pod.UID)) {
 			continue
 		}
-
-		podLabels := copyLabels(pod.Labels)
-		result := &domain.Pod{
-			Name:         pod.Name,
-			K8SNamespace: pod.Namespace,
-			Labels:       podLabels,
-			PodID:        string(pod.UID),
-			NodeID:       pod.Spec.NodeName,
-			Containers:   containers,
+		if len(opt.PodNames) > 0 && !slices.Contains(opt.PodNames, pod.Name) {
+			continue
+		}
+		if opt.Workload != nil && !opt.Workload.Equal(a.podWorkload(&pod)) {
+			continue
 		}
-		results = append(results, result)
+		containers := buildContainers(pod, matcher)
+		if matcher != nil && len(containers) == 0 {
+			continue
+		}
+
+		results = append(results, a.toDomainPod(pod, containers))
 	}
 
 	return results, nil
@@ -302,6 +494,168 @@ func (a *Adapter) deletePodCache(uid string) {
 	a.podCacheMu.Unlock()
 }
 
+// selectNamespaces returns the namespaces to list pods from, all namespaces when neither names nor requirements are given
+func (a *Adapter) selectNamespaces(ctx context.Context, names []string, requirements []domain.LabelSelectorRequirement) ([]string, error) {
+	if len(requirements) == 0 {
+		if len(names) == 0 {
+			return []string{metav1.NamespaceAll}, nil
+		}
+		return names, nil
+	}
+	selector, err := domain.NewK8SLabelSelector(requirements)
+	if err != nil {
+		return nil, err
+	}
+
+	var namespaces []apiv1.Namespace
+	if a.cacheHasSynced.Load() {
+		a.nsCacheMu.RLock()
+		for _, ns := range a.nsCache {
+			namespaces = append(namespaces, ns)
+		}
+		a.nsCacheMu.RUnlock()
+	} else {
+		nsList, err := a.client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{
+			LabelSelector: selector.String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list namespaces: %w", err)
+		}
+		for _, ns := range nsList.Items {
+			a.setNamespaceCache(ns)
+		}
+		namespaces = nsList.Items
+	}
+
+	selected := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		if len(names) > 0 && !slices.Contains(names, ns.Name) {
+			continue
+		}
+		if selector.Matches(labels.Set(ns.Labels)) {
+			selected = append(selected, ns.Name)
+		}
+	}
+	slices.Sort(selected)
+	return selected, nil
+}
+
+// selectNodes returns the names of the nodes matching the requirements, or nil when there is no requirement
+func (a *Adapter) selectNodes(ctx context.Context, requirements []domain.LabelSelectorRequirement) (map[string]struct{}, error) {
+	if len(requirements) == 0 {
+		return nil, nil
+	}
+	selector, err := domain.NewK8SLabelSelector(requirements)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []apiv1.Node
+	if a.cacheHasSynced.Load() {
+		a.nodeCacheMu.RLock()
+		for _, node := range a.nodeCache {
+			nodes = append(nodes, node)
+		}
+		a.nodeCacheMu.RUnlock()
+	} else {
+		nodeList, err := a.client.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+			LabelSelector: selector.String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list nodes: %w", err)
+		}
+		for _, node := range nodeList.Items {
+			a.setNodeCache(node)
+		}
+		nodes = nodeList.Items
+	}
+
+	selected := make(map[string]struct{}, len(nodes))
+	for _, node := range nodes {
+		if selector.Matches(labels.Set(node.Labels)) {
+			selected[node.Name] = struct{}{}
+		}
+	}
+	return selected, nil
+}
+
+func (a *Adapter) setNodeCache(node apiv1.Node) {
+	a.nodeCacheMu.Lock()
+	if a.nodeCache == nil {
+		a.nodeCache = make(map[string]apiv1.Node)
+	}
+	a.nodeCache[node.Name] = node
+	a.nodeCacheMu.Unlock()
+}
+
+func (a *Adapter) deleteNodeCache(name string) {
+	a.nodeCacheMu.Lock()
+	delete(a.nodeCache, name)
+	a.nodeCacheMu.Unlock()
+}
+
+func (a *Adapter) nodeLabels(name string) map[string]string {
+	if name == "" {
+		return nil
+	}
+	a.nodeCacheMu.RLock()
+	defer a.nodeCacheMu.RUnlock()
+	return copyLabels(a.nodeCache[name].Labels)
+}
+
+// notifyNodePods notifies an update of every cached pod running on the node
+func (a *Adapter) notifyNodePods(nodeName string) {
+	a.podCacheMu.RLock()
+	pods := make([]apiv1.Pod, 0)
+	for _, pod := range a.podCache {
+		if pod.Spec.NodeName == nodeName {
+			pods = append(pods, pod)
+		}
+	}
+	a.podCacheMu.RUnlock()
+
+	for _, pod := range pods {
+		a.notifyPodEvent(domain.PodEventUpdated, pod)
+	}
+}
+
+func (a *Adapter) setNamespaceCache(ns apiv1.Namespace) {
+	a.nsCacheMu.Lock()
+	if a.nsCache == nil {
+		a.nsCache = make(map[string]apiv1.Namespace)
+	}
+	a.nsCache[ns.Name] = ns
+	a.nsCacheMu.Unlock()
+}
+
+func (a *Adapter) deleteNamespaceCache(name string) {
+	a.nsCacheMu.Lock()
+	delete(a.nsCache, name)
+	a.nsCacheMu.Unlock()
+}
+
+func (a *Adapter) namespaceLabels(name string) map[string]string {
+	a.nsCacheMu.RLock()
+	defer a.nsCacheMu.RUnlock()
+	return copyLabels(a.nsCache[name].Labels)
+}
+
+// notifyNamespacePods notifies an update of every cached pod of the namespace
+func (a *Adapter) notifyNamespacePods(namespace string) {
+	a.podCacheMu.RLock()
+	pods := make([]apiv1.Pod, 0)
+	for _, pod := range a.podCache {
+		if pod.Namespace == namespace {
+			pods = append(pods, pod)
+		}
+	}
+	a.podCacheMu.RUnlock()
+
+	for _, pod := range pods {
+		a.notifyPodEvent(domain.PodEventUpdated, pod)
+	}
+}
+
 func buildLabelSelector(selectors []domain.LabelSelector) string {
 	labels := make([]string, 0, len(selectors))
 	for _, selector := range selectors {
@@ -317,7 +671,22 @@ func buildLabelSelector(selectors []domain.LabelSelector) string {
 	return strings.Join(labels, ",")
 }
 
-func buildContainers(pod apiv1.Pod, cmdRegex *regexp.Regexp) []domain.Container {
+func (a *Adapter) toDomainPod(pod apiv1.Pod, containers []domain.Container) *domain.Pod {
+	return &domain.Pod{
+		Name:            pod.Name,
+		K8SNamespace:    pod.Namespace,
+		NamespaceLabels: a.namespaceLabels(pod.Namespace),
+		Labels:          copyLabels(pod.Labels),
+		PodID:           string(pod.UID),
+		NodeID:          pod.Spec.NodeName,
+		NodeLabels:      a.nodeLabels(pod.Spec.NodeName),
+		Containers:      containers,
+		Hints:           a.schedulingHints(pod),
+		Workload:        a.podWorkload(&pod),
+	}
+}
+
+func buildContainers(pod apiv1.Pod, matcher *domain.ContainerMatcher) []domain.Container {
 	statusByName := make(map[string]string, len(pod.Status.ContainerStatuses))
 	for _, status := range pod.Status.ContainerStatuses {
 		statusByName[status.Name] = status.ContainerID
@@ -328,17 +697,13 @@ func buildContainers(pod apiv1.Pod, cmdRegex *regexp.Regexp) []domain.Container
 		command := append([]string{}, container.Command...)
 		command = append(command, container.Args...)
 
-		if cmdRegex != nil && !cmdRegex.MatchString(strings.Join(command, " ")) {
-			continue
-		}
-
 		result = append(result, domain.Container{
 			ContainerID: statusByName[container.Name],
 			Name:        container.Name,
 			Command:     command,
 		})
 	}
-	return result
+	return matcher.MatchingContainers(result)
 }
 
 func copyLabels(labels map[string]string) map[string]string {
@@ -5,12 +5,14 @@ package k8sadapter
 
 import (
 	"context"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/Gthulhu/api/manager/domain"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes/fake"
 )
@@ -285,3 +287,224 @@ func TestQueryDecisionMakerPodsUsesCache(t *testing.T) {
 		t.Fatalf("unexpected state %v", got.State)
 	}
 }
+
+func TestQueryPodsMatchExpressions(t *testing.T) {
+	t.Parallel()
+
+	adapter := &Adapter{
+		client:   fake.NewSimpleClientset(),
+		podCache: make(map[string]apiv1.Pod),
+	}
+	adapter.cacheHasSynced.Store(true)
+	for uid, labels := range map[string]map[string]string{
+		"uid-1": {"tier": "frontend", "app": "web"},
+		"uid-2": {"tier": "backend", "app": "api"},
+		"uid-3": {"tier": "cache", "app": "redis", "canary": "true"},
+		"uid-4": {"tier": "backend", "app": "api", "canary": "true"},
+	} {
+		adapter.setPodCache(apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{UID: types.UID(uid), Namespace: "ns1", Labels: labels},
+		})
+	}
+
+	opt := &domain.QueryPodsOptions{
+		LabelRequirements: []domain.LabelSelectorRequirement{
+			{Key: "tier", Operator: domain.LabelSelectorOpIn, Values: []string{"frontend", "backend"}},
+			{Key: "canary", Operator: domain.LabelSelectorOpDoesNotExist},
+		},
+	}
+	results, err := adapter.QueryPods(context.Background(), opt)
+	if err != nil {
+		t.Fatalf("QueryPods returned error: %v", err)
+	}
+	podIDs := make([]string, 0, len(results))
+	for _, pod := range results {
+		podIDs = append(podIDs, pod.PodID)
+	}
+	slices.Sort(podIDs)
+	if !slices.Equal(podIDs, []string{"uid-1", "uid-2"}) {
+		t.Fatalf("unexpected pods %v", podIDs)
+	}
+
+	opt.LabelRequirements = []domain.LabelSelectorRequirement{{Key: "tier", Operator: domain.LabelSelectorOpIn}}
+	if _, err := adapter.QueryPods(context.Background(), opt); err == nil {
+		t.Fatalf("expected error for In requirement without values")
+	}
+}
+
+func TestNamespaceSelector(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewSimpleClientset(
+		&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "billing", Labels: map[string]string{"team": "payments"}}},
+		&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ledger", Labels: map[string]string{"team": "accounting"}}},
+		&apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Namespace: "billing", UID: "uid-1"}},
+		&apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-2", Namespace: "ledger", UID: "uid-2"}},
+	)
+	adapter := &Adapter{
+		client:   client,
+		podCache: make(map[string]apiv1.Pod),
+		nsCache:  make(map[string]apiv1.Namespace),
+		stopCh:   make(chan struct{}),
+	}
+	adapter.startPodWatcher()
+	t.Cleanup(adapter.StopPodWatcher)
+
+	opt := &domain.QueryPodsOptions{
+		NamespaceRequirements: []domain.LabelSelectorRequirement{
+			{Key: "team", Operator: domain.LabelSelectorOpIn, Values: []string{"payments"}},
+		},
+	}
+	results, err := adapter.QueryPods(context.Background(), opt)
+	if err != nil {
+		t.Fatalf("QueryPods returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].PodID != "uid-1" {
+		t.Fatalf("expected only the pod of the billing namespace, got %+v", results)
+	}
+	if results[0].NamespaceLabels["team"] != "payments" {
+		t.Fatalf("unexpected namespace labels %v", results[0].NamespaceLabels)
+	}
+
+	events := make(chan *domain.PodEvent, 4)
+	adapter.AddPodEventHandler(func(_ context.Context, event *domain.PodEvent) {
+		if event.Type == domain.PodEventUpdated {
+			events <- event
+		}
+	})
+	ledger := &apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ledger", Labels: map[string]string{"team": "payments"}}}
+	if _, err := client.CoreV1().Namespaces().Update(context.Background(), ledger, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update namespace: %v", err)
+	}
+	select {
+	case event := <-events:
+		if event.Pod.PodID != "uid-2" || event.Pod.NamespaceLabels["team"] != "payments" {
+			t.Fatalf("unexpected pod event %+v", event.Pod)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("expected a pod update after the namespace labels changed")
+	}
+
+	results, err = adapter.QueryPods(context.Background(), opt)
+	if err != nil {
+		t.Fatalf("QueryPods returned error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected the pods of both namespaces, got %d", len(results))
+	}
+}
+
+func TestQueryPodsNodeSelector(t *testing.T) {
+	t.Parallel()
+
+	adapter := &Adapter{
+		client:    fake.NewSimpleClientset(),
+		podCache:  make(map[string]apiv1.Pod),
+		nodeCache: make(map[string]apiv1.Node),
+	}
+	adapter.cacheHasSynced.Store(true)
+	adapter.setNodeCache(apiv1.Node{ObjectMeta: metav1.ObjectMeta{Name: "edge-1", Labels: map[string]string{"node-role": "edge"}}})
+	adapter.setNodeCache(apiv1.Node{ObjectMeta: metav1.ObjectMeta{Name: "batch-1", Labels: map[string]string{"node-role": "batch"}}})
+	adapter.setPodCache(apiv1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "uid-1", Namespace: "ns1"}, Spec: apiv1.PodSpec{NodeName: "edge-1"}})
+	adapter.setPodCache(apiv1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "uid-2", Namespace: "ns1"}, Spec: apiv1.PodSpec{NodeName: "batch-1"}})
+	adapter.setPodCache(apiv1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "uid-3", Namespace: "ns1"}})
+
+	opt := &domain.QueryPodsOptions{
+		NodeRequirements: []domain.LabelSelectorRequirement{
+			{Key: "node-role", Operator: domain.LabelSelectorOpIn, Values: []string{"edge"}},
+		},
+	}
+	results, err := adapter.QueryPods(context.Background(), opt)
+	if err != nil {
+		t.Fatalf("QueryPods returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].PodID != "uid-1" {
+		t.Fatalf("expected only the pod of the edge node, got %+v", results)
+	}
+	if results[0].NodeLabels["node-role"] != "edge" {
+		t.Fatalf("unexpected node labels %v", results[0].NodeLabels)
+	}
+
+	results, err = adapter.QueryPods(context.Background(), &domain.QueryPodsOptions{})
+	if err != nil {
+		t.Fatalf("QueryPods returned error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected every pod without node selector, got %d", len(results))
+	}
+}
+
+func TestQueryPodsByIDAndName(t *testing.T) {
+	t.Parallel()
+
+	adapter := &Adapter{
+		client:   fake.NewSimpleClientset(),
+		podCache: make(map[string]apiv1.Pod),
+	}
+	adapter.cacheHasSynced.Store(true)
+	adapter.setPodCache(apiv1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "uid-1", Name: "web", Namespace: "ns1"}})
+	adapter.setPodCache(apiv1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "uid-2", Name: "web", Namespace: "ns2"}})
+	adapter.setPodCache(apiv1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "uid-3", Name: "db", Namespace: "ns1"}})
+
+	results, err := adapter.QueryPods(context.Background(), &domain.QueryPodsOptions{K8SNamespace: []string{"ns1"}, PodNames: []string{"web"}})
+	if err != nil {
+		t.Fatalf("QueryPods returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].PodID != "uid-1" {
+		t.Fatalf("expected only the web pod of ns1, got %+v", results)
+	}
+
+	results, err = adapter.QueryPods(context.Background(), &domain.QueryPodsOptions{PodIDs: []string{"uid-2", "uid-3"}})
+	if err != nil {
+		t.Fatalf("QueryPods returned error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected the pods with the given UIDs, got %+v", results)
+	}
+}
+
+func TestQueryPodsContainers(t *testing.T) {
+	t.Parallel()
+
+	adapter := &Adapter{
+		client:   fake.NewSimpleClientset(),
+		podCache: make(map[string]apiv1.Pod),
+	}
+	adapter.cacheHasSynced.Store(true)
+	adapter.setPodCache(apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{UID: "uid-1", Name: "web", Namespace: "ns1"},
+		Spec: apiv1.PodSpec{Containers: []apiv1.Container{
+			{Name: "app", Command: []string{"nginx"}},
+			{Name: "envoy", Command: []string{"envoy"}},
+			{Name: "app-worker", Command: []string{"worker"}},
+		}},
+		Status: apiv1.PodStatus{ContainerStatuses: []apiv1.ContainerStatus{
+			{Name: "app", ContainerID: "containerd://app-1"},
+			{Name: "envoy", ContainerID: "containerd://envoy-1"},
+		}},
+	})
+
+	results, err := adapter.QueryPods(context.Background(), &domain.QueryPodsOptions{ContainerNames: []string{"envoy"}, ContainerRegex: "^app"})
+	if err != nil {
+		t.Fatalf("QueryPods returned error: %v", err)
+	}
+	if len(results) != 1 || len(results[0].Containers) != 2 {
+		t.Fatalf("expected the started containers named by the query, got %+v", results)
+	}
+	if results[0].Containers[0].Name != "app" || results[0].Containers[1].Name != "envoy" {
+		t.Fatalf("unexpected containers %+v", results[0].Containers)
+	}
+
+	results, err = adapter.QueryPods(context.Background(), &domain.QueryPodsOptions{ContainerRegex: "^app", CommandRegex: "envoy"})
+	if err != nil {
+		t.Fatalf("QueryPods returned error: %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("expected no pod without a container matching both the name and the command, got %+v", results)
+	}
+
+	_, err = adapter.QueryPods(context.Background(), &domain.QueryPodsOptions{ContainerRegex: "(app"})
+	if err == nil {
+		t.Fatalf("expected an invalid container regex to fail")
+	}
+}
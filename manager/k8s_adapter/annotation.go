@@ -0,0 +1,61 @@
+package k8sadapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// annotationPriorities maps the values of the priority annotation to strategy priorities
+var annotationPriorities = map[string]int{
+	"high":   1,
+	"normal": 0,
+}
+
+// schedulingHints returns the scheduling requested by the annotations of the pod, or nil when the pod requests none
+// or its namespace is not allowed to; an invalid annotation is ignored
+func (a *Adapter) schedulingHints(pod apiv1.Pod) *domain.SchedulingHints {
+	if !slices.Contains(a.annotationNamespaces, pod.Namespace) {
+		return nil
+	}
+	hints, err := parseSchedulingHints(pod.Annotations)
+	if err != nil {
+		logger.Logger(context.Background()).Warn().Err(err).Msgf("ignoring invalid scheduling annotation of pod %s/%s", pod.Namespace, pod.Name)
+	}
+	return hints
+}
+
+// parseSchedulingHints parses the scheduling annotations, the valid ones are returned along with the error of the invalid ones
+func parseSchedulingHints(annotations map[string]string) (*domain.SchedulingHints, error) {
+	var hints *domain.SchedulingHints
+	var errs []error
+	if value, ok := annotations[domain.PriorityAnnotation]; ok {
+		priority, ok := annotationPriorities[value]
+		if ok {
+			hints = &domain.SchedulingHints{Priority: priority}
+		} else {
+			errs = append(errs, fmt.Errorf("%s: unknown priority %q, expected high or normal", domain.PriorityAnnotation, value))
+		}
+	}
+	if value, ok := annotations[domain.ExecutionTimeAnnotation]; ok {
+		executionTime, err := time.ParseDuration(value)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("%s: %w", domain.ExecutionTimeAnnotation, err))
+		case executionTime <= 0:
+			errs = append(errs, fmt.Errorf("%s: the execution time must be positive", domain.ExecutionTimeAnnotation))
+		default:
+			if hints == nil {
+				hints = &domain.SchedulingHints{}
+			}
+			hints.ExecutionTime = executionTime.Nanoseconds()
+		}
+	}
+	return hints, errors.Join(errs...)
+}
@@ -0,0 +1,130 @@
+//go:build k3d
+// +build k3d
+
+package k8sadapter
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/Gthulhu/api/manager/domain"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseSchedulingHints(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *domain.SchedulingHints
+		wantErr     bool
+	}{
+		{name: "no annotation", annotations: map[string]string{"other": "value"}},
+		{
+			name:        "priority and execution time",
+			annotations: map[string]string{domain.PriorityAnnotation: "high", domain.ExecutionTimeAnnotation: "5ms"},
+			want:        &domain.SchedulingHints{Priority: 1, ExecutionTime: 5000000},
+		},
+		{
+			name:        "execution time only",
+			annotations: map[string]string{domain.ExecutionTimeAnnotation: "1.5ms"},
+			want:        &domain.SchedulingHints{ExecutionTime: 1500000},
+		},
+		{
+			name:        "unknown priority",
+			annotations: map[string]string{domain.PriorityAnnotation: "urgent", domain.ExecutionTimeAnnotation: "5ms"},
+			want:        &domain.SchedulingHints{ExecutionTime: 5000000},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid execution time",
+			annotations: map[string]string{domain.PriorityAnnotation: "normal", domain.ExecutionTimeAnnotation: "-5ms"},
+			want:        &domain.SchedulingHints{},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSchedulingHints(tt.annotations)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPodWatcherSchedulingHints(t *testing.T) {
+	t.Parallel()
+
+	annotations := map[string]string{domain.PriorityAnnotation: "high", domain.ExecutionTimeAnnotation: "5ms"}
+	client := fake.NewSimpleClientset(
+		&apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "allowed", Namespace: "team-a", UID: "uid-allowed", Annotations: annotations},
+			Spec:       apiv1.PodSpec{NodeName: "node-1"},
+		},
+		&apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "denied", Namespace: "team-b", UID: "uid-denied", Annotations: annotations},
+			Spec:       apiv1.PodSpec{NodeName: "node-1"},
+		},
+	)
+	adapter := &Adapter{
+		client:               client,
+		podCache:             make(map[string]apiv1.Pod),
+		stopCh:               make(chan struct{}),
+		annotationNamespaces: []string{"team-a"},
+	}
+	adapter.startPodWatcher()
+	t.Cleanup(adapter.StopPodWatcher)
+
+	var mu sync.Mutex
+	hints := make(map[string]*domain.SchedulingHints)
+	adapter.AddPodEventHandler(func(_ context.Context, event *domain.PodEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		hints[event.Pod.Name] = event.Pod.Hints
+	})
+
+	{ /*** Test Replayed Pods ***/
+		mu.Lock()
+		if got := hints["allowed"]; got == nil || *got != (domain.SchedulingHints{Priority: 1, ExecutionTime: 5000000}) {
+			t.Fatalf("unexpected hints of the allowed pod: %+v", got)
+		}
+		if got, ok := hints["denied"]; !ok || got != nil {
+			t.Fatalf("expected no hints for the pod of a namespace not allowed, got %+v", got)
+		}
+		mu.Unlock()
+	}
+
+	{ /*** Test Updating Annotations ***/
+		pod, err := client.CoreV1().Pods("team-a").Get(context.Background(), "allowed", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get pod: %v", err)
+		}
+		pod.Annotations = map[string]string{domain.ExecutionTimeAnnotation: "20ms"}
+		if _, err := client.CoreV1().Pods("team-a").Update(context.Background(), pod, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("update pod: %v", err)
+		}
+		waitFor(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			got := hints["allowed"]
+			return got != nil && got.ExecutionTime == 20000000 && got.Priority == 0
+		})
+
+		results, err := adapter.QueryPods(context.Background(), &domain.QueryPodsOptions{PodNames: []string{"allowed"}})
+		if err != nil {
+			t.Fatalf("query pods: %v", err)
+		}
+		if len(results) != 1 || results[0].Hints == nil || results[0].Hints.ExecutionTime != 20000000 {
+			t.Fatalf("expected the queried pod to carry its hints, got %+v", results)
+		}
+	}
+}
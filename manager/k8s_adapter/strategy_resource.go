@@ -0,0 +1,353 @@
+package k8sadapter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+)
+
+// StrategyResourceGVR is the resource of the SchedulingStrategy custom resources, see deployment/k8s/schedulingstrategy-crd.yaml
+var StrategyResourceGVR = schema.GroupVersionResource{Group: "gthulhu.io", Version: "v1", Resource: "schedulingstrategies"}
+
+// strategyResourceSpec is the spec of a SchedulingStrategy resource, the fields of a strategy without the ones scoping it
+// to namespaces: a resource only targets the pods of its own namespace
+type strategyResourceSpec struct {
+	Template           string                        `json:"template,omitempty"`
+	LabelSelectors     []resourceLabelSelector       `json:"labelSelectors,omitempty"`
+	MatchLabels        map[string]string             `json:"matchLabels,omitempty"`
+	MatchExpressions   []resourceSelectorRequirement `json:"matchExpressions,omitempty"`
+	NodeSelector       *resourceSelectorSpec         `json:"nodeSelector,omitempty"`
+	Workload           *resourceWorkloadRef          `json:"workload,omitempty"`
+	ContainerNames     []string                      `json:"containerNames,omitempty"`
+	ContainerRegex     string                        `json:"containerRegex,omitempty"`
+	CommandRegex       string                        `json:"commandRegex,omitempty"`
+	ProcessMatchers    []resourceProcessMatcher      `json:"processMatchers,omitempty"`
+	ThreadRegex        string                        `json:"threadRegex,omitempty"`
+	IncludeDescendants bool                          `json:"includeDescendants,omitempty"`
+	Priority           int                           `json:"priority,omitempty"`
+	ExecutionTime      int64                         `json:"executionTime,omitempty"`
+	Weight             int                           `json:"weight,omitempty"`
+	ActivateAt         int64                         `json:"activateAt,omitempty"` // unix seconds, unlike the unix milliseconds of the strategies
+	ExpireAt           int64                         `json:"expireAt,omitempty"`   // unix seconds
+	Window             *resourceRecurringWindow      `json:"window,omitempty"`
+}
+
+type resourceLabelSelector struct {
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+type resourceSelectorRequirement struct {
+	Key      string   `json:"key"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values,omitempty"`
+}
+
+type resourceSelectorSpec struct {
+	MatchLabels      map[string]string             `json:"matchLabels,omitempty"`
+	MatchExpressions []resourceSelectorRequirement `json:"matchExpressions,omitempty"`
+}
+
+type resourceProcessMatcher struct {
+	Field string `json:"field"`
+	Regex string `json:"regex"`
+}
+
+// resourceWorkloadRef is a workload of the namespace of the resource
+type resourceWorkloadRef struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+type resourceRecurringWindow struct {
+	Cron        string `json:"cron"`
+	DurationSec int64  `json:"durationSec"`
+}
+
+func toDomainRequirements(requirements []resourceSelectorRequirement) []domain.LabelSelectorRequirement {
+	var domainRequirements []domain.LabelSelectorRequirement
+	for _, requirement := range requirements {
+		domainRequirements = append(domainRequirements, domain.LabelSelectorRequirement{
+			Key:      requirement.Key,
+			Operator: requirement.Operator,
+			Values:   requirement.Values,
+		})
+	}
+	return domainRequirements
+}
+
+func (spec *strategyResourceSpec) toDomainStrategy() *domain.ScheduleStrategy {
+	strategy := &domain.ScheduleStrategy{
+		MatchLabels:        spec.MatchLabels,
+		MatchExpressions:   toDomainRequirements(spec.MatchExpressions),
+		ContainerNames:     spec.ContainerNames,
+		ContainerRegex:     spec.ContainerRegex,
+		CommandRegex:       spec.CommandRegex,
+		ThreadRegex:        spec.ThreadRegex,
+		IncludeDescendants: spec.IncludeDescendants,
+		Priority:           spec.Priority,
+		ExecutionTime:      spec.ExecutionTime,
+		Weight:             spec.Weight,
+		ActivateAt:         spec.ActivateAt * 1000,
+		ExpireAt:           spec.ExpireAt * 1000,
+	}
+	for _, ls := range spec.LabelSelectors {
+		strategy.LabelSelectors = append(strategy.LabelSelectors, domain.LabelSelector{Key: ls.Key, Value: ls.Value})
+	}
+	if spec.NodeSelector != nil {
+		strategy.NodeSelector = &domain.LabelSelectorSpec{
+			MatchLabels:      spec.NodeSelector.MatchLabels,
+			MatchExpressions: toDomainRequirements(spec.NodeSelector.MatchExpressions),
+		}
+	}
+	for _, matcher := range spec.ProcessMatchers {
+		strategy.ProcessMatchers = append(strategy.ProcessMatchers, domain.ProcessMatcher{Field: matcher.Field, Regex: matcher.Regex})
+	}
+	if spec.Workload != nil {
+		strategy.Workload = &domain.WorkloadRef{Kind: spec.Workload.Kind, Name: spec.Workload.Name}
+	}
+	if spec.Window != nil {
+		strategy.Window = &domain.RecurringWindow{Cron: spec.Window.Cron, DurationSec: spec.Window.DurationSec}
+	}
+	return strategy
+}
+
+func toDomainStrategyResource(obj *unstructured.Unstructured) *domain.StrategyResource {
+	resource := &domain.StrategyResource{
+		StrategyResourceRef: domain.StrategyResourceRef{
+			UID:       string(obj.GetUID()),
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+		},
+		Generation: obj.GetGeneration(),
+	}
+	specObj, _, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		resource.SpecErr = fmt.Errorf("decode spec: %w", err)
+		return resource
+	}
+	var spec strategyResourceSpec
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(specObj, &spec)
+	if err != nil {
+		resource.SpecErr = fmt.Errorf("decode spec: %w", err)
+		return resource
+	}
+	resource.Template = spec.Template
+	resource.Spec = spec.toDomainStrategy()
+	return resource
+}
+
+// startStrategyResourceWatcher watches the SchedulingStrategy resources of every namespace, the resources are notified again every resync.
+// It does not wait for the cache to sync, so that a missing CRD does not block the adapter.
+func (a *Adapter) startStrategyResourceWatcher(resync time.Duration) {
+	a.startResourceWatcher.Do(func() {
+		informerFactory := dynamicinformer.NewDynamicSharedInformerFactory(a.dynamicClient, resync)
+		informer := informerFactory.ForResource(StrategyResourceGVR).Informer()
+
+		registration, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				u, ok := obj.(*unstructured.Unstructured)
+				if !ok {
+					return
+				}
+				logger.Logger(context.Background()).Debug().Msgf("SchedulingStrategy added: %s/%s", u.GetNamespace(), u.GetName())
+				resource := toDomainStrategyResource(u)
+				a.setStrategyResourceCache(resource)
+				a.notifyStrategyResourceEvent(domain.StrategyResourceAdded, resource)
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				oldU, ok := oldObj.(*unstructured.Unstructured)
+				if !ok {
+					return
+				}
+				u, ok := newObj.(*unstructured.Unstructured)
+				if !ok {
+					return
+				}
+				resource := toDomainStrategyResource(u)
+				a.setStrategyResourceCache(resource)
+				// writing the status changes the resource version but not the generation, it is not notified so that it does not loop;
+				// a resync keeps the resource version and is notified to refresh the status
+				if oldU.GetResourceVersion() != u.GetResourceVersion() && oldU.GetGeneration() == u.GetGeneration() {
+					return
+				}
+				a.notifyStrategyResourceEvent(domain.StrategyResourceUpdated, resource)
+			},
+			DeleteFunc: func(obj interface{}) {
+				switch u := obj.(type) {
+				case *unstructured.Unstructured:
+					logger.Logger(context.Background()).Debug().Msgf("SchedulingStrategy deleted: %s/%s", u.GetNamespace(), u.GetName())
+					a.deleteStrategyResourceCache(string(u.GetUID()))
+					a.notifyStrategyResourceEvent(domain.StrategyResourceDeleted, toDomainStrategyResource(u))
+				case cache.DeletedFinalStateUnknown:
+					if deleted, ok := u.Obj.(*unstructured.Unstructured); ok {
+						a.deleteStrategyResourceCache(string(deleted.GetUID()))
+						a.notifyStrategyResourceEvent(domain.StrategyResourceDeleted, toDomainStrategyResource(deleted))
+					}
+				}
+			},
+		})
+
+		if err != nil {
+			logger.Logger(context.Background()).Error().Err(err).Msg("failed to watch SchedulingStrategy resources")
+			return
+		}
+
+		informerFactory.Start(a.stopCh)
+		logger.Logger(context.Background()).Info().Msg("starting k8s SchedulingStrategy watcher")
+		go func() {
+			if cache.WaitForCacheSync(a.stopCh, registration.HasSynced) {
+				close(a.strategyResourcesSynced())
+			}
+		}()
+	})
+}
+
+// strategyResourcesSynced returns the channel closed once the handlers received every SchedulingStrategy resource of the initial list
+func (a *Adapter) strategyResourcesSynced() chan struct{} {
+	a.resourceSyncedOnce.Do(func() {
+		a.resourceSynced = make(chan struct{})
+	})
+	return a.resourceSynced
+}
+
+// StrategyResourceUIDs waits for the SchedulingStrategy resources to be cached and returns the UIDs of the cached resources,
+// it waits until ctx is done when the CRD is not installed
+func (a *Adapter) StrategyResourceUIDs(ctx context.Context) ([]string, error) {
+	select {
+	case <-a.strategyResourcesSynced():
+	case <-ctx.Done():
+		return nil, fmt.Errorf("wait for the SchedulingStrategy resources to be cached: %w", ctx.Err())
+	}
+	a.resourceCacheMu.RLock()
+	defer a.resourceCacheMu.RUnlock()
+	uids := make([]string, 0, len(a.resourceCache))
+	for uid := range a.resourceCache {
+		uids = append(uids, uid)
+	}
+	return uids, nil
+}
+
+// AddStrategyResourceHandler registers a handler that is called for every SchedulingStrategy resource added, updated or deleted by the informer.
+// Resources that are already cached are replayed to the new handler as add events.
+func (a *Adapter) AddStrategyResourceHandler(handler domain.StrategyResourceHandler) {
+	if handler == nil {
+		return
+	}
+	a.resourceHandlersMu.Lock()
+	a.resourceHandlers = append(a.resourceHandlers, handler)
+	a.resourceHandlersMu.Unlock()
+
+	a.resourceCacheMu.RLock()
+	resources := make([]*domain.StrategyResource, 0, len(a.resourceCache))
+	for _, resource := range a.resourceCache {
+		resources = append(resources, resource)
+	}
+	a.resourceCacheMu.RUnlock()
+
+	for _, resource := range resources {
+		handler(context.Background(), &domain.StrategyResourceEvent{
+			Type:     domain.StrategyResourceAdded,
+			Resource: resource,
+		})
+	}
+}
+
+func (a *Adapter) notifyStrategyResourceEvent(eventType domain.StrategyResourceEventType, resource *domain.StrategyResource) {
+	a.resourceHandlersMu.RLock()
+	handlers := append([]domain.StrategyResourceHandler{}, a.resourceHandlers...)
+	a.resourceHandlersMu.RUnlock()
+
+	event := &domain.StrategyResourceEvent{
+		Type:     eventType,
+		Resource: resource,
+	}
+	for _, handler := range handlers {
+		handler(context.Background(), event)
+	}
+}
+
+func (a *Adapter) setStrategyResourceCache(resource *domain.StrategyResource) {
+	a.resourceCacheMu.Lock()
+	if a.resourceCache == nil {
+		a.resourceCache = make(map[string]*domain.StrategyResource)
+	}
+	a.resourceCache[resource.UID] = resource
+	a.resourceCacheMu.Unlock()
+}
+
+func (a *Adapter) deleteStrategyResourceCache(uid string) {
+	a.resourceCacheMu.Lock()
+	delete(a.resourceCache, uid)
+	a.resourceCacheMu.Unlock()
+}
+
+// UpdateStrategyResourceStatus replaces the status of the SchedulingStrategy resource, a resource deleted or recreated meanwhile is left alone
+func (a *Adapter) UpdateStrategyResourceStatus(ctx context.Context, ref *domain.StrategyResourceRef, status *domain.StrategyResourceStatus) error {
+	if a == nil || a.dynamicClient == nil {
+		return domain.ErrNoClient
+	}
+	desired := toUnstructuredStatus(status)
+	client := a.dynamicClient.Resource(StrategyResourceGVR).Namespace(ref.Namespace)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := client.Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if string(obj.GetUID()) != ref.UID {
+			return nil
+		}
+		current, _, _ := unstructured.NestedMap(obj.Object, "status")
+		if equality.Semantic.DeepEqual(current, desired) {
+			return nil
+		}
+		obj.Object["status"] = desired
+		_, err = client.UpdateStatus(ctx, obj, metav1.UpdateOptions{})
+		return err
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("update status of SchedulingStrategy %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	return nil
+}
+
+// toUnstructuredStatus converts the status with the value types of an unstructured object, so that it compares to the stored one
+func toUnstructuredStatus(status *domain.StrategyResourceStatus) map[string]interface{} {
+	nodes := make([]interface{}, 0, len(status.Nodes))
+	for _, node := range status.Nodes {
+		nodes = append(nodes, node)
+	}
+	intentStates := make(map[string]interface{}, len(status.IntentStates))
+	for state, count := range status.IntentStates {
+		intentStates[state] = int64(count)
+	}
+	obj := map[string]interface{}{
+		"observedGeneration": status.ObservedGeneration,
+		"phase":              status.Phase,
+		"matchedPods":        int64(status.MatchedPods),
+		"nodes":              nodes,
+		"intentStates":       intentStates,
+	}
+	if status.Message != "" {
+		obj["message"] = status.Message
+	}
+	if status.StrategyID != "" {
+		obj["strategyId"] = status.StrategyID
+	}
+	return obj
+}
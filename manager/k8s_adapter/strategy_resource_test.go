@@ -0,0 +1,233 @@
+//go:build k3d
+// +build k3d
+
+package k8sadapter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Gthulhu/api/manager/domain"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func newStrategyResource(generation int64, priority int64) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gthulhu.io/v1",
+		"kind":       "SchedulingStrategy",
+		"metadata": map[string]interface{}{
+			"name":            "web",
+			"namespace":       "team-a",
+			"uid":             "uid-web",
+			"generation":      generation,
+			"resourceVersion": "1",
+		},
+		"spec": map[string]interface{}{
+			"matchLabels":   map[string]interface{}{"app": "web"},
+			"commandRegex":  "nginx",
+			"priority":      priority,
+			"executionTime": int64(5000000),
+			"nodeSelector": map[string]interface{}{
+				"matchExpressions": []interface{}{
+					map[string]interface{}{"key": "node-role", "operator": "In", "values": []interface{}{"edge"}},
+				},
+			},
+		},
+	}}
+	return obj
+}
+
+func TestStrategyResourceWatcher(t *testing.T) {
+	t.Parallel()
+
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		StrategyResourceGVR: "SchedulingStrategyList",
+	}, newStrategyResource(1, 1))
+	adapter := &Adapter{
+		dynamicClient: client,
+		stopCh:        make(chan struct{}),
+	}
+	t.Cleanup(adapter.StopPodWatcher)
+
+	var mu sync.Mutex
+	var events []*domain.StrategyResourceEvent
+	adapter.AddStrategyResourceHandler(func(_ context.Context, event *domain.StrategyResourceEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	})
+	lastEvent := func(count int) *domain.StrategyResourceEvent {
+		waitFor(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(events) >= count
+		})
+		mu.Lock()
+		defer mu.Unlock()
+		if len(events) != count {
+			t.Fatalf("expected %d events, got %d", count, len(events))
+		}
+		return events[count-1]
+	}
+	adapter.startStrategyResourceWatcher(0)
+
+	{ /*** Test Adding Resource ***/
+		event := lastEvent(1)
+		if event.Type != domain.StrategyResourceAdded {
+			t.Fatalf("expected an add event, got %d", event.Type)
+		}
+		resource := event.Resource
+		if resource.SpecErr != nil {
+			t.Fatalf("unexpected spec error: %v", resource.SpecErr)
+		}
+		if resource.UID != "uid-web" || resource.Namespace != "team-a" || resource.Name != "web" || resource.Generation != 1 {
+			t.Fatalf("unexpected resource %+v", resource.StrategyResourceRef)
+		}
+		if resource.Spec.MatchLabels["app"] != "web" || resource.Spec.CommandRegex != "nginx" || resource.Spec.Priority != 1 || resource.Spec.ExecutionTime != 5000000 {
+			t.Fatalf("unexpected spec %+v", resource.Spec)
+		}
+		if requirements := resource.Spec.NodeSelector.Requirements(); len(requirements) != 1 || requirements[0].Values[0] != "edge" {
+			t.Fatalf("unexpected node selector %+v", requirements)
+		}
+	}
+
+	{ /*** Test Listing Cached Resources ***/
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		uids, err := adapter.StrategyResourceUIDs(ctx)
+		if err != nil {
+			t.Fatalf("list the cached resources: %v", err)
+		}
+		if len(uids) != 1 || uids[0] != "uid-web" {
+			t.Fatalf("unexpected cached resources %v", uids)
+		}
+	}
+
+	{ /*** Test Writing Status ***/
+		status := &domain.StrategyResourceStatus{
+			ObservedGeneration: 1,
+			Phase:              domain.StrategyResourcePhaseReady,
+			StrategyID:         "strategy-1",
+			MatchedPods:        2,
+			Nodes:              []string{"node-1"},
+			IntentStates:       map[string]int{"Applied": 2},
+		}
+		ref := &domain.StrategyResourceRef{UID: "uid-web", Namespace: "team-a", Name: "web"}
+		if err := adapter.UpdateStrategyResourceStatus(context.Background(), ref, status); err != nil {
+			t.Fatalf("update status: %v", err)
+		}
+		obj, err := client.Resource(StrategyResourceGVR).Namespace("team-a").Get(context.Background(), "web", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get resource: %v", err)
+		}
+		applied, _, _ := unstructured.NestedInt64(obj.Object, "status", "intentStates", "Applied")
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		if applied != 2 || phase != domain.StrategyResourcePhaseReady {
+			t.Fatalf("unexpected status %v", obj.Object["status"])
+		}
+
+		// an unchanged status is not written again
+		actions := len(client.Actions())
+		if err := adapter.UpdateStrategyResourceStatus(context.Background(), ref, status); err != nil {
+			t.Fatalf("update status: %v", err)
+		}
+		if len(client.Actions()) != actions+1 {
+			t.Fatalf("expected only a get for an unchanged status, got %v", client.Actions()[actions:])
+		}
+
+		// a recreated resource is left alone
+		if err := adapter.UpdateStrategyResourceStatus(context.Background(), &domain.StrategyResourceRef{UID: "other", Namespace: "team-a", Name: "web"}, &domain.StrategyResourceStatus{Phase: domain.StrategyResourcePhaseInvalid}); err != nil {
+			t.Fatalf("update status: %v", err)
+		}
+
+		// the fake client keeps the resource version, so the status write is seen as a resync
+		if event := lastEvent(2); event.Type != domain.StrategyResourceUpdated {
+			t.Fatalf("expected an update event, got %d", event.Type)
+		}
+	}
+
+	{ /*** Test Status Only Update ***/
+		obj := newStrategyResource(1, 1)
+		obj.SetResourceVersion("2")
+		obj.Object["status"] = map[string]interface{}{"phase": "Ready"}
+		if _, err := client.Resource(StrategyResourceGVR).Namespace("team-a").Update(context.Background(), obj, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("update resource: %v", err)
+		}
+		obj = newStrategyResource(2, 5)
+		obj.SetResourceVersion("3")
+		if _, err := client.Resource(StrategyResourceGVR).Namespace("team-a").Update(context.Background(), obj, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("update resource: %v", err)
+		}
+		// the status update is skipped, the spec update is notified
+		event := lastEvent(3)
+		if event.Type != domain.StrategyResourceUpdated || event.Resource.Spec.Priority != 5 {
+			t.Fatalf("unexpected event %d %+v", event.Type, event.Resource.Spec)
+		}
+	}
+
+	{ /*** Test Deleting Resource ***/
+		if err := client.Resource(StrategyResourceGVR).Namespace("team-a").Delete(context.Background(), "web", metav1.DeleteOptions{}); err != nil {
+			t.Fatalf("delete resource: %v", err)
+		}
+		event := lastEvent(4)
+		if event.Type != domain.StrategyResourceDeleted || event.Resource.UID != "uid-web" {
+			t.Fatalf("unexpected event %d %+v", event.Type, event.Resource.StrategyResourceRef)
+		}
+		adapter.resourceCacheMu.RLock()
+		defer adapter.resourceCacheMu.RUnlock()
+		if len(adapter.resourceCache) != 0 {
+			t.Fatalf("expected the resource to leave the cache")
+		}
+	}
+}
+
+func TestStrategyResourceInvalidSpec(t *testing.T) {
+	t.Parallel()
+
+	obj := newStrategyResource(1, 1)
+	obj.Object["spec"].(map[string]interface{})["priority"] = "high"
+	resource := toDomainStrategyResource(obj)
+	if resource.SpecErr == nil {
+		t.Fatalf("expected a spec error")
+	}
+}
+
+// TestStrategyResourceSchedule tests that the activation and expiry of a resource, in unix seconds, bound the strategy it is mirrored to
+func TestStrategyResourceSchedule(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	activateAt := now.Add(time.Hour).Unix()
+	expireAt := now.Add(2 * time.Hour).Unix()
+	obj := newStrategyResource(1, 1)
+	spec := obj.Object["spec"].(map[string]interface{})
+	spec["activateAt"] = activateAt
+	spec["expireAt"] = expireAt
+	resource := toDomainStrategyResource(obj)
+	if resource.SpecErr != nil {
+		t.Fatalf("unexpected spec error: %v", resource.SpecErr)
+	}
+
+	strategy := resource.Spec
+	if strategy.ActivateAt != activateAt*1000 || strategy.ExpireAt != expireAt*1000 {
+		t.Fatalf("expected the times in unix milliseconds, got activateAt %d and expireAt %d", strategy.ActivateAt, strategy.ExpireAt)
+	}
+	for _, tc := range []struct {
+		at    time.Time
+		state domain.IntentState
+	}{
+		{at: now, state: domain.IntentStateScheduled},
+		{at: time.Unix(activateAt, 0), state: domain.IntentStateInitialized},
+		{at: time.Unix(expireAt, 0), state: domain.IntentStateExpired},
+	} {
+		if state := strategy.IntentStateAt(tc.at); state != tc.state {
+			t.Fatalf("expected the strategy to be %s at %s, got %s", tc.state, tc.at, state)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package k8sadapter
+
+import (
+	"context"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+// watchOwners caches the controller of the ReplicaSets or Jobs of the informer, so that their pods resolve to the Deployment or CronJob above them.
+// A new or changed controller is replayed as an update of each of their pods: the pods of a ReplicaSet seen before the ReplicaSet
+// itself, as during a rollover, are bound to their Deployment once it is known.
+func (a *Adapter) watchOwners(informer cache.SharedIndexInformer) {
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			owned, err := meta.Accessor(obj)
+			if err != nil {
+				return
+			}
+			a.setOwnerCache(owned)
+			if metav1.GetControllerOfNoCopy(owned) != nil {
+				a.notifyOwnedPods(owned.GetUID())
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldOwned, err := meta.Accessor(oldObj)
+			if err != nil {
+				return
+			}
+			owned, err := meta.Accessor(newObj)
+			if err != nil {
+				return
+			}
+			a.setOwnerCache(owned)
+			if !sameController(metav1.GetControllerOfNoCopy(oldOwned), metav1.GetControllerOfNoCopy(owned)) {
+				logger.Logger(context.Background()).Debug().Msgf("controller of %s/%s updated", owned.GetNamespace(), owned.GetName())
+				a.notifyOwnedPods(owned.GetUID())
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if deleted, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = deleted.Obj
+			}
+			owned, err := meta.Accessor(obj)
+			if err != nil {
+				return
+			}
+			a.deleteOwnerCache(owned.GetUID())
+		},
+	})
+}
+
+func sameController(a, b *metav1.OwnerReference) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.UID == b.UID
+}
+
+func (a *Adapter) setOwnerCache(owned metav1.Object) {
+	var controller *metav1.OwnerReference
+	if ref := metav1.GetControllerOfNoCopy(owned); ref != nil {
+		controller = ref.DeepCopy()
+	}
+	a.ownerCacheMu.Lock()
+	if a.ownerCache == nil {
+		a.ownerCache = make(map[types.UID]*metav1.OwnerReference)
+	}
+	a.ownerCache[owned.GetUID()] = controller
+	a.ownerCacheMu.Unlock()
+}
+
+func (a *Adapter) deleteOwnerCache(uid types.UID) {
+	a.ownerCacheMu.Lock()
+	delete(a.ownerCache, uid)
+	a.ownerCacheMu.Unlock()
+}
+
+func (a *Adapter) controllerOf(uid types.UID) *metav1.OwnerReference {
+	a.ownerCacheMu.RLock()
+	defer a.ownerCacheMu.RUnlock()
+	return a.ownerCache[uid]
+}
+
+// notifyOwnedPods notifies an update of every cached pod controlled by the owner
+func (a *Adapter) notifyOwnedPods(uid types.UID) {
+	a.podCacheMu.RLock()
+	pods := make([]apiv1.Pod, 0)
+	for _, pod := range a.podCache {
+		if controller := metav1.GetControllerOfNoCopy(&pod); controller != nil && controller.UID == uid {
+			pods = append(pods, pod)
+		}
+	}
+	a.podCacheMu.RUnlock()
+
+	for _, pod := range pods {
+		a.notifyPodEvent(domain.PodEventUpdated, pod)
+	}
+}
+
+// podWorkload resolves the workload controlling the pod through its owner references: the pods of a ReplicaSet controlled
+// by a Deployment and of a Job controlled by a CronJob resolve to the Deployment and the CronJob
+func (a *Adapter) podWorkload(pod *apiv1.Pod) *domain.WorkloadRef {
+	controller := metav1.GetControllerOfNoCopy(pod)
+	if controller == nil {
+		return nil
+	}
+	workload := &domain.WorkloadRef{Kind: controller.Kind, Namespace: pod.Namespace, Name: controller.Name}
+	switch controller.Kind {
+	case domain.WorkloadKindReplicaSet, domain.WorkloadKindJob:
+		if parent := a.controllerOf(controller.UID); parent != nil {
+			workload.Kind = parent.Kind
+			workload.Name = parent.Name
+		}
+	}
+	return workload
+}
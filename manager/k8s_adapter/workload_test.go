@@ -0,0 +1,116 @@
+//go:build k3d
+// +build k3d
+
+package k8sadapter
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/Gthulhu/api/manager/domain"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func controllerRef(kind, name string, uid types.UID) []metav1.OwnerReference {
+	isController := true
+	return []metav1.OwnerReference{{Kind: kind, Name: name, UID: uid, Controller: &isController}}
+}
+
+func TestPodWorkload(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewSimpleClientset(
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name: "report-1", Namespace: "ns", UID: "uid-job",
+			OwnerReferences: controllerRef("CronJob", "report", "uid-cronjob"),
+		}},
+		&apiv1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: "report-1-abc", Namespace: "ns", UID: "uid-report",
+			OwnerReferences: controllerRef("Job", "report-1", "uid-job"),
+		}},
+		&apiv1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: "db-0", Namespace: "ns", UID: "uid-db",
+			OwnerReferences: controllerRef("StatefulSet", "db", "uid-sts"),
+		}},
+		&apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "bare", Namespace: "ns", UID: "uid-bare"}},
+	)
+	adapter := &Adapter{
+		client:   client,
+		podCache: make(map[string]apiv1.Pod),
+		stopCh:   make(chan struct{}),
+	}
+	adapter.startPodWatcher()
+	t.Cleanup(adapter.StopPodWatcher)
+
+	var mu sync.Mutex
+	workloads := make(map[string]*domain.WorkloadRef)
+	adapter.AddPodEventHandler(func(_ context.Context, event *domain.PodEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		workloads[event.Pod.Name] = event.Pod.Workload
+	})
+	workloadOf := func(name string) *domain.WorkloadRef {
+		mu.Lock()
+		defer mu.Unlock()
+		return workloads[name]
+	}
+
+	{ /*** Test Resolving Owners ***/
+		if got := workloadOf("report-1-abc"); !got.Equal(&domain.WorkloadRef{Kind: "CronJob", Namespace: "ns", Name: "report"}) {
+			t.Fatalf("expected the pod of the job to resolve to the cronjob, got %+v", got)
+		}
+		if got := workloadOf("db-0"); !got.Equal(&domain.WorkloadRef{Kind: "StatefulSet", Namespace: "ns", Name: "db"}) {
+			t.Fatalf("unexpected workload %+v", got)
+		}
+		if got := workloadOf("bare"); got != nil {
+			t.Fatalf("expected no workload for a bare pod, got %+v", got)
+		}
+	}
+
+	{ /*** Test Rolling Over ***/
+		// the pod of the new ReplicaSet is seen before the ReplicaSet
+		pod := &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: "web-v2-abc", Namespace: "ns", UID: "uid-web",
+			OwnerReferences: controllerRef("ReplicaSet", "web-v2", "uid-rs-v2"),
+		}}
+		if _, err := client.CoreV1().Pods("ns").Create(context.Background(), pod, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("create pod: %v", err)
+		}
+		waitFor(t, func() bool {
+			return workloadOf("web-v2-abc").Equal(&domain.WorkloadRef{Kind: "ReplicaSet", Namespace: "ns", Name: "web-v2"})
+		})
+
+		rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "web-v2", Namespace: "ns", UID: "uid-rs-v2",
+			OwnerReferences: controllerRef("Deployment", "web", "uid-deploy"),
+		}}
+		if _, err := client.AppsV1().ReplicaSets("ns").Create(context.Background(), rs, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("create replicaset: %v", err)
+		}
+		deployment := &domain.WorkloadRef{Kind: "Deployment", Namespace: "ns", Name: "web"}
+		waitFor(t, func() bool {
+			return workloadOf("web-v2-abc").Equal(deployment)
+		})
+
+		results, err := adapter.QueryPods(context.Background(), &domain.QueryPodsOptions{Workload: deployment})
+		if err != nil {
+			t.Fatalf("query pods: %v", err)
+		}
+		if len(results) != 1 || results[0].PodID != "uid-web" || !results[0].Workload.Equal(deployment) {
+			t.Fatalf("expected the pod of the deployment, got %+v", results)
+		}
+		results, err = adapter.QueryPods(context.Background(), &domain.QueryPodsOptions{K8SNamespace: []string{"other"}, Workload: deployment})
+		if err != nil {
+			t.Fatalf("query pods: %v", err)
+		}
+		if len(results) != 0 {
+			t.Fatalf("expected no pod outside the namespaces of the query, got %d", len(results))
+		}
+	}
+}
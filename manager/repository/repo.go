@@ -70,4 +70,5 @@ const (
 	defaultTimestampField      = "timestamp"
 	scheduleStrategyCollection = "schedule_strategies"
 	scheduleIntentCollection   = "schedule_intents"
+	strategyTemplateCollection = "strategy_templates"
 )
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Gthulhu/api/manager/domain"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
 func (r *repo) InsertStrategyAndIntents(ctx context.Context, strategy *domain.ScheduleStrategy, intents []*domain.ScheduleIntent) error {
@@ -41,6 +42,10 @@ func (r *repo) InsertStrategyAndIntents(ctx context.Context, strategy *domain.Sc
 			intent.UpdatedTime = now
 		}
 	}
+	// a strategy may not target any pod yet, its intents are created when matching pods appear
+	if len(intents) == 0 {
+		return nil
+	}
 	_, err = r.db.Collection(scheduleIntentCollection).InsertMany(ctx, intents)
 	if err != nil {
 		return err
@@ -48,6 +53,51 @@ func (r *repo) InsertStrategyAndIntents(ctx context.Context, strategy *domain.Sc
 	return nil
 }
 
+func (r *repo) InsertIntents(ctx context.Context, intents []*domain.ScheduleIntent) error {
+	if len(intents) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	for _, intent := range intents {
+		if intent.ID.IsZero() {
+			intent.ID = bson.NewObjectID()
+		}
+		if intent.CreatedTime == 0 {
+			intent.CreatedTime = now
+		}
+		if intent.UpdatedTime == 0 {
+			intent.UpdatedTime = now
+		}
+	}
+	_, err := r.db.Collection(scheduleIntentCollection).InsertMany(ctx, intents)
+	return err
+}
+
+func (r *repo) UpdateStrategy(ctx context.Context, strategy *domain.ScheduleStrategy) error {
+	if strategy == nil {
+		return errors.New("nil strategy")
+	}
+	strategy.UpdatedTime = time.Now().UnixMilli()
+	_, err := r.db.Collection(scheduleStrategyCollection).ReplaceOne(ctx, bson.M{"_id": strategy.ID}, strategy)
+	return err
+}
+
+func (r *repo) UpdateIntents(ctx context.Context, intents []*domain.ScheduleIntent) error {
+	if len(intents) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	models := make([]mongo.WriteModel, 0, len(intents))
+	for _, intent := range intents {
+		intent.UpdatedTime = now
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": intent.ID}).
+			SetReplacement(intent))
+	}
+	_, err := r.db.Collection(scheduleIntentCollection).BulkWrite(ctx, models)
+	return err
+}
+
 func (r *repo) BatchUpdateIntentsState(ctx context.Context, intentIDs []bson.ObjectID, newState domain.IntentState) error {
 	update := bson.M{
 		"$set": bson.M{
@@ -64,6 +114,47 @@ func (r *repo) BatchUpdateIntentsState(ctx context.Context, intentIDs []bson.Obj
 	return nil
 }
 
+func (r *repo) BatchUpdateIntentsDelivery(ctx context.Context, intentIDs []bson.ObjectID, delivery domain.IntentDelivery) error {
+	if len(intentIDs) == 0 {
+		return nil
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"state":            delivery.State,
+			"deliveryAttempts": delivery.DeliveryAttempts,
+			"nextDeliveryTime": delivery.NextDeliveryTime,
+			"lastError":        delivery.LastError,
+			"updatedTime":      time.Now().UnixMilli(),
+		},
+	}
+	_, err := r.db.Collection(scheduleIntentCollection).UpdateMany(ctx, bson.M{
+		"_id": bson.M{"$in": intentIDs},
+	}, update)
+	return err
+}
+
+func (r *repo) BulkUpdateIntentsState(ctx context.Context, updates []*domain.IntentStateUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	models := make([]mongo.WriteModel, 0, len(updates))
+	for _, update := range updates {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": update.IntentID}).
+			SetUpdate(bson.M{
+				"$set": bson.M{
+					"state":       update.State,
+					"matchedPIDs": update.MatchedPIDs,
+					"lastError":   update.LastError,
+					"updatedTime": now,
+				},
+			}))
+	}
+	_, err := r.db.Collection(scheduleIntentCollection).BulkWrite(ctx, models)
+	return err
+}
+
 func (r *repo) QueryStrategies(ctx context.Context, opt *domain.QueryStrategyOptions) error {
 	if opt == nil {
 		return errors.New("nil query options")
@@ -78,6 +169,27 @@ func (r *repo) QueryStrategies(ctx context.Context, opt *domain.QueryStrategyOpt
 	if len(opt.CreatorIDs) > 0 {
 		filter["creatorID"] = bson.M{"$in": opt.CreatorIDs}
 	}
+	if len(opt.StrategyNamespaces) > 0 {
+		filter["strategyNamespace"] = bson.M{"$in": opt.StrategyNamespaces}
+	}
+	if len(opt.Names) > 0 {
+		filter["name"] = bson.M{"$in": opt.Names}
+	}
+	if len(opt.ResourceUIDs) > 0 {
+		filter["resource.uid"] = bson.M{"$in": opt.ResourceUIDs}
+	} else if opt.FromResources {
+		filter["resource.uid"] = bson.M{"$exists": true}
+	}
+	if len(opt.TemplateIDs) > 0 {
+		filter["templateID"] = bson.M{"$in": opt.TemplateIDs}
+	}
+	if opt.TimeBounded {
+		filter["$or"] = bson.A{
+			bson.M{"activateAt": bson.M{"$gt": 0}},
+			bson.M{"expireAt": bson.M{"$gt": 0}},
+			bson.M{"window": bson.M{"$exists": true}},
+		}
+	}
 	cursor, err := r.db.Collection(scheduleStrategyCollection).Find(ctx, filter)
 	if err != nil {
 		return err
@@ -111,6 +223,9 @@ func (r *repo) QueryIntents(ctx context.Context, opt *domain.QueryIntentOptions)
 	if len(opt.PodIDs) > 0 {
 		filter["podID"] = bson.M{"$in": opt.PodIDs}
 	}
+	if len(opt.NodeIDs) > 0 {
+		filter["nodeID"] = bson.M{"$in": opt.NodeIDs}
+	}
 	if len(opt.States) > 0 {
 		filter["state"] = bson.M{"$in": opt.States}
 	}
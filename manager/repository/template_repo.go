@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func (r *repo) InsertStrategyTemplate(ctx context.Context, template *domain.StrategyTemplate) error {
+	if template == nil {
+		return errors.New("nil strategy template")
+	}
+	now := time.Now().UnixMilli()
+	if template.ID.IsZero() {
+		template.ID = bson.NewObjectID()
+	}
+	if template.CreatedTime == 0 {
+		template.CreatedTime = now
+	}
+	template.UpdatedTime = now
+	_, err := r.db.Collection(strategyTemplateCollection).InsertOne(ctx, template)
+	if err != nil {
+		return fmt.Errorf("insert strategy template, err: %w", err)
+	}
+	return nil
+}
+
+func (r *repo) UpdateStrategyTemplate(ctx context.Context, template *domain.StrategyTemplate) error {
+	if template == nil {
+		return errors.New("nil strategy template")
+	}
+	if template.ID.IsZero() {
+		return errors.New("strategy template id is required")
+	}
+	template.UpdatedTime = time.Now().UnixMilli()
+	res, err := r.db.Collection(strategyTemplateCollection).ReplaceOne(ctx, bson.M{"_id": template.ID}, template)
+	if err != nil {
+		return fmt.Errorf("update strategy template, err: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *repo) QueryStrategyTemplates(ctx context.Context, opt *domain.QueryStrategyTemplateOptions) error {
+	if opt == nil {
+		return errors.New("nil query options")
+	}
+	filter := bson.M{}
+	if len(opt.IDs) > 0 {
+		filter["_id"] = bson.M{"$in": opt.IDs}
+	}
+	if len(opt.Names) > 0 {
+		filter["name"] = bson.M{"$in": opt.Names}
+	}
+	cursor, err := r.db.Collection(strategyTemplateCollection).Find(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("find strategy templates, err: %w", err)
+	}
+	var result []*domain.StrategyTemplate
+	if err := cursor.All(ctx, &result); err != nil {
+		return fmt.Errorf("decode strategy templates, err: %w", err)
+	}
+	opt.Result = result
+	return nil
+}
+
+func (r *repo) DeleteStrategyTemplate(ctx context.Context, templateID bson.ObjectID) error {
+	_, err := r.db.Collection(strategyTemplateCollection).DeleteOne(ctx, bson.M{"_id": templateID})
+	return err
+}
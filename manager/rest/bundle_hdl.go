@@ -0,0 +1,258 @@
+package rest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	StrategyBundleAPIVersion = "gthulhu.io/v1"
+	StrategyBundleKind       = "StrategyBundle"
+)
+
+// StrategyBundle is the YAML or JSON document declaring every strategy of a strategy namespace
+type StrategyBundle struct {
+	APIVersion        string            `json:"apiVersion"`
+	Kind              string            `json:"kind"`
+	StrategyNamespace string            `json:"strategyNamespace"`
+	Strategies        []*BundleStrategy `json:"strategies"`
+}
+
+// BundleStrategy is a strategy of a bundle, identified by its name; the template is referenced by name
+type BundleStrategy struct {
+	Name               string                     `json:"name"`
+	Template           string                     `json:"template,omitempty"` // the template priority and execution time override the ones of the strategy
+	LabelSelectors     []LabelSelector            `json:"labelSelectors,omitempty"`
+	MatchLabels        map[string]string          `json:"matchLabels,omitempty"`
+	MatchExpressions   []LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+	K8sNamespace       []string                   `json:"k8sNamespace,omitempty"`
+	NamespaceSelector  *LabelSelectorSpec         `json:"namespaceSelector,omitempty"`
+	NodeSelector       *LabelSelectorSpec         `json:"nodeSelector,omitempty"`
+	Workload           *WorkloadRef               `json:"workload,omitempty"`
+	ContainerNames     []string                   `json:"containerNames,omitempty"`
+	ContainerRegex     string                     `json:"containerRegex,omitempty"`
+	CommandRegex       string                     `json:"commandRegex,omitempty"`
+	ProcessMatchers    []ProcessMatcher           `json:"processMatchers,omitempty"`
+	ThreadRegex        string                     `json:"threadRegex,omitempty"`
+	IncludeDescendants bool                       `json:"includeDescendants,omitempty"`
+	Priority           int                        `json:"priority,omitempty"`
+	ExecutionTime      int64                      `json:"executionTime,omitempty"`
+	Weight             int                        `json:"weight,omitempty"`
+	ActivateAt         int64                      `json:"activateAt,omitempty"`
+	ExpireAt           int64                      `json:"expireAt,omitempty"`
+	Window             *RecurringWindow           `json:"window,omitempty"`
+}
+
+func (b *StrategyBundle) toDomainBundle() (*domain.StrategyBundle, error) {
+	if b.APIVersion != StrategyBundleAPIVersion || b.Kind != StrategyBundleKind {
+		return nil, fmt.Errorf("unsupported bundle %s %s, expected %s %s", b.APIVersion, b.Kind, StrategyBundleAPIVersion, StrategyBundleKind)
+	}
+	bundle := &domain.StrategyBundle{
+		StrategyNamespace: b.StrategyNamespace,
+		Entries:           make([]*domain.StrategyBundleEntry, 0, len(b.Strategies)),
+	}
+	for _, s := range b.Strategies {
+		req := CreateScheduleStrategyRequest{
+			Name:               s.Name,
+			LabelSelectors:     s.LabelSelectors,
+			MatchLabels:        s.MatchLabels,
+			MatchExpressions:   s.MatchExpressions,
+			K8sNamespace:       s.K8sNamespace,
+			NamespaceSelector:  s.NamespaceSelector,
+			NodeSelector:       s.NodeSelector,
+			Workload:           s.Workload,
+			ContainerNames:     s.ContainerNames,
+			ContainerRegex:     s.ContainerRegex,
+			CommandRegex:       s.CommandRegex,
+			ProcessMatchers:    s.ProcessMatchers,
+			ThreadRegex:        s.ThreadRegex,
+			IncludeDescendants: s.IncludeDescendants,
+			Priority:           s.Priority,
+			ExecutionTime:      s.ExecutionTime,
+			Weight:             s.Weight,
+			ActivateAt:         s.ActivateAt,
+			ExpireAt:           s.ExpireAt,
+			Window:             s.Window,
+		}
+		strategy, err := req.toDomainStrategy()
+		if err != nil {
+			return nil, err
+		}
+		bundle.Entries = append(bundle.Entries, &domain.StrategyBundleEntry{Template: s.Template, Strategy: strategy})
+	}
+	return bundle, nil
+}
+
+func convertDomainBundleToResponseBundle(bundle *domain.StrategyBundle) *StrategyBundle {
+	resp := &StrategyBundle{
+		APIVersion:        StrategyBundleAPIVersion,
+		Kind:              StrategyBundleKind,
+		StrategyNamespace: bundle.StrategyNamespace,
+		Strategies:        make([]*BundleStrategy, 0, len(bundle.Entries)),
+	}
+	for _, entry := range bundle.Entries {
+		strategy := entry.Strategy
+		s := &BundleStrategy{
+			Name:               strategy.Name,
+			Template:           entry.Template,
+			LabelSelectors:     convertDomainLabelSelectorsToResponseLabelSelectors(strategy.LabelSelectors),
+			MatchLabels:        strategy.MatchLabels,
+			MatchExpressions:   convertDomainRequirementsToResponseRequirements(strategy.MatchExpressions),
+			K8sNamespace:       strategy.K8sNamespace,
+			NamespaceSelector:  convertDomainSelectorSpecToResponseSelectorSpec(strategy.NamespaceSelector),
+			NodeSelector:       convertDomainSelectorSpecToResponseSelectorSpec(strategy.NodeSelector),
+			Workload:           convertDomainWorkloadToResponseWorkload(strategy.Workload),
+			ContainerNames:     strategy.ContainerNames,
+			ContainerRegex:     strategy.ContainerRegex,
+			CommandRegex:       strategy.CommandRegex,
+			ProcessMatchers:    convertDomainProcessMatchersToResponseMatchers(strategy.ProcessMatchers),
+			ThreadRegex:        strategy.ThreadRegex,
+			IncludeDescendants: strategy.IncludeDescendants,
+			Weight:             strategy.Weight,
+			ActivateAt:         strategy.ActivateAt,
+			ExpireAt:           strategy.ExpireAt,
+		}
+		// a strategy derived from a template takes its parameters from the template when the bundle is applied
+		if entry.Template == "" {
+			s.Priority = strategy.Priority
+			s.ExecutionTime = strategy.ExecutionTime
+		}
+		if strategy.Window != nil {
+			s.Window = &RecurringWindow{Cron: strategy.Window.Cron, DurationSec: strategy.Window.DurationSec}
+		}
+		resp.Strategies = append(resp.Strategies, s)
+	}
+	return resp
+}
+
+// ExportStrategyBundle godoc
+// @Summary Export strategy bundle
+// @Description Export every strategy of a strategy namespace as a bundle document that can be applied back, in YAML or JSON.
+// @Tags Strategies
+// @Produce json
+// @Produce application/yaml
+// @Security BearerAuth
+// @Param strategyNamespace query string true "Strategy namespace to export"
+// @Param format query string false "json (default) or yaml"
+// @Success 200 {object} StrategyBundle
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/strategies/bundle [get]
+func (h *Handler) ExportStrategyBundle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "yaml" {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Invalid format, expected json or yaml", nil)
+		return
+	}
+
+	bundle, err := h.Svc.ExportStrategyBundle(ctx, r.URL.Query().Get("strategyNamespace"))
+	if err != nil {
+		h.HandleError(ctx, w, err)
+		return
+	}
+
+	resp := convertDomainBundleToResponseBundle(bundle)
+	if format != "yaml" {
+		h.JSONResponse(ctx, w, http.StatusOK, resp)
+		return
+	}
+	data, err := yaml.Marshal(resp)
+	if err != nil {
+		h.ErrorResponse(ctx, w, http.StatusInternalServerError, "Failed to encode YAML response", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+type ApplyStrategyBundleResponse struct {
+	DryRun  bool              `json:"dryRun"`
+	Changes []*StrategyChange `json:"changes"` // applied changes, or the ones that would be applied on a dry run
+}
+
+// StrategyChange is a strategy the bundle creates, updates or deletes
+type StrategyChange struct {
+	Action     string   `json:"action"` // create, update or delete
+	Name       string   `json:"name"`
+	StrategyID string   `json:"strategyId,omitempty"`
+	Fields     []string `json:"fields,omitempty"` // fields of an updated strategy that change
+}
+
+// ApplyStrategyBundle godoc
+// @Summary Apply strategy bundle
+// @Description Create and update the strategies of the bundle namespace to match a YAML or JSON bundle, with prune the strategies missing from the bundle are deleted. Applying the same bundle again changes nothing. With dryRun only the changes are reported.
+// @Tags Strategies
+// @Accept json
+// @Accept application/yaml
+// @Produce json
+// @Security BearerAuth
+// @Param request body StrategyBundle true "Strategy bundle"
+// @Param dryRun query bool false "Only report the changes"
+// @Param prune query bool false "Delete the strategies of the namespace missing from the bundle"
+// @Success 200 {object} SuccessResponse[ApplyStrategyBundleResponse]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/strategies/bundle [post]
+func (h *Handler) ApplyStrategyBundle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	// YAML is a superset of JSON, so both formats are decoded the same way
+	var req StrategyBundle
+	err = yaml.UnmarshalStrict(body, &req)
+	if err != nil {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	bundle, err := req.toDomainBundle()
+	if err != nil {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Invalid strategy bundle", err)
+		return
+	}
+	opts := domain.ApplyBundleOptions{
+		DryRun: r.URL.Query().Get("dryRun") == "true",
+		Prune:  r.URL.Query().Get("prune") == "true",
+	}
+
+	claims, ok := h.GetClaimsFromContext(ctx)
+	if !ok {
+		h.ErrorResponse(ctx, w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	changes, err := h.Svc.ApplyStrategyBundle(ctx, &claims, bundle, opts)
+	if err != nil {
+		h.HandleError(ctx, w, err)
+		return
+	}
+
+	resp := ApplyStrategyBundleResponse{
+		DryRun:  opts.DryRun,
+		Changes: make([]*StrategyChange, len(changes)),
+	}
+	for i, change := range changes {
+		resp.Changes[i] = &StrategyChange{
+			Action: string(change.Action),
+			Name:   change.Name,
+			Fields: change.Fields,
+		}
+		if !change.StrategyID.IsZero() {
+			resp.Changes[i].StrategyID = change.StrategyID.Hex()
+		}
+	}
+	response := NewSuccessResponse[ApplyStrategyBundleResponse](&resp)
+	h.JSONResponse(ctx, w, http.StatusOK, response)
+}
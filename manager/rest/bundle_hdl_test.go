@@ -0,0 +1,64 @@
+package rest_test
+
+import (
+	"net/http"
+
+	"github.com/Gthulhu/api/config"
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/manager/rest"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *HandlerTestSuite) TestIntegrationStrategyBundleHandler() {
+	adminUser, adminPwd := config.GetManagerConfig().Account.AdminEmail, config.GetManagerConfig().Account.AdminPassword
+	adminToken := suite.login(adminUser, adminPwd.Value(), http.StatusOK)
+
+	bundle := &rest.StrategyBundle{
+		APIVersion:        rest.StrategyBundleAPIVersion,
+		Kind:              rest.StrategyBundleKind,
+		StrategyNamespace: "team-a",
+		Strategies: []*rest.BundleStrategy{
+			{Name: "web", LabelSelectors: []rest.LabelSelector{{Key: "app", Value: "web"}}, Priority: 1, ExecutionTime: 1000000},
+			{Name: "batch", Template: "background-batch", LabelSelectors: []rest.LabelSelector{{Key: "app", Value: "batch"}}},
+		},
+	}
+	dryRun := suite.applyStrategyBundle(adminToken, bundle, "?dryRun=true", http.StatusOK)
+	suite.Require().True(dryRun.DryRun)
+	suite.Require().Len(dryRun.Changes, 2, "Expected two strategies to create")
+	suite.Require().Empty(suite.listSelfStrategies(adminToken, http.StatusOK).Strategies, "Expected the dry run to change nothing")
+
+	suite.MockK8SAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return([]*domain.Pod{}, nil).Times(2)
+	applied := suite.applyStrategyBundle(adminToken, bundle, "", http.StatusOK)
+	suite.Require().Len(applied.Changes, 2, "Expected two strategies to be created")
+
+	// the exported bundle applies back without any change
+	exported := suite.exportStrategyBundle(adminToken, "team-a", http.StatusOK)
+	suite.Require().Len(exported.Strategies, 2, "Expected two strategies in the exported bundle")
+	suite.Require().Equal("background-batch", exported.Strategies[0].Template, "Template mismatch")
+	suite.Require().Empty(suite.applyStrategyBundle(adminToken, exported, "", http.StatusOK).Changes, "Expected no change")
+
+	bundle.Strategies = bundle.Strategies[:1]
+	bundle.Strategies[0].Priority = 0
+	pruned := suite.applyStrategyBundle(adminToken, bundle, "?dryRun=true&prune=true", http.StatusOK)
+	suite.Require().Equal([]*rest.StrategyChange{
+		{Action: "update", Name: "web", StrategyID: applied.Changes[0].StrategyID, Fields: []string{"priority"}},
+		{Action: "delete", Name: "batch", StrategyID: applied.Changes[1].StrategyID},
+	}, pruned.Changes)
+
+	bundle.Strategies = append(bundle.Strategies, &rest.BundleStrategy{Name: "web"})
+	suite.applyStrategyBundle(adminToken, bundle, "", http.StatusBadRequest)
+}
+
+func (suite *HandlerTestSuite) applyStrategyBundle(token string, bundle *rest.StrategyBundle, query string, expectedStatus int) *rest.ApplyStrategyBundleResponse {
+	applyResp := rest.SuccessResponse[rest.ApplyStrategyBundleResponse]{}
+	_, resp := suite.sendV1Request("POST", "/strategies/bundle"+query, bundle, &applyResp, token)
+	suite.Require().Equal(expectedStatus, resp.Code, "Unexpected status code on apply strategy bundle")
+	return applyResp.Data
+}
+
+func (suite *HandlerTestSuite) exportStrategyBundle(token, strategyNamespace string, expectedStatus int) *rest.StrategyBundle {
+	exportResp := rest.StrategyBundle{}
+	_, resp := suite.sendV1Request("GET", "/strategies/bundle?strategyNamespace="+strategyNamespace, nil, &exportResp, token)
+	suite.Require().Equal(expectedStatus, resp.Code, "Unexpected status code on export strategy bundle")
+	return &exportResp
+}
@@ -0,0 +1,106 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Gthulhu/api/manager/domain"
+)
+
+type NodeScheduleIntent struct {
+	ID                  string                     `json:"id"`
+	PodName             string                     `json:"podName,omitempty"`
+	PodID               string                     `json:"podID,omitempty"`
+	NodeID              string                     `json:"nodeID,omitempty"`
+	K8sNamespace        string                     `json:"k8sNamespace,omitempty"`
+	CommandRegex        string                     `json:"commandRegex,omitempty"`
+	ProcessMatchers     []ProcessMatcher           `json:"processMatchers,omitempty"`    // ANDed with the command regex
+	ThreadRegex         string                     `json:"threadRegex,omitempty"`        // the matching threads of the processes are targeted instead of the processes
+	IncludeDescendants  bool                       `json:"includeDescendants,omitempty"` // the descendants of the matching processes are targeted too
+	ContainerIDs        []string                   `json:"containerIDs,omitempty"`       // containers of the pod the intent is restricted to, every container if empty
+	Priority            int                        `json:"priority,omitempty"`
+	ExecutionTime       int64                      `json:"executionTime,omitempty"`
+	PodLabels           map[string]string          `json:"podLabels,omitempty"`
+	Selector            []LabelSelectorRequirement `json:"selector,omitempty"`
+	StrategyID          string                     `json:"strategyID,omitempty"`
+	Weight              int                        `json:"weight,omitempty"`
+	Specificity         int                        `json:"specificity,omitempty"`
+	StrategyCreatedTime int64                      `json:"strategyCreatedTime,omitempty"` // unix milli, the newest strategy wins a tie of weight and specificity
+}
+
+type ListNodeScheduleIntentsResponse struct {
+	NodeID  string                `json:"nodeID"`
+	Intents []*NodeScheduleIntent `json:"intents"`
+}
+
+// ListNodeScheduleIntents godoc
+// @Summary List schedule intents of a node
+// @Description Returns every schedule intent the decision maker of the node should enforce, used by the decision makers to sync their full state.
+// @Tags DecisionMaker
+// @Produce json
+// @Security BearerAuth
+// @Param nodeID query string false "Node ID, defaults to the node ID of the decision maker token"
+// @Success 200 {object} SuccessResponse[ListNodeScheduleIntentsResponse]
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/decisionmaker/intents [get]
+func (h *Handler) ListNodeScheduleIntents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claims, ok := h.GetDecisionMakerClaimsFromContext(ctx)
+	if !ok {
+		h.ErrorResponse(ctx, w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	nodeID := r.URL.Query().Get("nodeID")
+	if nodeID == "" {
+		nodeID = claims.NodeID
+	}
+	if nodeID != claims.NodeID {
+		h.ErrorResponse(ctx, w, http.StatusForbidden, "Decision maker can only sync the intents of its own node", fmt.Errorf("decision maker of node %s requested intents of node %s", claims.NodeID, nodeID))
+		return
+	}
+
+	queryOpt := &domain.QueryIntentOptions{
+		NodeIDs: []string{nodeID},
+	}
+	err := h.Svc.ListScheduleIntents(ctx, queryOpt)
+	if err != nil {
+		h.HandleError(ctx, w, err)
+		return
+	}
+
+	resp := ListNodeScheduleIntentsResponse{
+		NodeID:  nodeID,
+		Intents: make([]*NodeScheduleIntent, 0, len(queryOpt.Result)),
+	}
+	for _, intent := range queryOpt.Result {
+		// the failed intents and the intents of a strategy outside its active time are kept off the decision maker
+		if !intent.InEffect() {
+			continue
+		}
+		resp.Intents = append(resp.Intents, &NodeScheduleIntent{
+			ID:                  intent.ID.Hex(),
+			PodName:             intent.PodName,
+			PodID:               intent.PodID,
+			NodeID:              intent.NodeID,
+			K8sNamespace:        intent.K8sNamespace,
+			CommandRegex:        intent.CommandRegex,
+			ProcessMatchers:     convertDomainProcessMatchersToResponseMatchers(intent.ProcessMatchers),
+			ThreadRegex:         intent.ThreadRegex,
+			IncludeDescendants:  intent.IncludeDescendants,
+			ContainerIDs:        intent.ContainerIDs,
+			Priority:            intent.Priority,
+			ExecutionTime:       intent.ExecutionTime,
+			PodLabels:           intent.PodLabels,
+			Selector:            convertDomainRequirementsToResponseRequirements(intent.Selector),
+			StrategyID:          intent.StrategyID.Hex(),
+			Weight:              intent.Weight,
+			Specificity:         intent.Specificity,
+			StrategyCreatedTime: intent.StrategyCreatedTime,
+		})
+	}
+	response := NewSuccessResponse[ListNodeScheduleIntentsResponse](&resp)
+	h.JSONResponse(ctx, w, http.StatusOK, response)
+}
@@ -0,0 +1,50 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/Gthulhu/api/config"
+	dmclient "github.com/Gthulhu/api/decisionmaker/client"
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/manager/rest"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *HandlerTestSuite) TestIntegrationListNodeScheduleIntents() {
+	adminUser, adminPwd := config.GetManagerConfig().Account.AdminEmail, config.GetManagerConfig().Account.AdminPassword
+	adminToken := suite.login(adminUser, adminPwd.Value(), http.StatusOK)
+
+	strategyReq := rest.CreateScheduleStrategyRequest{
+		LabelSelectors: []rest.LabelSelector{{Key: "test", Value: "test"}},
+		CommandRegex:   "nginx",
+		Priority:       100,
+		ExecutionTime:  100,
+	}
+	suite.MockK8SAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return([]*domain.Pod{
+		{PodID: "pod-a", Labels: map[string]string{"test": "test"}, NodeID: "node-a"},
+		{PodID: "pod-b", Labels: map[string]string{"test": "test"}, NodeID: "node-b"},
+	}, nil).Once()
+	suite.MockK8SAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{{Host: "dm-host", NodeID: "node-a", Port: 8080}}, nil).Once()
+	suite.MockDMAdapter.EXPECT().SendSchedulingIntent(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	suite.createStrategy(adminToken, &strategyReq, http.StatusOK)
+
+	// requests without a decision maker token are rejected
+	_, rec := suite.sendV1Request(http.MethodGet, "/decisionmaker/intents?nodeID=node-a", nil, nil, adminToken)
+	suite.Require().Equal(http.StatusUnauthorized, rec.Code, "Expected user token to be rejected")
+
+	privateKeyPem, err := os.ReadFile(config.GetAbsPath("config", "jwt_private_key.key"))
+	suite.Require().NoError(err, "Failed to read decision maker private key")
+	server := httptest.NewServer(suite.Engine)
+	defer server.Close()
+	managerClient, err := dmclient.NewManagerClient(config.ManagerConfig{URL: server.URL, ClientID: "decisionmaker"}, config.TokenConfig{RsaPrivateKeyPem: config.SecretValue(privateKeyPem)})
+	suite.Require().NoError(err, "Failed to create manager client")
+
+	intents, err := managerClient.ListNodeIntents(suite.Ctx, "node-a")
+	suite.Require().NoError(err, "Failed to list node intents")
+	suite.Require().Len(intents, 1, "Expected only the intents of node-a")
+	suite.Require().Equal("pod-a", intents[0].PodID, "PodID mismatch")
+	suite.Require().Equal(strategyReq.CommandRegex, intents[0].CommandRegex, "CommandRegex mismatch")
+	suite.Require().Equal(strategyReq.Priority, intents[0].Priority, "Priority mismatch")
+}
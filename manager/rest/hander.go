@@ -153,6 +153,18 @@ func (h *Handler) SetClaimsInContext(ctx context.Context, claims domain.Claims)
 	return context.WithValue(ctx, claimsKey{}, claims)
 }
 
+type decisionMakerClaimsKey struct{}
+
+func (h *Handler) SetDecisionMakerClaimsInContext(ctx context.Context, claims domain.DecisionMakerClaims) context.Context {
+	return context.WithValue(ctx, decisionMakerClaimsKey{}, claims)
+}
+
+// GetDecisionMakerClaimsFromContext extracts domain.DecisionMakerClaims from the request context
+func (h *Handler) GetDecisionMakerClaimsFromContext(ctx context.Context) (domain.DecisionMakerClaims, bool) {
+	claims, ok := ctx.Value(decisionMakerClaimsKey{}).(domain.DecisionMakerClaims)
+	return claims, ok
+}
+
 type rolePolicyKey struct{}
 
 func (h *Handler) SetRolePolicyInContext(ctx context.Context, rolePolicy domain.RolePolicy) context.Context {
@@ -43,6 +43,32 @@ func (h *Handler) GetAuthMiddleware(permissionKey domain.PermissionKey) func(nex
 	}
 }
 
+// GetDecisionMakerAuthMiddleware authenticates the decision makers with the token they sign with their private key
+func (h *Handler) GetDecisionMakerAuthMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			tokenString := r.Header.Get("Authorization")
+			const bearerPrefix = "Bearer "
+			if len(tokenString) <= len(bearerPrefix) || tokenString[:len(bearerPrefix)] != bearerPrefix {
+				h.ErrorResponse(ctx, w, http.StatusUnauthorized, "Missing or invalid Authorization header", nil)
+				return
+			}
+			tokenString = tokenString[len(bearerPrefix):]
+
+			claims, err := h.Svc.VerifyDecisionMakerToken(ctx, tokenString)
+			if err != nil {
+				h.HandleError(ctx, w, err)
+				return
+			}
+
+			ctx = h.SetDecisionMakerClaimsInContext(ctx, claims)
+			r = r.WithContext(ctx)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func LoggerMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
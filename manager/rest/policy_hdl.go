@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/Gthulhu/api/manager/domain"
+)
+
+// EffectivePolicyResponse explains which strategies and intents apply to a pod and what its decision maker enforces
+type EffectivePolicyResponse struct {
+	PodID        string              `json:"podId"`
+	PodName      string              `json:"podName"`
+	K8sNamespace string              `json:"k8sNamespace"`
+	NodeID       string              `json:"nodeId"`
+	Strategies   []*ScheduleStrategy `json:"strategies"`
+	// Intents are ordered by precedence, a process matched by several intents is bound to the first one
+	Intents            []*ScheduleIntent    `json:"intents"`
+	WinningIntentID    string               `json:"winningIntentId,omitempty"`
+	Processes          []*ProcessScheduling `json:"processes"`                    // scheduling enforced per PID and TID by the decision maker of the node
+	DecisionMakerError string               `json:"decisionMakerError,omitempty"` // why the processes could not be queried
+}
+
+type ProcessScheduling struct {
+	PID           int    `json:"pid"`
+	TID           int    `json:"tid,omitempty"` // thread the scheduling is enforced for, it overrides the scheduling of its process
+	Priority      bool   `json:"priority"`
+	ExecutionTime uint64 `json:"executionTime"`
+	CommandRegex  string `json:"commandRegex,omitempty"`
+	StrategyID    string `json:"strategyId,omitempty"` // strategy of the intent that took precedence for the process
+}
+
+// GetEffectivePolicy godoc
+// @Summary Get the effective policy of a pod
+// @Description Returns every strategy and intent touching a pod ordered by precedence, the winning intent and the scheduling the decision maker of the node enforces per PID. The pod is given by its UID, or by its namespace and name.
+// @Tags Strategies
+// @Produce json
+// @Security BearerAuth
+// @Param podID query string false "Pod UID"
+// @Param namespace query string false "Namespace of the pod, with podName"
+// @Param podName query string false "Name of the pod, with namespace"
+// @Success 200 {object} SuccessResponse[EffectivePolicyResponse]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/pods/policy [get]
+func (h *Handler) GetEffectivePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := &domain.EffectivePolicyQuery{
+		PodID:        r.URL.Query().Get("podID"),
+		K8sNamespace: r.URL.Query().Get("namespace"),
+		PodName:      r.URL.Query().Get("podName"),
+	}
+	policy, err := h.Svc.GetEffectivePolicy(ctx, query)
+	if err != nil {
+		h.HandleError(ctx, w, err)
+		return
+	}
+
+	resp := EffectivePolicyResponse{
+		PodID:              policy.PodID,
+		PodName:            policy.PodName,
+		K8sNamespace:       policy.K8sNamespace,
+		NodeID:             policy.NodeID,
+		Strategies:         make([]*ScheduleStrategy, 0, len(policy.Strategies)),
+		Intents:            make([]*ScheduleIntent, 0, len(policy.Intents)),
+		Processes:          make([]*ProcessScheduling, 0, len(policy.Processes)),
+		DecisionMakerError: policy.DecisionMakerError,
+	}
+	if !policy.WinningIntentID.IsZero() {
+		resp.WinningIntentID = policy.WinningIntentID.Hex()
+	}
+	for _, strategy := range policy.Strategies {
+		resp.Strategies = append(resp.Strategies, h.convertDomainStrategyToResponseStrategy(strategy))
+	}
+	for _, intent := range policy.Intents {
+		resp.Intents = append(resp.Intents, h.convertDomainIntentToResponseIntent(intent))
+	}
+	for _, process := range policy.Processes {
+		resp.Processes = append(resp.Processes, &ProcessScheduling{
+			PID:           process.PID,
+			TID:           process.TID,
+			Priority:      process.Priority,
+			ExecutionTime: process.ExecutionTime,
+			CommandRegex:  process.CommandRegex,
+			StrategyID:    process.StrategyID,
+		})
+	}
+	h.JSONResponse(ctx, w, http.StatusOK, NewSuccessResponse[EffectivePolicyResponse](&resp))
+}
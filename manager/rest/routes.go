@@ -40,9 +40,23 @@ func (h *Handler) SetupRoutes(engine *echo.Echo) {
 		// strategy routes
 		apiV1.POST("/strategies", h.echoHandler(h.CreateScheduleStrategy), echo.WrapMiddleware(h.GetAuthMiddleware(domain.ScheduleStrategyCreate)))
 		apiV1.GET("/strategies/self", h.echoHandler(h.ListSelfScheduleStrategies), echo.WrapMiddleware(h.GetAuthMiddleware(domain.ScheduleStrategyRead)))
+		apiV1.POST("/strategies/preview", h.echoHandler(h.PreviewScheduleStrategy), echo.WrapMiddleware(h.GetAuthMiddleware(domain.ScheduleStrategyCreate)))
+		apiV1.PUT("/strategies", h.echoHandler(h.UpdateScheduleStrategy), echo.WrapMiddleware(h.GetAuthMiddleware(domain.ScheduleStrategyUpdate)))
 		apiV1.DELETE("/strategies", h.echoHandler(h.DeleteScheduleStrategy), echo.WrapMiddleware(h.GetAuthMiddleware(domain.ScheduleStrategyDelete)))
+		apiV1.GET("/strategies/bundle", h.echoHandler(h.ExportStrategyBundle), echo.WrapMiddleware(h.GetAuthMiddleware(domain.ScheduleStrategyRead)))
+		apiV1.POST("/strategies/bundle", h.echoHandler(h.ApplyStrategyBundle), echo.WrapMiddleware(h.GetAuthMiddleware(domain.ScheduleStrategyApply)))
 		apiV1.GET("/intents/self", h.echoHandler(h.ListSelfScheduleIntents), echo.WrapMiddleware(h.GetAuthMiddleware(domain.ScheduleIntentRead)))
 		apiV1.DELETE("/intents", h.echoHandler(h.DeleteScheduleIntents), echo.WrapMiddleware(h.GetAuthMiddleware(domain.ScheduleIntentDelete)))
+		apiV1.GET("/pods/policy", h.echoHandler(h.GetEffectivePolicy), echo.WrapMiddleware(h.GetAuthMiddleware(domain.ScheduleIntentRead)))
+
+		// strategy template routes
+		apiV1.POST("/strategy-templates", h.echoHandler(h.CreateStrategyTemplate), echo.WrapMiddleware(h.GetAuthMiddleware(domain.StrategyTemplateCreate)))
+		apiV1.GET("/strategy-templates", h.echoHandler(h.ListStrategyTemplates), echo.WrapMiddleware(h.GetAuthMiddleware(domain.StrategyTemplateRead)))
+		apiV1.PUT("/strategy-templates", h.echoHandler(h.UpdateStrategyTemplate), echo.WrapMiddleware(h.GetAuthMiddleware(domain.StrategyTemplateUpdate)))
+		apiV1.DELETE("/strategy-templates", h.echoHandler(h.DeleteStrategyTemplate), echo.WrapMiddleware(h.GetAuthMiddleware(domain.StrategyTemplateDelete)))
+
+		// decision maker routes
+		apiV1.GET("/decisionmaker/intents", h.echoHandler(h.ListNodeScheduleIntents), echo.WrapMiddleware(h.GetDecisionMakerAuthMiddleware()))
 	}
 
 }
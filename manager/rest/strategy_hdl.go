@@ -1,7 +1,9 @@
 package rest
 
 import (
+	"cmp"
 	"net/http"
+	"slices"
 
 	"github.com/Gthulhu/api/manager/domain"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -12,24 +14,216 @@ type LabelSelector struct {
 	Value string `json:"value,omitempty"`
 }
 
+// LabelSelectorRequirement mirrors the matchExpressions of a kubernetes label selector
+type LabelSelectorRequirement struct {
+	Key      string   `json:"key"`
+	Operator string   `json:"operator"` // In, NotIn, Exists or DoesNotExist
+	Values   []string `json:"values,omitempty"`
+}
+
+// LabelSelectorSpec selects namespaces or nodes by their labels, as a kubernetes label selector
+type LabelSelectorSpec struct {
+	MatchLabels      map[string]string          `json:"matchLabels,omitempty"`
+	MatchExpressions []LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+}
+
+// ProcessMatcher matches a regex against a field of the processes: comm, cmdline, exe, uid, gid, cgroup or thread (the name of any thread)
+type ProcessMatcher struct {
+	Field string `json:"field"`
+	Regex string `json:"regex"`
+}
+
+func toDomainProcessMatchers(matchers []ProcessMatcher) []domain.ProcessMatcher {
+	if len(matchers) == 0 {
+		return nil
+	}
+	domainMatchers := make([]domain.ProcessMatcher, 0, len(matchers))
+	for _, matcher := range matchers {
+		domainMatchers = append(domainMatchers, domain.ProcessMatcher{Field: matcher.Field, Regex: matcher.Regex})
+	}
+	return domainMatchers
+}
+
+func convertDomainProcessMatchersToResponseMatchers(matchers []domain.ProcessMatcher) []ProcessMatcher {
+	if len(matchers) == 0 {
+		return nil
+	}
+	respMatchers := make([]ProcessMatcher, 0, len(matchers))
+	for _, matcher := range matchers {
+		respMatchers = append(respMatchers, ProcessMatcher{Field: matcher.Field, Regex: matcher.Regex})
+	}
+	return respMatchers
+}
+
+// WorkloadRef identifies a workload by kind (Deployment, ReplicaSet, StatefulSet, DaemonSet, Job or CronJob), namespace and name
+type WorkloadRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+func (w *WorkloadRef) toDomainWorkload() *domain.WorkloadRef {
+	if w == nil {
+		return nil
+	}
+	return &domain.WorkloadRef{Kind: w.Kind, Namespace: w.Namespace, Name: w.Name}
+}
+
+func convertDomainWorkloadToResponseWorkload(workload *domain.WorkloadRef) *WorkloadRef {
+	if workload == nil {
+		return nil
+	}
+	return &WorkloadRef{Kind: workload.Kind, Namespace: workload.Namespace, Name: workload.Name}
+}
+
+// RecurringWindow enforces a strategy for durationSec seconds every time the 5-field cron expression fires, evaluated in UTC
+type RecurringWindow struct {
+	Cron        string `json:"cron"`
+	DurationSec int64  `json:"durationSec"`
+}
+
 type CreateScheduleStrategyRequest struct {
-	StrategyNamespace string          `json:"strategyNamespace,omitempty"`
-	LabelSelectors    []LabelSelector `json:"labelSelectors,omitempty"`
-	K8sNamespace      []string        `json:"k8sNamespace,omitempty"`
-	CommandRegex      string          `json:"commandRegex,omitempty"`
-	Priority          int             `json:"priority,omitempty"`
-	ExecutionTime     int64           `json:"executionTime,omitempty"`
+	StrategyNamespace  string                     `json:"strategyNamespace,omitempty"`
+	Name               string                     `json:"name,omitempty"` // identifies the strategy in the bundles of its strategy namespace
+	LabelSelectors     []LabelSelector            `json:"labelSelectors,omitempty"`
+	MatchLabels        map[string]string          `json:"matchLabels,omitempty"`
+	MatchExpressions   []LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+	K8sNamespace       []string                   `json:"k8sNamespace,omitempty"`
+	NamespaceSelector  *LabelSelectorSpec         `json:"namespaceSelector,omitempty"`
+	NodeSelector       *LabelSelectorSpec         `json:"nodeSelector,omitempty"`
+	Workload           *WorkloadRef               `json:"workload,omitempty"`       // targets the pods controlled by the workload
+	ContainerNames     []string                   `json:"containerNames,omitempty"` // targets the containers with these names instead of every container of the pods
+	ContainerRegex     string                     `json:"containerRegex,omitempty"` // targets the containers whose name matches, ORed with ContainerNames
+	CommandRegex       string                     `json:"commandRegex,omitempty"`
+	ProcessMatchers    []ProcessMatcher           `json:"processMatchers,omitempty"`    // ANDed with the command regex, which applies to the comm of the processes
+	ThreadRegex        string                     `json:"threadRegex,omitempty"`        // the matching threads of the processes are targeted instead of the processes
+	IncludeDescendants bool                       `json:"includeDescendants,omitempty"` // the descendants of the matching processes are targeted too
+	Priority           int                        `json:"priority,omitempty"`
+	ExecutionTime      int64                      `json:"executionTime,omitempty"`
+	Weight             int                        `json:"weight,omitempty"`     // precedence over the other strategies targeting the same processes, higher wins
+	ActivateAt         int64                      `json:"activateAt,omitempty"` // unix milli time before which the strategy is not enforced
+	ExpireAt           int64                      `json:"expireAt,omitempty"`   // unix milli time from which the strategy is no longer enforced
+	Window             *RecurringWindow           `json:"window,omitempty"`     // the strategy is only enforced inside the window
+	TemplateID         string                     `json:"templateId,omitempty"` // template providing the priority and execution time, which override the ones of the request
+}
+
+func (req *CreateScheduleStrategyRequest) toDomainStrategy() (*domain.ScheduleStrategy, error) {
+	strategy := &domain.ScheduleStrategy{
+		StrategyNamespace:  req.StrategyNamespace,
+		Name:               req.Name,
+		LabelSelectors:     make([]domain.LabelSelector, len(req.LabelSelectors)),
+		MatchLabels:        req.MatchLabels,
+		K8sNamespace:       req.K8sNamespace,
+		Workload:           req.Workload.toDomainWorkload(),
+		ContainerNames:     req.ContainerNames,
+		ContainerRegex:     req.ContainerRegex,
+		CommandRegex:       req.CommandRegex,
+		ProcessMatchers:    toDomainProcessMatchers(req.ProcessMatchers),
+		ThreadRegex:        req.ThreadRegex,
+		IncludeDescendants: req.IncludeDescendants,
+		Priority:           req.Priority,
+		ExecutionTime:      req.ExecutionTime,
+		Weight:             req.Weight,
+		ActivateAt:         req.ActivateAt,
+		ExpireAt:           req.ExpireAt,
+	}
+	if req.Window != nil {
+		strategy.Window = &domain.RecurringWindow{Cron: req.Window.Cron, DurationSec: req.Window.DurationSec}
+	}
+	for i, ls := range req.LabelSelectors {
+		strategy.LabelSelectors[i] = domain.LabelSelector{
+			Key:   ls.Key,
+			Value: ls.Value,
+		}
+	}
+	strategy.MatchExpressions = convertRequirementsToDomainRequirements(req.MatchExpressions)
+	strategy.NamespaceSelector = convertSelectorSpecToDomainSelectorSpec(req.NamespaceSelector)
+	strategy.NodeSelector = convertSelectorSpecToDomainSelectorSpec(req.NodeSelector)
+	if req.TemplateID != "" {
+		templateID, err := bson.ObjectIDFromHex(req.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		strategy.TemplateID = templateID
+	}
+	return strategy, nil
+}
+
+func convertSelectorSpecToDomainSelectorSpec(spec *LabelSelectorSpec) *domain.LabelSelectorSpec {
+	if spec == nil {
+		return nil
+	}
+	return &domain.LabelSelectorSpec{
+		MatchLabels:      spec.MatchLabels,
+		MatchExpressions: convertRequirementsToDomainRequirements(spec.MatchExpressions),
+	}
+}
+
+func convertDomainSelectorSpecToResponseSelectorSpec(spec *domain.LabelSelectorSpec) *LabelSelectorSpec {
+	if spec == nil {
+		return nil
+	}
+	return &LabelSelectorSpec{
+		MatchLabels:      spec.MatchLabels,
+		MatchExpressions: convertDomainRequirementsToResponseRequirements(spec.MatchExpressions),
+	}
+}
+
+func convertRequirementsToDomainRequirements(requirements []LabelSelectorRequirement) []domain.LabelSelectorRequirement {
+	var domainRequirements []domain.LabelSelectorRequirement
+	for _, requirement := range requirements {
+		domainRequirements = append(domainRequirements, domain.LabelSelectorRequirement{
+			Key:      requirement.Key,
+			Operator: requirement.Operator,
+			Values:   requirement.Values,
+		})
+	}
+	return domainRequirements
+}
+
+// ScheduleStrategyResponse is returned when a strategy is created or updated
+type ScheduleStrategyResponse struct {
+	StrategyID string             `json:"strategyId"`
+	Conflicts  []StrategyConflict `json:"conflicts"` // other strategies targeting some of the same pods with a different priority or execution time
+}
+
+// StrategyConflict is another strategy targeting some pods of the strategy with a different setting
+type StrategyConflict struct {
+	StrategyID      string   `json:"strategyId"`
+	PodIDs          []string `json:"podIds"`
+	Priority        int      `json:"priority"`
+	ExecutionTime   int64    `json:"executionTime"`
+	Weight          int      `json:"weight"`
+	TakesPrecedence bool     `json:"takesPrecedence"` // the conflicting strategy wins on those pods: higher weight, then more specific selector, then newer
+}
+
+func newScheduleStrategyResponse(strategy *domain.ScheduleStrategy, conflicts []*domain.StrategyConflict) *ScheduleStrategyResponse {
+	resp := &ScheduleStrategyResponse{
+		StrategyID: strategy.ID.Hex(),
+		Conflicts:  make([]StrategyConflict, 0, len(conflicts)),
+	}
+	for _, conflict := range conflicts {
+		resp.Conflicts = append(resp.Conflicts, StrategyConflict{
+			StrategyID:      conflict.StrategyID.Hex(),
+			PodIDs:          conflict.PodIDs,
+			Priority:        conflict.Priority,
+			ExecutionTime:   conflict.ExecutionTime,
+			Weight:          conflict.Weight,
+			TakesPrecedence: conflict.TakesPrecedence,
+		})
+	}
+	return resp
 }
 
 // CreateScheduleStrategy godoc
 // @Summary Create schedule strategy
-// @Description Create a new schedule strategy.
+// @Description Create a new schedule strategy, the response reports the other strategies targeting the same pods with a different setting.
 // @Tags Strategies
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body CreateScheduleStrategyRequest true "Schedule strategy payload"
-// @Success 200 {object} SuccessResponse[EmptyResponse]
+// @Success 200 {object} SuccessResponse[ScheduleStrategyResponse]
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
@@ -44,19 +238,179 @@ func (h *Handler) CreateScheduleStrategy(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	strategy := &domain.ScheduleStrategy{
-		StrategyNamespace: req.StrategyNamespace,
-		LabelSelectors:    make([]domain.LabelSelector, len(req.LabelSelectors)),
-		K8sNamespace:      req.K8sNamespace,
-		CommandRegex:      req.CommandRegex,
-		Priority:          req.Priority,
-		ExecutionTime:     req.ExecutionTime,
+	strategy, err := req.toDomainStrategy()
+	if err != nil {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Invalid template ID", err)
+		return
 	}
-	for i, ls := range req.LabelSelectors {
-		strategy.LabelSelectors[i] = domain.LabelSelector{
-			Key:   ls.Key,
-			Value: ls.Value,
+
+	claims, ok := h.GetClaimsFromContext(ctx)
+	if !ok {
+		h.ErrorResponse(ctx, w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	conflicts, err := h.Svc.CreateScheduleStrategy(ctx, &claims, strategy)
+	if err != nil {
+		h.HandleError(ctx, w, err)
+		return
+	}
+
+	response := NewSuccessResponse[ScheduleStrategyResponse](newScheduleStrategyResponse(strategy, conflicts))
+	h.JSONResponse(ctx, w, http.StatusOK, response)
+}
+
+type PreviewScheduleStrategyRequest struct {
+	CreateScheduleStrategyRequest
+	IncludeProcesses bool `json:"includeProcesses,omitempty"` // ask the decision makers which processes would match
+}
+
+type PreviewScheduleStrategyResponse struct {
+	PodCount int            `json:"podCount"`
+	Nodes    []*NodePreview `json:"nodes"`
+}
+
+type NodePreview struct {
+	NodeID string        `json:"nodeID"`
+	Pods   []*PodPreview `json:"pods"`
+	Error  string        `json:"error,omitempty"`
+}
+
+type PodPreview struct {
+	PodID        string             `json:"podID"`
+	PodName      string             `json:"podName"`
+	K8sNamespace string             `json:"k8sNamespace"`
+	Containers   []PreviewContainer `json:"containers"`
+	Processes    []PreviewProcess   `json:"processes,omitempty"`
+}
+
+type PreviewContainer struct {
+	ContainerID string `json:"containerID"`
+	Name        string `json:"name"`
+}
+
+type PreviewProcess struct {
+	PID         int    `json:"pid"`
+	Command     string `json:"command"`
+	ContainerID string `json:"containerID,omitempty"`
+	Cmdline     string `json:"cmdline,omitempty"`
+	Exe         string `json:"exe,omitempty"`
+	TIDs        []int  `json:"tids,omitempty"` // threads matching the thread regex of the strategy
+}
+
+// PreviewScheduleStrategy godoc
+// @Summary Preview schedule strategy
+// @Description Resolve the pods a schedule strategy would target, grouped by node, without creating it. With includeProcesses the decision makers report the processes matching the command regex.
+// @Tags Strategies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body PreviewScheduleStrategyRequest true "Schedule strategy payload"
+// @Success 200 {object} SuccessResponse[PreviewScheduleStrategyResponse]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/strategies/preview [post]
+func (h *Handler) PreviewScheduleStrategy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req PreviewScheduleStrategyRequest
+	err := h.JSONBind(r, &req)
+	if err != nil {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	strategy, err := req.toDomainStrategy()
+	if err != nil {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Invalid template ID", err)
+		return
+	}
+
+	preview, err := h.Svc.PreviewScheduleStrategy(ctx, strategy, req.IncludeProcesses)
+	if err != nil {
+		h.HandleError(ctx, w, err)
+		return
+	}
+
+	resp := PreviewScheduleStrategyResponse{
+		Nodes: make([]*NodePreview, 0, len(preview.Nodes)),
+	}
+	for _, node := range preview.Nodes {
+		nodePreview := &NodePreview{
+			NodeID: node.NodeID,
+			Pods:   make([]*PodPreview, 0, len(node.Pods)),
+			Error:  node.Error,
+		}
+		for _, pod := range node.Pods {
+			podPreview := &PodPreview{
+				PodID:        pod.PodID,
+				PodName:      pod.PodName,
+				K8sNamespace: pod.K8sNamespace,
+				Containers:   make([]PreviewContainer, 0, len(pod.Containers)),
+			}
+			for _, container := range pod.Containers {
+				podPreview.Containers = append(podPreview.Containers, PreviewContainer{
+					ContainerID: container.ContainerID,
+					Name:        container.Name,
+				})
+			}
+			for _, process := range pod.Processes {
+				podPreview.Processes = append(podPreview.Processes, PreviewProcess{
+					PID:         process.PID,
+					Command:     process.Command,
+					ContainerID: process.ContainerID,
+					Cmdline:     process.Cmdline,
+					Exe:         process.Exe,
+					TIDs:        process.TIDs,
+				})
+			}
+			nodePreview.Pods = append(nodePreview.Pods, podPreview)
 		}
+		resp.PodCount += len(node.Pods)
+		resp.Nodes = append(resp.Nodes, nodePreview)
+	}
+	h.JSONResponse(ctx, w, http.StatusOK, NewSuccessResponse[PreviewScheduleStrategyResponse](&resp))
+}
+
+type UpdateScheduleStrategyRequest struct {
+	StrategyID string `json:"strategyId"`
+	CreateScheduleStrategyRequest
+}
+
+// UpdateScheduleStrategy godoc
+// @Summary Update schedule strategy
+// @Description Replace the criteria and scheduling parameters of a schedule strategy, only the changed intents are propagated to the decision makers.
+// @Tags Strategies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpdateScheduleStrategyRequest true "Schedule strategy payload"
+// @Success 200 {object} SuccessResponse[ScheduleStrategyResponse]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/strategies [put]
+func (h *Handler) UpdateScheduleStrategy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req UpdateScheduleStrategyRequest
+	err := h.JSONBind(r, &req)
+	if err != nil {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if req.StrategyID == "" {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Strategy ID is required", nil)
+		return
+	}
+
+	strategy, err := req.toDomainStrategy()
+	if err != nil {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Invalid template ID", err)
+		return
 	}
 
 	claims, ok := h.GetClaimsFromContext(ctx)
@@ -65,13 +419,13 @@ func (h *Handler) CreateScheduleStrategy(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = h.Svc.CreateScheduleStrategy(ctx, &claims, strategy)
+	conflicts, err := h.Svc.UpdateScheduleStrategy(ctx, &claims, req.StrategyID, strategy)
 	if err != nil {
 		h.HandleError(ctx, w, err)
 		return
 	}
 
-	response := NewSuccessResponse[string](nil)
+	response := NewSuccessResponse[ScheduleStrategyResponse](newScheduleStrategyResponse(strategy, conflicts))
 	h.JSONResponse(ctx, w, http.StatusOK, response)
 }
 
@@ -80,13 +434,29 @@ type ListSchedulerStrategiesResponse struct {
 }
 
 type ScheduleStrategy struct {
-	ID                bson.ObjectID   `bson:"_id,omitempty"`
-	StrategyNamespace string          `bson:"strategyNamespace,omitempty"`
-	LabelSelectors    []LabelSelector `bson:"labelSelectors,omitempty"`
-	K8sNamespace      []string        `bson:"k8sNamespace,omitempty"`
-	CommandRegex      string          `bson:"commandRegex,omitempty"`
-	Priority          int             `bson:"priority,omitempty"`
-	ExecutionTime     int64           `bson:"executionTime,omitempty"`
+	ID                 bson.ObjectID              `bson:"_id,omitempty"`
+	StrategyNamespace  string                     `bson:"strategyNamespace,omitempty"`
+	Name               string                     `bson:"name,omitempty"`
+	LabelSelectors     []LabelSelector            `bson:"labelSelectors,omitempty"`
+	MatchLabels        map[string]string          `bson:"matchLabels,omitempty"`
+	MatchExpressions   []LabelSelectorRequirement `bson:"matchExpressions,omitempty"`
+	K8sNamespace       []string                   `bson:"k8sNamespace,omitempty"`
+	NamespaceSelector  *LabelSelectorSpec         `bson:"namespaceSelector,omitempty"`
+	NodeSelector       *LabelSelectorSpec         `bson:"nodeSelector,omitempty"`
+	Workload           *WorkloadRef               `bson:"workload,omitempty"`
+	ContainerNames     []string                   `bson:"containerNames,omitempty"`
+	ContainerRegex     string                     `bson:"containerRegex,omitempty"`
+	CommandRegex       string                     `bson:"commandRegex,omitempty"`
+	ProcessMatchers    []ProcessMatcher           `bson:"processMatchers,omitempty"`
+	ThreadRegex        string                     `bson:"threadRegex,omitempty"`
+	IncludeDescendants bool                       `bson:"includeDescendants,omitempty"`
+	Priority           int                        `bson:"priority,omitempty"`
+	ExecutionTime      int64                      `bson:"executionTime,omitempty"`
+	Weight             int                        `bson:"weight,omitempty"`
+	ActivateAt         int64                      `bson:"activateAt,omitempty"`
+	ExpireAt           int64                      `bson:"expireAt,omitempty"`
+	Window             *RecurringWindow           `bson:"window,omitempty"`
+	TemplateID         bson.ObjectID              `bson:"templateID,omitempty"`
 }
 
 // ListSelfScheduleStrategies godoc
@@ -136,15 +506,34 @@ func (h *Handler) ListSelfScheduleStrategies(w http.ResponseWriter, r *http.Requ
 }
 
 func (h *Handler) convertDomainStrategyToResponseStrategy(domainStrategy *domain.ScheduleStrategy) *ScheduleStrategy {
-	return &ScheduleStrategy{
-		ID:                domainStrategy.ID,
-		StrategyNamespace: domainStrategy.StrategyNamespace,
-		LabelSelectors:    convertDomainLabelSelectorsToResponseLabelSelectors(domainStrategy.LabelSelectors),
-		K8sNamespace:      domainStrategy.K8sNamespace,
-		CommandRegex:      domainStrategy.CommandRegex,
-		Priority:          domainStrategy.Priority,
-		ExecutionTime:     domainStrategy.ExecutionTime,
+	strategy := &ScheduleStrategy{
+		ID:                 domainStrategy.ID,
+		StrategyNamespace:  domainStrategy.StrategyNamespace,
+		Name:               domainStrategy.Name,
+		LabelSelectors:     convertDomainLabelSelectorsToResponseLabelSelectors(domainStrategy.LabelSelectors),
+		MatchLabels:        domainStrategy.MatchLabels,
+		MatchExpressions:   convertDomainRequirementsToResponseRequirements(domainStrategy.MatchExpressions),
+		K8sNamespace:       domainStrategy.K8sNamespace,
+		NamespaceSelector:  convertDomainSelectorSpecToResponseSelectorSpec(domainStrategy.NamespaceSelector),
+		NodeSelector:       convertDomainSelectorSpecToResponseSelectorSpec(domainStrategy.NodeSelector),
+		Workload:           convertDomainWorkloadToResponseWorkload(domainStrategy.Workload),
+		ContainerNames:     domainStrategy.ContainerNames,
+		ContainerRegex:     domainStrategy.ContainerRegex,
+		CommandRegex:       domainStrategy.CommandRegex,
+		ProcessMatchers:    convertDomainProcessMatchersToResponseMatchers(domainStrategy.ProcessMatchers),
+		ThreadRegex:        domainStrategy.ThreadRegex,
+		IncludeDescendants: domainStrategy.IncludeDescendants,
+		Priority:           domainStrategy.Priority,
+		ExecutionTime:      domainStrategy.ExecutionTime,
+		Weight:             domainStrategy.Weight,
+		ActivateAt:         domainStrategy.ActivateAt,
+		ExpireAt:           domainStrategy.ExpireAt,
+		TemplateID:         domainStrategy.TemplateID,
+	}
+	if domainStrategy.Window != nil {
+		strategy.Window = &RecurringWindow{Cron: domainStrategy.Window.Cron, DurationSec: domainStrategy.Window.DurationSec}
 	}
+	return strategy
 }
 
 func convertDomainLabelSelectorsToResponseLabelSelectors(domainLabelSelectors []domain.LabelSelector) []LabelSelector {
@@ -158,30 +547,94 @@ func convertDomainLabelSelectorsToResponseLabelSelectors(domainLabelSelectors []
 	return responseLabelSelectors
 }
 
+func convertDomainRequirementsToResponseRequirements(domainRequirements []domain.LabelSelectorRequirement) []LabelSelectorRequirement {
+	responseRequirements := make([]LabelSelectorRequirement, len(domainRequirements))
+	for i, requirement := range domainRequirements {
+		responseRequirements[i] = LabelSelectorRequirement{
+			Key:      requirement.Key,
+			Operator: requirement.Operator,
+			Values:   requirement.Values,
+		}
+	}
+	return responseRequirements
+}
+
 type ListScheduleIntentsResponse struct {
-	Intents []*ScheduleIntent `json:"intents"`
+	Intents   []*ScheduleIntent  `json:"intents"`
+	Workloads []*WorkloadIntents `json:"workloads,omitempty"` // the intents grouped by workload, with groupBy=workload
+}
+
+// WorkloadIntents are the intents of the pods controlled by a workload, the workload is empty for the bare pods
+type WorkloadIntents struct {
+	Workload *WorkloadRef      `json:"workload,omitempty"`
+	Intents  []*ScheduleIntent `json:"intents"`
+}
+
+// groupIntentsByWorkload groups the intents by the workload of their pod, ordered by workload with the bare pods last
+func groupIntentsByWorkload(intents []*ScheduleIntent) []*WorkloadIntents {
+	groups := make([]*WorkloadIntents, 0)
+	for _, intent := range intents {
+		idx := slices.IndexFunc(groups, func(group *WorkloadIntents) bool {
+			return group.Workload.toDomainWorkload().Equal(intent.Workload.toDomainWorkload())
+		})
+		if idx < 0 {
+			groups = append(groups, &WorkloadIntents{Workload: intent.Workload})
+			idx = len(groups) - 1
+		}
+		groups[idx].Intents = append(groups[idx].Intents, intent)
+	}
+	slices.SortFunc(groups, func(a, b *WorkloadIntents) int {
+		if a.Workload == nil || b.Workload == nil {
+			return cmp.Compare(workloadGroupRank(a), workloadGroupRank(b))
+		}
+		return cmp.Or(
+			cmp.Compare(a.Workload.Namespace, b.Workload.Namespace),
+			cmp.Compare(a.Workload.Kind, b.Workload.Kind),
+			cmp.Compare(a.Workload.Name, b.Workload.Name),
+		)
+	})
+	return groups
+}
+
+func workloadGroupRank(group *WorkloadIntents) int {
+	if group.Workload == nil {
+		return 1
+	}
+	return 0
 }
 
 type ScheduleIntent struct {
-	ID            bson.ObjectID      `bson:"_id,omitempty"`
-	StrategyID    bson.ObjectID      `bson:"strategyID,omitempty"`
-	PodID         string             `bson:"podID,omitempty"`
-	NodeID        string             `bson:"nodeID,omitempty"`
-	K8sNamespace  string             `bson:"k8sNamespace,omitempty"`
-	CommandRegex  string             `bson:"commandRegex,omitempty"`
-	Priority      int                `bson:"priority,omitempty"`
-	ExecutionTime int64              `bson:"executionTime,omitempty"`
-	PodLabels     map[string]string  `bson:"podLabels,omitempty"`
-	State         domain.IntentState `bson:"state,omitempty"`
+	ID                 bson.ObjectID              `bson:"_id,omitempty"`
+	StrategyID         bson.ObjectID              `bson:"strategyID,omitempty"`
+	PodID              string                     `bson:"podID,omitempty"`
+	NodeID             string                     `bson:"nodeID,omitempty"`
+	K8sNamespace       string                     `bson:"k8sNamespace,omitempty"`
+	Workload           *WorkloadRef               `bson:"workload,omitempty"`
+	ContainerIDs       []string                   `bson:"containerIDs,omitempty"`
+	CommandRegex       string                     `bson:"commandRegex,omitempty"`
+	ProcessMatchers    []ProcessMatcher           `bson:"processMatchers,omitempty"`
+	ThreadRegex        string                     `bson:"threadRegex,omitempty"`
+	IncludeDescendants bool                       `bson:"includeDescendants,omitempty"`
+	Priority           int                        `bson:"priority,omitempty"`
+	ExecutionTime      int64                      `bson:"executionTime,omitempty"`
+	PodLabels          map[string]string          `bson:"podLabels,omitempty"`
+	Selector           []LabelSelectorRequirement `bson:"selector,omitempty"`
+	Weight             int                        `bson:"weight,omitempty"`
+	Specificity        int                        `bson:"specificity,omitempty"`
+	State              domain.IntentState         `bson:"state,omitempty"`
+	DeliveryAttempts   int                        `bson:"deliveryAttempts,omitempty"`
+	LastError          string                     `bson:"lastError,omitempty"`
+	MatchedPIDs        int                        `bson:"matchedPIDs,omitempty"`
 }
 
 // ListSelfScheduleIntents godoc
 // @Summary List self schedule intents
-// @Description List schedule intents created by the authenticated user.
+// @Description List schedule intents created by the authenticated user. With groupBy=workload the intents are also grouped by the workload controlling their pod.
 // @Tags Strategies
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param groupBy query string false "Group the intents, only workload is supported"
 // @Success 200 {object} SuccessResponse[ListScheduleIntentsResponse]
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
@@ -196,6 +649,12 @@ func (h *Handler) ListSelfScheduleIntents(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	groupBy := r.URL.Query().Get("groupBy")
+	if groupBy != "" && groupBy != "workload" {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Invalid groupBy, only workload is supported", nil)
+		return
+	}
+
 	uid, err := claims.GetBsonObjectUID()
 	if err != nil {
 		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Invalid user ID in token", err)
@@ -217,22 +676,36 @@ func (h *Handler) ListSelfScheduleIntents(w http.ResponseWriter, r *http.Request
 	for i, di := range queryOpt.Result {
 		resp.Intents[i] = h.convertDomainIntentToResponseIntent(di)
 	}
+	if groupBy == "workload" {
+		resp.Workloads = groupIntentsByWorkload(resp.Intents)
+	}
 	response := NewSuccessResponse[ListScheduleIntentsResponse](&resp)
 	h.JSONResponse(ctx, w, http.StatusOK, response)
 }
 
 func (h *Handler) convertDomainIntentToResponseIntent(domainIntent *domain.ScheduleIntent) *ScheduleIntent {
 	return &ScheduleIntent{
-		ID:            domainIntent.ID,
-		StrategyID:    domainIntent.StrategyID,
-		PodID:         domainIntent.PodID,
-		NodeID:        domainIntent.NodeID,
-		K8sNamespace:  domainIntent.K8sNamespace,
-		CommandRegex:  domainIntent.CommandRegex,
-		Priority:      domainIntent.Priority,
-		ExecutionTime: domainIntent.ExecutionTime,
-		PodLabels:     domainIntent.PodLabels,
-		State:         domainIntent.State,
+		ID:                 domainIntent.ID,
+		StrategyID:         domainIntent.StrategyID,
+		PodID:              domainIntent.PodID,
+		NodeID:             domainIntent.NodeID,
+		K8sNamespace:       domainIntent.K8sNamespace,
+		Workload:           convertDomainWorkloadToResponseWorkload(domainIntent.Workload),
+		ContainerIDs:       domainIntent.ContainerIDs,
+		CommandRegex:       domainIntent.CommandRegex,
+		ProcessMatchers:    convertDomainProcessMatchersToResponseMatchers(domainIntent.ProcessMatchers),
+		ThreadRegex:        domainIntent.ThreadRegex,
+		IncludeDescendants: domainIntent.IncludeDescendants,
+		Priority:           domainIntent.Priority,
+		ExecutionTime:      domainIntent.ExecutionTime,
+		PodLabels:          domainIntent.PodLabels,
+		Selector:           convertDomainRequirementsToResponseRequirements(domainIntent.Selector),
+		Weight:             domainIntent.Weight,
+		Specificity:        domainIntent.Specificity,
+		State:              domainIntent.State,
+		DeliveryAttempts:   domainIntent.DeliveryAttempts,
+		LastError:          domainIntent.LastError,
+		MatchedPIDs:        domainIntent.MatchedPIDs,
 	}
 }
 
@@ -25,7 +25,7 @@ func (suite *HandlerTestSuite) TestIntegrationStrategyHandler() {
 
 	suite.MockK8SAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return([]*domain.Pod{{PodID: "Test", Labels: map[string]string{"test": "test"}, NodeID: "test"}}, nil).Once()
 	suite.MockK8SAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{{Host: "dm-host", NodeID: "test", Port: 8080}}, nil).Once()
-	suite.MockDMAdapter.EXPECT().SendSchedulingIntent(mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(1)
+	suite.MockDMAdapter.EXPECT().SendSchedulingIntent(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Times(1)
 	suite.createStrategy(adminToken, &strategyReq, http.StatusOK)
 
 	strategies := suite.listSelfStrategies(adminToken, http.StatusOK)
@@ -61,7 +61,7 @@ func (suite *HandlerTestSuite) TestIntegrationDeleteStrategyHandler() {
 	// Create strategy
 	suite.MockK8SAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return([]*domain.Pod{{PodID: "Test", Labels: map[string]string{"test": "test"}, NodeID: "test"}}, nil).Once()
 	suite.MockK8SAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{{Host: "dm-host", NodeID: "test", Port: 8080}}, nil).Once()
-	suite.MockDMAdapter.EXPECT().SendSchedulingIntent(mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(1)
+	suite.MockDMAdapter.EXPECT().SendSchedulingIntent(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Times(1)
 	suite.createStrategy(adminToken, &strategyReq, http.StatusOK)
 
 	strategies := suite.listSelfStrategies(adminToken, http.StatusOK)
@@ -97,7 +97,7 @@ func (suite *HandlerTestSuite) TestIntegrationDeleteIntentsHandler() {
 	// Create strategy
 	suite.MockK8SAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return([]*domain.Pod{{PodID: "Test1", Labels: map[string]string{"test": "test"}, NodeID: "test"}, {PodID: "Test2", Labels: map[string]string{"test": "test"}, NodeID: "test"}}, nil).Once()
 	suite.MockK8SAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{{Host: "dm-host", NodeID: "test", Port: 8080}}, nil).Once()
-	suite.MockDMAdapter.EXPECT().SendSchedulingIntent(mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(1)
+	suite.MockDMAdapter.EXPECT().SendSchedulingIntent(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Times(1)
 	suite.createStrategy(adminToken, &strategyReq, http.StatusOK)
 
 	intents := suite.listSelfIntents(adminToken, http.StatusOK)
@@ -113,8 +113,68 @@ func (suite *HandlerTestSuite) TestIntegrationDeleteIntentsHandler() {
 	suite.Require().Len(intents.Intents, 1, "Expected one intent after deletion")
 }
 
+func (suite *HandlerTestSuite) TestIntegrationWorkloadStrategyHandler() {
+	adminUser, adminPwd := config.GetManagerConfig().Account.AdminEmail, config.GetManagerConfig().Account.AdminPassword
+	adminToken := suite.login(adminUser, adminPwd.Value(), http.StatusOK)
+
+	workload := &domain.WorkloadRef{Kind: domain.WorkloadKindDeployment, Namespace: "team-a", Name: "web"}
+	strategyReq := rest.CreateScheduleStrategyRequest{
+		Workload:      &rest.WorkloadRef{Kind: workload.Kind, Namespace: workload.Namespace, Name: workload.Name},
+		Priority:      1,
+		ExecutionTime: 100,
+	}
+	suite.MockK8SAdapter.EXPECT().QueryPods(mock.Anything, mock.MatchedBy(func(opt *domain.QueryPodsOptions) bool {
+		return workload.Equal(opt.Workload)
+	})).Return([]*domain.Pod{
+		{PodID: "web-1", K8SNamespace: "team-a", NodeID: "test", Workload: workload},
+		{PodID: "web-2", K8SNamespace: "team-a", NodeID: "test", Workload: workload},
+	}, nil).Once()
+	suite.MockK8SAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{{Host: "dm-host", NodeID: "test", Port: 8080}}, nil).Once()
+	suite.MockDMAdapter.EXPECT().SendSchedulingIntent(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	suite.createStrategy(adminToken, &strategyReq, http.StatusOK)
+
+	// a bare pod targeted by labels
+	strategyReq = rest.CreateScheduleStrategyRequest{
+		LabelSelectors: []rest.LabelSelector{{Key: "test", Value: "test"}},
+		Priority:       1,
+		ExecutionTime:  100,
+	}
+	suite.MockK8SAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return([]*domain.Pod{{PodID: "bare", Labels: map[string]string{"test": "test"}, NodeID: "test"}}, nil).Once()
+	suite.MockK8SAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{{Host: "dm-host", NodeID: "test", Port: 8080}}, nil).Once()
+	suite.MockDMAdapter.EXPECT().SendSchedulingIntent(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	suite.createStrategy(adminToken, &strategyReq, http.StatusOK)
+
+	strategies := suite.listSelfStrategies(adminToken, http.StatusOK)
+	suite.Require().Len(strategies.Strategies, 2, "Expected two strategies")
+	suite.Require().Equal("Deployment/team-a/web", strategyWorkload(strategies), "Workload mismatch")
+
+	listResp := rest.SuccessResponse[rest.ListScheduleIntentsResponse]{}
+	_, resp := suite.sendV1Request("GET", "/intents/self?groupBy=workload", nil, &listResp, adminToken)
+	suite.Require().Equal(http.StatusOK, resp.Code, "Unexpected status code on list intents")
+	suite.Require().Len(listResp.Data.Intents, 3, "Expected three intents")
+	suite.Require().Len(listResp.Data.Workloads, 2, "Expected the intents of the workload and of the bare pod")
+	suite.Require().Equal("web", listResp.Data.Workloads[0].Workload.Name, "Workload mismatch")
+	suite.Require().Len(listResp.Data.Workloads[0].Intents, 2, "Expected the intents of the two pods of the workload")
+	suite.Require().Nil(listResp.Data.Workloads[1].Workload, "Expected the bare pod last")
+
+	_, resp = suite.sendV1Request("GET", "/intents/self?groupBy=node", nil, &listResp, adminToken)
+	suite.Require().Equal(http.StatusBadRequest, resp.Code, "Unexpected status code on list intents")
+
+	strategyReq = rest.CreateScheduleStrategyRequest{Workload: &rest.WorkloadRef{Kind: "Pod", Namespace: "team-a", Name: "web"}}
+	suite.createStrategy(adminToken, &strategyReq, http.StatusBadRequest)
+}
+
+func strategyWorkload(strategies *rest.ListSchedulerStrategiesResponse) string {
+	for _, strategy := range strategies.Strategies {
+		if strategy.Workload != nil {
+			return strategy.Workload.Kind + "/" + strategy.Workload.Namespace + "/" + strategy.Workload.Name
+		}
+	}
+	return ""
+}
+
 func (suite *HandlerTestSuite) createStrategy(token string, strategyReq *rest.CreateScheduleStrategyRequest, expectedStatus int) {
-	createStrategyResp := rest.SuccessResponse[string]{}
+	createStrategyResp := rest.SuccessResponse[rest.ScheduleStrategyResponse]{}
 	_, resp := suite.sendV1Request("POST", "/strategies", strategyReq, &createStrategyResp, token)
 	suite.Require().Equal(expectedStatus, resp.Code, "Unexpected status code on create strategy")
 }
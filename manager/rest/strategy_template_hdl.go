@@ -0,0 +1,227 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/Gthulhu/api/manager/domain"
+)
+
+type CreateStrategyTemplateRequest struct {
+	Name          string `json:"name"`
+	Description   string `json:"description,omitempty"`
+	Priority      int    `json:"priority,omitempty"`
+	ExecutionTime int64  `json:"executionTime,omitempty"`
+}
+
+func (req *CreateStrategyTemplateRequest) toDomainTemplate() *domain.StrategyTemplate {
+	return &domain.StrategyTemplate{
+		Name:          req.Name,
+		Description:   req.Description,
+		Priority:      req.Priority,
+		ExecutionTime: req.ExecutionTime,
+	}
+}
+
+// StrategyTemplate is a named preset of the scheduling parameters of a strategy
+type StrategyTemplate struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Priority      int    `json:"priority"`
+	ExecutionTime int64  `json:"executionTime"`
+}
+
+func convertDomainTemplateToResponseTemplate(template *domain.StrategyTemplate) *StrategyTemplate {
+	return &StrategyTemplate{
+		ID:            template.ID.Hex(),
+		Name:          template.Name,
+		Description:   template.Description,
+		Priority:      template.Priority,
+		ExecutionTime: template.ExecutionTime,
+	}
+}
+
+// CreateStrategyTemplate godoc
+// @Summary Create strategy template
+// @Description Create a named preset of priority and execution time, strategies can be created from it.
+// @Tags StrategyTemplates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateStrategyTemplateRequest true "Strategy template payload"
+// @Success 200 {object} SuccessResponse[StrategyTemplate]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/strategy-templates [post]
+func (h *Handler) CreateStrategyTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req CreateStrategyTemplateRequest
+	err := h.JSONBind(r, &req)
+	if err != nil {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	claims, ok := h.GetClaimsFromContext(ctx)
+	if !ok {
+		h.ErrorResponse(ctx, w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	template := req.toDomainTemplate()
+	err = h.Svc.CreateStrategyTemplate(ctx, &claims, template)
+	if err != nil {
+		h.HandleError(ctx, w, err)
+		return
+	}
+
+	response := NewSuccessResponse[StrategyTemplate](convertDomainTemplateToResponseTemplate(template))
+	h.JSONResponse(ctx, w, http.StatusOK, response)
+}
+
+type ListStrategyTemplatesResponse struct {
+	Templates []*StrategyTemplate `json:"templates"`
+}
+
+// ListStrategyTemplates godoc
+// @Summary List strategy templates
+// @Description List the strategy templates, including the default ones.
+// @Tags StrategyTemplates
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} SuccessResponse[ListStrategyTemplatesResponse]
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/strategy-templates [get]
+func (h *Handler) ListStrategyTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	queryOpt := &domain.QueryStrategyTemplateOptions{}
+	err := h.Svc.ListStrategyTemplates(ctx, queryOpt)
+	if err != nil {
+		h.HandleError(ctx, w, err)
+		return
+	}
+
+	resp := ListStrategyTemplatesResponse{
+		Templates: make([]*StrategyTemplate, len(queryOpt.Result)),
+	}
+	for i, template := range queryOpt.Result {
+		resp.Templates[i] = convertDomainTemplateToResponseTemplate(template)
+	}
+	response := NewSuccessResponse[ListStrategyTemplatesResponse](&resp)
+	h.JSONResponse(ctx, w, http.StatusOK, response)
+}
+
+type UpdateStrategyTemplateRequest struct {
+	TemplateID string `json:"templateId"`
+	CreateStrategyTemplateRequest
+	Propagate bool `json:"propagate,omitempty"` // also update the strategies derived from the template
+}
+
+type UpdateStrategyTemplateResponse struct {
+	PropagatedStrategyIDs []string `json:"propagatedStrategyIds"` // strategies updated with the new priority and execution time
+}
+
+// UpdateStrategyTemplate godoc
+// @Summary Update strategy template
+// @Description Replace a strategy template, with propagate the strategies derived from it are updated and their intents sent to the decision makers.
+// @Tags StrategyTemplates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpdateStrategyTemplateRequest true "Strategy template payload"
+// @Success 200 {object} SuccessResponse[UpdateStrategyTemplateResponse]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/strategy-templates [put]
+func (h *Handler) UpdateStrategyTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req UpdateStrategyTemplateRequest
+	err := h.JSONBind(r, &req)
+	if err != nil {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if req.TemplateID == "" {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Template ID is required", nil)
+		return
+	}
+
+	claims, ok := h.GetClaimsFromContext(ctx)
+	if !ok {
+		h.ErrorResponse(ctx, w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	propagated, err := h.Svc.UpdateStrategyTemplate(ctx, &claims, req.TemplateID, req.toDomainTemplate(), req.Propagate)
+	if err != nil {
+		h.HandleError(ctx, w, err)
+		return
+	}
+
+	resp := UpdateStrategyTemplateResponse{
+		PropagatedStrategyIDs: make([]string, len(propagated)),
+	}
+	for i, strategyID := range propagated {
+		resp.PropagatedStrategyIDs[i] = strategyID.Hex()
+	}
+	response := NewSuccessResponse[UpdateStrategyTemplateResponse](&resp)
+	h.JSONResponse(ctx, w, http.StatusOK, response)
+}
+
+type DeleteStrategyTemplateRequest struct {
+	TemplateID string `json:"templateId"`
+}
+
+// DeleteStrategyTemplate godoc
+// @Summary Delete strategy template
+// @Description Delete a strategy template no strategy is derived from.
+// @Tags StrategyTemplates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body DeleteStrategyTemplateRequest true "Delete strategy template payload"
+// @Success 200 {object} SuccessResponse[EmptyResponse]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/strategy-templates [delete]
+func (h *Handler) DeleteStrategyTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req DeleteStrategyTemplateRequest
+	err := h.JSONBind(r, &req)
+	if err != nil {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if req.TemplateID == "" {
+		h.ErrorResponse(ctx, w, http.StatusBadRequest, "Template ID is required", nil)
+		return
+	}
+
+	claims, ok := h.GetClaimsFromContext(ctx)
+	if !ok {
+		h.ErrorResponse(ctx, w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	err = h.Svc.DeleteStrategyTemplate(ctx, &claims, req.TemplateID)
+	if err != nil {
+		h.HandleError(ctx, w, err)
+		return
+	}
+
+	response := NewSuccessResponse[EmptyResponse](&EmptyResponse{})
+	h.JSONResponse(ctx, w, http.StatusOK, response)
+}
@@ -0,0 +1,78 @@
+package rest_test
+
+import (
+	"net/http"
+
+	"github.com/Gthulhu/api/config"
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/manager/rest"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *HandlerTestSuite) TestIntegrationStrategyTemplateHandler() {
+	adminUser, adminPwd := config.GetManagerConfig().Account.AdminEmail, config.GetManagerConfig().Account.AdminPassword
+	adminToken := suite.login(adminUser, adminPwd.Value(), http.StatusOK)
+
+	templates := suite.listStrategyTemplates(adminToken, http.StatusOK)
+	suite.Require().Len(templates.Templates, 3, "Expected the default templates")
+
+	template := suite.createStrategyTemplate(adminToken, &rest.CreateStrategyTemplateRequest{Name: "benchmark", Priority: 1, ExecutionTime: 2000000}, http.StatusOK)
+	suite.createStrategyTemplate(adminToken, &rest.CreateStrategyTemplateRequest{Name: "benchmark"}, http.StatusConflict)
+
+	// the template overrides the priority and execution time of the request
+	suite.MockK8SAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return([]*domain.Pod{{PodID: "Test", Labels: map[string]string{"test": "test"}, NodeID: "test"}}, nil).Once()
+	suite.MockK8SAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{{Host: "dm-host", NodeID: "test", Port: 8080}}, nil).Once()
+	suite.MockDMAdapter.EXPECT().SendSchedulingIntent(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	suite.createStrategy(adminToken, &rest.CreateScheduleStrategyRequest{
+		LabelSelectors: []rest.LabelSelector{{Key: "test", Value: "test"}},
+		Priority:       100,
+		TemplateID:     template.ID,
+	}, http.StatusOK)
+	strategies := suite.listSelfStrategies(adminToken, http.StatusOK)
+	suite.Require().Len(strategies.Strategies, 1, "Expected one strategy")
+	suite.Require().Equal(template.ID, strategies.Strategies[0].TemplateID.Hex(), "TemplateID mismatch")
+	suite.Require().Equal(1, strategies.Strategies[0].Priority, "Priority mismatch")
+	suite.Require().Equal(int64(2000000), strategies.Strategies[0].ExecutionTime, "ExecutionTime mismatch")
+
+	suite.MockK8SAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return([]*domain.Pod{{PodID: "Test", Labels: map[string]string{"test": "test"}, NodeID: "test"}}, nil).Once()
+	suite.MockK8SAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{{Host: "dm-host", NodeID: "test", Port: 8080}}, nil).Once()
+	suite.MockDMAdapter.EXPECT().SendSchedulingIntent(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	updated := suite.updateStrategyTemplate(adminToken, &rest.UpdateStrategyTemplateRequest{
+		TemplateID:                    template.ID,
+		CreateStrategyTemplateRequest: rest.CreateStrategyTemplateRequest{Name: "benchmark", Priority: 1, ExecutionTime: 4000000},
+		Propagate:                     true,
+	}, http.StatusOK)
+	suite.Require().Equal([]string{strategies.Strategies[0].ID.Hex()}, updated.PropagatedStrategyIDs, "Expected the strategy to be propagated")
+	intents := suite.listSelfIntents(adminToken, http.StatusOK)
+	suite.Require().Len(intents.Intents, 1, "Expected one intent")
+	suite.Require().Equal(int64(4000000), intents.Intents[0].ExecutionTime, "ExecutionTime mismatch")
+
+	suite.deleteStrategyTemplate(adminToken, template.ID, http.StatusConflict)
+}
+
+func (suite *HandlerTestSuite) createStrategyTemplate(token string, req *rest.CreateStrategyTemplateRequest, expectedStatus int) *rest.StrategyTemplate {
+	createResp := rest.SuccessResponse[rest.StrategyTemplate]{}
+	_, resp := suite.sendV1Request("POST", "/strategy-templates", req, &createResp, token)
+	suite.Require().Equal(expectedStatus, resp.Code, "Unexpected status code on create strategy template")
+	return createResp.Data
+}
+
+func (suite *HandlerTestSuite) listStrategyTemplates(token string, expectedStatus int) *rest.ListStrategyTemplatesResponse {
+	listResp := rest.SuccessResponse[rest.ListStrategyTemplatesResponse]{}
+	_, resp := suite.sendV1Request("GET", "/strategy-templates", nil, &listResp, token)
+	suite.Require().Equal(expectedStatus, resp.Code, "Unexpected status code on list strategy templates")
+	return listResp.Data
+}
+
+func (suite *HandlerTestSuite) updateStrategyTemplate(token string, req *rest.UpdateStrategyTemplateRequest, expectedStatus int) *rest.UpdateStrategyTemplateResponse {
+	updateResp := rest.SuccessResponse[rest.UpdateStrategyTemplateResponse]{}
+	_, resp := suite.sendV1Request("PUT", "/strategy-templates", req, &updateResp, token)
+	suite.Require().Equal(expectedStatus, resp.Code, "Unexpected status code on update strategy template")
+	return updateResp.Data
+}
+
+func (suite *HandlerTestSuite) deleteStrategyTemplate(token, templateID string, expectedStatus int) {
+	deleteResp := rest.SuccessResponse[rest.EmptyResponse]{}
+	_, resp := suite.sendV1Request("DELETE", "/strategy-templates", rest.DeleteStrategyTemplateRequest{TemplateID: templateID}, &deleteResp, token)
+	suite.Require().Equal(expectedStatus, resp.Code, "Unexpected status code on delete strategy template")
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+)
+
+// EnsureAnnotationStrategy creates the system strategy owning the intents synthesized from the pod annotations, unless it exists.
+// The pods with scheduling hints are matched by the reconciliation of their events like for any other strategy.
+func (svc *Service) EnsureAnnotationStrategy(ctx context.Context) error {
+	queryOpt := &domain.QueryStrategyOptions{
+		StrategyNamespaces: []string{domain.AnnotationStrategyNamespace},
+		Names:              []string{domain.AnnotationStrategyName},
+	}
+	err := svc.Repo.QueryStrategies(ctx, queryOpt)
+	if err != nil {
+		return fmt.Errorf("query the annotation strategy: %w", err)
+	}
+	if len(queryOpt.Result) > 0 {
+		return nil
+	}
+	strategy := domain.NewAnnotationStrategy()
+	err = svc.Repo.InsertStrategyAndIntents(ctx, strategy, []*domain.ScheduleIntent{})
+	if err != nil {
+		return fmt.Errorf("create the annotation strategy: %w", err)
+	}
+	logger.Logger(ctx).Info().Msgf("created annotation strategy %s", strategy.ID.Hex())
+	return nil
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestEnsureAnnotationStrategy(t *testing.T) {
+	svc, repo, _, _ := newReconcileTestService(t)
+	ctx := context.Background()
+	queryOpt := &domain.QueryStrategyOptions{
+		StrategyNamespaces: []string{domain.AnnotationStrategyNamespace},
+		Names:              []string{domain.AnnotationStrategyName},
+	}
+
+	// the strategy is created once
+	repo.EXPECT().QueryStrategies(mock.Anything, queryOpt).Return(nil).Once()
+	repo.EXPECT().InsertStrategyAndIntents(mock.Anything, mock.MatchedBy(func(strategy *domain.ScheduleStrategy) bool {
+		return strategy.Annotation && strategy.StrategyNamespace == domain.AnnotationStrategyNamespace && strategy.CreatorID.IsZero()
+	}), []*domain.ScheduleIntent{}).Return(nil).Once()
+	require.NoError(t, svc.EnsureAnnotationStrategy(ctx))
+
+	repo.EXPECT().QueryStrategies(mock.Anything, queryOpt).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		opt.Result = []*domain.ScheduleStrategy{{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, Annotation: true}}
+		return nil
+	}).Once()
+	require.NoError(t, svc.EnsureAnnotationStrategy(ctx))
+}
@@ -267,6 +267,34 @@ func (svc *Service) VerifyJWTToken(ctx context.Context, tokenString string, perm
 	return *claims, rolePolicy, nil
 }
 
+// VerifyDecisionMakerToken verifies a token self-signed by a decision maker with the private key matching the configured DM public key,
+// issued for the configured decision maker client ID
+func (svc *Service) VerifyDecisionMakerToken(ctx context.Context, tokenString string) (domain.DecisionMakerClaims, error) {
+	if svc.dmPublicKey == nil {
+		return domain.DecisionMakerClaims{}, errs.NewHTTPStatusError(http.StatusUnauthorized, "decision maker authentication is not configured", errors.New("missing decision maker public key"))
+	}
+	token, err := jwt.ParseWithClaims(tokenString, &domain.DecisionMakerClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return svc.dmPublicKey, nil
+	})
+	if err != nil {
+		return domain.DecisionMakerClaims{}, errs.NewHTTPStatusError(http.StatusUnauthorized, "invalid decision maker token", err)
+	}
+	claims, ok := token.Claims.(*domain.DecisionMakerClaims)
+	if !ok || !token.Valid {
+		return domain.DecisionMakerClaims{}, errs.NewHTTPStatusError(http.StatusUnauthorized, "invalid decision maker token", errors.New("invalid decision maker token claims"))
+	}
+	if claims.NodeID == "" {
+		return domain.DecisionMakerClaims{}, errs.NewHTTPStatusError(http.StatusUnauthorized, "invalid decision maker token", errors.New("missing node ID in decision maker token"))
+	}
+	if claims.ClientID != svc.dmClientID {
+		return domain.DecisionMakerClaims{}, errs.NewHTTPStatusError(http.StatusUnauthorized, "invalid decision maker token", fmt.Errorf("unexpected client ID %q in decision maker token", claims.ClientID))
+	}
+	return *claims, nil
+}
+
 func (svc *Service) CreateAdminUserIfNotExists(ctx context.Context, username, password string) error {
 	opts := &domain.QueryUserOptions{
 		UserNames: []string{username},
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/manager/errs"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signDecisionMakerToken(t *testing.T, key *rsa.PrivateKey, nodeID string) string {
+	return signDecisionMakerClientToken(t, key, "decisionmaker", nodeID)
+}
+
+func signDecisionMakerClientToken(t *testing.T, key *rsa.PrivateKey, clientID string, nodeID string) string {
+	claims := domain.DecisionMakerClaims{
+		ClientID: clientID,
+		NodeID:   nodeID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+	require.NoError(t, err)
+	return token
+}
+
+func TestVerifyDecisionMakerToken(t *testing.T) {
+	ctx := context.Background()
+	dmKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	svc := &Service{dmPublicKey: &dmKey.PublicKey, dmClientID: "decisionmaker"}
+
+	claims, err := svc.VerifyDecisionMakerToken(ctx, signDecisionMakerToken(t, dmKey, "node-a"))
+	require.NoError(t, err)
+	assert.Equal(t, "node-a", claims.NodeID)
+	assert.Equal(t, "decisionmaker", claims.ClientID)
+
+	_, err = svc.VerifyDecisionMakerToken(ctx, signDecisionMakerToken(t, otherKey, "node-a"))
+	httpErr, ok := errs.IsHTTPStatusError(err)
+	require.True(t, ok, "token signed by another key should be rejected")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.StatusCode)
+
+	_, err = svc.VerifyDecisionMakerToken(ctx, signDecisionMakerToken(t, dmKey, ""))
+	require.Error(t, err, "token without node ID should be rejected")
+
+	_, err = svc.VerifyDecisionMakerToken(ctx, signDecisionMakerClientToken(t, dmKey, "manager-client", "node-a"))
+	httpErr, ok = errs.IsHTTPStatusError(err)
+	require.True(t, ok, "token of another client should be rejected")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.StatusCode)
+
+	_, err = (&Service{}).VerifyDecisionMakerToken(ctx, signDecisionMakerToken(t, dmKey, "node-a"))
+	require.Error(t, err, "token should be rejected when no decision maker public key is configured")
+}
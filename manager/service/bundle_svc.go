@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/manager/errs"
+	"github.com/Gthulhu/api/pkg/logger"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ExportStrategyBundle returns every strategy of the strategy namespace as a bundle ordered by name,
+// a strategy created without a name is exported under its ID
+func (svc *Service) ExportStrategyBundle(ctx context.Context, strategyNamespace string) (*domain.StrategyBundle, error) {
+	if strategyNamespace == "" {
+		return nil, errs.NewHTTPStatusError(http.StatusBadRequest, "strategy namespace is required", nil)
+	}
+	queryOpt := &domain.QueryStrategyOptions{StrategyNamespaces: []string{strategyNamespace}}
+	err := svc.Repo.QueryStrategies(ctx, queryOpt)
+	if err != nil {
+		return nil, err
+	}
+	templateIDs := make([]bson.ObjectID, 0)
+	for _, strategy := range queryOpt.Result {
+		if !strategy.TemplateID.IsZero() && !slices.Contains(templateIDs, strategy.TemplateID) {
+			templateIDs = append(templateIDs, strategy.TemplateID)
+		}
+	}
+	templateNames := make(map[bson.ObjectID]string, len(templateIDs))
+	if len(templateIDs) > 0 {
+		templateQueryOpt := &domain.QueryStrategyTemplateOptions{IDs: templateIDs}
+		err = svc.Repo.QueryStrategyTemplates(ctx, templateQueryOpt)
+		if err != nil {
+			return nil, err
+		}
+		for _, template := range templateQueryOpt.Result {
+			templateNames[template.ID] = template.Name
+		}
+	}
+
+	slices.SortFunc(queryOpt.Result, func(a, b *domain.ScheduleStrategy) int {
+		return strings.Compare(a.BundleKey(), b.BundleKey())
+	})
+	bundle := &domain.StrategyBundle{
+		StrategyNamespace: strategyNamespace,
+		Entries:           make([]*domain.StrategyBundleEntry, 0, len(queryOpt.Result)),
+	}
+	for _, strategy := range queryOpt.Result {
+		strategy.Name = strategy.BundleKey()
+		bundle.Entries = append(bundle.Entries, &domain.StrategyBundleEntry{
+			Template: templateNames[strategy.TemplateID],
+			Strategy: strategy,
+		})
+	}
+	return bundle, nil
+}
+
+// ApplyStrategyBundle converges the strategies of the bundle namespace to the bundle: the missing strategies are created, the differing ones
+// are updated and, with prune, the strategies missing from the bundle are deleted, the same way as the create, update and delete endpoints.
+// As with these endpoints, an operator only updates and deletes the strategies it created.
+// The bundle is fully validated before any change; a change failing stops the apply, applying the bundle again resumes it.
+// It returns the changes, the ones applied so far when it fails.
+func (svc *Service) ApplyStrategyBundle(ctx context.Context, operator *domain.Claims, bundle *domain.StrategyBundle, opts domain.ApplyBundleOptions) ([]*domain.StrategyChange, error) {
+	operatorID, err := operator.GetBsonObjectUID()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "invalid operator ID %s", operator.UID)
+	}
+	err = bundle.Validate()
+	if err != nil {
+		return nil, errs.NewHTTPStatusError(http.StatusBadRequest, "invalid strategy bundle", err)
+	}
+	if domain.IsReservedStrategyNamespace(bundle.StrategyNamespace) {
+		return nil, errs.NewHTTPStatusError(http.StatusBadRequest, "the strategy namespace is reserved", nil)
+	}
+	err = svc.applyBundleTemplates(ctx, bundle)
+	if err != nil {
+		return nil, err
+	}
+	desired := make(map[string]*domain.ScheduleStrategy, len(bundle.Entries))
+	for _, entry := range bundle.Entries {
+		strategy := entry.Strategy
+		strategy.StrategyNamespace = bundle.StrategyNamespace
+		err = strategy.ValidateLabelSelector()
+		if err == nil {
+			err = strategy.ValidateSchedule()
+		}
+		if err != nil {
+			return nil, errs.NewHTTPStatusError(http.StatusBadRequest, "invalid strategy bundle", fmt.Errorf("strategy %s: %w", strategy.Name, err))
+		}
+		desired[strategy.Name] = strategy
+	}
+
+	queryOpt := &domain.QueryStrategyOptions{StrategyNamespaces: []string{bundle.StrategyNamespace}}
+	err = svc.Repo.QueryStrategies(ctx, queryOpt)
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]*domain.ScheduleStrategy, len(queryOpt.Result))
+	for _, strategy := range queryOpt.Result {
+		current[strategy.BundleKey()] = strategy
+	}
+
+	changes := []*domain.StrategyChange{}
+	for _, entry := range bundle.Entries {
+		name := entry.Strategy.Name
+		existing, ok := current[name]
+		if !ok {
+			changes = append(changes, &domain.StrategyChange{Action: domain.StrategyChangeCreate, Name: name})
+			continue
+		}
+		fields := domain.DiffStrategySpec(existing, entry.Strategy)
+		if len(fields) > 0 && existing.CreatorID != operatorID {
+			// like the update endpoint, only the creator of a strategy may update it
+			return nil, errs.NewHTTPStatusError(http.StatusForbidden, "permission denied", fmt.Errorf("strategy %s of bundle %s belongs to another user", name, bundle.StrategyNamespace))
+		}
+		if len(fields) > 0 {
+			changes = append(changes, &domain.StrategyChange{Action: domain.StrategyChangeUpdate, Name: name, StrategyID: existing.ID, Fields: fields})
+		}
+	}
+	if opts.Prune {
+		pruned := make([]*domain.StrategyChange, 0)
+		for name, existing := range current {
+			// like the delete endpoint, only the strategies of the operator are deleted, the others are left alone
+			if _, ok := desired[name]; !ok && existing.CreatorID == operatorID {
+				pruned = append(pruned, &domain.StrategyChange{Action: domain.StrategyChangeDelete, Name: name, StrategyID: existing.ID})
+			}
+		}
+		slices.SortFunc(pruned, func(a, b *domain.StrategyChange) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		changes = append(changes, pruned...)
+	}
+	if opts.DryRun {
+		return changes, nil
+	}
+
+	for i, change := range changes {
+		strategy := desired[change.Name]
+		switch change.Action {
+		case domain.StrategyChangeCreate:
+			strategy.BaseEntity = domain.NewBaseEntity(&operatorID, &operatorID)
+			var pods []*domain.Pod
+			pods, err = svc.K8SAdapter.QueryPods(ctx, strategy.PodsQuery())
+			if err == nil {
+				_, err = svc.insertStrategy(ctx, strategy, pods)
+			}
+			change.StrategyID = strategy.ID
+		case domain.StrategyChangeUpdate:
+			strategy.BaseEntity = current[change.Name].BaseEntity
+			strategy.UpdaterID = operatorID
+			_, err = svc.updateStrategy(ctx, strategy)
+		case domain.StrategyChangeDelete:
+			err = svc.deleteStrategy(ctx, change.StrategyID)
+		}
+		if err != nil {
+			return changes[:i], fmt.Errorf("%s strategy %s of bundle %s: %w", change.Action, change.Name, bundle.StrategyNamespace, err)
+		}
+	}
+	logger.Logger(ctx).Info().Msgf("applied strategy bundle %s: %d changes", bundle.StrategyNamespace, len(changes))
+	return changes, nil
+}
+
+// applyBundleTemplates derives the strategies of the bundle from the templates they reference by name
+func (svc *Service) applyBundleTemplates(ctx context.Context, bundle *domain.StrategyBundle) error {
+	names := make([]string, 0)
+	for _, entry := range bundle.Entries {
+		if entry.Template != "" && !slices.Contains(names, entry.Template) {
+			names = append(names, entry.Template)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	queryOpt := &domain.QueryStrategyTemplateOptions{Names: names}
+	err := svc.Repo.QueryStrategyTemplates(ctx, queryOpt)
+	if err != nil {
+		return err
+	}
+	for _, entry := range bundle.Entries {
+		if entry.Template == "" {
+			continue
+		}
+		idx := slices.IndexFunc(queryOpt.Result, func(template *domain.StrategyTemplate) bool {
+			return template.Name == entry.Template
+		})
+		if idx < 0 {
+			return errs.NewHTTPStatusError(http.StatusBadRequest, "invalid strategy bundle", fmt.Errorf("strategy %s references the unknown template %s", entry.Strategy.Name, entry.Template))
+		}
+		queryOpt.Result[idx].ApplyTo(entry.Strategy)
+	}
+	return nil
+}
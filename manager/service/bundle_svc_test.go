@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/manager/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestDiffStrategySpec(t *testing.T) {
+	current := &domain.ScheduleStrategy{
+		BaseEntity:     domain.BaseEntity{ID: bson.NewObjectID(), CreatedTime: 1000},
+		Name:           "web",
+		LabelSelectors: []domain.LabelSelector{{Key: "app", Value: "web"}},
+		Priority:       1,
+		ExecutionTime:  1000000,
+	}
+	desired := &domain.ScheduleStrategy{
+		Name:           "web",
+		LabelSelectors: []domain.LabelSelector{{Key: "app", Value: "web"}},
+		MatchLabels:    map[string]string{},
+		Priority:       1,
+		ExecutionTime:  1000000,
+	}
+	assert.Empty(t, domain.DiffStrategySpec(current, desired))
+
+	desired.Priority = 5
+	desired.K8sNamespace = []string{"prod"}
+	desired.Window = &domain.RecurringWindow{Cron: "0 9 * * *", DurationSec: 3600}
+	assert.Equal(t, []string{"k8sNamespace", "priority", "window"}, domain.DiffStrategySpec(current, desired))
+}
+
+func mockBundleStrategies(repo *domain.MockRepository, strategyNamespace string, strategies ...*domain.ScheduleStrategy) {
+	repo.EXPECT().QueryStrategies(mock.Anything, &domain.QueryStrategyOptions{StrategyNamespaces: []string{strategyNamespace}}).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		opt.Result = strategies
+		return nil
+	}).Once()
+}
+
+// TestApplyStrategyBundleDryRun tests that a dry run reports the creations, updates and prunes without applying them
+func TestApplyStrategyBundleDryRun(t *testing.T) {
+	svc, repo, _, _ := newReconcileTestService(t)
+	operatorID := bson.NewObjectID()
+	operator := &domain.Claims{UID: operatorID.Hex()}
+	web := &domain.ScheduleStrategy{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatorID: operatorID}, StrategyNamespace: "team-a", Name: "web", Priority: 1, ExecutionTime: 1000000}
+	db := &domain.ScheduleStrategy{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatorID: operatorID}, StrategyNamespace: "team-a", Name: "db", Priority: 0, ExecutionTime: 20000000}
+	unnamed := &domain.ScheduleStrategy{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatorID: operatorID}, StrategyNamespace: "team-a", Priority: 1}
+	interactive := &domain.StrategyTemplate{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, Name: "interactive", Priority: 1, ExecutionTime: 5000000}
+
+	repo.EXPECT().QueryStrategyTemplates(mock.Anything, &domain.QueryStrategyTemplateOptions{Names: []string{"interactive"}}).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyTemplateOptions) error {
+		opt.Result = []*domain.StrategyTemplate{interactive}
+		return nil
+	}).Once()
+	mockBundleStrategies(repo, "team-a", web, db, unnamed)
+
+	bundle := &domain.StrategyBundle{
+		StrategyNamespace: "team-a",
+		Entries: []*domain.StrategyBundleEntry{
+			{Strategy: &domain.ScheduleStrategy{Name: "web", Priority: 5, ExecutionTime: 1000000}},
+			{Strategy: &domain.ScheduleStrategy{Name: "db", Priority: 0, ExecutionTime: 20000000}},
+			{Template: "interactive", Strategy: &domain.ScheduleStrategy{Name: "cache"}},
+		},
+	}
+	changes, err := svc.ApplyStrategyBundle(context.Background(), operator, bundle, domain.ApplyBundleOptions{Prune: true, DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.StrategyChange{
+		{Action: domain.StrategyChangeUpdate, Name: "web", StrategyID: web.ID, Fields: []string{"priority"}},
+		{Action: domain.StrategyChangeCreate, Name: "cache"},
+		{Action: domain.StrategyChangeDelete, Name: unnamed.ID.Hex(), StrategyID: unnamed.ID},
+	}, changes)
+	assert.Equal(t, interactive.ID, bundle.Entries[2].Strategy.TemplateID)
+	assert.Equal(t, int64(5000000), bundle.Entries[2].Strategy.ExecutionTime)
+}
+
+// TestApplyStrategyBundleAppliesChanges tests that the missing strategies are created and the pruned ones deleted
+func TestApplyStrategyBundleAppliesChanges(t *testing.T) {
+	svc, repo, k8sAdapter, _ := newReconcileTestService(t)
+	operatorID := bson.NewObjectID()
+	operator := &domain.Claims{UID: operatorID.Hex()}
+	old := &domain.ScheduleStrategy{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatorID: operatorID}, StrategyNamespace: "team-a", Name: "old"}
+	others := &domain.ScheduleStrategy{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatorID: bson.NewObjectID()}, StrategyNamespace: "team-a", Name: "others"}
+
+	// the strategy of another user is not pruned
+	mockBundleStrategies(repo, "team-a", old, others)
+	k8sAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return(nil, nil).Once()
+	repo.EXPECT().InsertStrategyAndIntents(mock.Anything, mock.MatchedBy(func(strategy *domain.ScheduleStrategy) bool {
+		return strategy.Name == "web" && strategy.StrategyNamespace == "team-a" && strategy.CreatorID == operatorID
+	}), []*domain.ScheduleIntent{}).RunAndReturn(func(_ context.Context, strategy *domain.ScheduleStrategy, _ []*domain.ScheduleIntent) error {
+		strategy.ID = bson.NewObjectID()
+		return nil
+	}).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, &domain.QueryIntentOptions{StrategyIDs: []bson.ObjectID{old.ID}}).Return(nil).Once()
+	repo.EXPECT().DeleteIntentsByStrategyID(mock.Anything, old.ID).Return(nil).Once()
+	repo.EXPECT().DeleteStrategy(mock.Anything, old.ID).Return(nil).Once()
+
+	bundle := &domain.StrategyBundle{
+		StrategyNamespace: "team-a",
+		Entries:           []*domain.StrategyBundleEntry{{Strategy: &domain.ScheduleStrategy{Name: "web", Priority: 1}}},
+	}
+	changes, err := svc.ApplyStrategyBundle(context.Background(), operator, bundle, domain.ApplyBundleOptions{Prune: true})
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, domain.StrategyChangeCreate, changes[0].Action)
+	assert.False(t, changes[0].StrategyID.IsZero())
+	assert.Equal(t, domain.StrategyChangeDelete, changes[1].Action)
+}
+
+// TestApplyStrategyBundleRejectsUpdatingOthers tests that a bundle updating the strategy of another user is rejected before any change
+func TestApplyStrategyBundleRejectsUpdatingOthers(t *testing.T) {
+	svc, repo, _, _ := newReconcileTestService(t)
+	operatorID := bson.NewObjectID()
+	operator := &domain.Claims{UID: operatorID.Hex()}
+	others := &domain.ScheduleStrategy{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatorID: bson.NewObjectID()}, StrategyNamespace: "team-a", Name: "web", Priority: 1}
+
+	mockBundleStrategies(repo, "team-a", others)
+
+	bundle := &domain.StrategyBundle{
+		StrategyNamespace: "team-a",
+		Entries: []*domain.StrategyBundleEntry{
+			{Strategy: &domain.ScheduleStrategy{Name: "cache", Priority: 1}},
+			{Strategy: &domain.ScheduleStrategy{Name: "web", Priority: 5}},
+		},
+	}
+	changes, err := svc.ApplyStrategyBundle(context.Background(), operator, bundle, domain.ApplyBundleOptions{})
+	httpErr, ok := errs.IsHTTPStatusError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusForbidden, httpErr.StatusCode)
+	assert.Empty(t, changes)
+}
+
+func TestApplyStrategyBundleRejectsDuplicateNames(t *testing.T) {
+	svc, _, _, _ := newReconcileTestService(t)
+	operator := &domain.Claims{UID: bson.NewObjectID().Hex()}
+	bundle := &domain.StrategyBundle{
+		StrategyNamespace: "team-a",
+		Entries: []*domain.StrategyBundleEntry{
+			{Strategy: &domain.ScheduleStrategy{Name: "web"}},
+			{Strategy: &domain.ScheduleStrategy{Name: "web"}},
+		},
+	}
+	_, err := svc.ApplyStrategyBundle(context.Background(), operator, bundle, domain.ApplyBundleOptions{})
+	httpErr, ok := errs.IsHTTPStatusError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Gthulhu/api/config"
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// intentDeliveryQueue delivers the initialized schedule intents to the decision makers.
+// The queue itself is the intent collection: an intent stays initialized until it is sent or given up,
+// so that the deliveries pending when the manager stops are resumed on the next start.
+type intentDeliveryQueue struct {
+	cfg config.DeliveryConfig
+	mu  sync.Mutex
+	// nodeLocks serializes the deliveries of a node, so that its intents reach the decision maker in creation order
+	nodeLocks map[string]*sync.Mutex
+	// removals are the failed removals of stale intents per node, retried by RunIntentDelivery. The stale intents are
+	// already deleted from the collection, so a removal still pending when the manager stops is left to the full-state sync of the decision makers.
+	removals map[string]*staleIntentsRemoval
+}
+
+// staleIntentsRemoval is a failed removal of the stale intents of a node waiting for another attempt
+type staleIntentsRemoval struct {
+	intents         []*domain.ScheduleIntent
+	attempts        int
+	nextAttemptTime time.Time
+}
+
+func newIntentDeliveryQueue(cfg config.DeliveryConfig) *intentDeliveryQueue {
+	return &intentDeliveryQueue{
+		cfg:       cfg,
+		nodeLocks: make(map[string]*sync.Mutex),
+		removals:  make(map[string]*staleIntentsRemoval),
+	}
+}
+
+func (q *intentDeliveryQueue) lockNode(nodeID string) func() {
+	q.mu.Lock()
+	lock, ok := q.nodeLocks[nodeID]
+	if !ok {
+		lock = &sync.Mutex{}
+		q.nodeLocks[nodeID] = lock
+	}
+	q.mu.Unlock()
+	lock.Lock()
+	return lock.Unlock
+}
+
+// recordRemovalFailure queues the stale intents of a node for another removal with an exponential backoff, merged with the removal
+// already pending for the node, or gives them up once they reached the max attempts
+func (q *intentDeliveryQueue) recordRemovalFailure(ctx context.Context, nodeID string, intents []*domain.ScheduleIntent, attempts int, removalErr error, now time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	removal, ok := q.removals[nodeID]
+	if !ok {
+		removal = &staleIntentsRemoval{}
+		q.removals[nodeID] = removal
+	}
+	for _, intent := range intents {
+		if !slices.ContainsFunc(removal.intents, func(pending *domain.ScheduleIntent) bool { return pending.ID == intent.ID }) {
+			removal.intents = append(removal.intents, intent)
+		}
+	}
+	removal.attempts = max(removal.attempts, attempts)
+	if removal.attempts >= q.cfg.GetMaxAttempts() {
+		delete(q.removals, nodeID)
+		logger.Logger(ctx).Error().Err(removalErr).Msgf("giving up removal of %d stale intents from node %s after %d attempts", len(removal.intents), nodeID, removal.attempts)
+		return
+	}
+	removal.nextAttemptTime = now.Add(q.cfg.Backoff(removal.attempts))
+	logger.Logger(ctx).Warn().Err(removalErr).Msgf("removal of %d stale intents from node %s failed, attempt %d, retrying at %s", len(removal.intents), nodeID, removal.attempts, removal.nextAttemptTime.Format(time.RFC3339))
+}
+
+// dueRemovalNodes returns the nodes whose failed removal is due for another attempt
+func (q *intentDeliveryQueue) dueRemovalNodes(now time.Time) []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	nodeIDs := make([]string, 0)
+	for nodeID, removal := range q.removals {
+		if !removal.nextAttemptTime.After(now) {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+	return nodeIDs
+}
+
+// takeDueRemoval dequeues the failed removal of a node if it is due for another attempt
+func (q *intentDeliveryQueue) takeDueRemoval(nodeID string, now time.Time) *staleIntentsRemoval {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	removal, ok := q.removals[nodeID]
+	if !ok || removal.nextAttemptTime.After(now) {
+		return nil
+	}
+	delete(q.removals, nodeID)
+	return removal
+}
+
+// RunIntentDelivery scans the pending intents every poll interval and hands the nodes that are due to a pool of workers.
+// A node is always handled by the same worker, so that two deliveries of a node never overlap. It blocks until ctx is cancelled.
+func (svc *Service) RunIntentDelivery(ctx context.Context) {
+	workers := make([]chan string, svc.delivery.cfg.GetWorkers())
+	var wg sync.WaitGroup
+	for i := range workers {
+		workers[i] = make(chan string, 64)
+		wg.Add(1)
+		go func(nodeIDs <-chan string) {
+			defer wg.Done()
+			for nodeID := range nodeIDs {
+				svc.retryStaleIntentsRemoval(ctx, nodeID)
+				svc.deliverNodeIntents(ctx, nodeID)
+			}
+		}(workers[i])
+	}
+	defer func() {
+		for _, worker := range workers {
+			close(worker)
+		}
+		wg.Wait()
+	}()
+
+	ticker := time.NewTicker(svc.delivery.cfg.PollInterval())
+	defer ticker.Stop()
+	for {
+		now := time.Now()
+		nodeIDs, err := svc.dueDeliveryNodes(ctx, now)
+		if err != nil {
+			logger.Logger(ctx).Warn().Err(err).Msg("failed to scan pending intents")
+		}
+		for _, nodeID := range svc.delivery.dueRemovalNodes(now) {
+			if !slices.Contains(nodeIDs, nodeID) {
+				nodeIDs = append(nodeIDs, nodeID)
+			}
+		}
+		for _, nodeID := range nodeIDs {
+			hash := fnv.New32a()
+			_, _ = hash.Write([]byte(nodeID))
+			select {
+			case workers[hash.Sum32()%uint32(len(workers))] <- nodeID:
+			case <-ctx.Done():
+				return
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dueDeliveryNodes returns the nodes whose oldest pending intent is due for delivery
+func (svc *Service) dueDeliveryNodes(ctx context.Context, now time.Time) ([]string, error) {
+	queryOpt := &domain.QueryIntentOptions{
+		States: []domain.IntentState{domain.IntentStateInitialized},
+	}
+	err := svc.Repo.QueryIntents(ctx, queryOpt)
+	if err != nil {
+		return nil, err
+	}
+	nodeIntents := make(map[string][]*domain.ScheduleIntent)
+	for _, intent := range queryOpt.Result {
+		nodeIntents[intent.NodeID] = append(nodeIntents[intent.NodeID], intent)
+	}
+	nodeIDs := make([]string, 0, len(nodeIntents))
+	for nodeID, intents := range nodeIntents {
+		sortIntentsByCreation(intents)
+		if intents[0].NextDeliveryTime <= now.UnixMilli() {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+	slices.Sort(nodeIDs)
+	return nodeIDs, nil
+}
+
+// deliverNodeIntents sends the pending intents of a node to its decision maker in creation order.
+// When the oldest pending intent is still backing off the node is skipped, so that newer intents never overtake it.
+// A failed delivery is recorded on the intents, which are retried later or marked as failed after too many attempts.
+func (svc *Service) deliverNodeIntents(ctx context.Context, nodeID string) {
+	unlock := svc.delivery.lockNode(nodeID)
+	defer unlock()
+
+	queryOpt := &domain.QueryIntentOptions{
+		NodeIDs: []string{nodeID},
+		States:  []domain.IntentState{domain.IntentStateInitialized},
+	}
+	err := svc.Repo.QueryIntents(ctx, queryOpt)
+	if err != nil {
+		logger.Logger(ctx).Warn().Err(err).Msgf("failed to query pending intents of node %s", nodeID)
+		return
+	}
+	intents := queryOpt.Result
+	if len(intents) == 0 {
+		return
+	}
+	sortIntentsByCreation(intents)
+	now := time.Now()
+	if intents[0].NextDeliveryTime > now.UnixMilli() {
+		return
+	}
+
+	updates, err := svc.sendNodeIntents(ctx, nodeID, intents)
+	if err != nil {
+		svc.recordDeliveryFailure(ctx, intents, err, now)
+		return
+	}
+	err = svc.Repo.BulkUpdateIntentsState(ctx, updates)
+	if err != nil {
+		logger.Logger(ctx).Warn().Err(err).Msgf("failed to update the state of intents of node %s", nodeID)
+		return
+	}
+	logger.Logger(ctx).Info().Msgf("sent %d scheduling intents to decision maker of node %s", len(intents), nodeID)
+}
+
+// sendNodeIntents sends the intents to every decision maker of the node and returns the states they acknowledged
+func (svc *Service) sendNodeIntents(ctx context.Context, nodeID string, intents []*domain.ScheduleIntent) ([]*domain.IntentStateUpdate, error) {
+	dmPods, err := svc.queryDecisionMakers(ctx, []string{nodeID})
+	if err != nil {
+		return nil, err
+	}
+	if len(dmPods) == 0 {
+		return nil, fmt.Errorf("no decision maker pod found on node %s", nodeID)
+	}
+	acks := make([][]*domain.IntentResult, 0, len(dmPods))
+	for _, dmPod := range dmPods {
+		results, err := svc.DMAdapter.SendSchedulingIntent(ctx, dmPod, intents)
+		if err != nil {
+			return nil, fmt.Errorf("send scheduling intents to decision maker %s: %w", dmPod.Host, err)
+		}
+		acks = append(acks, results)
+	}
+	return intentStateUpdates(intents, acks), nil
+}
+
+// intentStateUpdates merges the acknowledgements of the decision makers of a node, one result list per decision maker,
+// into the state of every intent. An intent is applied when every decision maker bound it and partially applied when
+// only some did, a decision maker that does not report a result for an intent counts as sent.
+func intentStateUpdates(intents []*domain.ScheduleIntent, acks [][]*domain.IntentResult) []*domain.IntentStateUpdate {
+	updates := make([]*domain.IntentStateUpdate, 0, len(intents))
+	for _, intent := range intents {
+		update := &domain.IntentStateUpdate{IntentID: intent.ID}
+		states := make(map[domain.IntentState]int)
+		for _, results := range acks {
+			idx := slices.IndexFunc(results, func(result *domain.IntentResult) bool {
+				return result.PodID == intent.PodID && result.StrategyID == intent.StrategyID.Hex() && result.CommandRegex == intent.CommandRegex &&
+					slices.Equal(result.ProcessMatchers, intent.ProcessMatchers) && result.ThreadRegex == intent.ThreadRegex &&
+					slices.Equal(result.ContainerIDs, intent.ContainerIDs)
+			})
+			if idx < 0 {
+				states[domain.IntentStateSent]++
+				continue
+			}
+			result := results[idx]
+			states[result.State]++
+			update.MatchedPIDs += result.MatchedPIDs
+			if result.Error != "" && update.LastError == "" {
+				update.LastError = result.Error
+			}
+		}
+		switch {
+		case states[domain.IntentStateApplied] == len(acks):
+			update.State = domain.IntentStateApplied
+		case states[domain.IntentStateApplied] > 0:
+			update.State = domain.IntentStatePartiallyApplied
+		case states[domain.IntentStateFailed] > 0:
+			update.State = domain.IntentStateFailed
+		case states[domain.IntentStateNoMatchingProcess] > 0:
+			update.State = domain.IntentStateNoMatchingProcess
+		default:
+			update.State = domain.IntentStateSent
+		}
+		updates = append(updates, update)
+	}
+	return updates
+}
+
+// recordDeliveryFailure schedules the next attempt of the intents with an exponential backoff,
+// or marks them as failed once they reached the max attempts
+func (svc *Service) recordDeliveryFailure(ctx context.Context, intents []*domain.ScheduleIntent, deliveryErr error, now time.Time) {
+	deliveries := make(map[int][]bson.ObjectID)
+	for _, intent := range intents {
+		deliveries[intent.DeliveryAttempts+1] = append(deliveries[intent.DeliveryAttempts+1], intent.ID)
+	}
+	for attempts, intentIDs := range deliveries {
+		delivery := domain.IntentDelivery{
+			State:            domain.IntentStateInitialized,
+			DeliveryAttempts: attempts,
+			NextDeliveryTime: now.Add(svc.delivery.cfg.Backoff(attempts)).UnixMilli(),
+			LastError:        deliveryErr.Error(),
+		}
+		if attempts >= svc.delivery.cfg.GetMaxAttempts() {
+			delivery.State = domain.IntentStateFailed
+			delivery.NextDeliveryTime = 0
+			logger.Logger(ctx).Error().Err(deliveryErr).Msgf("giving up delivery of %d intents after %d attempts", len(intentIDs), attempts)
+		} else {
+			logger.Logger(ctx).Warn().Err(deliveryErr).Msgf("delivery of %d intents failed, attempt %d, retrying at %s", len(intentIDs), attempts, time.UnixMilli(delivery.NextDeliveryTime).Format(time.RFC3339))
+		}
+		err := svc.Repo.BatchUpdateIntentsDelivery(ctx, intentIDs, delivery)
+		if err != nil {
+			logger.Logger(ctx).Warn().Err(err).Msg("failed to record delivery failure of intents")
+		}
+	}
+}
+
+func sortIntentsByCreation(intents []*domain.ScheduleIntent) {
+	slices.SortStableFunc(intents, func(a, b *domain.ScheduleIntent) int {
+		return cmp.Or(cmp.Compare(a.CreatedTime, b.CreatedTime), bytes.Compare(a.ID[:], b.ID[:]))
+	})
+}
+
+// retryStaleIntentsRemoval removes again the stale intents of a node whose removal failed, once it is due
+func (svc *Service) retryStaleIntentsRemoval(ctx context.Context, nodeID string) {
+	removal := svc.delivery.takeDueRemoval(nodeID, time.Now())
+	if removal == nil {
+		return
+	}
+	svc.removeStaleIntentsAttempt(ctx, removal.intents, removal.attempts)
+}
+
+// removeNodeStaleIntents asks the decision makers of a node to forget the stale intents, a failed removal is queued for another attempt
+func (svc *Service) removeNodeStaleIntents(ctx context.Context, nodeID string, intents []*domain.ScheduleIntent, attempts int) {
+	unlock := svc.delivery.lockNode(nodeID)
+	defer unlock()
+
+	dmPods, err := svc.queryDecisionMakers(ctx, []string{nodeID})
+	if err != nil {
+		svc.delivery.recordRemovalFailure(ctx, nodeID, intents, attempts+1, err, time.Now())
+		return
+	}
+	deleteReq := &domain.DeleteIntentsRequest{
+		Intents: intents,
+	}
+	for _, dmPod := range dmPods {
+		if err := svc.DMAdapter.DeleteSchedulingIntents(ctx, dmPod, deleteReq); err != nil {
+			svc.delivery.recordRemovalFailure(ctx, nodeID, intents, attempts+1, fmt.Errorf("delete scheduling intents from decision maker %s: %w", dmPod.Host, err), time.Now())
+			return
+		}
+	}
+}
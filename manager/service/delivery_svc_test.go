@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Gthulhu/api/config"
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newDeliveryTestService(t *testing.T) (*Service, *domain.MockRepository, *domain.MockK8SAdapter, *domain.MockDecisionMakerAdapter) {
+	svc, repo, k8sAdapter, dmAdapter := newReconcileTestService(t)
+	svc.delivery = newIntentDeliveryQueue(config.DeliveryConfig{MaxAttempts: 3})
+	return svc, repo, k8sAdapter, dmAdapter
+}
+
+// mockPendingIntents makes the repository return the given pending intents when the intents of nodeID are queried
+func mockPendingIntents(repo *domain.MockRepository, nodeID string, intents ...*domain.ScheduleIntent) {
+	repo.EXPECT().QueryIntents(mock.Anything, mock.MatchedBy(func(opt *domain.QueryIntentOptions) bool {
+		return len(opt.NodeIDs) == 1 && opt.NodeIDs[0] == nodeID
+	})).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = intents
+		return nil
+	}).Once()
+}
+
+func mockNodeDecisionMaker(k8sAdapter *domain.MockK8SAdapter, dmPod *domain.DecisionMakerPod) {
+	k8sAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.MatchedBy(func(opt *domain.QueryDecisionMakerPodsOptions) bool {
+		return len(opt.NodeIDs) == 1 && opt.NodeIDs[0] == dmPod.NodeID
+	})).Return([]*domain.DecisionMakerPod{dmPod}, nil).Once()
+}
+
+// TestCreateScheduleStrategyContinuesAfterDeliveryFailure tests that a decision maker failing does not prevent the other nodes from receiving their intents
+func TestCreateScheduleStrategyContinuesAfterDeliveryFailure(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newDeliveryTestService(t)
+	ctx := context.Background()
+	operator := &domain.Claims{UID: bson.NewObjectID().Hex()}
+	strategy := &domain.ScheduleStrategy{LabelSelectors: []domain.LabelSelector{{Key: "app", Value: "web"}}}
+	pods := []*domain.Pod{
+		{PodID: "pod-1", NodeID: "node-1", Labels: map[string]string{"app": "web"}},
+		{PodID: "pod-2", NodeID: "node-2", Labels: map[string]string{"app": "web"}},
+	}
+	dmPod1 := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+	dmPod2 := &domain.DecisionMakerPod{NodeID: "node-2", Host: "10.0.0.2", Port: 8080}
+
+	var intents []*domain.ScheduleIntent
+	k8sAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return(pods, nil).Once()
+	repo.EXPECT().InsertStrategyAndIntents(mock.Anything, strategy, mock.Anything).RunAndReturn(func(_ context.Context, _ *domain.ScheduleStrategy, inserted []*domain.ScheduleIntent) error {
+		for _, intent := range inserted {
+			intent.ID = bson.NewObjectID()
+		}
+		intents = inserted
+		return nil
+	}).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		for _, intent := range intents {
+			if intent.NodeID == opt.NodeIDs[0] {
+				opt.Result = append(opt.Result, intent)
+			}
+		}
+		return nil
+	}).Twice()
+	mockNodeDecisionMaker(k8sAdapter, dmPod1)
+	mockNodeDecisionMaker(k8sAdapter, dmPod2)
+	dmAdapter.EXPECT().SendSchedulingIntent(mock.Anything, dmPod1, mock.Anything).Return(nil, errors.New("connection refused")).Once()
+	dmAdapter.EXPECT().SendSchedulingIntent(mock.Anything, dmPod2, mock.Anything).Return(nil, nil).Once()
+	repo.EXPECT().BatchUpdateIntentsDelivery(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, intentIDs []bson.ObjectID, delivery domain.IntentDelivery) error {
+		require.Equal(t, []bson.ObjectID{intents[0].ID}, intentIDs)
+		assert.Equal(t, domain.IntentStateInitialized, delivery.State)
+		assert.Equal(t, 1, delivery.DeliveryAttempts)
+		assert.Greater(t, delivery.NextDeliveryTime, time.Now().UnixMilli())
+		assert.Contains(t, delivery.LastError, "connection refused")
+		return nil
+	}).Once()
+	repo.EXPECT().BulkUpdateIntentsState(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, updates []*domain.IntentStateUpdate) error {
+		require.Equal(t, []*domain.IntentStateUpdate{{IntentID: intents[1].ID, State: domain.IntentStateSent}}, updates)
+		return nil
+	}).Once()
+
+	repo.EXPECT().QueryIntents(mock.Anything, mock.MatchedBy(func(opt *domain.QueryIntentOptions) bool {
+		return len(opt.PodIDs) > 0
+	})).Return(nil).Once()
+
+	conflicts, err := svc.CreateScheduleStrategy(ctx, operator, strategy)
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+}
+
+// TestDeliverNodeIntentsInCreationOrder tests that the pending intents of a node are sent oldest first
+func TestDeliverNodeIntentsInCreationOrder(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newDeliveryTestService(t)
+	older := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatedTime: 1000}, PodID: "pod-1", NodeID: "node-1", DeliveryAttempts: 1}
+	newer := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatedTime: 2000}, PodID: "pod-2", NodeID: "node-1"}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+
+	mockPendingIntents(repo, "node-1", newer, older)
+	mockNodeDecisionMaker(k8sAdapter, dmPod)
+	dmAdapter.EXPECT().SendSchedulingIntent(mock.Anything, dmPod, []*domain.ScheduleIntent{older, newer}).Return(nil, nil).Once()
+	repo.EXPECT().BulkUpdateIntentsState(mock.Anything, []*domain.IntentStateUpdate{
+		{IntentID: older.ID, State: domain.IntentStateSent},
+		{IntentID: newer.ID, State: domain.IntentStateSent},
+	}).Return(nil).Once()
+
+	svc.deliverNodeIntents(context.Background(), "node-1")
+}
+
+// TestDeliverNodeIntentsWaitsForBackoff tests that newer intents do not overtake an older intent that is backing off
+func TestDeliverNodeIntentsWaitsForBackoff(t *testing.T) {
+	svc, repo, _, _ := newDeliveryTestService(t)
+	backingOff := &domain.ScheduleIntent{
+		BaseEntity:       domain.BaseEntity{ID: bson.NewObjectID(), CreatedTime: 1000},
+		NodeID:           "node-1",
+		DeliveryAttempts: 2,
+		NextDeliveryTime: time.Now().Add(time.Minute).UnixMilli(),
+	}
+	newer := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatedTime: 2000}, NodeID: "node-1"}
+
+	mockPendingIntents(repo, "node-1", newer, backingOff)
+
+	svc.deliverNodeIntents(context.Background(), "node-1")
+}
+
+// TestDeliverNodeIntentsMarksFailed tests that the intents are marked as failed with the last error once they reached the max attempts
+func TestDeliverNodeIntentsMarksFailed(t *testing.T) {
+	svc, repo, k8sAdapter, _ := newDeliveryTestService(t)
+	intent := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, NodeID: "node-1", DeliveryAttempts: 2}
+
+	mockPendingIntents(repo, "node-1", intent)
+	k8sAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return(nil, nil).Once()
+	repo.EXPECT().BatchUpdateIntentsDelivery(mock.Anything, []bson.ObjectID{intent.ID}, domain.IntentDelivery{
+		State:            domain.IntentStateFailed,
+		DeliveryAttempts: 3,
+		LastError:        "no decision maker pod found on node node-1",
+	}).Return(nil).Once()
+
+	svc.deliverNodeIntents(context.Background(), "node-1")
+}
+
+// TestDueDeliveryNodes tests that only the nodes whose oldest pending intent is due are picked up
+func TestDueDeliveryNodes(t *testing.T) {
+	svc, repo, _, _ := newDeliveryTestService(t)
+	now := time.Now()
+	repo.EXPECT().QueryIntents(mock.Anything, &domain.QueryIntentOptions{States: []domain.IntentState{domain.IntentStateInitialized}}).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = []*domain.ScheduleIntent{
+			{BaseEntity: domain.BaseEntity{CreatedTime: 1000}, NodeID: "node-1"},
+			{BaseEntity: domain.BaseEntity{CreatedTime: 1000}, NodeID: "node-2", NextDeliveryTime: now.Add(time.Minute).UnixMilli()},
+			{BaseEntity: domain.BaseEntity{CreatedTime: 2000}, NodeID: "node-2"},
+			{BaseEntity: domain.BaseEntity{CreatedTime: 1000}, NodeID: "node-3", NextDeliveryTime: now.Add(-time.Second).UnixMilli()},
+		}
+		return nil
+	}).Once()
+
+	nodeIDs, err := svc.dueDeliveryNodes(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"node-1", "node-3"}, nodeIDs)
+}
+
+func TestDeliveryBackoff(t *testing.T) {
+	cfg := config.DeliveryConfig{InitialBackoffMs: 500, MaxBackoffSec: 3}
+	assert.Equal(t, 500*time.Millisecond, cfg.Backoff(1))
+	assert.Equal(t, time.Second, cfg.Backoff(2))
+	assert.Equal(t, 2*time.Second, cfg.Backoff(3))
+	assert.Equal(t, 3*time.Second, cfg.Backoff(4))
+	assert.Equal(t, 3*time.Second, cfg.Backoff(100))
+}
+
+// TestIntentStateUpdates tests how the acknowledgements of the decision makers of a node are merged into the intent states
+func TestIntentStateUpdates(t *testing.T) {
+	strategyID := bson.NewObjectID()
+	intents := []*domain.ScheduleIntent{
+		{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, PodID: "pod-1", StrategyID: strategyID, CommandRegex: "^nginx"},
+		{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, PodID: "pod-2", StrategyID: strategyID, CommandRegex: "^nginx"},
+		{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, PodID: "pod-3", StrategyID: strategyID, CommandRegex: "(nginx"},
+		{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, PodID: "pod-4", StrategyID: strategyID},
+	}
+	acks := [][]*domain.IntentResult{
+		{
+			{PodID: "pod-1", StrategyID: strategyID.Hex(), CommandRegex: "^nginx", State: domain.IntentStateApplied, MatchedPIDs: 2},
+			{PodID: "pod-2", StrategyID: strategyID.Hex(), CommandRegex: "^nginx", State: domain.IntentStateApplied, MatchedPIDs: 1},
+			{PodID: "pod-3", StrategyID: strategyID.Hex(), CommandRegex: "(nginx", State: domain.IntentStateFailed, Error: "invalid command regex"},
+			{PodID: "pod-4", StrategyID: strategyID.Hex(), State: domain.IntentStateNoMatchingProcess},
+		},
+		{
+			{PodID: "pod-1", StrategyID: strategyID.Hex(), CommandRegex: "^nginx", State: domain.IntentStateApplied, MatchedPIDs: 2},
+			{PodID: "pod-2", StrategyID: strategyID.Hex(), CommandRegex: "^nginx", State: domain.IntentStateNoMatchingProcess},
+			{PodID: "pod-3", StrategyID: strategyID.Hex(), CommandRegex: "(nginx", State: domain.IntentStateFailed, Error: "invalid command regex"},
+			{PodID: "pod-4", StrategyID: strategyID.Hex(), State: domain.IntentStateNoMatchingProcess},
+		},
+	}
+
+	updates := intentStateUpdates(intents, acks)
+	assert.Equal(t, []*domain.IntentStateUpdate{
+		{IntentID: intents[0].ID, State: domain.IntentStateApplied, MatchedPIDs: 4},
+		{IntentID: intents[1].ID, State: domain.IntentStatePartiallyApplied, MatchedPIDs: 1},
+		{IntentID: intents[2].ID, State: domain.IntentStateFailed, LastError: "invalid command regex"},
+		{IntentID: intents[3].ID, State: domain.IntentStateNoMatchingProcess},
+	}, updates)
+
+	// a decision maker that does not report results
+	updates = intentStateUpdates(intents[:1], [][]*domain.IntentResult{nil})
+	assert.Equal(t, domain.IntentStateSent, updates[0].State)
+
+	// the intents of a pod with the same command regex are told apart by their process matchers
+	matchers := []domain.ProcessMatcher{{Field: domain.ProcessFieldCmdline, Regex: "b\\.jar"}}
+	matcherIntent := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, PodID: "pod-1", StrategyID: strategyID, CommandRegex: "^nginx", ProcessMatchers: matchers}
+	updates = intentStateUpdates([]*domain.ScheduleIntent{matcherIntent}, [][]*domain.IntentResult{{
+		{PodID: "pod-1", StrategyID: strategyID.Hex(), CommandRegex: "^nginx", State: domain.IntentStateApplied, MatchedPIDs: 2},
+		{PodID: "pod-1", StrategyID: strategyID.Hex(), CommandRegex: "^nginx", ProcessMatchers: matchers, State: domain.IntentStateNoMatchingProcess},
+	}})
+	assert.Equal(t, domain.IntentStateNoMatchingProcess, updates[0].State)
+
+	// and by their thread regex
+	threadIntent := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, PodID: "pod-1", StrategyID: strategyID, CommandRegex: "^nginx", ThreadRegex: "^worker"}
+	updates = intentStateUpdates([]*domain.ScheduleIntent{threadIntent}, [][]*domain.IntentResult{{
+		{PodID: "pod-1", StrategyID: strategyID.Hex(), CommandRegex: "^nginx", State: domain.IntentStateNoMatchingProcess},
+		{PodID: "pod-1", StrategyID: strategyID.Hex(), CommandRegex: "^nginx", ThreadRegex: "^worker", State: domain.IntentStateApplied, MatchedPIDs: 1},
+	}})
+	assert.Equal(t, domain.IntentStateApplied, updates[0].State)
+	assert.Equal(t, 1, updates[0].MatchedPIDs)
+
+	// and by their strategy
+	updates = intentStateUpdates(intents[:1], [][]*domain.IntentResult{{
+		{PodID: "pod-1", StrategyID: bson.NewObjectID().Hex(), CommandRegex: "^nginx", State: domain.IntentStateApplied, MatchedPIDs: 2},
+		{PodID: "pod-1", StrategyID: strategyID.Hex(), CommandRegex: "^nginx", State: domain.IntentStateNoMatchingProcess},
+	}})
+	assert.Equal(t, domain.IntentStateNoMatchingProcess, updates[0].State)
+
+	// and by their containers
+	containerIntent := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, PodID: "pod-1", StrategyID: strategyID, CommandRegex: "^nginx", ContainerIDs: []string{"app-1"}}
+	updates = intentStateUpdates([]*domain.ScheduleIntent{containerIntent}, [][]*domain.IntentResult{{
+		{PodID: "pod-1", StrategyID: strategyID.Hex(), CommandRegex: "^nginx", ContainerIDs: []string{"sidecar-1"}, State: domain.IntentStateNoMatchingProcess},
+		{PodID: "pod-1", StrategyID: strategyID.Hex(), CommandRegex: "^nginx", ContainerIDs: []string{"app-1"}, State: domain.IntentStateApplied, MatchedPIDs: 1},
+	}})
+	assert.Equal(t, domain.IntentStateApplied, updates[0].State)
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/manager/errs"
+	"github.com/Gthulhu/api/pkg/logger"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// GetEffectivePolicy lists the intents touching a pod ordered by precedence with their strategies, and asks the decision maker
+// of its node which scheduling it enforces for every PID. A pod that left the cluster is still explained from its intents.
+func (svc *Service) GetEffectivePolicy(ctx context.Context, query *domain.EffectivePolicyQuery) (*domain.EffectivePolicy, error) {
+	if query.PodID == "" && (query.K8sNamespace == "" || query.PodName == "") {
+		return nil, errs.NewHTTPStatusError(http.StatusBadRequest, "either the pod ID or the namespace and name of the pod is required", nil)
+	}
+	podsQuery := &domain.QueryPodsOptions{}
+	if query.PodID != "" {
+		podsQuery.PodIDs = []string{query.PodID}
+	} else {
+		podsQuery.K8SNamespace = []string{query.K8sNamespace}
+		podsQuery.PodNames = []string{query.PodName}
+	}
+	pods, err := svc.K8SAdapter.QueryPods(ctx, podsQuery)
+	if err != nil {
+		return nil, err
+	}
+	policy := &domain.EffectivePolicy{
+		PodID:        query.PodID,
+		PodName:      query.PodName,
+		K8sNamespace: query.K8sNamespace,
+		Strategies:   []*domain.ScheduleStrategy{},
+		Intents:      []*domain.ScheduleIntent{},
+		Processes:    []*domain.ProcessScheduling{},
+	}
+	if len(pods) > 0 {
+		policy.PodID = pods[0].PodID
+		policy.PodName = pods[0].Name
+		policy.K8sNamespace = pods[0].K8SNamespace
+		policy.NodeID = pods[0].NodeID
+	}
+	if policy.PodID == "" {
+		return nil, errs.NewHTTPStatusError(http.StatusNotFound, "pod not found", fmt.Errorf("no pod %s/%s", query.K8sNamespace, query.PodName))
+	}
+
+	intentQueryOpt := &domain.QueryIntentOptions{PodIDs: []string{policy.PodID}}
+	err = svc.Repo.QueryIntents(ctx, intentQueryOpt)
+	if err != nil {
+		return nil, fmt.Errorf("query intents of pod: %w", err)
+	}
+	if len(pods) == 0 {
+		if len(intentQueryOpt.Result) == 0 {
+			return nil, errs.NewHTTPStatusError(http.StatusNotFound, "pod not found", fmt.Errorf("no pod or intent with pod ID %s", policy.PodID))
+		}
+		policy.PodName = intentQueryOpt.Result[0].PodName
+		policy.K8sNamespace = intentQueryOpt.Result[0].K8sNamespace
+		policy.NodeID = intentQueryOpt.Result[0].NodeID
+	}
+	policy.Intents = intentQueryOpt.Result
+	slices.SortStableFunc(policy.Intents, func(a, b *domain.ScheduleIntent) int {
+		return domain.CompareIntentPrecedence(b, a)
+	})
+	strategyIDs := make([]bson.ObjectID, 0, len(policy.Intents))
+	for _, intent := range policy.Intents {
+		if policy.WinningIntentID.IsZero() && intent.InEffect() {
+			policy.WinningIntentID = intent.ID
+		}
+		if !slices.Contains(strategyIDs, intent.StrategyID) {
+			strategyIDs = append(strategyIDs, intent.StrategyID)
+		}
+	}
+	if len(strategyIDs) > 0 {
+		strategyQueryOpt := &domain.QueryStrategyOptions{IDs: strategyIDs}
+		err = svc.Repo.QueryStrategies(ctx, strategyQueryOpt)
+		if err != nil {
+			return nil, fmt.Errorf("query strategies of pod: %w", err)
+		}
+		// the strategies follow the precedence of their intents
+		for _, strategyID := range strategyIDs {
+			idx := slices.IndexFunc(strategyQueryOpt.Result, func(strategy *domain.ScheduleStrategy) bool {
+				return strategy.ID == strategyID
+			})
+			if idx >= 0 {
+				policy.Strategies = append(policy.Strategies, strategyQueryOpt.Result[idx])
+			}
+		}
+	}
+
+	err = svc.queryPodProcesses(ctx, policy)
+	if err != nil {
+		logger.Logger(ctx).Warn().Err(err).Msgf("failed to query the processes of pod %s", policy.PodID)
+		policy.DecisionMakerError = err.Error()
+	}
+	return policy, nil
+}
+
+// queryPodProcesses fills the processes of the policy with the scheduling the decision maker of the node of the pod enforces
+func (svc *Service) queryPodProcesses(ctx context.Context, policy *domain.EffectivePolicy) error {
+	if policy.NodeID == "" {
+		return fmt.Errorf("pod %s is not scheduled on a node", policy.PodID)
+	}
+	dmPods, err := svc.queryDecisionMakers(ctx, []string{policy.NodeID})
+	if err != nil {
+		return err
+	}
+	if len(dmPods) == 0 {
+		return fmt.Errorf("no decision maker pod found on node %s", policy.NodeID)
+	}
+	processes, err := svc.DMAdapter.ListPodSchedulingIntents(ctx, dmPods[0], policy.PodID)
+	if err != nil {
+		return fmt.Errorf("list scheduling intents of pod on decision maker %s: %w", dmPods[0].Host, err)
+	}
+	if processes != nil {
+		policy.Processes = processes
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/manager/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// TestGetEffectivePolicy tests that the intents of a pod are ordered by precedence and the processes are queried from the decision maker of its node
+func TestGetEffectivePolicy(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newReconcileTestService(t)
+	ctx := context.Background()
+	broad := &domain.ScheduleStrategy{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, Priority: 1}
+	heavy := &domain.ScheduleStrategy{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, ExecutionTime: 5000, Weight: 10}
+	failed := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, StrategyID: bson.NewObjectID(), PodID: "pod-1", Weight: 20, State: domain.IntentStateFailed}
+	broadIntent := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, StrategyID: broad.ID, PodID: "pod-1", Specificity: 3, State: domain.IntentStateApplied}
+	heavyIntent := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, StrategyID: heavy.ID, PodID: "pod-1", Weight: 10, State: domain.IntentStateApplied}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+	processes := []*domain.ProcessScheduling{{PID: 1234, ExecutionTime: 5000, StrategyID: heavy.ID.Hex()}}
+
+	k8sAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, opt *domain.QueryPodsOptions) ([]*domain.Pod, error) {
+		assert.Equal(t, []string{"default"}, opt.K8SNamespace)
+		assert.Equal(t, []string{"web-1"}, opt.PodNames)
+		return []*domain.Pod{{PodID: "pod-1", Name: "web-1", K8SNamespace: "default", NodeID: "node-1"}}, nil
+	}).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		assert.Equal(t, []string{"pod-1"}, opt.PodIDs)
+		opt.Result = []*domain.ScheduleIntent{broadIntent, failed, heavyIntent}
+		return nil
+	}).Once()
+	repo.EXPECT().QueryStrategies(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		assert.Equal(t, []bson.ObjectID{failed.StrategyID, heavy.ID, broad.ID}, opt.IDs)
+		opt.Result = []*domain.ScheduleStrategy{broad, heavy}
+		return nil
+	}).Once()
+	mockNodeDecisionMaker(k8sAdapter, dmPod)
+	dmAdapter.EXPECT().ListPodSchedulingIntents(mock.Anything, dmPod, "pod-1").Return(processes, nil).Once()
+
+	policy, err := svc.GetEffectivePolicy(ctx, &domain.EffectivePolicyQuery{K8sNamespace: "default", PodName: "web-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "pod-1", policy.PodID)
+	assert.Equal(t, "node-1", policy.NodeID)
+	assert.Equal(t, []*domain.ScheduleIntent{failed, heavyIntent, broadIntent}, policy.Intents)
+	assert.Equal(t, heavyIntent.ID, policy.WinningIntentID, "a failed intent never wins")
+	assert.Equal(t, []*domain.ScheduleStrategy{heavy, broad}, policy.Strategies)
+	assert.Equal(t, processes, policy.Processes)
+	assert.Empty(t, policy.DecisionMakerError)
+}
+
+// TestGetEffectivePolicyOfRemovedPod tests that a pod no longer in the cluster is explained from its intents and a decision maker failure is reported
+func TestGetEffectivePolicyOfRemovedPod(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newReconcileTestService(t)
+	ctx := context.Background()
+	intent := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, StrategyID: bson.NewObjectID(), PodID: "pod-1", PodName: "web-1", NodeID: "node-1"}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+
+	k8sAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return(nil, nil).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = []*domain.ScheduleIntent{intent}
+		return nil
+	}).Once()
+	repo.EXPECT().QueryStrategies(mock.Anything, mock.Anything).Return(nil).Once()
+	mockNodeDecisionMaker(k8sAdapter, dmPod)
+	dmAdapter.EXPECT().ListPodSchedulingIntents(mock.Anything, dmPod, "pod-1").Return(nil, errors.New("connection refused")).Once()
+
+	policy, err := svc.GetEffectivePolicy(ctx, &domain.EffectivePolicyQuery{PodID: "pod-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "web-1", policy.PodName)
+	assert.Equal(t, intent.ID, policy.WinningIntentID)
+	assert.Empty(t, policy.Processes)
+	assert.Contains(t, policy.DecisionMakerError, "connection refused")
+
+	_, err = svc.GetEffectivePolicy(ctx, &domain.EffectivePolicyQuery{PodName: "web-1"})
+	httpErr, ok := errs.IsHTTPStatusError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+}
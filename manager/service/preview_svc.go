@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/manager/errs"
+	"github.com/Gthulhu/api/pkg/logger"
+)
+
+// PreviewScheduleStrategy resolves the pods a strategy would target, grouped by node, without persisting anything.
+// When withProcesses is set the decision maker of every node is asked which processes of those pods match the command regex.
+func (svc *Service) PreviewScheduleStrategy(ctx context.Context, strategy *domain.ScheduleStrategy, withProcesses bool) (*domain.StrategyPreview, error) {
+	err := svc.applyStrategyTemplate(ctx, strategy)
+	if err != nil {
+		return nil, err
+	}
+	err = strategy.ValidateLabelSelector()
+	if err != nil {
+		return nil, errs.NewHTTPStatusError(http.StatusBadRequest, "invalid label selector", err)
+	}
+	err = strategy.ValidateSchedule()
+	if err != nil {
+		return nil, errs.NewHTTPStatusError(http.StatusBadRequest, "invalid schedule", err)
+	}
+	pods, err := svc.K8SAdapter.QueryPods(ctx, strategy.PodsQuery())
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &domain.StrategyPreview{Nodes: []*domain.NodePreview{}}
+	nodeIntents := make(map[string][]*domain.ScheduleIntent)
+	for _, pod := range pods {
+		idx := slices.IndexFunc(preview.Nodes, func(node *domain.NodePreview) bool {
+			return node.NodeID == pod.NodeID
+		})
+		if idx < 0 {
+			preview.Nodes = append(preview.Nodes, &domain.NodePreview{NodeID: pod.NodeID})
+			idx = len(preview.Nodes) - 1
+		}
+		preview.Nodes[idx].Pods = append(preview.Nodes[idx].Pods, &domain.PodPreview{
+			PodID:        pod.PodID,
+			PodName:      pod.Name,
+			K8sNamespace: pod.K8SNamespace,
+			Containers:   pod.Containers,
+		})
+		intent := domain.NewScheduleIntent(strategy, pod)
+		nodeIntents[pod.NodeID] = append(nodeIntents[pod.NodeID], &intent)
+	}
+	slices.SortFunc(preview.Nodes, func(a, b *domain.NodePreview) int {
+		return strings.Compare(a.NodeID, b.NodeID)
+	})
+	if !withProcesses {
+		return preview, nil
+	}
+
+	var wg sync.WaitGroup
+	for _, node := range preview.Nodes {
+		wg.Add(1)
+		go func(node *domain.NodePreview) {
+			defer wg.Done()
+			err := svc.previewNodeProcesses(ctx, node, nodeIntents[node.NodeID])
+			if err != nil {
+				logger.Logger(ctx).Warn().Err(err).Msgf("failed to preview the processes of node %s", node.NodeID)
+				node.Error = err.Error()
+			}
+		}(node)
+	}
+	wg.Wait()
+	return preview, nil
+}
+
+// previewNodeProcesses fills the processes of the pods of the node with the processes its decision maker would bind the intents to
+func (svc *Service) previewNodeProcesses(ctx context.Context, node *domain.NodePreview, intents []*domain.ScheduleIntent) error {
+	dmPods, err := svc.queryDecisionMakers(ctx, []string{node.NodeID})
+	if err != nil {
+		return err
+	}
+	if len(dmPods) == 0 {
+		return fmt.Errorf("no decision maker pod found on node %s", node.NodeID)
+	}
+	previews, err := svc.DMAdapter.PreviewSchedulingIntents(ctx, dmPods[0], intents)
+	if err != nil {
+		return fmt.Errorf("preview scheduling intents on decision maker %s: %w", dmPods[0].Host, err)
+	}
+	for _, intentPreview := range previews {
+		if intentPreview.Error != "" {
+			return fmt.Errorf("decision maker %s rejected the intent of pod %s: %s", dmPods[0].Host, intentPreview.PodID, intentPreview.Error)
+		}
+		idx := slices.IndexFunc(node.Pods, func(pod *domain.PodPreview) bool {
+			return pod.PodID == intentPreview.PodID
+		})
+		if idx >= 0 {
+			node.Pods[idx].Processes = append(node.Pods[idx].Processes, intentPreview.Processes...)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPreviewScheduleStrategy tests that a preview groups the matching pods by node and reports the processes of every decision maker
+func TestPreviewScheduleStrategy(t *testing.T) {
+	svc, _, k8sAdapter, dmAdapter := newReconcileTestService(t)
+	ctx := context.Background()
+	strategy := &domain.ScheduleStrategy{CommandRegex: "^nginx", LabelSelectors: []domain.LabelSelector{{Key: "app", Value: "web"}}}
+	pods := []*domain.Pod{
+		{PodID: "pod-1", Name: "web-1", NodeID: "node-2", Containers: []domain.Container{{ContainerID: "c1", Name: "nginx"}}},
+		{PodID: "pod-2", Name: "web-2", NodeID: "node-1"},
+		{PodID: "pod-3", Name: "web-3", NodeID: "node-2"},
+	}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-2", Host: "10.0.0.2", Port: 8080}
+
+	k8sAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return(pods, nil).Once()
+	k8sAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.MatchedBy(func(opt *domain.QueryDecisionMakerPodsOptions) bool {
+		return opt.NodeIDs[0] == "node-1"
+	})).Return(nil, nil).Once()
+	mockNodeDecisionMaker(k8sAdapter, dmPod)
+	dmAdapter.EXPECT().PreviewSchedulingIntents(mock.Anything, dmPod, mock.Anything).RunAndReturn(func(_ context.Context, _ *domain.DecisionMakerPod, intents []*domain.ScheduleIntent) ([]*domain.IntentPreview, error) {
+		require.Len(t, intents, 2)
+		assert.Equal(t, "^nginx", intents[0].CommandRegex)
+		return []*domain.IntentPreview{
+			{PodID: "pod-1", CommandRegex: "^nginx", Processes: []*domain.ProcessPreview{{PID: 1234, Command: "nginx", ContainerID: "c1"}}},
+			{PodID: "pod-3", CommandRegex: "^nginx"},
+		}, nil
+	}).Once()
+
+	preview, err := svc.PreviewScheduleStrategy(ctx, strategy, true)
+	require.NoError(t, err)
+	require.Len(t, preview.Nodes, 2)
+	assert.Equal(t, "node-1", preview.Nodes[0].NodeID)
+	assert.Contains(t, preview.Nodes[0].Error, "no decision maker pod found")
+	require.Len(t, preview.Nodes[0].Pods, 1)
+	assert.Equal(t, "node-2", preview.Nodes[1].NodeID)
+	assert.Empty(t, preview.Nodes[1].Error)
+	require.Len(t, preview.Nodes[1].Pods, 2)
+	assert.Equal(t, []*domain.ProcessPreview{{PID: 1234, Command: "nginx", ContainerID: "c1"}}, preview.Nodes[1].Pods[0].Processes)
+	assert.Equal(t, pods[0].Containers, preview.Nodes[1].Pods[0].Containers)
+	assert.Empty(t, preview.Nodes[1].Pods[1].Processes)
+}
+
+// TestPreviewScheduleStrategyWithoutPods tests that a strategy matching no pod is previewed instead of rejected
+func TestPreviewScheduleStrategyWithoutPods(t *testing.T) {
+	svc, _, k8sAdapter, _ := newReconcileTestService(t)
+	k8sAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return(nil, nil).Once()
+
+	preview, err := svc.PreviewScheduleStrategy(context.Background(), &domain.ScheduleStrategy{}, true)
+	require.NoError(t, err)
+	assert.Empty(t, preview.Nodes)
+}
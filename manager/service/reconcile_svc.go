@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var decisionMakerLabel = domain.LabelSelector{
+	Key:   "app",
+	Value: "decisionmaker",
+}
+
+// ReconcilePodEvent re-evaluates every stored schedule strategy against the pod of the event,
+// creates or deletes the pod's schedule intents accordingly and hands the delta to the delivery queue of the pod's node.
+func (svc *Service) ReconcilePodEvent(ctx context.Context, event *domain.PodEvent) error {
+	if event == nil || event.Pod == nil || event.Pod.PodID == "" {
+		return nil
+	}
+	pod := event.Pod
+
+	intentQueryOpt := &domain.QueryIntentOptions{
+		PodIDs: []string{pod.PodID},
+	}
+	err := svc.Repo.QueryIntents(ctx, intentQueryOpt)
+	if err != nil {
+		return fmt.Errorf("query intents of pod %s: %w", pod.PodID, err)
+	}
+
+	if event.Type == domain.PodEventDeleted {
+		return svc.removePodIntents(ctx, pod, intentQueryOpt.Result)
+	}
+
+	// pods that are not bound to a node yet have no decision maker to talk to,
+	// they are reconciled again once the scheduler assigns them.
+	if pod.NodeID == "" {
+		return nil
+	}
+
+	strategyQueryOpt := &domain.QueryStrategyOptions{}
+	err = svc.Repo.QueryStrategies(ctx, strategyQueryOpt)
+	if err != nil {
+		return fmt.Errorf("query strategies: %w", err)
+	}
+
+	existingIntents := make(map[bson.ObjectID]*domain.ScheduleIntent, len(intentQueryOpt.Result))
+	for _, intent := range intentQueryOpt.Result {
+		existingIntents[intent.StrategyID] = intent
+	}
+
+	newIntents := make([]*domain.ScheduleIntent, 0)
+	for _, strategy := range strategyQueryOpt.Result {
+		if !strategy.MatchesPod(pod) {
+			continue
+		}
+		intent := domain.NewScheduleIntent(strategy, pod)
+		// the intent of the annotation strategy is replaced when the hints of the pod change,
+		// and the intent restricted to containers when they restart with new IDs
+		if existing, ok := existingIntents[strategy.ID]; ok && (!strategy.Annotation || sameSchedulingParams(existing, &intent)) && slices.Equal(existing.ContainerIDs, intent.ContainerIDs) {
+			delete(existingIntents, strategy.ID)
+			continue
+		}
+		newIntents = append(newIntents, &intent)
+	}
+
+	staleIntents := make([]*domain.ScheduleIntent, 0, len(existingIntents))
+	staleIntentIDs := make([]bson.ObjectID, 0, len(existingIntents))
+	for _, intent := range existingIntents {
+		staleIntents = append(staleIntents, intent)
+		staleIntentIDs = append(staleIntentIDs, intent.ID)
+	}
+
+	if len(newIntents) == 0 && len(staleIntentIDs) == 0 {
+		return nil
+	}
+
+	if len(staleIntentIDs) > 0 {
+		err = svc.Repo.DeleteIntents(ctx, staleIntentIDs)
+		if err != nil {
+			return fmt.Errorf("delete stale intents of pod %s: %w", pod.PodID, err)
+		}
+	}
+	if len(newIntents) > 0 {
+		err = svc.Repo.InsertIntents(ctx, newIntents)
+		if err != nil {
+			return fmt.Errorf("insert intents of pod %s: %w", pod.PodID, err)
+		}
+	}
+	logger.Logger(ctx).Info().Msgf("reconciled pod %s/%s: %d new intents, %d stale intents", pod.K8SNamespace, pod.Name, len(newIntents), len(staleIntentIDs))
+
+	// the new intents are committed as initialized, they replace the stale ones on the decision maker before those are removed,
+	// and the intents of the strategies outside their active time stay off the decision maker
+	if slices.ContainsFunc(newIntents, func(intent *domain.ScheduleIntent) bool { return !intent.Dormant() }) {
+		svc.deliverNodeIntents(ctx, pod.NodeID)
+	}
+	svc.removeStaleIntents(ctx, slices.DeleteFunc(staleIntents, (*domain.ScheduleIntent).Dormant))
+	return nil
+}
+
+func sameSchedulingParams(a, b *domain.ScheduleIntent) bool {
+	return a.Priority == b.Priority && a.ExecutionTime == b.ExecutionTime
+}
+
+// removePodIntents deletes the intents of a deleted pod and asks the decision maker of its node to forget them.
+func (svc *Service) removePodIntents(ctx context.Context, pod *domain.Pod, intents []*domain.ScheduleIntent) error {
+	if len(intents) == 0 {
+		return nil
+	}
+	intentIDs := make([]bson.ObjectID, 0, len(intents))
+	for _, intent := range intents {
+		intentIDs = append(intentIDs, intent.ID)
+	}
+	err := svc.Repo.DeleteIntents(ctx, intentIDs)
+	if err != nil {
+		return fmt.Errorf("delete intents of pod %s: %w", pod.PodID, err)
+	}
+	logger.Logger(ctx).Info().Msgf("deleted %d intents of removed pod %s/%s", len(intentIDs), pod.K8SNamespace, pod.Name)
+
+	svc.removeStaleIntents(ctx, intents)
+	return nil
+}
+
+// queryDecisionMakers returns the decision maker pods running on the given nodes
+func (svc *Service) queryDecisionMakers(ctx context.Context, nodeIDs []string) ([]*domain.DecisionMakerPod, error) {
+	dmQueryOpt := &domain.QueryDecisionMakerPodsOptions{
+		DecisionMakerLabel: decisionMakerLabel,
+		NodeIDs:            nodeIDs,
+	}
+	dmPods, err := svc.K8SAdapter.QueryDecisionMakerPods(ctx, dmQueryOpt)
+	if err != nil {
+		return nil, fmt.Errorf("query decision maker pods: %w", err)
+	}
+	return dmPods, nil
+}
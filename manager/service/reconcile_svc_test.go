@@ -0,0 +1,423 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Gthulhu/api/config"
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newReconcileTestService(t *testing.T) (*Service, *domain.MockRepository, *domain.MockK8SAdapter, *domain.MockDecisionMakerAdapter) {
+	logger.InitLogger()
+	repo := domain.NewMockRepository(t)
+	k8sAdapter := domain.NewMockK8SAdapter(t)
+	dmAdapter := domain.NewMockDecisionMakerAdapter(t)
+	svc := &Service{
+		Repo:       repo,
+		K8SAdapter: k8sAdapter,
+		DMAdapter:  dmAdapter,
+		delivery:   newIntentDeliveryQueue(config.DeliveryConfig{}),
+	}
+	return svc, repo, k8sAdapter, dmAdapter
+}
+
+// mockInsertedIntentsPending makes the repository assign an ID to the inserted intents of the pod and return them as the pending intents of its node
+func mockInsertedIntentsPending(repo *domain.MockRepository, nodeID string, matches func([]*domain.ScheduleIntent) bool) {
+	var inserted []*domain.ScheduleIntent
+	repo.EXPECT().InsertIntents(mock.Anything, mock.MatchedBy(matches)).RunAndReturn(func(_ context.Context, intents []*domain.ScheduleIntent) error {
+		for _, intent := range intents {
+			intent.ID = bson.NewObjectID()
+		}
+		inserted = intents
+		return nil
+	}).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, mock.MatchedBy(func(opt *domain.QueryIntentOptions) bool {
+		return slices.Equal(opt.NodeIDs, []string{nodeID})
+	})).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = inserted
+		return nil
+	}).Once()
+}
+
+// mockPodIntents makes the repository return the given intents when the intents of a pod are queried
+func mockPodIntents(repo *domain.MockRepository, intents ...*domain.ScheduleIntent) {
+	repo.EXPECT().QueryIntents(mock.Anything, mock.MatchedBy(func(opt *domain.QueryIntentOptions) bool {
+		return len(opt.PodIDs) > 0
+	})).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = intents
+		return nil
+	}).Once()
+}
+
+func TestReconcilePodEventCreatesIntentsForNewPod(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newReconcileTestService(t)
+	ctx := context.Background()
+
+	strategy := &domain.ScheduleStrategy{
+		BaseEntity:     domain.BaseEntity{ID: bson.NewObjectID()},
+		LabelSelectors: []domain.LabelSelector{{Key: "app", Value: "web"}},
+		Priority:       10,
+		ExecutionTime:  5000,
+	}
+	otherStrategy := &domain.ScheduleStrategy{
+		BaseEntity:     domain.BaseEntity{ID: bson.NewObjectID()},
+		LabelSelectors: []domain.LabelSelector{{Key: "app", Value: "db"}},
+	}
+	pod := &domain.Pod{PodID: "pod-1", Name: "web-1", K8SNamespace: "default", NodeID: "node-1", Labels: map[string]string{"app": "web"}}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+
+	mockPodIntents(repo)
+	repo.EXPECT().QueryStrategies(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		opt.Result = []*domain.ScheduleStrategy{strategy, otherStrategy}
+		return nil
+	}).Once()
+	mockInsertedIntentsPending(repo, "node-1", func(intents []*domain.ScheduleIntent) bool {
+		return len(intents) == 1 && intents[0].StrategyID == strategy.ID && intents[0].PodID == "pod-1" && intents[0].Priority == 10 &&
+			intents[0].State == domain.IntentStateInitialized
+	})
+	k8sAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{dmPod}, nil).Once()
+	dmAdapter.EXPECT().SendSchedulingIntent(mock.Anything, dmPod, mock.Anything).RunAndReturn(func(_ context.Context, _ *domain.DecisionMakerPod, intents []*domain.ScheduleIntent) ([]*domain.IntentResult, error) {
+		return []*domain.IntentResult{{PodID: "pod-1", StrategyID: strategy.ID.Hex(), State: domain.IntentStateApplied, MatchedPIDs: 1}}, nil
+	}).Once()
+	repo.EXPECT().BulkUpdateIntentsState(mock.Anything, mock.MatchedBy(func(updates []*domain.IntentStateUpdate) bool {
+		return len(updates) == 1 && updates[0].State == domain.IntentStateApplied && updates[0].MatchedPIDs == 1
+	})).Return(nil).Once()
+
+	err := svc.ReconcilePodEvent(ctx, &domain.PodEvent{Type: domain.PodEventAdded, Pod: pod})
+	require.NoError(t, err)
+}
+
+func TestReconcilePodEventRemovesStaleIntents(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newReconcileTestService(t)
+	ctx := context.Background()
+
+	strategy := &domain.ScheduleStrategy{
+		BaseEntity:     domain.BaseEntity{ID: bson.NewObjectID()},
+		LabelSelectors: []domain.LabelSelector{{Key: "app", Value: "web"}},
+	}
+	staleIntent := &domain.ScheduleIntent{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()},
+		StrategyID: strategy.ID,
+		PodID:      "pod-1",
+		NodeID:     "node-1",
+	}
+	// the pod lost the label selected by the strategy
+	pod := &domain.Pod{PodID: "pod-1", NodeID: "node-1", Labels: map[string]string{"app": "batch"}}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+
+	mockPodIntents(repo, staleIntent)
+	repo.EXPECT().QueryStrategies(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		opt.Result = []*domain.ScheduleStrategy{strategy}
+		return nil
+	}).Once()
+	repo.EXPECT().DeleteIntents(mock.Anything, []bson.ObjectID{staleIntent.ID}).Return(nil).Once()
+	mockPodIntents(repo)
+	k8sAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{dmPod}, nil).Once()
+	dmAdapter.EXPECT().DeleteSchedulingIntents(mock.Anything, dmPod, &domain.DeleteIntentsRequest{Intents: []*domain.ScheduleIntent{staleIntent}}).Return(nil).Once()
+
+	err := svc.ReconcilePodEvent(ctx, &domain.PodEvent{Type: domain.PodEventUpdated, Pod: pod})
+	require.NoError(t, err)
+}
+
+// TestReconcilePodEventNamespaceSelector tests that the intents follow the labels of the namespace of the pod
+func TestReconcilePodEventNamespaceSelector(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newReconcileTestService(t)
+	ctx := context.Background()
+
+	strategy := &domain.ScheduleStrategy{
+		BaseEntity:        domain.BaseEntity{ID: bson.NewObjectID()},
+		NamespaceSelector: &domain.LabelSelectorSpec{MatchLabels: map[string]string{"team": "payments"}},
+	}
+	intent := &domain.ScheduleIntent{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()},
+		StrategyID: strategy.ID,
+		PodID:      "pod-1",
+		NodeID:     "node-1",
+	}
+	pod := &domain.Pod{PodID: "pod-1", K8SNamespace: "billing", NodeID: "node-1", NamespaceLabels: map[string]string{"team": "payments"}}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+
+	// the namespace still carries the label, nothing changes
+	repo.EXPECT().QueryIntents(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = []*domain.ScheduleIntent{intent}
+		return nil
+	}).Twice()
+	repo.EXPECT().QueryStrategies(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		opt.Result = []*domain.ScheduleStrategy{strategy}
+		return nil
+	}).Twice()
+	err := svc.ReconcilePodEvent(ctx, &domain.PodEvent{Type: domain.PodEventUpdated, Pod: pod})
+	require.NoError(t, err)
+
+	// the namespace lost the label
+	pod.NamespaceLabels = map[string]string{"team": "ledger"}
+	repo.EXPECT().DeleteIntents(mock.Anything, []bson.ObjectID{intent.ID}).Return(nil).Once()
+	mockPodIntents(repo)
+	k8sAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{dmPod}, nil).Once()
+	dmAdapter.EXPECT().DeleteSchedulingIntents(mock.Anything, dmPod, &domain.DeleteIntentsRequest{Intents: []*domain.ScheduleIntent{intent}}).Return(nil).Once()
+	err = svc.ReconcilePodEvent(ctx, &domain.PodEvent{Type: domain.PodEventUpdated, Pod: pod})
+	require.NoError(t, err)
+}
+
+func TestReconcilePodEventDeletedPod(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newReconcileTestService(t)
+	ctx := context.Background()
+
+	intent := &domain.ScheduleIntent{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()},
+		StrategyID: bson.NewObjectID(),
+		PodID:      "pod-1",
+		NodeID:     "node-1",
+	}
+	pod := &domain.Pod{PodID: "pod-1", NodeID: "node-1"}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+
+	mockPodIntents(repo, intent)
+	repo.EXPECT().DeleteIntents(mock.Anything, []bson.ObjectID{intent.ID}).Return(nil).Once()
+	mockPodIntents(repo)
+	k8sAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{dmPod}, nil).Once()
+	dmAdapter.EXPECT().DeleteSchedulingIntents(mock.Anything, dmPod, &domain.DeleteIntentsRequest{Intents: []*domain.ScheduleIntent{intent}}).Return(nil).Once()
+
+	err := svc.ReconcilePodEvent(ctx, &domain.PodEvent{Type: domain.PodEventDeleted, Pod: pod})
+	require.NoError(t, err)
+}
+
+func TestReconcilePodEventSkipsUnscheduledPod(t *testing.T) {
+	svc, repo, _, _ := newReconcileTestService(t)
+
+	repo.EXPECT().QueryIntents(mock.Anything, mock.Anything).Return(nil).Once()
+
+	err := svc.ReconcilePodEvent(context.Background(), &domain.PodEvent{Type: domain.PodEventAdded, Pod: &domain.Pod{PodID: "pod-1"}})
+	require.NoError(t, err)
+}
+
+// TestReconcilePodEventAnnotationHints tests that the annotation strategy synthesizes an intent from the hints of the pod
+// and replaces it when the hints change
+func TestReconcilePodEventAnnotationHints(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newReconcileTestService(t)
+	ctx := context.Background()
+
+	strategy := domain.NewAnnotationStrategy()
+	strategy.ID = bson.NewObjectID()
+	intent := &domain.ScheduleIntent{
+		BaseEntity:    domain.BaseEntity{ID: bson.NewObjectID()},
+		StrategyID:    strategy.ID,
+		PodID:         "pod-1",
+		NodeID:        "node-1",
+		Priority:      1,
+		ExecutionTime: 5000000,
+	}
+	pod := &domain.Pod{PodID: "pod-1", NodeID: "node-1", Hints: &domain.SchedulingHints{Priority: 1, ExecutionTime: 5000000}}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+
+	repo.EXPECT().QueryIntents(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = []*domain.ScheduleIntent{intent}
+		return nil
+	}).Twice()
+	repo.EXPECT().QueryStrategies(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		opt.Result = []*domain.ScheduleStrategy{strategy}
+		return nil
+	}).Twice()
+	// the hints are unchanged, nothing changes
+	err := svc.ReconcilePodEvent(ctx, &domain.PodEvent{Type: domain.PodEventUpdated, Pod: pod})
+	require.NoError(t, err)
+
+	// the execution time annotation changed
+	pod.Hints = &domain.SchedulingHints{Priority: 1, ExecutionTime: 20000000}
+	repo.EXPECT().DeleteIntents(mock.Anything, []bson.ObjectID{intent.ID}).Return(nil).Once()
+	mockInsertedIntentsPending(repo, "node-1", func(intents []*domain.ScheduleIntent) bool {
+		return len(intents) == 1 && intents[0].StrategyID == strategy.ID && intents[0].Priority == 1 && intents[0].ExecutionTime == 20000000
+	})
+	k8sAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{dmPod}, nil).Once()
+	dmAdapter.EXPECT().SendSchedulingIntent(mock.Anything, dmPod, mock.Anything).Return(nil, nil).Once()
+	repo.EXPECT().BulkUpdateIntentsState(mock.Anything, mock.Anything).Return(nil).Once()
+	// the replaced intent shares the pod and command regex of the new one, which is delivered again instead of being deleted
+	var replacement *domain.ScheduleIntent
+	repo.EXPECT().QueryIntents(mock.Anything, mock.MatchedBy(func(opt *domain.QueryIntentOptions) bool {
+		return len(opt.PodIDs) > 0
+	})).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		replacement = &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, StrategyID: strategy.ID, PodID: "pod-1", NodeID: "node-1", State: domain.IntentStateSent}
+		opt.Result = []*domain.ScheduleIntent{replacement}
+		return nil
+	}).Once()
+	repo.EXPECT().BulkUpdateIntentsState(mock.Anything, mock.MatchedBy(func(updates []*domain.IntentStateUpdate) bool {
+		return len(updates) == 1 && updates[0].IntentID == replacement.ID && updates[0].State == domain.IntentStateInitialized
+	})).Return(nil).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, mock.MatchedBy(func(opt *domain.QueryIntentOptions) bool {
+		return len(opt.NodeIDs) > 0
+	})).Return(nil).Once()
+	err = svc.ReconcilePodEvent(ctx, &domain.PodEvent{Type: domain.PodEventUpdated, Pod: pod})
+	require.NoError(t, err)
+}
+
+// TestReconcilePodEventWorkload tests that a strategy targeting a workload only matches its pods and the intents record the workload
+func TestReconcilePodEventWorkload(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newReconcileTestService(t)
+	ctx := context.Background()
+
+	workload := &domain.WorkloadRef{Kind: domain.WorkloadKindDeployment, Namespace: "default", Name: "web"}
+	strategy := &domain.ScheduleStrategy{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()},
+		Workload:   workload,
+		Priority:   1,
+	}
+	otherStrategy := &domain.ScheduleStrategy{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()},
+		Workload:   &domain.WorkloadRef{Kind: domain.WorkloadKindDeployment, Namespace: "default", Name: "db"},
+	}
+	pod := &domain.Pod{PodID: "pod-1", K8SNamespace: "default", NodeID: "node-1", Workload: &domain.WorkloadRef{Kind: domain.WorkloadKindDeployment, Namespace: "default", Name: "web"}}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+
+	mockPodIntents(repo)
+	repo.EXPECT().QueryStrategies(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		opt.Result = []*domain.ScheduleStrategy{strategy, otherStrategy}
+		return nil
+	}).Once()
+	mockInsertedIntentsPending(repo, "node-1", func(intents []*domain.ScheduleIntent) bool {
+		return len(intents) == 1 && intents[0].StrategyID == strategy.ID && workload.Equal(intents[0].Workload)
+	})
+	k8sAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{dmPod}, nil).Once()
+	dmAdapter.EXPECT().SendSchedulingIntent(mock.Anything, dmPod, mock.Anything).Return(nil, nil).Once()
+	repo.EXPECT().BulkUpdateIntentsState(mock.Anything, mock.Anything).Return(nil).Once()
+
+	err := svc.ReconcilePodEvent(ctx, &domain.PodEvent{Type: domain.PodEventAdded, Pod: pod})
+	require.NoError(t, err)
+}
+
+// TestReconcilePodEventContainers tests that a strategy naming containers waits for them to start,
+// and that its intent is replaced when they restart with new IDs
+func TestReconcilePodEventContainers(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newReconcileTestService(t)
+	ctx := context.Background()
+
+	strategy := &domain.ScheduleStrategy{
+		BaseEntity:     domain.BaseEntity{ID: bson.NewObjectID()},
+		ContainerNames: []string{"app"},
+		Priority:       1,
+	}
+	pod := &domain.Pod{PodID: "pod-1", K8SNamespace: "default", NodeID: "node-1", Containers: []domain.Container{
+		{Name: "app"},
+		{Name: "envoy", ContainerID: "containerd://envoy-1"},
+	}}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+	repo.EXPECT().QueryStrategies(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		opt.Result = []*domain.ScheduleStrategy{strategy}
+		return nil
+	}).Times(3)
+
+	// the app container is not started yet, its processes cannot be told apart
+	mockPodIntents(repo)
+	err := svc.ReconcilePodEvent(ctx, &domain.PodEvent{Type: domain.PodEventAdded, Pod: pod})
+	require.NoError(t, err)
+
+	// the app container started
+	pod.Containers[0].ContainerID = "containerd://app-1"
+	var intent *domain.ScheduleIntent
+	mockPodIntents(repo)
+	mockInsertedIntentsPending(repo, "node-1", func(intents []*domain.ScheduleIntent) bool {
+		if len(intents) != 1 {
+			return false
+		}
+		intent = intents[0]
+		return true
+	})
+	k8sAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{dmPod}, nil).Once()
+	dmAdapter.EXPECT().SendSchedulingIntent(mock.Anything, dmPod, mock.Anything).Return(nil, nil).Once()
+	repo.EXPECT().BulkUpdateIntentsState(mock.Anything, mock.Anything).Return(nil).Once()
+	err = svc.ReconcilePodEvent(ctx, &domain.PodEvent{Type: domain.PodEventUpdated, Pod: pod})
+	require.NoError(t, err)
+	require.Equal(t, []string{"app-1"}, intent.ContainerIDs)
+
+	// the app container restarted with a new ID
+	pod.Containers[0].ContainerID = "containerd://app-2"
+	previous := intent
+	mockPodIntents(repo, previous)
+	repo.EXPECT().DeleteIntents(mock.Anything, []bson.ObjectID{previous.ID}).Return(nil).Once()
+	mockInsertedIntentsPending(repo, "node-1", func(intents []*domain.ScheduleIntent) bool {
+		return len(intents) == 1 && slices.Equal(intents[0].ContainerIDs, []string{"app-2"})
+	})
+	k8sAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{dmPod}, nil).Once()
+	dmAdapter.EXPECT().SendSchedulingIntent(mock.Anything, dmPod, mock.Anything).Return(nil, nil).Once()
+	repo.EXPECT().BulkUpdateIntentsState(mock.Anything, mock.Anything).Return(nil).Once()
+	// the previous intent targets another container than the new one, the decision maker retains them apart and the previous one is deleted
+	repo.EXPECT().QueryIntents(mock.Anything, mock.MatchedBy(func(opt *domain.QueryIntentOptions) bool {
+		return len(opt.PodIDs) > 0
+	})).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = []*domain.ScheduleIntent{{
+			BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, StrategyID: strategy.ID, PodID: "pod-1", NodeID: "node-1",
+			ContainerIDs: []string{"app-2"}, State: domain.IntentStateSent,
+		}}
+		return nil
+	}).Once()
+	mockNodeDecisionMaker(k8sAdapter, dmPod)
+	dmAdapter.EXPECT().DeleteSchedulingIntents(mock.Anything, dmPod, &domain.DeleteIntentsRequest{Intents: []*domain.ScheduleIntent{previous}}).Return(nil).Once()
+	err = svc.ReconcilePodEvent(ctx, &domain.PodEvent{Type: domain.PodEventUpdated, Pod: pod})
+	require.NoError(t, err)
+}
+
+// TestReconcilePodEventDormantStrategy tests that the intent of a strategy that is not active yet is stored but not sent
+func TestReconcilePodEventDormantStrategy(t *testing.T) {
+	svc, repo, _, _ := newReconcileTestService(t)
+	ctx := context.Background()
+
+	strategy := &domain.ScheduleStrategy{
+		BaseEntity:     domain.BaseEntity{ID: bson.NewObjectID()},
+		LabelSelectors: []domain.LabelSelector{{Key: "app", Value: "web"}},
+		ActivateAt:     time.Now().Add(time.Hour).UnixMilli(),
+	}
+	pod := &domain.Pod{PodID: "pod-1", NodeID: "node-1", Labels: map[string]string{"app": "web"}}
+
+	mockPodIntents(repo)
+	repo.EXPECT().QueryStrategies(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		opt.Result = []*domain.ScheduleStrategy{strategy}
+		return nil
+	}).Once()
+	repo.EXPECT().InsertIntents(mock.Anything, mock.MatchedBy(func(intents []*domain.ScheduleIntent) bool {
+		return len(intents) == 1 && intents[0].State == domain.IntentStateScheduled
+	})).Return(nil).Once()
+
+	err := svc.ReconcilePodEvent(ctx, &domain.PodEvent{Type: domain.PodEventAdded, Pod: pod})
+	require.NoError(t, err)
+}
+
+// TestReconcilePodEventRetriesStaleIntentsRemoval tests that a stale intent the decision maker failed to forget is removed again by the delivery queue
+func TestReconcilePodEventRetriesStaleIntentsRemoval(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newReconcileTestService(t)
+	ctx := context.Background()
+
+	staleIntent := &domain.ScheduleIntent{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()},
+		StrategyID: bson.NewObjectID(),
+		PodID:      "pod-1",
+		NodeID:     "node-1",
+	}
+	pod := &domain.Pod{PodID: "pod-1", NodeID: "node-1"}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+	deleteReq := &domain.DeleteIntentsRequest{Intents: []*domain.ScheduleIntent{staleIntent}}
+
+	mockPodIntents(repo, staleIntent)
+	repo.EXPECT().QueryStrategies(mock.Anything, mock.Anything).Return(nil).Once()
+	repo.EXPECT().DeleteIntents(mock.Anything, []bson.ObjectID{staleIntent.ID}).Return(nil).Once()
+	mockPodIntents(repo)
+	k8sAdapter.EXPECT().QueryDecisionMakerPods(mock.Anything, mock.Anything).Return([]*domain.DecisionMakerPod{dmPod}, nil).Twice()
+	dmAdapter.EXPECT().DeleteSchedulingIntents(mock.Anything, dmPod, deleteReq).Return(errors.New("connection refused")).Once()
+
+	err := svc.ReconcilePodEvent(ctx, &domain.PodEvent{Type: domain.PodEventUpdated, Pod: pod})
+	require.NoError(t, err)
+	require.Equal(t, []string{"node-1"}, svc.delivery.dueRemovalNodes(time.Now().Add(time.Minute)))
+
+	// the removal is retried once its backoff elapsed
+	mockPodIntents(repo)
+	dmAdapter.EXPECT().DeleteSchedulingIntents(mock.Anything, dmPod, deleteReq).Return(nil).Once()
+	svc.delivery.removals["node-1"].nextAttemptTime = time.Now()
+	svc.retryStaleIntentsRemoval(ctx, "node-1")
+	require.Empty(t, svc.delivery.dueRemovalNodes(time.Now().Add(time.Minute)))
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ReconcileStrategyResource mirrors a SchedulingStrategy resource into the strategy it owns: the strategy is created with the resource,
+// updated when the spec of the resource changes and deleted with it. The resource only targets the pods of its own namespace.
+// The state of the strategy is written back to the resource status, an invalid spec is reported there without touching the strategy.
+func (svc *Service) ReconcileStrategyResource(ctx context.Context, event *domain.StrategyResourceEvent) error {
+	resource := event.Resource
+	queryOpt := &domain.QueryStrategyOptions{ResourceUIDs: []string{resource.UID}}
+	err := svc.Repo.QueryStrategies(ctx, queryOpt)
+	if err != nil {
+		return err
+	}
+	var current *domain.ScheduleStrategy
+	if len(queryOpt.Result) > 0 {
+		current = queryOpt.Result[0]
+	}
+
+	if event.Type == domain.StrategyResourceDeleted {
+		if current == nil {
+			return nil
+		}
+		return svc.deleteStrategy(ctx, current.ID)
+	}
+
+	strategy, err := svc.resourceStrategy(ctx, resource)
+	if err != nil {
+		logger.Logger(ctx).Warn().Err(err).Msgf("invalid SchedulingStrategy %s/%s", resource.Namespace, resource.Name)
+		return svc.K8SAdapter.UpdateStrategyResourceStatus(ctx, &resource.StrategyResourceRef, &domain.StrategyResourceStatus{
+			ObservedGeneration: resource.Generation,
+			Phase:              domain.StrategyResourcePhaseInvalid,
+			Message:            err.Error(),
+		})
+	}
+
+	switch {
+	case current == nil:
+		err = svc.deleteReplacedResourceStrategies(ctx, resource)
+		if err != nil {
+			return err
+		}
+		strategy.BaseEntity = domain.NewBaseEntity(nil, nil)
+		var pods []*domain.Pod
+		pods, err = svc.K8SAdapter.QueryPods(ctx, strategy.PodsQuery())
+		if err != nil {
+			return err
+		}
+		_, err = svc.insertStrategy(ctx, strategy, pods)
+		if err != nil {
+			return fmt.Errorf("create the strategy of SchedulingStrategy %s/%s: %w", resource.Namespace, resource.Name, err)
+		}
+		logger.Logger(ctx).Info().Msgf("created strategy %s from SchedulingStrategy %s/%s", strategy.ID.Hex(), resource.Namespace, resource.Name)
+	case len(domain.DiffStrategySpec(current, strategy)) > 0:
+		strategy.BaseEntity = current.BaseEntity
+		_, err = svc.updateStrategy(ctx, strategy)
+		if err != nil {
+			return fmt.Errorf("update the strategy of SchedulingStrategy %s/%s: %w", resource.Namespace, resource.Name, err)
+		}
+		logger.Logger(ctx).Info().Msgf("updated strategy %s from SchedulingStrategy %s/%s", strategy.ID.Hex(), resource.Namespace, resource.Name)
+	default:
+		strategy = current
+	}
+
+	status, err := svc.strategyResourceStatus(ctx, strategy)
+	if err != nil {
+		return err
+	}
+	status.ObservedGeneration = resource.Generation
+	return svc.K8SAdapter.UpdateStrategyResourceStatus(ctx, &resource.StrategyResourceRef, status)
+}
+
+// deleteReplacedResourceStrategies deletes the strategies of the previous SchedulingStrategy resources of the same namespace and name,
+// left behind when the resource was recreated before the delete event of the previous one was reconciled
+func (svc *Service) deleteReplacedResourceStrategies(ctx context.Context, resource *domain.StrategyResource) error {
+	queryOpt := &domain.QueryStrategyOptions{
+		StrategyNamespaces: []string{domain.ResourceStrategyNamespace(resource.Namespace)},
+		Names:              []string{resource.Name},
+	}
+	err := svc.Repo.QueryStrategies(ctx, queryOpt)
+	if err != nil {
+		return err
+	}
+	for _, strategy := range queryOpt.Result {
+		if strategy.Resource == nil || strategy.Resource.UID == resource.UID {
+			continue
+		}
+		err = svc.deleteStrategy(ctx, strategy.ID)
+		if err != nil {
+			return fmt.Errorf("delete the strategy of the replaced SchedulingStrategy %s/%s: %w", resource.Namespace, resource.Name, err)
+		}
+		logger.Logger(ctx).Info().Msgf("deleted strategy %s of the replaced SchedulingStrategy %s/%s", strategy.ID.Hex(), resource.Namespace, resource.Name)
+	}
+	return nil
+}
+
+// PruneStrategyResources deletes the strategies of the SchedulingStrategy resources deleted while the Manager was not watching them.
+// The strategies are queried before the resources are listed, so that a strategy created meanwhile always has its resource listed.
+func (svc *Service) PruneStrategyResources(ctx context.Context) error {
+	queryOpt := &domain.QueryStrategyOptions{FromResources: true}
+	err := svc.Repo.QueryStrategies(ctx, queryOpt)
+	if err != nil {
+		return err
+	}
+	uids, err := svc.K8SAdapter.StrategyResourceUIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, strategy := range queryOpt.Result {
+		if strategy.Resource == nil || slices.Contains(uids, strategy.Resource.UID) {
+			continue
+		}
+		err = svc.deleteStrategy(ctx, strategy.ID)
+		if err != nil {
+			return fmt.Errorf("delete the strategy of the deleted SchedulingStrategy %s/%s: %w", strategy.Resource.Namespace, strategy.Resource.Name, err)
+		}
+		logger.Logger(ctx).Info().Msgf("deleted strategy %s of the deleted SchedulingStrategy %s/%s", strategy.ID.Hex(), strategy.Resource.Namespace, strategy.Resource.Name)
+	}
+	return nil
+}
+
+// resourceStrategy builds the strategy a SchedulingStrategy resource declares
+func (svc *Service) resourceStrategy(ctx context.Context, resource *domain.StrategyResource) (*domain.ScheduleStrategy, error) {
+	if resource.SpecErr != nil {
+		return nil, resource.SpecErr
+	}
+	strategy := *resource.Spec
+	strategy.StrategyNamespace = domain.ResourceStrategyNamespace(resource.Namespace)
+	strategy.Name = resource.Name
+	strategy.K8sNamespace = []string{resource.Namespace}
+	strategy.NamespaceSelector = nil
+	if strategy.Workload != nil {
+		workload := *strategy.Workload
+		workload.Namespace = resource.Namespace
+		strategy.Workload = &workload
+	}
+	ref := resource.StrategyResourceRef
+	strategy.Resource = &ref
+
+	if resource.Template != "" {
+		queryOpt := &domain.QueryStrategyTemplateOptions{Names: []string{resource.Template}}
+		err := svc.Repo.QueryStrategyTemplates(ctx, queryOpt)
+		if err != nil {
+			return nil, err
+		}
+		if len(queryOpt.Result) == 0 {
+			return nil, fmt.Errorf("unknown template %s", resource.Template)
+		}
+		queryOpt.Result[0].ApplyTo(&strategy)
+	}
+	err := strategy.ValidateLabelSelector()
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector: %w", err)
+	}
+	err = strategy.ValidateSchedule()
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule: %w", err)
+	}
+	return &strategy, nil
+}
+
+// strategyResourceStatus summarizes the intents of the strategy: the pods and nodes they target and how many are in every state
+func (svc *Service) strategyResourceStatus(ctx context.Context, strategy *domain.ScheduleStrategy) (*domain.StrategyResourceStatus, error) {
+	intentQueryOpt := &domain.QueryIntentOptions{StrategyIDs: []bson.ObjectID{strategy.ID}}
+	err := svc.Repo.QueryIntents(ctx, intentQueryOpt)
+	if err != nil {
+		return nil, fmt.Errorf("query intents for strategy: %w", err)
+	}
+	status := &domain.StrategyResourceStatus{
+		Phase:        domain.StrategyResourcePhaseReady,
+		StrategyID:   strategy.ID.Hex(),
+		Nodes:        []string{},
+		IntentStates: map[string]int{},
+	}
+	pods := make(map[string]struct{}, len(intentQueryOpt.Result))
+	for _, intent := range intentQueryOpt.Result {
+		pods[intent.PodID] = struct{}{}
+		if !slices.Contains(status.Nodes, intent.NodeID) {
+			status.Nodes = append(status.Nodes, intent.NodeID)
+		}
+		status.IntentStates[intent.State.String()]++
+	}
+	slices.Sort(status.Nodes)
+	status.MatchedPods = len(pods)
+	return status, nil
+}
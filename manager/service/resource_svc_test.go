@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func newTestStrategyResource(spec *domain.ScheduleStrategy) *domain.StrategyResource {
+	return &domain.StrategyResource{
+		StrategyResourceRef: domain.StrategyResourceRef{UID: "uid-web", Namespace: "team-a", Name: "web"},
+		Generation:          3,
+		Spec:                spec,
+	}
+}
+
+func mockResourceStrategy(repo *domain.MockRepository, strategies ...*domain.ScheduleStrategy) {
+	repo.EXPECT().QueryStrategies(mock.Anything, &domain.QueryStrategyOptions{ResourceUIDs: []string{"uid-web"}}).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		opt.Result = strategies
+		return nil
+	}).Once()
+}
+
+// mockResourceStrategyName mocks the strategies named after the resource, queried before the strategy of the resource is created
+func mockResourceStrategyName(repo *domain.MockRepository, strategies ...*domain.ScheduleStrategy) {
+	repo.EXPECT().QueryStrategies(mock.Anything, &domain.QueryStrategyOptions{StrategyNamespaces: []string{"k8s/team-a"}, Names: []string{"web"}}).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		opt.Result = strategies
+		return nil
+	}).Once()
+}
+
+// TestReconcileStrategyResourceCreatesStrategy tests that a new resource gets a strategy scoped to its namespace and derived from its template
+func TestReconcileStrategyResourceCreatesStrategy(t *testing.T) {
+	svc, repo, k8sAdapter, _ := newReconcileTestService(t)
+	ctx := context.Background()
+	resource := newTestStrategyResource(&domain.ScheduleStrategy{MatchLabels: map[string]string{"app": "web"}})
+	resource.Template = "interactive"
+	interactive := &domain.StrategyTemplate{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, Name: "interactive", Priority: 1, ExecutionTime: 5000000}
+
+	mockResourceStrategy(repo)
+	mockResourceStrategyName(repo)
+	repo.EXPECT().QueryStrategyTemplates(mock.Anything, &domain.QueryStrategyTemplateOptions{Names: []string{"interactive"}}).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyTemplateOptions) error {
+		opt.Result = []*domain.StrategyTemplate{interactive}
+		return nil
+	}).Once()
+	k8sAdapter.EXPECT().QueryPods(mock.Anything, mock.MatchedBy(func(opt *domain.QueryPodsOptions) bool {
+		return len(opt.K8SNamespace) == 1 && opt.K8SNamespace[0] == "team-a"
+	})).Return([]*domain.Pod{}, nil).Once()
+	strategyID := bson.NewObjectID()
+	repo.EXPECT().InsertStrategyAndIntents(mock.Anything, mock.MatchedBy(func(strategy *domain.ScheduleStrategy) bool {
+		return strategy.StrategyNamespace == "k8s/team-a" && strategy.Name == "web" && strategy.Resource.UID == "uid-web" &&
+			strategy.TemplateID == interactive.ID && strategy.ExecutionTime == 5000000
+	}), []*domain.ScheduleIntent{}).RunAndReturn(func(_ context.Context, strategy *domain.ScheduleStrategy, _ []*domain.ScheduleIntent) error {
+		strategy.ID = strategyID
+		return nil
+	}).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, &domain.QueryIntentOptions{StrategyIDs: []bson.ObjectID{strategyID}}).Return(nil).Once()
+	k8sAdapter.EXPECT().UpdateStrategyResourceStatus(mock.Anything, &resource.StrategyResourceRef, &domain.StrategyResourceStatus{
+		ObservedGeneration: 3,
+		Phase:              domain.StrategyResourcePhaseReady,
+		StrategyID:         strategyID.Hex(),
+		Nodes:              []string{},
+		IntentStates:       map[string]int{},
+	}).Return(nil).Once()
+
+	require.NoError(t, svc.ReconcileStrategyResource(ctx, &domain.StrategyResourceEvent{Type: domain.StrategyResourceAdded, Resource: resource}))
+}
+
+// TestReconcileStrategyResourceRefreshesStatus tests that an unchanged resource only gets the state of the intents of its strategy
+func TestReconcileStrategyResourceRefreshesStatus(t *testing.T) {
+	svc, repo, k8sAdapter, _ := newReconcileTestService(t)
+	ctx := context.Background()
+	resource := newTestStrategyResource(&domain.ScheduleStrategy{MatchLabels: map[string]string{"app": "web"}, Priority: 1})
+	current := &domain.ScheduleStrategy{
+		BaseEntity:        domain.BaseEntity{ID: bson.NewObjectID()},
+		StrategyNamespace: "k8s/team-a",
+		Name:              "web",
+		K8sNamespace:      []string{"team-a"},
+		MatchLabels:       map[string]string{"app": "web"},
+		Priority:          1,
+		Resource:          &resource.StrategyResourceRef,
+	}
+
+	mockResourceStrategy(repo, current)
+	repo.EXPECT().QueryIntents(mock.Anything, &domain.QueryIntentOptions{StrategyIDs: []bson.ObjectID{current.ID}}).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = []*domain.ScheduleIntent{
+			{PodID: "pod-1", NodeID: "node-2", State: domain.IntentStateApplied},
+			{PodID: "pod-2", NodeID: "node-1", State: domain.IntentStateApplied},
+			{PodID: "pod-2", NodeID: "node-1", State: domain.IntentStateFailed},
+		}
+		return nil
+	}).Once()
+	k8sAdapter.EXPECT().UpdateStrategyResourceStatus(mock.Anything, &resource.StrategyResourceRef, &domain.StrategyResourceStatus{
+		ObservedGeneration: 3,
+		Phase:              domain.StrategyResourcePhaseReady,
+		StrategyID:         current.ID.Hex(),
+		MatchedPods:        2,
+		Nodes:              []string{"node-1", "node-2"},
+		IntentStates:       map[string]int{"Applied": 2, "Failed": 1},
+	}).Return(nil).Once()
+
+	require.NoError(t, svc.ReconcileStrategyResource(ctx, &domain.StrategyResourceEvent{Type: domain.StrategyResourceUpdated, Resource: resource}))
+}
+
+// TestReconcileStrategyResourceReportsInvalidSpec tests that an invalid resource is reported in its status and its strategy is kept
+func TestReconcileStrategyResourceReportsInvalidSpec(t *testing.T) {
+	svc, repo, k8sAdapter, _ := newReconcileTestService(t)
+	resource := newTestStrategyResource(&domain.ScheduleStrategy{Window: &domain.RecurringWindow{Cron: "0 9 * * *"}})
+
+	mockResourceStrategy(repo)
+	k8sAdapter.EXPECT().UpdateStrategyResourceStatus(mock.Anything, &resource.StrategyResourceRef, mock.MatchedBy(func(status *domain.StrategyResourceStatus) bool {
+		return status.Phase == domain.StrategyResourcePhaseInvalid && status.ObservedGeneration == 3 && status.Message != ""
+	})).Return(nil).Once()
+
+	assert.NoError(t, svc.ReconcileStrategyResource(context.Background(), &domain.StrategyResourceEvent{Type: domain.StrategyResourceAdded, Resource: resource}))
+}
+
+func TestReconcileStrategyResourceDeletesStrategy(t *testing.T) {
+	svc, repo, _, _ := newReconcileTestService(t)
+	resource := newTestStrategyResource(nil)
+	current := &domain.ScheduleStrategy{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, Resource: &resource.StrategyResourceRef}
+
+	mockResourceStrategy(repo, current)
+	repo.EXPECT().QueryIntents(mock.Anything, &domain.QueryIntentOptions{StrategyIDs: []bson.ObjectID{current.ID}}).Return(nil).Once()
+	repo.EXPECT().DeleteIntentsByStrategyID(mock.Anything, current.ID).Return(nil).Once()
+	repo.EXPECT().DeleteStrategy(mock.Anything, current.ID).Return(nil).Once()
+
+	assert.NoError(t, svc.ReconcileStrategyResource(context.Background(), &domain.StrategyResourceEvent{Type: domain.StrategyResourceDeleted, Resource: resource}))
+}
+
+// TestReconcileStrategyResourceReplacesStrategy tests that a resource recreated before the delete of the previous one was reconciled
+// replaces the strategy of the previous resource
+func TestReconcileStrategyResourceReplacesStrategy(t *testing.T) {
+	svc, repo, k8sAdapter, _ := newReconcileTestService(t)
+	resource := newTestStrategyResource(&domain.ScheduleStrategy{MatchLabels: map[string]string{"app": "web"}, Priority: 1})
+	previous := &domain.ScheduleStrategy{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()},
+		Resource:   &domain.StrategyResourceRef{UID: "uid-previous", Namespace: "team-a", Name: "web"},
+	}
+
+	mockResourceStrategy(repo)
+	mockResourceStrategyName(repo, previous)
+	repo.EXPECT().QueryIntents(mock.Anything, &domain.QueryIntentOptions{StrategyIDs: []bson.ObjectID{previous.ID}}).Return(nil).Once()
+	repo.EXPECT().DeleteIntentsByStrategyID(mock.Anything, previous.ID).Return(nil).Once()
+	repo.EXPECT().DeleteStrategy(mock.Anything, previous.ID).Return(nil).Once()
+	k8sAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return([]*domain.Pod{}, nil).Once()
+	strategyID := bson.NewObjectID()
+	repo.EXPECT().InsertStrategyAndIntents(mock.Anything, mock.MatchedBy(func(strategy *domain.ScheduleStrategy) bool {
+		return strategy.Resource.UID == "uid-web"
+	}), []*domain.ScheduleIntent{}).RunAndReturn(func(_ context.Context, strategy *domain.ScheduleStrategy, _ []*domain.ScheduleIntent) error {
+		strategy.ID = strategyID
+		return nil
+	}).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, &domain.QueryIntentOptions{StrategyIDs: []bson.ObjectID{strategyID}}).Return(nil).Once()
+	k8sAdapter.EXPECT().UpdateStrategyResourceStatus(mock.Anything, &resource.StrategyResourceRef, mock.Anything).Return(nil).Once()
+
+	require.NoError(t, svc.ReconcileStrategyResource(context.Background(), &domain.StrategyResourceEvent{Type: domain.StrategyResourceAdded, Resource: resource}))
+}
+
+// TestPruneStrategyResources tests that only the strategies of the resources missing from the cluster are deleted
+func TestPruneStrategyResources(t *testing.T) {
+	svc, repo, k8sAdapter, _ := newReconcileTestService(t)
+	kept := &domain.ScheduleStrategy{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()},
+		Resource:   &domain.StrategyResourceRef{UID: "uid-web", Namespace: "team-a", Name: "web"},
+	}
+	deleted := &domain.ScheduleStrategy{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()},
+		Resource:   &domain.StrategyResourceRef{UID: "uid-batch", Namespace: "team-a", Name: "batch"},
+	}
+
+	repo.EXPECT().QueryStrategies(mock.Anything, &domain.QueryStrategyOptions{FromResources: true}).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		opt.Result = []*domain.ScheduleStrategy{kept, deleted}
+		return nil
+	}).Once()
+	k8sAdapter.EXPECT().StrategyResourceUIDs(mock.Anything).Return([]string{"uid-web", "uid-api"}, nil).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, &domain.QueryIntentOptions{StrategyIDs: []bson.ObjectID{deleted.ID}}).Return(nil).Once()
+	repo.EXPECT().DeleteIntentsByStrategyID(mock.Anything, deleted.ID).Return(nil).Once()
+	repo.EXPECT().DeleteStrategy(mock.Anything, deleted.ID).Return(nil).Once()
+
+	assert.NoError(t, svc.PruneStrategyResources(context.Background()))
+}
+
+// TestReconcileStrategyResourceSchedule tests that the intents of a resource that is not active yet are scheduled,
+// then delivered and applied once the strategy activates
+func TestReconcileStrategyResourceSchedule(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newDeliveryTestService(t)
+	ctx := context.Background()
+	now := time.Now()
+	resource := newTestStrategyResource(&domain.ScheduleStrategy{
+		MatchLabels: map[string]string{"app": "web"},
+		Priority:    1,
+		ActivateAt:  now.Add(time.Hour).UnixMilli(),
+		ExpireAt:    now.Add(2 * time.Hour).UnixMilli(),
+	})
+	pod := &domain.Pod{PodID: "pod-1", K8SNamespace: "team-a", NodeID: "node-1", Labels: map[string]string{"app": "web"}}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+
+	var strategy *domain.ScheduleStrategy
+	var intent *domain.ScheduleIntent
+	mockResourceStrategy(repo)
+	mockResourceStrategyName(repo)
+	k8sAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return([]*domain.Pod{pod}, nil).Once()
+	repo.EXPECT().InsertStrategyAndIntents(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, inserted *domain.ScheduleStrategy, intents []*domain.ScheduleIntent) error {
+		require.Len(t, intents, 1)
+		assert.Equal(t, domain.IntentStateScheduled, intents[0].State)
+		inserted.ID = bson.NewObjectID()
+		intents[0].ID, intents[0].StrategyID = bson.NewObjectID(), inserted.ID
+		strategy, intent = inserted, intents[0]
+		return nil
+	}).Once()
+	// the scheduled intent is not pending, nothing is delivered
+	mockPendingIntents(repo, "node-1")
+	repo.EXPECT().QueryIntents(mock.Anything, mock.MatchedBy(func(opt *domain.QueryIntentOptions) bool {
+		return len(opt.PodIDs) > 0
+	})).Return(nil).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, mock.MatchedBy(func(opt *domain.QueryIntentOptions) bool {
+		return len(opt.StrategyIDs) > 0
+	})).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = []*domain.ScheduleIntent{intent}
+		return nil
+	}).Once()
+	k8sAdapter.EXPECT().UpdateStrategyResourceStatus(mock.Anything, &resource.StrategyResourceRef, mock.MatchedBy(func(status *domain.StrategyResourceStatus) bool {
+		return status.IntentStates[domain.IntentStateScheduled.String()] == 1
+	})).Return(nil).Once()
+	require.NoError(t, svc.ReconcileStrategyResource(ctx, &domain.StrategyResourceEvent{Type: domain.StrategyResourceAdded, Resource: resource}))
+
+	// the strategy activates
+	mockTimeBoundedStrategies(repo, []*domain.ScheduleStrategy{strategy}, intent)
+	repo.EXPECT().BatchUpdateIntentsDelivery(mock.Anything, []bson.ObjectID{intent.ID}, domain.IntentDelivery{State: domain.IntentStateInitialized}).RunAndReturn(func(_ context.Context, _ []bson.ObjectID, delivery domain.IntentDelivery) error {
+		intent.State = delivery.State
+		return nil
+	}).Once()
+	mockPendingIntents(repo, "node-1", intent)
+	mockNodeDecisionMaker(k8sAdapter, dmPod)
+	dmAdapter.EXPECT().SendSchedulingIntent(mock.Anything, dmPod, []*domain.ScheduleIntent{intent}).Return([]*domain.IntentResult{
+		{PodID: "pod-1", StrategyID: strategy.ID.Hex(), State: domain.IntentStateApplied, MatchedPIDs: 1},
+	}, nil).Once()
+	repo.EXPECT().BulkUpdateIntentsState(mock.Anything, []*domain.IntentStateUpdate{{IntentID: intent.ID, State: domain.IntentStateApplied, MatchedPIDs: 1}}).Return(nil).Once()
+	require.NoError(t, svc.applyStrategySchedules(ctx, time.UnixMilli(strategy.ActivateAt)))
+}
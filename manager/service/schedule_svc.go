@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/pkg/logger"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// RunStrategyScheduler evaluates the time bounds of the strategies every poll interval: the intents of a strategy that becomes active
+// are delivered to the decision makers, the intents of a strategy that leaves its active time are removed from them.
+// It blocks until ctx is cancelled.
+func (svc *Service) RunStrategyScheduler(ctx context.Context) {
+	ticker := time.NewTicker(svc.schedule.PollInterval())
+	defer ticker.Stop()
+	for {
+		err := svc.applyStrategySchedules(ctx, time.Now())
+		if err != nil {
+			logger.Logger(ctx).Warn().Err(err).Msg("failed to apply the schedules of the time-bounded strategies")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// applyStrategySchedules moves the intents of the time-bounded strategies to the state their strategy has at the given time.
+// Failed intents are left alone, a failed delivery is not retried by a new window.
+func (svc *Service) applyStrategySchedules(ctx context.Context, now time.Time) error {
+	strategyQueryOpt := &domain.QueryStrategyOptions{TimeBounded: true}
+	err := svc.Repo.QueryStrategies(ctx, strategyQueryOpt)
+	if err != nil {
+		return err
+	}
+	if len(strategyQueryOpt.Result) == 0 {
+		return nil
+	}
+	strategies := make(map[bson.ObjectID]*domain.ScheduleStrategy, len(strategyQueryOpt.Result))
+	strategyIDs := make([]bson.ObjectID, 0, len(strategyQueryOpt.Result))
+	for _, strategy := range strategyQueryOpt.Result {
+		strategies[strategy.ID] = strategy
+		strategyIDs = append(strategyIDs, strategy.ID)
+	}
+	intentQueryOpt := &domain.QueryIntentOptions{StrategyIDs: strategyIDs}
+	err = svc.Repo.QueryIntents(ctx, intentQueryOpt)
+	if err != nil {
+		return err
+	}
+
+	activated := make([]bson.ObjectID, 0)
+	activatedNodes := make([]string, 0)
+	deactivated := make([]*domain.IntentStateUpdate, 0)
+	// stale are the intents the decision makers still enforce although their strategy left its active time
+	stale := make([]*domain.ScheduleIntent, 0)
+	for _, intent := range intentQueryOpt.Result {
+		strategy, ok := strategies[intent.StrategyID]
+		if !ok || intent.State == domain.IntentStateFailed {
+			continue
+		}
+		state := strategy.IntentStateAt(now)
+		switch {
+		case state == domain.IntentStateInitialized && intent.Dormant():
+			activated = append(activated, intent.ID)
+			if !slices.Contains(activatedNodes, intent.NodeID) {
+				activatedNodes = append(activatedNodes, intent.NodeID)
+			}
+		case state != domain.IntentStateInitialized && intent.State != state:
+			deactivated = append(deactivated, &domain.IntentStateUpdate{IntentID: intent.ID, State: state})
+			if !intent.Dormant() {
+				stale = append(stale, intent)
+			}
+		}
+	}
+
+	if len(activated) > 0 {
+		// a fresh delivery, the attempts of a previous window do not count
+		err = svc.Repo.BatchUpdateIntentsDelivery(ctx, activated, domain.IntentDelivery{State: domain.IntentStateInitialized})
+		if err != nil {
+			return err
+		}
+		for _, nodeID := range activatedNodes {
+			svc.deliverNodeIntents(ctx, nodeID)
+		}
+		logger.Logger(ctx).Info().Msgf("activated %d scheduling intents of time-bounded strategies", len(activated))
+	}
+	if len(deactivated) > 0 {
+		// the intents are marked first, so that removeStaleIntents does not take them for live intents sharing the pod and command regex
+		err = svc.Repo.BulkUpdateIntentsState(ctx, deactivated)
+		if err != nil {
+			return err
+		}
+		svc.removeStaleIntents(ctx, stale)
+		logger.Logger(ctx).Info().Msgf("deactivated %d scheduling intents of time-bounded strategies", len(deactivated))
+	}
+	return nil
+}
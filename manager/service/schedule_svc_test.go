@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestStrategyIntentStateAt(t *testing.T) {
+	// 2025-01-06 is a Monday
+	now := time.Date(2025, 1, 6, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		strategy domain.ScheduleStrategy
+		want     domain.IntentState
+	}{
+		{"unbounded", domain.ScheduleStrategy{}, domain.IntentStateInitialized},
+		{"not activated yet", domain.ScheduleStrategy{ActivateAt: now.Add(time.Minute).UnixMilli()}, domain.IntentStateScheduled},
+		{"activated", domain.ScheduleStrategy{ActivateAt: now.Add(-time.Minute).UnixMilli(), ExpireAt: now.Add(time.Hour).UnixMilli()}, domain.IntentStateInitialized},
+		{"expired", domain.ScheduleStrategy{ActivateAt: now.Add(-time.Hour).UnixMilli(), ExpireAt: now.UnixMilli()}, domain.IntentStateExpired},
+		{"inside window", domain.ScheduleStrategy{Window: &domain.RecurringWindow{Cron: "0 9 * * 1-5", DurationSec: 7200}}, domain.IntentStateInitialized},
+		{"outside window", domain.ScheduleStrategy{Window: &domain.RecurringWindow{Cron: "0 9 * * 1-5", DurationSec: 3600}}, domain.IntentStateScheduled},
+		{"window on another day", domain.ScheduleStrategy{Window: &domain.RecurringWindow{Cron: "0 9 * * 6", DurationSec: 7200}}, domain.IntentStateScheduled},
+		{"window across midnight", domain.ScheduleStrategy{Window: &domain.RecurringWindow{Cron: "0 22 * * 0", DurationSec: 13 * 3600}}, domain.IntentStateInitialized},
+		{"window after expiry", domain.ScheduleStrategy{ExpireAt: now.Add(-time.Minute).UnixMilli(), Window: &domain.RecurringWindow{Cron: "0 9 * * *", DurationSec: 7200}}, domain.IntentStateExpired},
+		{"invalid window", domain.ScheduleStrategy{Window: &domain.RecurringWindow{Cron: "bad", DurationSec: 7200}}, domain.IntentStateScheduled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.strategy.IntentStateAt(now))
+		})
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	assert.NoError(t, (&domain.ScheduleStrategy{}).ValidateSchedule())
+	assert.NoError(t, (&domain.ScheduleStrategy{ActivateAt: 1000, ExpireAt: 2000, Window: &domain.RecurringWindow{Cron: "*/30 * * * *", DurationSec: 60}}).ValidateSchedule())
+	assert.Error(t, (&domain.ScheduleStrategy{ActivateAt: 2000, ExpireAt: 2000}).ValidateSchedule())
+	assert.Error(t, (&domain.ScheduleStrategy{Window: &domain.RecurringWindow{Cron: "0 9 * * *"}}).ValidateSchedule())
+	assert.Error(t, (&domain.ScheduleStrategy{Window: &domain.RecurringWindow{Cron: "0 25 * * *", DurationSec: 60}}).ValidateSchedule())
+}
+
+// mockTimeBoundedStrategies makes the repository return the given time-bounded strategies and their intents
+func mockTimeBoundedStrategies(repo *domain.MockRepository, strategies []*domain.ScheduleStrategy, intents ...*domain.ScheduleIntent) {
+	repo.EXPECT().QueryStrategies(mock.Anything, &domain.QueryStrategyOptions{TimeBounded: true}).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		opt.Result = strategies
+		return nil
+	}).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, mock.MatchedBy(func(opt *domain.QueryIntentOptions) bool {
+		return len(opt.StrategyIDs) == len(strategies)
+	})).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = intents
+		return nil
+	}).Once()
+}
+
+// TestApplyStrategySchedulesActivatesIntents tests that the scheduled intents of an activated strategy are reset and delivered
+func TestApplyStrategySchedulesActivatesIntents(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newDeliveryTestService(t)
+	now := time.Now()
+	strategy := &domain.ScheduleStrategy{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, ActivateAt: now.Add(-time.Second).UnixMilli()}
+	scheduled := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, StrategyID: strategy.ID, PodID: "pod-1", NodeID: "node-1", State: domain.IntentStateScheduled}
+	failed := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, StrategyID: strategy.ID, PodID: "pod-2", NodeID: "node-1", State: domain.IntentStateFailed}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+
+	mockTimeBoundedStrategies(repo, []*domain.ScheduleStrategy{strategy}, scheduled, failed)
+	repo.EXPECT().BatchUpdateIntentsDelivery(mock.Anything, []bson.ObjectID{scheduled.ID}, domain.IntentDelivery{State: domain.IntentStateInitialized}).Return(nil).Once()
+	activated := &domain.ScheduleIntent{BaseEntity: scheduled.BaseEntity, StrategyID: strategy.ID, PodID: "pod-1", NodeID: "node-1", State: domain.IntentStateInitialized}
+	mockPendingIntents(repo, "node-1", activated)
+	mockNodeDecisionMaker(k8sAdapter, dmPod)
+	dmAdapter.EXPECT().SendSchedulingIntent(mock.Anything, dmPod, []*domain.ScheduleIntent{activated}).Return(nil, nil).Once()
+	repo.EXPECT().BulkUpdateIntentsState(mock.Anything, []*domain.IntentStateUpdate{{IntentID: scheduled.ID, State: domain.IntentStateSent}}).Return(nil).Once()
+
+	assert.NoError(t, svc.applyStrategySchedules(context.Background(), now))
+}
+
+// TestApplyStrategySchedulesExpiresIntents tests that the intents of an expired strategy are marked as expired
+// and only the ones the decision makers enforce are deleted from them
+func TestApplyStrategySchedulesExpiresIntents(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newDeliveryTestService(t)
+	now := time.Now()
+	strategy := &domain.ScheduleStrategy{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, ExpireAt: now.Add(-time.Second).UnixMilli()}
+	applied := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, StrategyID: strategy.ID, PodID: "pod-1", NodeID: "node-1", State: domain.IntentStateApplied}
+	scheduled := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, StrategyID: strategy.ID, PodID: "pod-2", NodeID: "node-1", State: domain.IntentStateScheduled}
+	expired := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, StrategyID: strategy.ID, PodID: "pod-3", NodeID: "node-1", State: domain.IntentStateExpired}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+
+	mockTimeBoundedStrategies(repo, []*domain.ScheduleStrategy{strategy}, applied, scheduled, expired)
+	repo.EXPECT().BulkUpdateIntentsState(mock.Anything, []*domain.IntentStateUpdate{
+		{IntentID: applied.ID, State: domain.IntentStateExpired},
+		{IntentID: scheduled.ID, State: domain.IntentStateExpired},
+	}).Return(nil).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, &domain.QueryIntentOptions{PodIDs: []string{"pod-1"}}).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = []*domain.ScheduleIntent{{BaseEntity: applied.BaseEntity, PodID: "pod-1", NodeID: "node-1", State: domain.IntentStateExpired}}
+		return nil
+	}).Once()
+	mockNodeDecisionMaker(k8sAdapter, dmPod)
+	dmAdapter.EXPECT().DeleteSchedulingIntents(mock.Anything, dmPod, &domain.DeleteIntentsRequest{Intents: []*domain.ScheduleIntent{applied}}).Return(nil).Once()
+
+	assert.NoError(t, svc.applyStrategySchedules(context.Background(), now))
+}
+
+// TestApplyStrategySchedulesClosesWindow tests that the intents of a strategy outside its recurring window go back to scheduled
+func TestApplyStrategySchedulesClosesWindow(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newDeliveryTestService(t)
+	now := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+	strategy := &domain.ScheduleStrategy{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, Window: &domain.RecurringWindow{Cron: "0 9 * * *", DurationSec: 3600}}
+	sent := &domain.ScheduleIntent{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, StrategyID: strategy.ID, PodID: "pod-1", NodeID: "node-1", State: domain.IntentStateSent}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+
+	mockTimeBoundedStrategies(repo, []*domain.ScheduleStrategy{strategy}, sent)
+	repo.EXPECT().BulkUpdateIntentsState(mock.Anything, []*domain.IntentStateUpdate{{IntentID: sent.ID, State: domain.IntentStateScheduled}}).Return(nil).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, &domain.QueryIntentOptions{PodIDs: []string{"pod-1"}}).Return(nil).Once()
+	mockNodeDecisionMaker(k8sAdapter, dmPod)
+	dmAdapter.EXPECT().DeleteSchedulingIntents(mock.Anything, dmPod, &domain.DeleteIntentsRequest{Intents: []*domain.ScheduleIntent{sent}}).Return(nil).Once()
+
+	assert.NoError(t, svc.applyStrategySchedules(context.Background(), now))
+}
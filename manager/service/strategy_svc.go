@@ -3,7 +3,11 @@ package service
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/Gthulhu/api/manager/domain"
 	"github.com/Gthulhu/api/manager/errs"
@@ -12,28 +16,47 @@ import (
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
-func (svc *Service) CreateScheduleStrategy(ctx context.Context, operator *domain.Claims, strategy *domain.ScheduleStrategy) error {
+func (svc *Service) CreateScheduleStrategy(ctx context.Context, operator *domain.Claims, strategy *domain.ScheduleStrategy) ([]*domain.StrategyConflict, error) {
 	operatorID, err := operator.GetBsonObjectUID()
 	if err != nil {
-		return errors.WithMessagef(err, "invalid operator ID %s", operator.UID)
+		return nil, errors.WithMessagef(err, "invalid operator ID %s", operator.UID)
+	}
+	if domain.IsReservedStrategyNamespace(strategy.StrategyNamespace) {
+		return nil, errs.NewHTTPStatusError(http.StatusBadRequest, "the strategy namespace is reserved", nil)
 	}
-	queryOpt := &domain.QueryPodsOptions{
-		K8SNamespace:   strategy.K8sNamespace,
-		LabelSelectors: strategy.LabelSelectors,
-		CommandRegex:   strategy.CommandRegex,
+	err = svc.applyStrategyTemplate(ctx, strategy)
+	if err != nil {
+		return nil, err
+	}
+	err = strategy.ValidateLabelSelector()
+	if err != nil {
+		return nil, errs.NewHTTPStatusError(http.StatusBadRequest, "invalid label selector", err)
+	}
+	err = strategy.ValidateSchedule()
+	if err != nil {
+		return nil, errs.NewHTTPStatusError(http.StatusBadRequest, "invalid schedule", err)
+	}
+	err = svc.checkStrategyName(ctx, strategy)
+	if err != nil {
+		return nil, err
 	}
+	queryOpt := strategy.PodsQuery()
 	pods, err := svc.K8SAdapter.QueryPods(ctx, queryOpt)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(pods) == 0 {
-		return errs.NewHTTPStatusError(http.StatusNotFound, "no pods match the strategy criteria", fmt.Errorf("no pods found for the given namespaces and label selectors, opts:%+v", queryOpt))
+		return nil, errs.NewHTTPStatusError(http.StatusNotFound, "no pods match the strategy criteria", fmt.Errorf("no pods found for the given namespaces and label selectors, opts:%+v", queryOpt))
 	}
 
 	logger.Logger(ctx).Debug().Msgf("found %d pods matching the strategy criteria", len(pods))
 
 	strategy.BaseEntity = domain.NewBaseEntity(&operatorID, &operatorID)
+	return svc.insertStrategy(ctx, strategy, pods)
+}
 
+// insertStrategy persists a validated strategy with the intents of the pods it targets and delivers them to the decision makers
+func (svc *Service) insertStrategy(ctx context.Context, strategy *domain.ScheduleStrategy, pods []*domain.Pod) ([]*domain.StrategyConflict, error) {
 	intents := make([]*domain.ScheduleIntent, 0, len(pods))
 	nodeIDsMap := make(map[string]struct{})
 	nodeIDs := make([]string, 0)
@@ -46,56 +69,327 @@ func (svc *Service) CreateScheduleStrategy(ctx context.Context, operator *domain
 		}
 	}
 
-	err = svc.Repo.InsertStrategyAndIntents(ctx, strategy, intents)
+	err := svc.Repo.InsertStrategyAndIntents(ctx, strategy, intents)
 	if err != nil {
-		return fmt.Errorf("insert strategy and intents into repository: %w", err)
+		return nil, fmt.Errorf("insert strategy and intents into repository: %w", err)
 	}
 
-	dmLabel := domain.LabelSelector{
-		Key:   "app",
-		Value: "decisionmaker",
+	// the intents are committed, a node that cannot be reached now is retried by the delivery queue
+	for _, nodeID := range nodeIDs {
+		svc.deliverNodeIntents(ctx, nodeID)
 	}
+	return svc.strategyConflicts(ctx, strategy, intents), nil
+}
 
-	dmQueryOpt := &domain.QueryDecisionMakerPodsOptions{
-		DecisionMakerLabel: dmLabel,
-		NodeIDs:            nodeIDs,
+// UpdateScheduleStrategy replaces the criteria and the scheduling parameters of a strategy and propagates the difference to the decision makers.
+// The intents of the pods that still match are modified in place, new pods get an intent and the pods that no longer match lose theirs.
+// The new intents are delivered before the outdated ones are removed, so that a pod is never left without a policy during the update.
+func (svc *Service) UpdateScheduleStrategy(ctx context.Context, operator *domain.Claims, strategyID string, strategy *domain.ScheduleStrategy) ([]*domain.StrategyConflict, error) {
+	strategyObjID, err := bson.ObjectIDFromHex(strategyID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "invalid strategy ID %s", strategyID)
 	}
-	dms, err := svc.K8SAdapter.QueryDecisionMakerPods(ctx, dmQueryOpt)
+	operatorID, err := operator.GetBsonObjectUID()
 	if err != nil {
-		return err
+		return nil, errors.WithMessagef(err, "invalid operator ID %s", operator.UID)
 	}
-	if len(dms) == 0 {
-		logger.Logger(ctx).Warn().Msgf("no decision maker pods found for scheduling intents, opts:%+v", dmQueryOpt)
-		return nil
+	if domain.IsReservedStrategyNamespace(strategy.StrategyNamespace) {
+		return nil, errs.NewHTTPStatusError(http.StatusBadRequest, "the strategy namespace is reserved", nil)
 	}
 
-	logger.Logger(ctx).Debug().Msgf("found %d decision maker pods for scheduling intents", len(dms))
+	queryOpt := &domain.QueryStrategyOptions{
+		IDs:        []bson.ObjectID{strategyObjID},
+		CreatorIDs: []bson.ObjectID{operatorID},
+	}
+	err = svc.Repo.QueryStrategies(ctx, queryOpt)
+	if err != nil {
+		return nil, err
+	}
+	if len(queryOpt.Result) == 0 {
+		return nil, errs.NewHTTPStatusError(http.StatusNotFound, "strategy not found or you don't have permission to update it", nil)
+	}
+	current := queryOpt.Result[0]
+	strategy.BaseEntity = current.BaseEntity
+	strategy.UpdaterID = operatorID
+	err = svc.applyStrategyTemplate(ctx, strategy)
+	if err != nil {
+		return nil, err
+	}
+	err = svc.checkStrategyName(ctx, strategy)
+	if err != nil {
+		return nil, err
+	}
+	return svc.updateStrategy(ctx, strategy)
+}
 
-	nodeIDIntentsMap := make(map[string][]*domain.ScheduleIntent)
-	nodeIDIntentIDsMap := make(map[string][]bson.ObjectID)
-	nodeIDDMap := make(map[string]*domain.DecisionMakerPod)
-	for _, dmPod := range dms {
-		for _, intent := range intents {
-			if intent.NodeID == dmPod.NodeID {
-				nodeIDIntentIDsMap[dmPod.Host] = append(nodeIDIntentIDsMap[dmPod.Host], intent.ID)
-				nodeIDIntentsMap[dmPod.Host] = append(nodeIDIntentsMap[dmPod.Host], intent)
-				nodeIDDMap[dmPod.Host] = dmPod
-			}
+// checkStrategyName reports whether no other strategy of the strategy namespace uses the name of the strategy, unnamed strategies are not checked
+func (svc *Service) checkStrategyName(ctx context.Context, strategy *domain.ScheduleStrategy) error {
+	if strategy.Name == "" {
+		return nil
+	}
+	queryOpt := &domain.QueryStrategyOptions{StrategyNamespaces: []string{strategy.StrategyNamespace}, Names: []string{strategy.Name}}
+	err := svc.Repo.QueryStrategies(ctx, queryOpt)
+	if err != nil {
+		return err
+	}
+	for _, other := range queryOpt.Result {
+		if other.ID != strategy.ID {
+			return errs.NewHTTPStatusError(http.StatusConflict, "strategy name already exists in the strategy namespace", fmt.Errorf("strategy %s already exists in %s", strategy.Name, strategy.StrategyNamespace))
 		}
 	}
-	for host, intents := range nodeIDIntentsMap {
-		dmPod := nodeIDDMap[host]
-		err = svc.DMAdapter.SendSchedulingIntent(ctx, dmPod, intents)
+	return nil
+}
+
+// updateStrategy persists the new version of a strategy and propagates the difference of its intents to the decision makers
+func (svc *Service) updateStrategy(ctx context.Context, strategy *domain.ScheduleStrategy) ([]*domain.StrategyConflict, error) {
+	err := strategy.ValidateLabelSelector()
+	if err != nil {
+		return nil, errs.NewHTTPStatusError(http.StatusBadRequest, "invalid label selector", err)
+	}
+	err = strategy.ValidateSchedule()
+	if err != nil {
+		return nil, errs.NewHTTPStatusError(http.StatusBadRequest, "invalid schedule", err)
+	}
+	pods, err := svc.K8SAdapter.QueryPods(ctx, strategy.PodsQuery())
+	if err != nil {
+		return nil, err
+	}
+	intentQueryOpt := &domain.QueryIntentOptions{
+		StrategyIDs: []bson.ObjectID{strategy.ID},
+	}
+	err = svc.Repo.QueryIntents(ctx, intentQueryOpt)
+	if err != nil {
+		return nil, fmt.Errorf("query intents for strategy: %w", err)
+	}
+
+	diff := diffStrategyIntents(strategy, intentQueryOpt.Result, pods)
+	logger.Logger(ctx).Debug().Msgf("updating strategy %s: %d intents added, %d modified, %d removed", strategy.ID.Hex(), len(diff.added), len(diff.modified), len(diff.removed))
+
+	err = svc.Repo.UpdateStrategy(ctx, strategy)
+	if err != nil {
+		return nil, fmt.Errorf("update strategy: %w", err)
+	}
+	err = svc.Repo.InsertIntents(ctx, diff.added)
+	if err != nil {
+		return nil, fmt.Errorf("insert intents: %w", err)
+	}
+	err = svc.Repo.UpdateIntents(ctx, diff.modified)
+	if err != nil {
+		return nil, fmt.Errorf("update intents: %w", err)
+	}
+	removedIDs := make([]bson.ObjectID, 0, len(diff.removed))
+	for _, intent := range diff.removed {
+		removedIDs = append(removedIDs, intent.ID)
+	}
+	if len(removedIDs) > 0 {
+		err = svc.Repo.DeleteIntents(ctx, removedIDs)
 		if err != nil {
-			return fmt.Errorf("send scheduling intents to decision maker %s: %w", host, err)
+			return nil, fmt.Errorf("delete intents: %w", err)
+		}
+	}
+
+	// the added and modified intents replace the outdated ones on the decision makers before those are removed
+	nodeIDs := make([]string, 0)
+	for _, intent := range slices.Concat(diff.added, diff.modified) {
+		if !slices.Contains(nodeIDs, intent.NodeID) {
+			nodeIDs = append(nodeIDs, intent.NodeID)
+		}
+	}
+	for _, nodeID := range nodeIDs {
+		svc.deliverNodeIntents(ctx, nodeID)
+	}
+	svc.removeStaleIntents(ctx, diff.stale)
+
+	logger.Logger(ctx).Info().Msgf("updated strategy %s", strategy.ID.Hex())
+	intents := make([]*domain.ScheduleIntent, 0, len(pods))
+	for _, pod := range pods {
+		intent := domain.NewScheduleIntent(strategy, pod)
+		intents = append(intents, &intent)
+	}
+	return svc.strategyConflicts(ctx, strategy, intents), nil
+}
+
+// strategyIntentsDiff is the difference between the intents of a strategy and the intents of its updated version
+type strategyIntentsDiff struct {
+	added    []*domain.ScheduleIntent
+	modified []*domain.ScheduleIntent
+	removed  []*domain.ScheduleIntent
+	// stale are the intents the decision makers no longer need, i.e. the removed intents and the previous version of
+	// the modified intents that moved to another command regex, process matchers or node
+	stale []*domain.ScheduleIntent
+}
+
+func diffStrategyIntents(strategy *domain.ScheduleStrategy, intents []*domain.ScheduleIntent, pods []*domain.Pod) strategyIntentsDiff {
+	diff := strategyIntentsDiff{}
+	existing := make(map[string]*domain.ScheduleIntent, len(intents))
+	for _, intent := range intents {
+		existing[intent.PodID] = intent
+	}
+	for _, pod := range pods {
+		intent := domain.NewScheduleIntent(strategy, pod)
+		old, ok := existing[pod.PodID]
+		if !ok {
+			diff.added = append(diff.added, &intent)
+			continue
+		}
+		delete(existing, pod.PodID)
+		if sameIntentSpec(old, &intent) {
+			continue
+		}
+		intent.ID = old.ID
+		intent.CreatedTime = old.CreatedTime
+		intent.CreatorID = old.CreatorID
+		diff.modified = append(diff.modified, &intent)
+		// a modified intent outside the active time of its strategy is not delivered, its previous version must be removed
+		if old.CommandRegex != intent.CommandRegex || !slices.Equal(old.ProcessMatchers, intent.ProcessMatchers) || old.ThreadRegex != intent.ThreadRegex || old.NodeID != intent.NodeID || (intent.Dormant() && !old.Dormant()) {
+			diff.stale = append(diff.stale, old)
+		}
+	}
+	for _, intent := range intents {
+		if _, ok := existing[intent.PodID]; ok {
+			diff.removed = append(diff.removed, intent)
+			diff.stale = append(diff.stale, intent)
+		}
+	}
+	return diff
+}
+
+// sameIntentSpec reports whether two intents ask the decision maker for the same scheduling
+func sameIntentSpec(a, b *domain.ScheduleIntent) bool {
+	return a.CommandRegex == b.CommandRegex &&
+		slices.Equal(a.ProcessMatchers, b.ProcessMatchers) &&
+		a.ThreadRegex == b.ThreadRegex &&
+		a.IncludeDescendants == b.IncludeDescendants &&
+		slices.Equal(a.ContainerIDs, b.ContainerIDs) &&
+		a.Priority == b.Priority &&
+		a.ExecutionTime == b.ExecutionTime &&
+		a.NodeID == b.NodeID &&
+		a.PodName == b.PodName &&
+		slices.EqualFunc(a.Selector, b.Selector, func(x, y domain.LabelSelectorRequirement) bool {
+			return x.Key == y.Key && x.Operator == y.Operator && slices.Equal(x.Values, y.Values)
+		}) &&
+		a.K8sNamespace == b.K8sNamespace &&
+		a.Weight == b.Weight &&
+		a.Specificity == b.Specificity &&
+		a.StrategyCreatedTime == b.StrategyCreatedTime &&
+		maps.Equal(a.PodLabels, b.PodLabels)
+}
+
+// strategyConflicts reports the other strategies whose live intents target the pods of the given intents with a different
+// priority or execution time, grouped by strategy. The strategy is already committed, so a failed lookup is only logged.
+func (svc *Service) strategyConflicts(ctx context.Context, strategy *domain.ScheduleStrategy, intents []*domain.ScheduleIntent) []*domain.StrategyConflict {
+	conflicts := []*domain.StrategyConflict{}
+	if len(intents) == 0 {
+		return conflicts
+	}
+	podIntents := make(map[string]*domain.ScheduleIntent, len(intents))
+	podIDs := make([]string, 0, len(intents))
+	for _, intent := range intents {
+		podIntents[intent.PodID] = intent
+		podIDs = append(podIDs, intent.PodID)
+	}
+	queryOpt := &domain.QueryIntentOptions{PodIDs: podIDs}
+	err := svc.Repo.QueryIntents(ctx, queryOpt)
+	if err != nil {
+		logger.Logger(ctx).Warn().Err(err).Msgf("failed to query the intents overlapping strategy %s", strategy.ID.Hex())
+		return conflicts
+	}
+
+	for _, other := range queryOpt.Result {
+		intent, ok := podIntents[other.PodID]
+		if !ok || other.StrategyID == strategy.ID || !other.InEffect() {
+			continue
+		}
+		if other.Priority == intent.Priority && other.ExecutionTime == intent.ExecutionTime {
+			continue
+		}
+		idx := slices.IndexFunc(conflicts, func(conflict *domain.StrategyConflict) bool {
+			return conflict.StrategyID == other.StrategyID
+		})
+		if idx < 0 {
+			conflicts = append(conflicts, &domain.StrategyConflict{
+				StrategyID:      other.StrategyID,
+				Priority:        other.Priority,
+				ExecutionTime:   other.ExecutionTime,
+				Weight:          other.Weight,
+				TakesPrecedence: domain.CompareIntentPrecedence(other, intent) > 0,
+			})
+			idx = len(conflicts) - 1
+		}
+		if !slices.Contains(conflicts[idx].PodIDs, other.PodID) {
+			conflicts[idx].PodIDs = append(conflicts[idx].PodIDs, other.PodID)
+		}
+	}
+	slices.SortFunc(conflicts, func(a, b *domain.StrategyConflict) int {
+		return strings.Compare(a.StrategyID.Hex(), b.StrategyID.Hex())
+	})
+	for _, conflict := range conflicts {
+		logger.Logger(ctx).Warn().Msgf("strategy %s conflicts with strategy %s on pods %v", strategy.ID.Hex(), conflict.StrategyID.Hex(), conflict.PodIDs)
+	}
+	return conflicts
+}
+
+// removeStaleIntents removes the stale intents from the decision makers. The decision makers identify an intent by its pod, strategy,
+// command regex, process matchers, thread regex and containers, so when another intent of the strategy still shares them, e.g. the intent of an
+// updated strategy, it is delivered again instead of deleting the shared entry.
+// A removal that fails is retried by the delivery queue.
+func (svc *Service) removeStaleIntents(ctx context.Context, stale []*domain.ScheduleIntent) {
+	svc.removeStaleIntentsAttempt(ctx, stale, 0)
+}
+
+// removeStaleIntentsAttempt removes the stale intents whose removal already failed attempts times
+func (svc *Service) removeStaleIntentsAttempt(ctx context.Context, stale []*domain.ScheduleIntent, attempts int) {
+	if len(stale) == 0 {
+		return
+	}
+	podIDs := make([]string, 0, len(stale))
+	nodeStale := make(map[string][]*domain.ScheduleIntent)
+	for _, intent := range stale {
+		podIDs = append(podIDs, intent.PodID)
+		nodeStale[intent.NodeID] = append(nodeStale[intent.NodeID], intent)
+	}
+	queryOpt := &domain.QueryIntentOptions{PodIDs: podIDs}
+	err := svc.Repo.QueryIntents(ctx, queryOpt)
+	if err != nil {
+		err = fmt.Errorf("query the live intents of the updated pods: %w", err)
+		for nodeID, intents := range nodeStale {
+			svc.delivery.recordRemovalFailure(ctx, nodeID, intents, attempts+1, err, time.Now())
+		}
+		return
+	}
+	clear(nodeStale)
+
+	redeliver := make([]*domain.IntentStateUpdate, 0)
+	redeliverNodes := make([]string, 0)
+	for _, intent := range stale {
+		idx := slices.IndexFunc(queryOpt.Result, func(live *domain.ScheduleIntent) bool {
+			return live.PodID == intent.PodID && live.StrategyID == intent.StrategyID && live.CommandRegex == intent.CommandRegex &&
+				slices.Equal(live.ProcessMatchers, intent.ProcessMatchers) && live.ThreadRegex == intent.ThreadRegex &&
+				slices.Equal(live.ContainerIDs, intent.ContainerIDs) && live.NodeID == intent.NodeID && !live.Dormant()
+		})
+		if idx < 0 {
+			nodeStale[intent.NodeID] = append(nodeStale[intent.NodeID], intent)
+			continue
+		}
+		redeliver = append(redeliver, &domain.IntentStateUpdate{IntentID: queryOpt.Result[idx].ID, State: domain.IntentStateInitialized})
+		if !slices.Contains(redeliverNodes, intent.NodeID) {
+			redeliverNodes = append(redeliverNodes, intent.NodeID)
 		}
-		err = svc.Repo.BatchUpdateIntentsState(ctx, nodeIDIntentIDsMap[host], domain.IntentStateSent)
+	}
+
+	if len(redeliver) > 0 {
+		err = svc.Repo.BulkUpdateIntentsState(ctx, redeliver)
 		if err != nil {
-			return fmt.Errorf("insert strategy and intents into repository: %w", err)
+			logger.Logger(ctx).Warn().Err(err).Msg("failed to mark the shared intents for delivery")
+		}
+		for _, nodeID := range redeliverNodes {
+			svc.deliverNodeIntents(ctx, nodeID)
 		}
-		logger.Logger(ctx).Info().Msgf("sent %d scheduling intents to decision maker %s", len(intents), host)
 	}
-	return nil
+
+	for nodeID, intents := range nodeStale {
+		svc.removeNodeStaleIntents(ctx, nodeID, intents, attempts)
+	}
 }
 
 func (svc *Service) ListScheduleStrategies(ctx context.Context, filterOpts *domain.QueryStrategyOptions) error {
@@ -129,32 +423,20 @@ func (svc *Service) DeleteScheduleStrategy(ctx context.Context, operator *domain
 	if len(queryOpt.Result) == 0 {
 		return errs.NewHTTPStatusError(http.StatusNotFound, "strategy not found or you don't have permission to delete it", nil)
 	}
+	return svc.deleteStrategy(ctx, strategyObjID)
+}
 
-	// Query intents associated with this strategy to get node IDs and pod IDs for DM notification
+// deleteStrategy deletes a strategy with its intents and removes them from the decision makers
+func (svc *Service) deleteStrategy(ctx context.Context, strategyObjID bson.ObjectID) error {
+	// Query the intents of the strategy to remove them from the decision makers
 	intentQueryOpt := &domain.QueryIntentOptions{
 		StrategyIDs: []bson.ObjectID{strategyObjID},
 	}
-	err = svc.Repo.QueryIntents(ctx, intentQueryOpt)
+	err := svc.Repo.QueryIntents(ctx, intentQueryOpt)
 	if err != nil {
 		return fmt.Errorf("query intents for strategy: %w", err)
 	}
 
-	// Collect unique node IDs and pod IDs from intents
-	nodeIDsMap := make(map[string]struct{})
-	podIDsMap := make(map[string]struct{})
-	for _, intent := range intentQueryOpt.Result {
-		nodeIDsMap[intent.NodeID] = struct{}{}
-		podIDsMap[intent.PodID] = struct{}{}
-	}
-	nodeIDs := make([]string, 0, len(nodeIDsMap))
-	for nodeID := range nodeIDsMap {
-		nodeIDs = append(nodeIDs, nodeID)
-	}
-	podIDs := make([]string, 0, len(podIDsMap))
-	for podID := range podIDsMap {
-		podIDs = append(podIDs, podID)
-	}
-
 	// Delete associated intents first
 	err = svc.Repo.DeleteIntentsByStrategyID(ctx, strategyObjID)
 	if err != nil {
@@ -167,32 +449,10 @@ func (svc *Service) DeleteScheduleStrategy(ctx context.Context, operator *domain
 		return fmt.Errorf("delete strategy: %w", err)
 	}
 
-	// Notify decision makers to remove intents from their in-memory cache
-	if len(nodeIDs) > 0 && len(podIDs) > 0 {
-		dmLabel := domain.LabelSelector{
-			Key:   "app",
-			Value: "decisionmaker",
-		}
-		dmQueryOpt := &domain.QueryDecisionMakerPodsOptions{
-			DecisionMakerLabel: dmLabel,
-			NodeIDs:            nodeIDs,
-		}
-		dmPods, err := svc.K8SAdapter.QueryDecisionMakerPods(ctx, dmQueryOpt)
-		if err != nil {
-			logger.Logger(ctx).Warn().Err(err).Msg("failed to query decision maker pods for deletion notification")
-		} else {
-			deleteReq := &domain.DeleteIntentsRequest{
-				PodIDs: podIDs,
-			}
-			for _, dmPod := range dmPods {
-				if err := svc.DMAdapter.DeleteSchedulingIntents(ctx, dmPod, deleteReq); err != nil {
-					logger.Logger(ctx).Warn().Err(err).Msgf("failed to notify decision maker %s to delete intents", dmPod.NodeID)
-				}
-			}
-		}
-	}
+	// Remove only the intents of this strategy from the decision makers, the intents of the other strategies of the pods are kept
+	svc.removeStaleIntents(ctx, slices.DeleteFunc(intentQueryOpt.Result, (*domain.ScheduleIntent).Dormant))
 
-	logger.Logger(ctx).Info().Msgf("deleted strategy %s and its associated intents", strategyID)
+	logger.Logger(ctx).Info().Msgf("deleted strategy %s and its associated intents", strategyObjID.Hex())
 	return nil
 }
 
@@ -254,52 +514,14 @@ func (svc *Service) DeleteScheduleIntents(ctx context.Context, operator *domain.
 		return errs.NewHTTPStatusError(http.StatusNotFound, "one or more intents not found or you don't have permission to delete them", nil)
 	}
 
-	// Collect unique node IDs and pod IDs for DM notification before deleting
-	nodeIDsMap := make(map[string]struct{})
-	podIDsMap := make(map[string]struct{})
-	for _, intent := range queryOpt.Result {
-		nodeIDsMap[intent.NodeID] = struct{}{}
-		podIDsMap[intent.PodID] = struct{}{}
-	}
-	nodeIDs := make([]string, 0, len(nodeIDsMap))
-	for nodeID := range nodeIDsMap {
-		nodeIDs = append(nodeIDs, nodeID)
-	}
-	podIDs := make([]string, 0, len(podIDsMap))
-	for podID := range podIDsMap {
-		podIDs = append(podIDs, podID)
-	}
-
 	// Delete the intents
 	err = svc.Repo.DeleteIntents(ctx, intentObjIDs)
 	if err != nil {
 		return fmt.Errorf("delete intents: %w", err)
 	}
 
-	// Notify decision makers to remove intents from their in-memory cache
-	if len(nodeIDs) > 0 && len(podIDs) > 0 {
-		dmLabel := domain.LabelSelector{
-			Key:   "app",
-			Value: "decisionmaker",
-		}
-		dmQueryOpt := &domain.QueryDecisionMakerPodsOptions{
-			DecisionMakerLabel: dmLabel,
-			NodeIDs:            nodeIDs,
-		}
-		dmPods, err := svc.K8SAdapter.QueryDecisionMakerPods(ctx, dmQueryOpt)
-		if err != nil {
-			logger.Logger(ctx).Warn().Err(err).Msg("failed to query decision maker pods for deletion notification")
-		} else {
-			deleteReq := &domain.DeleteIntentsRequest{
-				PodIDs: podIDs,
-			}
-			for _, dmPod := range dmPods {
-				if err := svc.DMAdapter.DeleteSchedulingIntents(ctx, dmPod, deleteReq); err != nil {
-					logger.Logger(ctx).Warn().Err(err).Msgf("failed to notify decision maker %s to delete intents", dmPod.NodeID)
-				}
-			}
-		}
-	}
+	// Remove only these intents from the decision makers, the other intents of the pods are kept
+	svc.removeStaleIntents(ctx, slices.DeleteFunc(queryOpt.Result, (*domain.ScheduleIntent).Dormant))
 
 	logger.Logger(ctx).Info().Msgf("deleted %d intents", len(intentIDs))
 	return nil
@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/manager/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// TestDiffStrategyIntents tests that the intents of an updated strategy are split into added, modified and removed intents
+func TestDiffStrategyIntents(t *testing.T) {
+	strategyID := bson.NewObjectID()
+	creatorID := bson.NewObjectID()
+	unchanged := &domain.ScheduleIntent{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatedTime: 1, CreatorID: creatorID},
+		StrategyID: strategyID, PodID: "pod-1", NodeID: "node-1", CommandRegex: "^nginx", Priority: 1, Specificity: 1,
+	}
+	moved := &domain.ScheduleIntent{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatedTime: 2, CreatorID: creatorID},
+		StrategyID: strategyID, PodID: "pod-2", NodeID: "node-1", CommandRegex: "^nginx", Priority: 1, Specificity: 1,
+	}
+	gone := &domain.ScheduleIntent{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatedTime: 3, CreatorID: creatorID},
+		StrategyID: strategyID, PodID: "pod-3", NodeID: "node-2", CommandRegex: "^nginx", Priority: 1, Specificity: 1,
+	}
+	strategy := &domain.ScheduleStrategy{
+		BaseEntity:   domain.BaseEntity{ID: strategyID, CreatorID: creatorID},
+		CommandRegex: "^nginx",
+		Priority:     1,
+	}
+	pods := []*domain.Pod{
+		{PodID: "pod-1", NodeID: "node-1"},
+		{PodID: "pod-2", NodeID: "node-3"},
+		{PodID: "pod-4", NodeID: "node-2"},
+	}
+
+	diff := diffStrategyIntents(strategy, []*domain.ScheduleIntent{unchanged, moved, gone}, pods)
+	require.Len(t, diff.added, 1)
+	assert.Equal(t, "pod-4", diff.added[0].PodID)
+	assert.True(t, diff.added[0].ID.IsZero(), "added intents should get a new ID on insert")
+	require.Len(t, diff.modified, 1)
+	assert.Equal(t, moved.ID, diff.modified[0].ID, "modified intents should keep their ID")
+	assert.Equal(t, moved.CreatedTime, diff.modified[0].CreatedTime)
+	assert.Equal(t, "node-3", diff.modified[0].NodeID)
+	assert.Equal(t, domain.IntentStateInitialized, diff.modified[0].State)
+	assert.Equal(t, []*domain.ScheduleIntent{gone}, diff.removed)
+	assert.Equal(t, []*domain.ScheduleIntent{moved, gone}, diff.stale)
+}
+
+// TestUpdateScheduleStrategy tests that an update delivers the changed intents before deleting the ones that no longer match
+func TestUpdateScheduleStrategy(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newDeliveryTestService(t)
+	ctx := context.Background()
+	operatorID := bson.NewObjectID()
+	operator := &domain.Claims{UID: operatorID.Hex()}
+	current := &domain.ScheduleStrategy{
+		BaseEntity:   domain.BaseEntity{ID: bson.NewObjectID(), CreatedTime: 1, CreatorID: operatorID},
+		CommandRegex: "^nginx",
+		Priority:     1,
+	}
+	kept := &domain.ScheduleIntent{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatedTime: 1, CreatorID: operatorID},
+		StrategyID: current.ID, PodID: "pod-1", NodeID: "node-1", CommandRegex: "^nginx", Priority: 1, State: domain.IntentStateApplied,
+	}
+	removed := &domain.ScheduleIntent{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatedTime: 1, CreatorID: operatorID},
+		StrategyID: current.ID, PodID: "pod-2", NodeID: "node-2", CommandRegex: "^nginx", Priority: 1, State: domain.IntentStateApplied,
+	}
+	dmPod1 := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+	dmPod2 := &domain.DecisionMakerPod{NodeID: "node-2", Host: "10.0.0.2", Port: 8080}
+	update := &domain.ScheduleStrategy{CommandRegex: "^nginx", Priority: 2}
+
+	repo.EXPECT().QueryStrategies(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		require.Equal(t, []bson.ObjectID{current.ID}, opt.IDs)
+		require.Equal(t, []bson.ObjectID{operatorID}, opt.CreatorIDs)
+		opt.Result = []*domain.ScheduleStrategy{current}
+		return nil
+	}).Once()
+	k8sAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return([]*domain.Pod{
+		{PodID: "pod-1", NodeID: "node-1"},
+		{PodID: "pod-3", NodeID: "node-2"},
+	}, nil).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, mock.MatchedBy(func(opt *domain.QueryIntentOptions) bool {
+		return len(opt.StrategyIDs) == 1
+	})).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = []*domain.ScheduleIntent{kept, removed}
+		return nil
+	}).Once()
+	repo.EXPECT().UpdateStrategy(mock.Anything, update).RunAndReturn(func(_ context.Context, strategy *domain.ScheduleStrategy) error {
+		assert.Equal(t, current.ID, strategy.ID)
+		assert.Equal(t, current.CreatedTime, strategy.CreatedTime)
+		assert.Equal(t, operatorID, strategy.UpdaterID)
+		return nil
+	}).Once()
+
+	var added, modified []*domain.ScheduleIntent
+	repo.EXPECT().InsertIntents(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, intents []*domain.ScheduleIntent) error {
+		require.Len(t, intents, 1)
+		assert.Equal(t, "pod-3", intents[0].PodID)
+		intents[0].ID = bson.NewObjectID()
+		added = intents
+		return nil
+	}).Once()
+	repo.EXPECT().UpdateIntents(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, intents []*domain.ScheduleIntent) error {
+		require.Len(t, intents, 1)
+		assert.Equal(t, kept.ID, intents[0].ID)
+		assert.Equal(t, 2, intents[0].Priority)
+		modified = intents
+		return nil
+	}).Once()
+	repo.EXPECT().DeleteIntents(mock.Anything, []bson.ObjectID{removed.ID}).Return(nil).Once()
+
+	delivered := 0
+	repo.EXPECT().QueryIntents(mock.Anything, mock.MatchedBy(func(opt *domain.QueryIntentOptions) bool {
+		return len(opt.NodeIDs) == 1
+	})).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		if opt.NodeIDs[0] == "node-1" {
+			opt.Result = modified
+		} else {
+			opt.Result = added
+		}
+		return nil
+	}).Twice()
+	mockNodeDecisionMaker(k8sAdapter, dmPod1)
+	mockNodeDecisionMaker(k8sAdapter, dmPod2)
+	dmAdapter.EXPECT().SendSchedulingIntent(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, _ *domain.DecisionMakerPod, _ []*domain.ScheduleIntent) ([]*domain.IntentResult, error) {
+		delivered++
+		return nil, nil
+	}).Twice()
+	repo.EXPECT().BulkUpdateIntentsState(mock.Anything, mock.Anything).Return(nil).Twice()
+
+	repo.EXPECT().QueryIntents(mock.Anything, mock.MatchedBy(func(opt *domain.QueryIntentOptions) bool {
+		return len(opt.PodIDs) > 0
+	})).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		assert.Equal(t, []string{"pod-2"}, opt.PodIDs)
+		return nil
+	}).Once()
+	mockNodeDecisionMaker(k8sAdapter, dmPod2)
+	dmAdapter.EXPECT().DeleteSchedulingIntents(mock.Anything, dmPod2, mock.Anything).RunAndReturn(func(_ context.Context, _ *domain.DecisionMakerPod, req *domain.DeleteIntentsRequest) error {
+		assert.Equal(t, 2, delivered, "stale intents should be deleted after the new intents are delivered")
+		assert.Empty(t, req.PodIDs)
+		assert.Equal(t, []*domain.ScheduleIntent{removed}, req.Intents)
+		return nil
+	}).Once()
+
+	repo.EXPECT().QueryIntents(mock.Anything, mock.MatchedBy(func(opt *domain.QueryIntentOptions) bool {
+		return len(opt.PodIDs) > 0
+	})).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		assert.Equal(t, []string{"pod-1", "pod-3"}, opt.PodIDs)
+		opt.Result = append(opt.Result, modified...)
+		return nil
+	}).Once()
+
+	conflicts, err := svc.UpdateScheduleStrategy(ctx, operator, current.ID.Hex(), update)
+	require.NoError(t, err)
+	assert.Empty(t, conflicts, "the intents of the strategy itself are not conflicts")
+}
+
+// TestUpdateScheduleStrategyRejectsReservedNamespace tests that a strategy cannot be moved into the strategy namespaces managed by the Manager
+func TestUpdateScheduleStrategyRejectsReservedNamespace(t *testing.T) {
+	svc, _, _, _ := newReconcileTestService(t)
+	operator := &domain.Claims{UID: bson.NewObjectID().Hex()}
+
+	for _, strategyNamespace := range []string{domain.AnnotationStrategyNamespace, domain.ResourceStrategyNamespace("team-a")} {
+		update := &domain.ScheduleStrategy{StrategyNamespace: strategyNamespace, CommandRegex: "^nginx", Priority: 1}
+		_, err := svc.UpdateScheduleStrategy(context.Background(), operator, bson.NewObjectID().Hex(), update)
+		require.Error(t, err)
+		httpErr, ok := errs.IsHTTPStatusError(err)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode, strategyNamespace)
+	}
+}
+
+// TestRemoveStaleIntentsRedeliversSharedIntent tests that a stale intent sharing its pod, strategy and command regex with a live intent
+// is not deleted from the decision maker
+func TestRemoveStaleIntentsRedeliversSharedIntent(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newDeliveryTestService(t)
+	ctx := context.Background()
+	strategyID := bson.NewObjectID()
+	stale := &domain.ScheduleIntent{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()},
+		StrategyID: strategyID, PodID: "pod-1", NodeID: "node-1", CommandRegex: "^nginx",
+	}
+	shared := &domain.ScheduleIntent{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()},
+		StrategyID: strategyID, PodID: "pod-1", NodeID: "node-1", CommandRegex: "^nginx", State: domain.IntentStateApplied,
+	}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+
+	repo.EXPECT().QueryIntents(mock.Anything, mock.MatchedBy(func(opt *domain.QueryIntentOptions) bool {
+		return len(opt.PodIDs) > 0
+	})).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = []*domain.ScheduleIntent{shared}
+		return nil
+	}).Once()
+	repo.EXPECT().BulkUpdateIntentsState(mock.Anything, []*domain.IntentStateUpdate{
+		{IntentID: shared.ID, State: domain.IntentStateInitialized},
+	}).Return(nil).Once()
+	mockPendingIntents(repo, "node-1", shared)
+	mockNodeDecisionMaker(k8sAdapter, dmPod)
+	dmAdapter.EXPECT().SendSchedulingIntent(mock.Anything, dmPod, []*domain.ScheduleIntent{shared}).Return(nil, nil).Once()
+	repo.EXPECT().BulkUpdateIntentsState(mock.Anything, []*domain.IntentStateUpdate{
+		{IntentID: shared.ID, State: domain.IntentStateSent},
+	}).Return(nil).Once()
+
+	svc.removeStaleIntents(ctx, []*domain.ScheduleIntent{stale})
+}
+
+// TestDeleteScheduleStrategyRemovesOnlyItsIntents tests that deleting a strategy removes its own intents from the decision makers,
+// and leaves the intents of the other strategies of the pods, even with the same command regex
+func TestDeleteScheduleStrategyRemovesOnlyItsIntents(t *testing.T) {
+	svc, repo, k8sAdapter, dmAdapter := newDeliveryTestService(t)
+	ctx := context.Background()
+	operatorID := bson.NewObjectID()
+	operator := &domain.Claims{UID: operatorID.Hex()}
+	strategy := &domain.ScheduleStrategy{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatorID: operatorID}, CommandRegex: "^nginx"}
+	applied := &domain.ScheduleIntent{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()},
+		StrategyID: strategy.ID, PodID: "pod-1", NodeID: "node-1", CommandRegex: "^nginx", State: domain.IntentStateApplied,
+	}
+	scheduled := &domain.ScheduleIntent{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()},
+		StrategyID: strategy.ID, PodID: "pod-2", NodeID: "node-1", CommandRegex: "^nginx", State: domain.IntentStateScheduled,
+	}
+	other := &domain.ScheduleIntent{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()},
+		StrategyID: bson.NewObjectID(), PodID: "pod-1", NodeID: "node-1", CommandRegex: "^nginx", State: domain.IntentStateApplied,
+	}
+	dmPod := &domain.DecisionMakerPod{NodeID: "node-1", Host: "10.0.0.1", Port: 8080}
+
+	repo.EXPECT().QueryStrategies(mock.Anything, &domain.QueryStrategyOptions{IDs: []bson.ObjectID{strategy.ID}, CreatorIDs: []bson.ObjectID{operatorID}}).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		opt.Result = []*domain.ScheduleStrategy{strategy}
+		return nil
+	}).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, &domain.QueryIntentOptions{StrategyIDs: []bson.ObjectID{strategy.ID}}).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = []*domain.ScheduleIntent{applied, scheduled}
+		return nil
+	}).Once()
+	repo.EXPECT().DeleteIntentsByStrategyID(mock.Anything, strategy.ID).Return(nil).Once()
+	repo.EXPECT().DeleteStrategy(mock.Anything, strategy.ID).Return(nil).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, &domain.QueryIntentOptions{PodIDs: []string{"pod-1"}}).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = []*domain.ScheduleIntent{other}
+		return nil
+	}).Once()
+	mockNodeDecisionMaker(k8sAdapter, dmPod)
+	dmAdapter.EXPECT().DeleteSchedulingIntents(mock.Anything, dmPod, &domain.DeleteIntentsRequest{Intents: []*domain.ScheduleIntent{applied}}).Return(nil).Once()
+
+	require.NoError(t, svc.DeleteScheduleStrategy(ctx, operator, strategy.ID.Hex()))
+}
+
+// TestCreateScheduleStrategyRejectsInvalidSelector tests that an invalid match expression is rejected before any pod is queried
+func TestCreateScheduleStrategyRejectsInvalidSelector(t *testing.T) {
+	svc, _, _, _ := newReconcileTestService(t)
+	operator := &domain.Claims{UID: bson.NewObjectID().Hex()}
+	strategy := &domain.ScheduleStrategy{
+		MatchExpressions: []domain.LabelSelectorRequirement{{Key: "tier", Operator: domain.LabelSelectorOpExists, Values: []string{"frontend"}}},
+	}
+
+	_, err := svc.CreateScheduleStrategy(context.Background(), operator, strategy)
+	require.Error(t, err)
+	httpErr, ok := errs.IsHTTPStatusError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+}
+
+// TestStrategyMatchesPodExpressions tests that the match labels and match expressions are ANDed with the label selectors
+func TestStrategyMatchesPodExpressions(t *testing.T) {
+	strategy := &domain.ScheduleStrategy{
+		LabelSelectors: []domain.LabelSelector{{Key: "app"}},
+		MatchLabels:    map[string]string{"team": "core"},
+		MatchExpressions: []domain.LabelSelectorRequirement{
+			{Key: "tier", Operator: domain.LabelSelectorOpNotIn, Values: []string{"cache"}},
+		},
+	}
+	assert.True(t, strategy.MatchesPod(&domain.Pod{Labels: map[string]string{"app": "web", "team": "core", "tier": "frontend"}}))
+	assert.True(t, strategy.MatchesPod(&domain.Pod{Labels: map[string]string{"app": "web", "team": "core"}}), "NotIn matches a pod without the key")
+	assert.False(t, strategy.MatchesPod(&domain.Pod{Labels: map[string]string{"app": "web", "team": "core", "tier": "cache"}}))
+	assert.False(t, strategy.MatchesPod(&domain.Pod{Labels: map[string]string{"team": "core", "tier": "frontend"}}))
+}
+
+// TestStrategyMatchesPodNodeSelector tests that a strategy with a node selector only matches the scheduled pods of the selected nodes
+func TestStrategyMatchesPodNodeSelector(t *testing.T) {
+	strategy := &domain.ScheduleStrategy{
+		NodeSelector: &domain.LabelSelectorSpec{MatchLabels: map[string]string{"node-role": "edge"}},
+	}
+	assert.True(t, strategy.MatchesPod(&domain.Pod{NodeID: "edge-1", NodeLabels: map[string]string{"node-role": "edge"}}))
+	assert.False(t, strategy.MatchesPod(&domain.Pod{NodeID: "batch-1", NodeLabels: map[string]string{"node-role": "batch"}}))
+	assert.False(t, strategy.MatchesPod(&domain.Pod{}), "an unscheduled pod runs on no selected node")
+}
+
+// TestStrategyConflicts tests that only the live intents of other strategies with a different setting are reported, grouped by strategy
+func TestStrategyConflicts(t *testing.T) {
+	svc, repo, _, _ := newReconcileTestService(t)
+	strategy := &domain.ScheduleStrategy{
+		BaseEntity:   domain.BaseEntity{ID: bson.NewObjectID(), CreatedTime: 2000},
+		CommandRegex: "^nginx",
+		Priority:     1,
+	}
+	pods := []*domain.Pod{{PodID: "pod-1", NodeID: "node-1"}, {PodID: "pod-2", NodeID: "node-1"}}
+	intents := make([]*domain.ScheduleIntent, 0, len(pods))
+	for _, pod := range pods {
+		intent := domain.NewScheduleIntent(strategy, pod)
+		intents = append(intents, &intent)
+	}
+	heavier := bson.NewObjectID()
+	older := bson.NewObjectID()
+	live := []*domain.ScheduleIntent{
+		{StrategyID: heavier, PodID: "pod-1", Priority: 0, ExecutionTime: 5000, Weight: 10, State: domain.IntentStateApplied},
+		{StrategyID: heavier, PodID: "pod-2", Priority: 0, ExecutionTime: 5000, Weight: 10, State: domain.IntentStateApplied},
+		{StrategyID: older, PodID: "pod-2", Priority: 0, Specificity: 1, StrategyCreatedTime: 1000, State: domain.IntentStateSent},
+		{StrategyID: bson.NewObjectID(), PodID: "pod-1", Priority: 1, State: domain.IntentStateApplied},
+		{StrategyID: bson.NewObjectID(), PodID: "pod-1", Priority: 0, State: domain.IntentStateFailed},
+		intents[0],
+	}
+	repo.EXPECT().QueryIntents(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		assert.Equal(t, []string{"pod-1", "pod-2"}, opt.PodIDs)
+		opt.Result = live
+		return nil
+	}).Once()
+
+	conflicts := svc.strategyConflicts(context.Background(), strategy, intents)
+	require.Len(t, conflicts, 2)
+	byStrategy := map[bson.ObjectID]*domain.StrategyConflict{}
+	for _, conflict := range conflicts {
+		byStrategy[conflict.StrategyID] = conflict
+	}
+	require.Contains(t, byStrategy, heavier)
+	assert.Equal(t, []string{"pod-1", "pod-2"}, byStrategy[heavier].PodIDs)
+	assert.True(t, byStrategy[heavier].TakesPrecedence, "a higher weight wins")
+	require.Contains(t, byStrategy, older)
+	assert.Equal(t, []string{"pod-2"}, byStrategy[older].PodIDs)
+	assert.False(t, byStrategy[older].TakesPrecedence, "the newer strategy wins a tie of weight and specificity")
+}
+
+// TestCompareIntentPrecedence tests that the weight is compared first, then the specificity, then the strategy creation time
+func TestCompareIntentPrecedence(t *testing.T) {
+	base := domain.ScheduleIntent{Weight: 1, Specificity: 2, StrategyCreatedTime: 1000}
+	heavier := base
+	heavier.Weight = 2
+	heavier.Specificity = 0
+	moreSpecific := base
+	moreSpecific.Specificity = 3
+	moreSpecific.StrategyCreatedTime = 0
+	newer := base
+	newer.StrategyCreatedTime = 2000
+
+	assert.Positive(t, domain.CompareIntentPrecedence(&heavier, &base))
+	assert.Positive(t, domain.CompareIntentPrecedence(&moreSpecific, &base))
+	assert.Positive(t, domain.CompareIntentPrecedence(&newer, &base))
+	assert.Negative(t, domain.CompareIntentPrecedence(&base, &newer))
+	assert.Zero(t, domain.CompareIntentPrecedence(&base, &base))
+}
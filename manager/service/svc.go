@@ -11,16 +11,19 @@ import (
 
 	"github.com/Gthulhu/api/config"
 	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/pkg/util"
 	"go.uber.org/fx"
 )
 
 type Params struct {
 	fx.In
-	Repo          domain.Repository
-	KeyConfig     config.KeyConfig
-	AccountConfig config.AccountConfig
-	K8SAdapter    domain.K8SAdapter
-	DMAdapter     domain.DecisionMakerAdapter
+	Repo           domain.Repository
+	KeyConfig      config.KeyConfig
+	AccountConfig  config.AccountConfig
+	K8SAdapter     domain.K8SAdapter
+	DMAdapter      domain.DecisionMakerAdapter
+	DeliveryConfig config.DeliveryConfig
+	ScheduleConfig config.ScheduleConfig
 }
 
 func NewService(params Params) (domain.Service, error) {
@@ -34,6 +37,15 @@ func NewService(params Params) (domain.Service, error) {
 		DMAdapter:     params.DMAdapter,
 		Repo:          params.Repo,
 		jwtPrivateKey: jwtPrivateKey,
+		delivery:      newIntentDeliveryQueue(params.DeliveryConfig),
+		schedule:      params.ScheduleConfig,
+		dmClientID:    params.KeyConfig.DMClientID,
+	}
+	if params.KeyConfig.DMPublicKeyPem.Value() != "" {
+		svc.dmPublicKey, err = util.PEMToRSAPublicKey(params.KeyConfig.DMPublicKeyPem.Value())
+		if err != nil {
+			return nil, fmt.Errorf("initialize decision maker public key: %w", err)
+		}
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
@@ -51,6 +63,12 @@ type Service struct {
 	DMAdapter     domain.DecisionMakerAdapter
 	Repo          domain.Repository
 	jwtPrivateKey *rsa.PrivateKey
+	// dmPublicKey verifies the tokens self-signed by the decision makers
+	dmPublicKey *rsa.PublicKey
+	// dmClientID is the client ID the decision makers present in their tokens
+	dmClientID string
+	delivery   *intentDeliveryQueue
+	schedule   config.ScheduleConfig
 }
 
 func initRSAPrivateKey(pemStr string) (*rsa.PrivateKey, error) {
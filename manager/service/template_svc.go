@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/manager/errs"
+	"github.com/Gthulhu/api/pkg/logger"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func (svc *Service) CreateStrategyTemplate(ctx context.Context, operator *domain.Claims, template *domain.StrategyTemplate) error {
+	operatorID, err := operator.GetBsonObjectUID()
+	if err != nil {
+		return errors.WithMessagef(err, "invalid operator ID %s", operator.UID)
+	}
+	err = svc.checkStrategyTemplateName(ctx, template)
+	if err != nil {
+		return err
+	}
+	template.BaseEntity = domain.NewBaseEntity(&operatorID, &operatorID)
+	return svc.Repo.InsertStrategyTemplate(ctx, template)
+}
+
+func (svc *Service) ListStrategyTemplates(ctx context.Context, filterOpts *domain.QueryStrategyTemplateOptions) error {
+	return svc.Repo.QueryStrategyTemplates(ctx, filterOpts)
+}
+
+// UpdateStrategyTemplate replaces the template. When propagate is set the strategies derived from it take its new priority and execution time,
+// every one of them is updated as if its creator updated it; a failure stops the propagation and the strategies updated so far are kept.
+func (svc *Service) UpdateStrategyTemplate(ctx context.Context, operator *domain.Claims, templateID string, template *domain.StrategyTemplate, propagate bool) ([]bson.ObjectID, error) {
+	operatorID, err := operator.GetBsonObjectUID()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "invalid operator ID %s", operator.UID)
+	}
+	current, err := svc.getStrategyTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	template.BaseEntity = current.BaseEntity
+	template.UpdaterID = operatorID
+	err = svc.checkStrategyTemplateName(ctx, template)
+	if err != nil {
+		return nil, err
+	}
+	err = svc.Repo.UpdateStrategyTemplate(ctx, template)
+	if err != nil {
+		return nil, fmt.Errorf("update strategy template: %w", err)
+	}
+
+	propagated := []bson.ObjectID{}
+	if !propagate {
+		return propagated, nil
+	}
+	queryOpt := &domain.QueryStrategyOptions{TemplateIDs: []bson.ObjectID{template.ID}}
+	err = svc.Repo.QueryStrategies(ctx, queryOpt)
+	if err != nil {
+		return propagated, fmt.Errorf("query the strategies derived from template %s: %w", templateID, err)
+	}
+	for _, current := range queryOpt.Result {
+		if current.Priority == template.Priority && current.ExecutionTime == template.ExecutionTime {
+			continue
+		}
+		strategy := *current
+		template.ApplyTo(&strategy)
+		strategy.UpdaterID = operatorID
+		_, err = svc.updateStrategy(ctx, &strategy)
+		if err != nil {
+			return propagated, fmt.Errorf("propagate template %s to strategy %s: %w", templateID, current.ID.Hex(), err)
+		}
+		propagated = append(propagated, current.ID)
+	}
+	logger.Logger(ctx).Info().Msgf("propagated template %s to %d strategies", templateID, len(propagated))
+	return propagated, nil
+}
+
+// DeleteStrategyTemplate deletes a template no strategy is derived from
+func (svc *Service) DeleteStrategyTemplate(ctx context.Context, operator *domain.Claims, templateID string) error {
+	template, err := svc.getStrategyTemplate(ctx, templateID)
+	if err != nil {
+		return err
+	}
+	queryOpt := &domain.QueryStrategyOptions{TemplateIDs: []bson.ObjectID{template.ID}}
+	err = svc.Repo.QueryStrategies(ctx, queryOpt)
+	if err != nil {
+		return err
+	}
+	if len(queryOpt.Result) > 0 {
+		return errs.NewHTTPStatusError(http.StatusConflict, "strategies are still derived from the template", fmt.Errorf("%d strategies derived from template %s", len(queryOpt.Result), templateID))
+	}
+	return svc.Repo.DeleteStrategyTemplate(ctx, template.ID)
+}
+
+// applyStrategyTemplate derives a strategy referencing a template from it, the template overrides the priority and execution time of the strategy
+func (svc *Service) applyStrategyTemplate(ctx context.Context, strategy *domain.ScheduleStrategy) error {
+	if strategy.TemplateID.IsZero() {
+		return nil
+	}
+	template, err := svc.getStrategyTemplate(ctx, strategy.TemplateID.Hex())
+	if err != nil {
+		return err
+	}
+	template.ApplyTo(strategy)
+	return nil
+}
+
+func (svc *Service) getStrategyTemplate(ctx context.Context, templateID string) (*domain.StrategyTemplate, error) {
+	templateObjID, err := bson.ObjectIDFromHex(templateID)
+	if err != nil {
+		return nil, errs.NewHTTPStatusError(http.StatusBadRequest, "invalid strategy template ID", err)
+	}
+	queryOpt := &domain.QueryStrategyTemplateOptions{IDs: []bson.ObjectID{templateObjID}}
+	err = svc.Repo.QueryStrategyTemplates(ctx, queryOpt)
+	if err != nil {
+		return nil, err
+	}
+	if len(queryOpt.Result) == 0 {
+		return nil, errs.NewHTTPStatusError(http.StatusNotFound, "strategy template not found", fmt.Errorf("strategy template %s not found", templateID))
+	}
+	return queryOpt.Result[0], nil
+}
+
+// checkStrategyTemplateName reports whether the template has a name no other template uses
+func (svc *Service) checkStrategyTemplateName(ctx context.Context, template *domain.StrategyTemplate) error {
+	if template.Name == "" {
+		return errs.NewHTTPStatusError(http.StatusBadRequest, "strategy template name is required", nil)
+	}
+	queryOpt := &domain.QueryStrategyTemplateOptions{Names: []string{template.Name}}
+	err := svc.Repo.QueryStrategyTemplates(ctx, queryOpt)
+	if err != nil {
+		return err
+	}
+	for _, other := range queryOpt.Result {
+		if other.ID != template.ID {
+			return errs.NewHTTPStatusError(http.StatusConflict, "strategy template name already exists", fmt.Errorf("strategy template %s already exists", template.Name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Gthulhu/api/manager/domain"
+	"github.com/Gthulhu/api/manager/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func mockStrategyTemplate(repo *domain.MockRepository, template *domain.StrategyTemplate) {
+	repo.EXPECT().QueryStrategyTemplates(mock.Anything, &domain.QueryStrategyTemplateOptions{IDs: []bson.ObjectID{template.ID}}).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyTemplateOptions) error {
+		opt.Result = []*domain.StrategyTemplate{template}
+		return nil
+	}).Once()
+}
+
+func TestApplyStrategyTemplate(t *testing.T) {
+	svc, repo, _, _ := newReconcileTestService(t)
+	ctx := context.Background()
+	template := &domain.StrategyTemplate{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, Name: "latency-critical", Priority: 1, ExecutionTime: 1000000}
+
+	mockStrategyTemplate(repo, template)
+	strategy := &domain.ScheduleStrategy{TemplateID: template.ID, Priority: 0, ExecutionTime: 20000000}
+	require.NoError(t, svc.applyStrategyTemplate(ctx, strategy))
+	assert.Equal(t, 1, strategy.Priority)
+	assert.Equal(t, int64(1000000), strategy.ExecutionTime)
+
+	repo.EXPECT().QueryStrategyTemplates(mock.Anything, mock.Anything).Return(nil).Once()
+	err := svc.applyStrategyTemplate(ctx, &domain.ScheduleStrategy{TemplateID: bson.NewObjectID()})
+	httpErr, ok := errs.IsHTTPStatusError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+}
+
+func TestCreateStrategyTemplateRejectsDuplicateName(t *testing.T) {
+	svc, repo, _, _ := newReconcileTestService(t)
+	operator := &domain.Claims{UID: bson.NewObjectID().Hex()}
+	repo.EXPECT().QueryStrategyTemplates(mock.Anything, &domain.QueryStrategyTemplateOptions{Names: []string{"interactive"}}).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyTemplateOptions) error {
+		opt.Result = []*domain.StrategyTemplate{{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, Name: "interactive"}}
+		return nil
+	}).Once()
+
+	err := svc.CreateStrategyTemplate(context.Background(), operator, &domain.StrategyTemplate{Name: "interactive"})
+	httpErr, ok := errs.IsHTTPStatusError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusConflict, httpErr.StatusCode)
+}
+
+// TestUpdateStrategyTemplatePropagates tests that only the derived strategies whose parameters differ from the template are updated
+func TestUpdateStrategyTemplatePropagates(t *testing.T) {
+	svc, repo, k8sAdapter, _ := newDeliveryTestService(t)
+	ctx := context.Background()
+	operatorID := bson.NewObjectID()
+	operator := &domain.Claims{UID: operatorID.Hex()}
+	current := &domain.StrategyTemplate{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatedTime: 1}, Name: "batch", ExecutionTime: 20000000}
+	outdated := &domain.ScheduleStrategy{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatedTime: 1, CreatorID: bson.NewObjectID()},
+		TemplateID: current.ID, ExecutionTime: 20000000,
+	}
+	upToDate := &domain.ScheduleStrategy{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatedTime: 1},
+		TemplateID: current.ID, ExecutionTime: 40000000,
+	}
+	intent := &domain.ScheduleIntent{
+		BaseEntity: domain.BaseEntity{ID: bson.NewObjectID(), CreatedTime: 1},
+		StrategyID: outdated.ID, PodID: "pod-1", NodeID: "node-1", ExecutionTime: 20000000, State: domain.IntentStateApplied,
+	}
+
+	mockStrategyTemplate(repo, current)
+	repo.EXPECT().QueryStrategyTemplates(mock.Anything, &domain.QueryStrategyTemplateOptions{Names: []string{"batch"}}).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyTemplateOptions) error {
+		opt.Result = []*domain.StrategyTemplate{current}
+		return nil
+	}).Once()
+	repo.EXPECT().UpdateStrategyTemplate(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, template *domain.StrategyTemplate) error {
+		assert.Equal(t, current.ID, template.ID)
+		assert.Equal(t, operatorID, template.UpdaterID)
+		return nil
+	}).Once()
+	repo.EXPECT().QueryStrategies(mock.Anything, &domain.QueryStrategyOptions{TemplateIDs: []bson.ObjectID{current.ID}}).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		opt.Result = []*domain.ScheduleStrategy{outdated, upToDate}
+		return nil
+	}).Once()
+
+	k8sAdapter.EXPECT().QueryPods(mock.Anything, mock.Anything).Return([]*domain.Pod{{PodID: "pod-1", NodeID: "node-1"}}, nil).Once()
+	repo.EXPECT().QueryIntents(mock.Anything, &domain.QueryIntentOptions{StrategyIDs: []bson.ObjectID{outdated.ID}}).RunAndReturn(func(_ context.Context, opt *domain.QueryIntentOptions) error {
+		opt.Result = []*domain.ScheduleIntent{intent}
+		return nil
+	}).Once()
+	repo.EXPECT().UpdateStrategy(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, strategy *domain.ScheduleStrategy) error {
+		assert.Equal(t, outdated.ID, strategy.ID)
+		assert.Equal(t, outdated.CreatorID, strategy.CreatorID)
+		assert.Equal(t, operatorID, strategy.UpdaterID)
+		assert.Equal(t, int64(40000000), strategy.ExecutionTime)
+		return nil
+	}).Once()
+	repo.EXPECT().InsertIntents(mock.Anything, mock.Anything).Return(nil).Once()
+	repo.EXPECT().UpdateIntents(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, intents []*domain.ScheduleIntent) error {
+		require.Len(t, intents, 1)
+		assert.Equal(t, intent.ID, intents[0].ID)
+		assert.Equal(t, int64(40000000), intents[0].ExecutionTime)
+		return nil
+	}).Once()
+	mockPendingIntents(repo, "node-1")
+	repo.EXPECT().QueryIntents(mock.Anything, &domain.QueryIntentOptions{PodIDs: []string{"pod-1"}}).Return(nil).Once()
+
+	propagated, err := svc.UpdateStrategyTemplate(ctx, operator, current.ID.Hex(), &domain.StrategyTemplate{Name: "batch", ExecutionTime: 40000000}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []bson.ObjectID{outdated.ID}, propagated)
+}
+
+func TestDeleteStrategyTemplateInUse(t *testing.T) {
+	svc, repo, _, _ := newReconcileTestService(t)
+	template := &domain.StrategyTemplate{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, Name: "interactive"}
+	mockStrategyTemplate(repo, template)
+	repo.EXPECT().QueryStrategies(mock.Anything, &domain.QueryStrategyOptions{TemplateIDs: []bson.ObjectID{template.ID}}).RunAndReturn(func(_ context.Context, opt *domain.QueryStrategyOptions) error {
+		opt.Result = []*domain.ScheduleStrategy{{BaseEntity: domain.BaseEntity{ID: bson.NewObjectID()}, TemplateID: template.ID}}
+		return nil
+	}).Once()
+
+	err := svc.DeleteStrategyTemplate(context.Background(), &domain.Claims{UID: bson.NewObjectID().Hex()}, template.ID.Hex())
+	httpErr, ok := errs.IsHTTPStatusError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusConflict, httpErr.StatusCode)
+}
@@ -0,0 +1,151 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchDays bounds the search of the next activation, a schedule that never fires within it (e.g. February 30) has no next time
+const cronSearchDays = 5 * 366
+
+// CronSchedule is a standard 5-field cron expression: minute, hour, day of month, month and day of week (0 or 7 is Sunday).
+// Every field accepts *, numbers, ranges (1-5), lists (1,3,5) and steps (*/15, 0-30/10).
+// As in cron, when both the day of month and the day of week are restricted a day matching either of them matches.
+type CronSchedule struct {
+	minutes     [60]bool
+	hours       [24]bool
+	daysOfMonth [32]bool
+	months      [13]bool
+	daysOfWeek  [7]bool
+	// anyDayOfMonth and anyDayOfWeek record a * field, which does not take part in the day of month / day of week OR
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// ParseCron parses a 5-field cron expression
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields, got %d", expr, len(fields))
+	}
+	schedule := &CronSchedule{
+		anyDayOfMonth: strings.HasPrefix(fields[2], "*"),
+		anyDayOfWeek:  strings.HasPrefix(fields[4], "*"),
+	}
+	var daysOfWeek [8]bool
+	for _, field := range []struct {
+		name     string
+		expr     string
+		min, max int
+		set      []bool
+	}{
+		{"minute", fields[0], 0, 59, schedule.minutes[:]},
+		{"hour", fields[1], 0, 23, schedule.hours[:]},
+		{"day of month", fields[2], 1, 31, schedule.daysOfMonth[:]},
+		{"month", fields[3], 1, 12, schedule.months[:]},
+		{"day of week", fields[4], 0, 7, daysOfWeek[:]},
+	} {
+		err := parseCronField(field.expr, field.min, field.max, field.set)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s field of cron expression %q: %w", field.name, expr, err)
+		}
+	}
+	copy(schedule.daysOfWeek[:], daysOfWeek[:7])
+	schedule.daysOfWeek[0] = schedule.daysOfWeek[0] || daysOfWeek[7]
+	return schedule, nil
+}
+
+func parseCronField(expr string, min, max int, set []bool) error {
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepExpr)
+			if err != nil || step <= 0 {
+				return fmt.Errorf("invalid step %q", stepExpr)
+			}
+		}
+		low, high := min, max
+		switch {
+		case rangeExpr == "*":
+		case strings.Contains(rangeExpr, "-"):
+			lowExpr, highExpr, _ := strings.Cut(rangeExpr, "-")
+			var err error
+			low, err = parseCronValue(lowExpr, min, max)
+			if err != nil {
+				return err
+			}
+			high, err = parseCronValue(highExpr, min, max)
+			if err != nil {
+				return err
+			}
+			if low > high {
+				return fmt.Errorf("invalid range %q", rangeExpr)
+			}
+		default:
+			value, err := parseCronValue(rangeExpr, min, max)
+			if err != nil {
+				return err
+			}
+			low = value
+			high = value
+			if hasStep {
+				high = max
+			}
+		}
+		for value := low; value <= high; value += step {
+			set[value] = true
+		}
+	}
+	return nil
+}
+
+func parseCronValue(expr string, min, max int) (int, error) {
+	value, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", expr)
+	}
+	if value < min || value > max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", value, min, max)
+	}
+	return value, nil
+}
+
+// Next returns the first activation strictly after the given time, in the location of the given time.
+// It returns the zero time when the schedule never fires.
+func (c *CronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	for range cronSearchDays {
+		if c.matchesDay(t) {
+			for day := t.Day(); t.Day() == day; t = t.Add(time.Minute) {
+				if c.hours[t.Hour()] && c.minutes[t.Minute()] {
+					return t
+				}
+			}
+			continue
+		}
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Time{}
+}
+
+func (c *CronSchedule) matchesDay(t time.Time) bool {
+	if !c.months[t.Month()] {
+		return false
+	}
+	dayOfMonth := c.daysOfMonth[t.Day()]
+	dayOfWeek := c.daysOfWeek[t.Weekday()]
+	switch {
+	case c.anyDayOfMonth && c.anyDayOfWeek:
+		return true
+	case c.anyDayOfMonth:
+		return dayOfWeek
+	case c.anyDayOfWeek:
+		return dayOfMonth
+	default:
+		return dayOfMonth || dayOfWeek
+	}
+}